@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/check"
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+)
+
+func TestDiagnoseReport(t *testing.T) {
+	diagnosis := &executor.DiagnoseResult{
+		Components: []executor.ComponentCheck{
+			{Name: "proxy", Status: executor.CheckStatusOK, Message: "1/1 ready", Replicas: 1, Ready: 1},
+			{Name: "querynode", Status: executor.CheckStatusWarning, Message: "1/2 ready (degraded)", Replicas: 2, Ready: 1},
+		},
+		Connectivity: []executor.ConnectivityCheck{
+			{Name: "milvus", Target: "demo-milvus:19530", Status: executor.CheckStatusOK, Message: "reachable"},
+		},
+		Issues: []executor.Issue{
+			{Severity: executor.CheckStatusWarning, Component: "querynode", Description: "querynode has fewer ready replicas than desired", Suggestion: "Check pod status"},
+			{Severity: executor.CheckStatusError, Component: "cluster", Description: "Condition MilvusReady is False", Suggestion: "Check Milvus Operator logs"},
+		},
+	}
+
+	report := diagnoseReport(diagnosis)
+	if report.Summary != (check.Summary{Total: 4, Passed: 2, Warned: 1, Failed: 1}) {
+		t.Errorf("Summary = %+v", report.Summary)
+	}
+	if report.Status != check.StatusFail {
+		t.Errorf("Status = %s, want fail", report.Status)
+	}
+
+	querynode := report.Results[1]
+	if querynode.Name != "Component querynode" || querynode.Suggest != "Check pod status" {
+		t.Errorf("querynode result = %+v, want the issue's suggestion", querynode)
+	}
+	cluster := report.Results[3]
+	if cluster.Name != "cluster" || cluster.Status != check.StatusFail || cluster.Message != "Condition MilvusReady is False" {
+		t.Errorf("issue result = %+v", cluster)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/check"
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/manager"
+	"github.com/mmga-lab/miup/pkg/output"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err      error
+		wantCode output.ErrorCode
+		wantExit int
+	}{
+		{manager.ErrClusterNotFound, output.ErrNotFound, 3},
+		{manager.ErrConfigKeyNotFound, output.ErrNotFound, 3},
+		{manager.ErrClusterExists, output.ErrAlreadyExists, 4},
+		{manager.ErrInvalidTopology, output.ErrInvalidInput, 5},
+		{executor.ErrInvalidComponent, output.ErrInvalidInput, 5},
+		{executor.ErrStorageClassNotFound, output.ErrInvalidInput, 5},
+		{manager.ErrOperationInProgress, output.ErrConflict, 6},
+		{manager.ErrClusterStopped, output.ErrConflict, 6},
+		{executor.ErrAlreadyAtVersion, output.ErrConflict, 6},
+		{executor.ErrTimeout, output.ErrTimeout, 7},
+		{executor.ErrDeleteTimeout, output.ErrTimeout, 7},
+		{context.DeadlineExceeded, output.ErrTimeout, 7},
+		{executor.ErrPermissionDenied, output.ErrPermission, 8},
+		{check.ErrChecksFailed, output.ErrCheckFailed, 1},
+		{check.ErrChecksWarned, output.ErrCheckWarned, 2},
+		{errors.New("boom"), output.ErrInternal, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			// Commands wrap sentinels with context, e.g. the instance name
+			err := fmt.Errorf("instance prod: %w", tt.err)
+			code := errorCode(err)
+			if code != tt.wantCode {
+				t.Errorf("errorCode(%v) = %s, want %s", err, code, tt.wantCode)
+			}
+			if got := exitCodes[code]; got != tt.wantExit {
+				t.Errorf("exit code for %s = %d, want %d", code, got, tt.wantExit)
+			}
+		})
+	}
+}
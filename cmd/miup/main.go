@@ -1,34 +1,45 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"text/tabwriter"
 	"time"
 
 	"github.com/fatih/color"
-	"golang.org/x/term"
+	"github.com/mmga-lab/miup/pkg/archive"
 	"github.com/mmga-lab/miup/pkg/audit"
 	"github.com/mmga-lab/miup/pkg/check"
 	"github.com/mmga-lab/miup/pkg/cluster/executor"
 	"github.com/mmga-lab/miup/pkg/cluster/manager"
 	"github.com/mmga-lab/miup/pkg/cluster/spec"
 	"github.com/mmga-lab/miup/pkg/component"
+	localexec "github.com/mmga-lab/miup/pkg/executor"
+	"github.com/mmga-lab/miup/pkg/k8s"
 	"github.com/mmga-lab/miup/pkg/localdata"
 	"github.com/mmga-lab/miup/pkg/logger"
 	"github.com/mmga-lab/miup/pkg/output"
 	"github.com/mmga-lab/miup/pkg/playground"
+	"github.com/mmga-lab/miup/pkg/timing"
 	"github.com/mmga-lab/miup/pkg/version"
 	"github.com/mmga-lab/miup/skills"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // auditLog logs an operation to the audit log
@@ -59,10 +70,41 @@ func auditLog(instance, command string, args []string, err error, duration time.
 	_ = logger.Log(entry)
 }
 
+// warnIfOutdatedMilvus warns when the chosen Milvus version is at least one
+// minor release behind the latest GitHub release. Network failures are ignored.
+func warnIfOutdatedMilvus(ctx context.Context, milvusVersion string) {
+	if !versionCheck || version.VersionCheckDisabled() {
+		return
+	}
+
+	defer timing.Start(ctx, "version check")()
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	release, err := component.NewDownloader().GetLatestRelease(ctx, version.MilvusRepo)
+	if err != nil {
+		logger.Debug("Skipping Milvus version check: %v", err)
+		return
+	}
+
+	if version.IsSignificantlyBehind(milvusVersion, release.TagName) {
+		logger.Warn("Milvus %s is outdated, latest release is %s (set %s=1 or --version-check=false to skip this check)",
+			milvusVersion, release.TagName, version.SkipVersionCheckEnv)
+	}
+}
+
 var (
-	verbose bool
-	noColor bool
-	rootCmd = &cobra.Command{
+	verbose      bool
+	noColor      bool
+	versionCheck bool
+	eventsJSON   string
+	stopEvents   = func() {}
+	eventsStdout bool
+	kubeAPIQPS   float32
+	kubeAPIBurst int
+	timings      bool
+	rootCmd      = &cobra.Command{
 		Use:   "miup",
 		Short: "MiUp is a component manager for Milvus",
 		Long: `MiUp is a component manager for Milvus vector database.
@@ -79,7 +121,7 @@ Quick start:
   miup instance deploy     Deploy a Milvus instance
 
 For more information, visit: https://github.com/mmga-lab/miup`,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			if verbose {
 				logger.EnableDebug()
 			}
@@ -87,6 +129,32 @@ For more information, visit: https://github.com/mmga-lab/miup`,
 			if noColor || !term.IsTerminal(int(os.Stdout.Fd())) {
 				color.NoColor = true
 			}
+			if eventsJSON != "" {
+				w, err := openEventsOutput(eventsJSON)
+				if err != nil {
+					return err
+				}
+				eventsStdout = w == os.Stdout
+				stop := logger.StartEvents(w)
+				stopEvents = func() {
+					stop()
+					if f, ok := w.(*os.File); ok && f != os.Stdout {
+						f.Close()
+					}
+				}
+			}
+
+			limit, err := k8s.RateLimitFromEnv()
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("kube-api-qps") {
+				limit.QPS = kubeAPIQPS
+			}
+			if cmd.Flags().Changed("kube-api-burst") {
+				limit.Burst = kubeAPIBurst
+			}
+			return k8s.SetDefaultRateLimit(limit)
 		},
 		SilenceUsage:  true,
 		SilenceErrors: true,
@@ -96,11 +164,20 @@ For more information, visit: https://github.com/mmga-lab/miup`,
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable color output")
+	rootCmd.PersistentFlags().BoolVar(&versionCheck, "version-check", true, "Warn when the selected Milvus version is outdated")
+	rootCmd.PersistentFlags().StringVar(&eventsJSON, "events-json", "", "Emit progress events as JSON lines to stdout (-), a file descriptor (fd:N) or a file")
+	rootCmd.PersistentFlags().Lookup("events-json").NoOptDefVal = "-"
+	rootCmd.PersistentFlags().Float32Var(&kubeAPIQPS, "kube-api-qps", k8s.DefaultQPS, "Sustained Kubernetes API requests per second (env "+k8s.QPSEnv+")")
+	rootCmd.PersistentFlags().IntVar(&kubeAPIBurst, "kube-api-burst", k8s.DefaultBurst, "Kubernetes API requests allowed in a burst above --kube-api-qps (env "+k8s.BurstEnv+")")
+	rootCmd.PersistentFlags().BoolVar(&timings, "timings", false, "Print the time spent in each phase of the command to stderr when it ends")
 
 	// Add subcommands
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(newInstallCmd())
 	rootCmd.AddCommand(newUninstallCmd())
+	rootCmd.AddCommand(newCacheCmd())
+	rootCmd.AddCommand(newComponentCmd())
+	rootCmd.AddCommand(newProfileCmd())
 	rootCmd.AddCommand(newListCmd())
 	rootCmd.AddCommand(newRunCmd())
 	rootCmd.AddCommand(newPlaygroundCmd())
@@ -109,6 +186,9 @@ func init() {
 	rootCmd.AddCommand(newMirrorCmd())
 	rootCmd.AddCommand(newBenchCmd())
 	rootCmd.AddCommand(newSkillCmd())
+	rootCmd.AddCommand(newSupportBundleCmd())
+	rootCmd.AddCommand(newDoctorCmd())
+	rootCmd.AddCommand(newEnvCmd())
 }
 
 func newVersionCmd() *cobra.Command {
@@ -149,8 +229,16 @@ func newVersionCmd() *cobra.Command {
 }
 
 func newInstallCmd() *cobra.Command {
+	var (
+		noCache    bool
+		allowHooks bool
+		timeout    time.Duration
+		from       string
+		fromVer    string
+	)
+
 	cmd := &cobra.Command{
-		Use:   "install <component>[:<version>]",
+		Use:   "install <component>[:<version>|@<ref>]",
 		Short: "Install a Milvus ecosystem tool",
 		Long: `Install a Milvus ecosystem tool from GitHub Releases.
 
@@ -161,12 +249,60 @@ Available components:
 Version specification:
   - If no version is specified, the latest release will be installed
   - Use :<version> to install a specific version (e.g., birdwatcher:v1.1.0)
+  - Use a semver range to install the newest matching release, e.g.
+    ^1.2 (>=1.2.0 <2.0.0), ~1.2.3 (>=1.2.3 <1.3.0), 1.2.x or ">=1.2,<2".
+    Pre-releases are never selected by a range.
+  - Use @<branch> or @<commit> to install the latest build of unreleased
+    code (see Development builds below)
 
 Examples:
   miup install birdwatcher              Install latest birdwatcher
   miup install birdwatcher:v1.1.0       Install specific version
+  miup install 'birdwatcher:^1.2'       Install the newest 1.x release from 1.2 on
+  miup install 'birdwatcher:>=1.2,<2'   Same, with explicit bounds
   miup install milvus-backup            Install milvus-backup
-  miup install birdwatcher milvus-backup   Install multiple components`,
+  miup install birdwatcher milvus-backup   Install multiple components
+  miup install birdwatcher --no-cache   Always download from GitHub
+  miup install birdwatcher --timeout 2m Allow a slow connection to stall longer
+  miup install birdwatcher --from ./birdwatcher_v1.2.0_linux_amd64.tar.gz
+                                        Install offline from a local archive
+  miup install birdwatcher@main         Install the latest build of main
+  miup install birdwatcher@1a2b3c4      Install the build of a commit
+
+Install hooks:
+  Shell commands to run before and after installing a component can be set
+  in ~/.miup/components.yaml:
+
+    components:
+      milvus-backup:
+        hooks:
+          pre_install: echo "installing $MIUP_COMPONENT_VERSION"
+          post_install: cp ~/backup.yaml "$MIUP_INSTALL_DIR/configs/"
+
+  Hooks get MIUP_COMPONENT, MIUP_COMPONENT_VERSION, MIUP_INSTALL_DIR and
+  MIUP_BINARY in their environment. Each hook asks for confirmation before
+  it runs; --allow-hooks runs them without asking. Without a terminal to ask
+  on, hooks are skipped unless --allow-hooks is given.
+
+Offline install:
+  --from installs from a local release archive (.tar.gz, .tgz, .zip), a
+  binary (optionally .gz) or a directory holding an extracted release,
+  without contacting GitHub. The version is taken from <component>:<version>
+  or --version, else inferred from the file name.
+
+Development builds:
+  <component>@<ref> installs a build of a branch or commit. The newest
+  nightly pre-release built from it (per its target branch or commit) is
+  used if it has an asset for this platform. Otherwise the artifact for this
+  platform of the newest successful GitHub Actions run of the ref is
+  downloaded, which needs a token in GITHUB_TOKEN. The build is installed as
+  the pre-release tag, or as <branch>-<short commit> (<short commit> for a
+  commit). If neither exists, build it and install it with --from.
+
+Timeouts:
+  A download fails if the server sends no response, or stops sending data,
+  for --timeout (0 disables it). A slow download that keeps receiving data
+  never times out. Ctrl-C cancels a download in progress.`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			profile, err := localdata.DefaultProfile()
@@ -177,7 +313,7 @@ Examples:
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
+			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
 			sigCh := make(chan os.Signal, 1)
@@ -187,20 +323,58 @@ Examples:
 				cancel()
 			}()
 
-			mgr := component.NewManager(profile)
+			if timeout < 0 {
+				return fmt.Errorf("--timeout must not be negative")
+			}
+			if from == "" && fromVer != "" {
+				return fmt.Errorf("--version requires --from; use <component>:<version> instead")
+			}
+			if from != "" && len(args) > 1 {
+				return fmt.Errorf("--from installs a single component, got %d", len(args))
+			}
+			mgr := component.NewManager(profile).WithDownloadTimeout(timeout)
 
 			for _, arg := range args {
 				name, ver := parseComponentArg(arg)
-				if err := mgr.Install(ctx, name, ver); err != nil {
+				name, ref, _ := strings.Cut(name, "@")
+				if fromVer != "" {
+					if ver != "" && ver != fromVer {
+						return fmt.Errorf("conflicting versions %s and --version %s", ver, fromVer)
+					}
+					ver = fromVer
+				}
+				opts := component.InstallOptions{NoCache: noCache, From: from, Ref: ref, ConfirmHook: confirmHook(allowHooks)}
+				if err := mgr.Install(ctx, name, ver, opts); err != nil {
 					return fmt.Errorf("failed to install %s: %w", name, err)
 				}
 			}
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Bypass the download cache")
+	cmd.Flags().BoolVar(&allowHooks, "allow-hooks", false, "Run install hooks from components.yaml without asking")
+	cmd.Flags().StringVar(&from, "from", "", "Install from a local archive, binary or directory instead of GitHub")
+	cmd.Flags().StringVar(&fromVer, "version", "", "Version to register a --from install as (default: inferred from the file name)")
+	cmd.Flags().DurationVar(&timeout, "timeout", component.DefaultDownloadTimeout, "Fail a download that receives no data for this long (0 for no limit)")
+
 	return cmd
 }
 
+// confirmHook returns the install hook approval for --allow-hooks: approve
+// every hook if set, otherwise ask on the terminal, or skip without one
+func confirmHook(allowHooks bool) func(component.Hook) bool {
+	return func(hook component.Hook) bool {
+		if allowHooks {
+			return true
+		}
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return false
+		}
+		return confirm(fmt.Sprintf("Run %s hook of %s: %s ?", hook.Stage, hook.Component, hook.Command))
+	}
+}
+
 // parseComponentArg parses "component:version" format
 func parseComponentArg(arg string) (name, version string) {
 	parts := strings.SplitN(arg, ":", 2)
@@ -211,43 +385,230 @@ func parseComponentArg(arg string) (name, version string) {
 	return
 }
 
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the component download cache",
+	}
+
+	cmd.AddCommand(newCacheCleanCmd())
+
+	return cmd
+}
+
+func newCacheCleanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove all cached component downloads",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			mgr := component.NewManager(profile)
+			if err := mgr.CleanCache(); err != nil {
+				return err
+			}
+
+			logger.Success("Cleaned download cache %s", mgr.CacheDir())
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newComponentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "component",
+		Short: "Manage installed component versions",
+	}
+
+	cmd.AddCommand(newComponentActivateCmd())
+
+	return cmd
+}
+
+func newComponentActivateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "activate <component> <version>",
+		Short: "Set the version used by miup run",
+		Long: `Set the active version of an installed component.
+
+The active version is used by 'miup run' when no version is specified.
+
+Examples:
+  miup component activate birdwatcher v1.1.0`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			mgr := component.NewManager(profile)
+			return mgr.Activate(cmd.Context(), args[0], args[1])
+		},
+	}
+	return cmd
+}
+
+func newProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage the miup data directory",
+		Long: `Manage the miup data directory.
+
+miup stores components, playgrounds, instance metadata, the download cache and
+the audit log under ~/.miup, or under $MIUP_HOME when it is set.`,
+	}
+
+	cmd.AddCommand(newProfileMigrateCmd())
+
+	return cmd
+}
+
+func newProfileMigrateCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate <new-dir>",
+		Short: "Move miup data to a new directory",
+		Long: `Move all miup data to a new directory and update stored paths.
+
+The destination must not exist or must be empty. After migrating, set
+MIUP_HOME to the new directory so that later commands find the data.
+
+Examples:
+  miup profile migrate /data/miup`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			// Running playgrounds bind-mount files from the profile directory
+			if !force {
+				instances, err := playground.NewManager(profile).List(ctx)
+				if err != nil {
+					return err
+				}
+				for _, inst := range instances {
+					if inst.Status == playground.StatusRunning {
+						return fmt.Errorf("playground '%s' is running, stop it first or use --force", inst.Meta.Tag)
+					}
+				}
+			}
+
+			logger.Info("Migrating %s to %s...", profile.Root(), args[0])
+			migrated, err := profile.Migrate(args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := component.NewManager(migrated).RefreshPaths(ctx); err != nil {
+				return err
+			}
+
+			logger.Success("Migrated miup data to %s", migrated.Root())
+			fmt.Printf("\nSet %s to use the new location:\n", localdata.HomeEnv)
+			fmt.Printf("  export %s=%s\n", localdata.HomeEnv, migrated.Root())
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Migrate even if playgrounds are running")
+	return cmd
+}
+
 func newUninstallCmd() *cobra.Command {
+	var (
+		all        bool
+		keepActive bool
+		olderThan  string
+	)
 	cmd := &cobra.Command{
 		Use:   "uninstall <component>[:<version>]",
 		Short: "Uninstall a Milvus ecosystem tool",
 		Long: `Uninstall a Milvus ecosystem tool.
 
 If no version is specified, all versions of the component will be removed.
+Use --keep-active or --older-than to remove only some versions.
 
 Examples:
-  miup uninstall birdwatcher           Uninstall all versions of birdwatcher
-  miup uninstall birdwatcher:v1.1.0    Uninstall specific version`,
-		Args: cobra.MinimumNArgs(1),
+  miup uninstall birdwatcher                       Uninstall all versions of birdwatcher
+  miup uninstall birdwatcher:v1.1.0                Uninstall specific version
+  miup uninstall birdwatcher --keep-active         Remove all but the active version
+  miup uninstall birdwatcher --older-than v1.1.0   Remove versions older than v1.1.0
+  miup uninstall --all                             Uninstall every component
+  miup uninstall --all --keep-active               Keep only active versions`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !all && len(args) == 0 {
+				return fmt.Errorf("requires at least 1 component, or --all")
+			}
+			if all && len(args) > 0 {
+				return fmt.Errorf("--all cannot be combined with component names")
+			}
+
 			profile, err := localdata.DefaultProfile()
 			if err != nil {
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			mgr := component.NewManager(profile)
+			opts := component.UninstallOptions{
+				KeepActive: keepActive,
+				OlderThan:  olderThan,
+			}
+			selective := keepActive || olderThan != ""
+
+			if all {
+				if !selective {
+					return mgr.UninstallAll(ctx)
+				}
+				components, err := mgr.List(ctx)
+				if err != nil {
+					return err
+				}
+				for _, meta := range components {
+					args = append(args, meta.Name)
+				}
+			}
 
 			for _, arg := range args {
 				name, ver := parseComponentArg(arg)
-				if err := mgr.Uninstall(ctx, name, ver); err != nil {
+				if selective {
+					if ver != "" {
+						return fmt.Errorf("cannot combine a version with --keep-active or --older-than")
+					}
+					err = mgr.UninstallVersions(ctx, name, opts)
+				} else {
+					err = mgr.Uninstall(ctx, name, ver)
+				}
+				if err != nil {
 					return fmt.Errorf("failed to uninstall %s: %w", name, err)
 				}
 			}
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&all, "all", false, "Uninstall all components")
+	cmd.Flags().BoolVar(&keepActive, "keep-active", false, "Keep the active version and remove the others")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Remove versions older than the given version")
 	return cmd
 }
 
 func newListCmd() *cobra.Command {
 	var (
-		available  bool
-		jsonOutput bool
+		available    bool
+		jsonOutput   bool
+		checkUpdates bool
+		offline      bool
+		sortBy       string
 	)
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -257,6 +618,8 @@ func newListCmd() *cobra.Command {
 Examples:
   miup list              List all installed components
   miup list --available  List all available components
+  miup list --check-updates  Mark components with a newer release
+  miup list --sort size  Show the largest installs first
   miup list --json       List in JSON format`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if available {
@@ -284,7 +647,7 @@ Examples:
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			mgr := component.NewManager(profile)
 
 			components, err := mgr.List(ctx)
@@ -292,20 +655,57 @@ Examples:
 				return err
 			}
 
-			if jsonOutput {
-				var compList []output.ComponentInfo
-				for _, meta := range components {
-					for ver, info := range meta.Versions {
-						compList = append(compList, output.ComponentInfo{
-							Name:        meta.Name,
-							Version:     ver,
-							Active:      ver == meta.Active,
-							InstalledAt: info.InstalledAt,
-							Path:        info.BinaryPath,
-						})
+			var latest map[string]string
+			if checkUpdates {
+				if offline {
+					logger.Warn("Skipping update check in offline mode")
+				} else {
+					names := make([]string, 0, len(components))
+					for _, meta := range components {
+						names = append(names, meta.Name)
+					}
+					latest = mgr.LatestVersions(ctx, names)
+				}
+			}
+
+			var compList []output.ComponentInfo
+			var total int64
+			for _, meta := range components {
+				for _, ver := range component.SortedVersions(meta) {
+					info := meta.Versions[ver]
+					item := output.ComponentInfo{
+						Name:        meta.Name,
+						Version:     ver,
+						Active:      ver == meta.Active,
+						InstalledAt: info.InstalledAt,
+						Path:        info.BinaryPath,
 					}
+					if size, err := component.DirSize(mgr.VersionDir(meta.Name, ver)); err != nil {
+						logger.Debug("Failed to compute size of %s %s: %v", meta.Name, ver, err)
+					} else {
+						item.Size = size
+						total += size
+					}
+					if item.Active {
+						item.Latest = latest[meta.Name]
+						item.UpdateAvailable = component.HasUpdate(ver, item.Latest)
+					}
+					compList = append(compList, item)
 				}
-				return output.PrintJSON(os.Stdout, output.NewSuccessResult(output.ComponentList{Components: compList}))
+			}
+
+			switch sortBy {
+			case "name":
+			case "size":
+				sort.SliceStable(compList, func(i, j int) bool {
+					return compList[i].Size > compList[j].Size
+				})
+			default:
+				return fmt.Errorf("invalid sort key: %s (valid: name, size)", sortBy)
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(os.Stdout, output.NewSuccessResult(output.ComponentList{Components: compList, TotalSize: total}))
 			}
 
 			if len(components) == 0 {
@@ -318,44 +718,72 @@ Examples:
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "COMPONENT\tVERSION\tINSTALLED\tPATH")
-			for _, meta := range components {
-				for ver, info := range meta.Versions {
-					activeMarker := ""
-					if ver == meta.Active {
-						activeMarker = " (active)"
+			fmt.Fprintln(w, "COMPONENT\tVERSION\tSIZE\tINSTALLED\tPATH")
+			for _, item := range compList {
+				activeMarker := ""
+				if item.Active {
+					activeMarker = " (active)"
+					if item.UpdateAvailable {
+						activeMarker += fmt.Sprintf(" -> %s", item.Latest)
 					}
-					fmt.Fprintf(w, "%s\t%s%s\t%s\t%s\n",
-						meta.Name,
-						ver,
-						activeMarker,
-						info.InstalledAt.Format("2006-01-02"),
-						info.BinaryPath,
-					)
 				}
+				fmt.Fprintf(w, "%s\t%s%s\t%s\t%s\t%s\n",
+					item.Name,
+					item.Version,
+					activeMarker,
+					output.FormatBytes(item.Size),
+					item.InstalledAt.Format("2006-01-02"),
+					item.Path,
+				)
 			}
 			w.Flush()
+			fmt.Printf("\nTotal: %s\n", output.FormatBytes(total))
 			return nil
 		},
 	}
 	cmd.Flags().BoolVar(&available, "available", false, "List available components")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&checkUpdates, "check-updates", false, "Check for newer releases of installed components")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Skip network access when checking updates")
+	cmd.Flags().StringVar(&sortBy, "sort", "name", "Sort installed components by: name, size")
 	return cmd
 }
 
 func newRunCmd() *cobra.Command {
+	var (
+		helpComponent bool
+		waitReady     bool
+		readyTimeout  time.Duration
+	)
+
 	cmd := &cobra.Command{
 		Use:   "run <component>[:<version>] [-- args...]",
 		Short: "Run an installed component",
 		Long: `Run an installed Milvus ecosystem tool.
 
 If no version is specified, the active (most recently installed) version is used.
-Use -- to separate miup flags from component arguments.
+
+Ctrl-C and SIGTERM are passed on to the component so it can clean up; it is
+killed only if it is still running 10s later.
+
+Every argument after the component is passed to it. Put component flags after
+-- so that miup doesn't parse them itself: 'miup run birdwatcher --help' shows
+this help, 'miup run birdwatcher -- --help' shows birdwatcher's. The
+--help-component shortcut does the same.
+
+With --wait-ready, miup probes the port of a server started by the component
+(e.g. 'milvus-backup server', on --port or 8080) and prints "<component> is
+ready on <address>" to stderr once it accepts connections, along with a
+component_ready event for --events-json. If it doesn't serve within
+--ready-timeout, the component is stopped and miup exits with an error.
 
 Examples:
   miup run birdwatcher                      Run birdwatcher (active version)
   miup run birdwatcher:v1.1.0               Run specific version
-  miup run birdwatcher -- connect etcd      Pass arguments to birdwatcher`,
+  miup run birdwatcher -- connect etcd      Pass arguments to birdwatcher
+  miup run birdwatcher -- --help            Show birdwatcher's own flags
+  miup run birdwatcher --help-component     Same
+  miup run milvus-backup --wait-ready -- server --port 9090`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			profile, err := localdata.DefaultProfile()
@@ -363,29 +791,33 @@ Examples:
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-
-			sigCh := make(chan os.Signal, 1)
-			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-			go func() {
-				<-sigCh
-				cancel()
-			}()
-
-			// Parse component and version
-			name, ver := parseComponentArg(args[0])
+			// Run passes signals on to the component itself
+			ctx := cmd.Context()
 
-			// Args after -- are passed to the component
-			componentArgs := args[1:]
-			if cmd.ArgsLenAtDash() > 0 {
-				componentArgs = args[cmd.ArgsLenAtDash():]
+			compArg, componentArgs, err := component.SplitRunArgs(args, cmd.ArgsLenAtDash())
+			if err != nil {
+				return err
 			}
+			name, ver := parseComponentArg(compArg)
 
 			mgr := component.NewManager(profile)
-			return mgr.Run(ctx, name, ver, componentArgs)
+			if helpComponent {
+				if len(componentArgs) > 0 {
+					return fmt.Errorf("--help-component takes no component arguments")
+				}
+				return mgr.RunHelp(ctx, name, ver)
+			}
+			return mgr.Run(ctx, name, ver, componentArgs, component.RunOptions{
+				WaitReady:    waitReady,
+				ReadyTimeout: readyTimeout,
+			})
 		},
 	}
+
+	cmd.Flags().BoolVar(&helpComponent, "help-component", false, "Show the component's own help instead of running it")
+	cmd.Flags().BoolVar(&waitReady, "wait-ready", false, "Report when a server started by the component accepts connections")
+	cmd.Flags().DurationVar(&readyTimeout, "ready-timeout", component.DefaultReadyTimeout, "How long --wait-ready waits for the component to serve")
+
 	return cmd
 }
 
@@ -401,15 +833,19 @@ Examples:
   miup playground start --with-monitor   Start with Prometheus and Grafana
   miup playground stop               Stop the playground
   miup playground status             Show playground status
-  miup playground list               List all playground instances`,
+  miup playground diagnose           Run health checks on the playground
+  miup playground list               List all playground instances
+  miup playground reap -y            Clean up playgrounds past their --ttl`,
 	}
 
 	cmd.AddCommand(newPlaygroundStartCmd())
 	cmd.AddCommand(newPlaygroundStopCmd())
 	cmd.AddCommand(newPlaygroundStatusCmd())
+	cmd.AddCommand(newPlaygroundDiagnoseCmd())
 	cmd.AddCommand(newPlaygroundListCmd())
 	cmd.AddCommand(newPlaygroundLogsCmd())
 	cmd.AddCommand(newPlaygroundCleanCmd())
+	cmd.AddCommand(newPlaygroundReapCmd())
 
 	return cmd
 }
@@ -420,11 +856,29 @@ func newPlaygroundStartCmd() *cobra.Command {
 		withMonitor bool
 		milvusVer   string
 		milvusPort  int
+		pull        string
+		offline     bool
+		ttl         time.Duration
+		credsFile   string
+		importPath  string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "start",
 		Short: "Start a local Milvus playground (standalone mode)",
+		Long: `Start a local Milvus playground in standalone mode with docker compose.
+
+--import takes the shape of the playground from an instance topology instead
+of flags: monitoring and the ports of Milvus, etcd, MinIO, Prometheus and
+Grafana. For the stored topology of a deployed instance
+(~/.miup/clusters/<name>/topology.yaml) the instance's Milvus version is used
+too. Flags given explicitly take precedence. Parts of the topology the
+playground can't reproduce, such as distributed mode, are listed as warnings.
+
+Examples:
+  miup playground start
+  miup playground start --with-monitor --milvus.version v2.5.4
+  miup playground start --tag prod-copy --import ~/.miup/clusters/prod/topology.yaml`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			profile, err := localdata.DefaultProfile()
 			if err != nil {
@@ -439,19 +893,36 @@ func newPlaygroundStartCmd() *cobra.Command {
 				tag = "default"
 			}
 
-			// Create configuration
+			// Create configuration, from a topology if one is imported
 			cfg := playground.DefaultConfig()
+			if importPath != "" {
+				var notes []string
+				if cfg, notes, err = playground.ImportTopology(importPath); err != nil {
+					return err
+				}
+				for _, note := range notes {
+					logger.Warn("%s: %s", importPath, note)
+				}
+			}
 			cfg.Tag = tag
-			cfg.WithMonitor = withMonitor
-			if milvusVer != "latest" && milvusVer != "" {
+			if importPath == "" || cmd.Flags().Changed("with-monitor") {
+				cfg.WithMonitor = withMonitor
+			}
+			if (importPath == "" || cmd.Flags().Changed("milvus.version")) && milvusVer != "latest" && milvusVer != "" {
 				cfg.MilvusVersion = milvusVer
 			}
-			if milvusPort != 0 {
+			if (importPath == "" || cmd.Flags().Changed("port")) && milvusPort != 0 {
 				cfg.MilvusPort = milvusPort
 			}
+			cfg.PullPolicy = playground.PullPolicy(pull)
+			cfg.Offline = offline
+			cfg.TTL = ttl
+			if cfg.Minio, err = playground.LoadMinioCredentials(credsFile); err != nil {
+				return err
+			}
 
 			// Create context with signal handling
-			ctx, cancel := context.WithCancel(context.Background())
+			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
 			sigCh := make(chan os.Signal, 1)
@@ -461,26 +932,34 @@ func newPlaygroundStartCmd() *cobra.Command {
 				cancel()
 			}()
 
+			warnIfOutdatedMilvus(ctx, cfg.MilvusVersion)
+
 			// Start playground
 			manager := playground.NewManager(profile)
 			if err := manager.Start(ctx, cfg); err != nil {
 				return err
 			}
 
-			// Print connection info
-			fmt.Println()
-			fmt.Println("Connect to Milvus:")
-			fmt.Printf("  %s\n", color.CyanString("Endpoint: localhost:%d", cfg.MilvusPort))
-			fmt.Printf("  %s\n", color.CyanString("SDK:      from pymilvus import MilvusClient"))
-			fmt.Printf("  %s\n", color.CyanString("          client = MilvusClient('http://localhost:%d')", cfg.MilvusPort))
-			if withMonitor {
-				fmt.Println()
-				fmt.Println("Monitoring:")
-				fmt.Printf("  %s\n", color.CyanString("Prometheus: http://localhost:%d", cfg.PrometheusPort))
-				fmt.Printf("  %s\n", color.CyanString("Grafana:    http://localhost:%d (admin/admin)", cfg.GrafanaPort))
+			// Print connection info, keeping stdout to the events if they
+			// are written there
+			out := commandOutput()
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "Connect to Milvus:")
+			fmt.Fprintf(out, "  %s\n", color.CyanString("Endpoint: localhost:%d", cfg.MilvusPort))
+			fmt.Fprintf(out, "  %s\n", color.CyanString("SDK:      from pymilvus import MilvusClient"))
+			fmt.Fprintf(out, "  %s\n", color.CyanString("          client = MilvusClient('http://localhost:%d')", cfg.MilvusPort))
+			if cfg.WithMonitor {
+				fmt.Fprintln(out)
+				fmt.Fprintln(out, "Monitoring:")
+				fmt.Fprintf(out, "  %s\n", color.CyanString("Prometheus: http://localhost:%d", cfg.PrometheusPort))
+				fmt.Fprintf(out, "  %s\n", color.CyanString("Grafana:    http://localhost:%d (admin/admin)", cfg.GrafanaPort))
+			}
+			fmt.Fprintln(out)
+			if cfg.Minio.AccessKey == playground.DefaultMinioKey && cfg.Minio.SecretKey == playground.DefaultMinioKey {
+				fmt.Fprintf(out, "MinIO Console: %s\n", color.CyanString("http://localhost:%d (minioadmin/minioadmin)", cfg.MinioConsole))
+			} else {
+				fmt.Fprintf(out, "MinIO Console: %s\n", color.CyanString("http://localhost:%d (user %s)", cfg.MinioConsole, cfg.Minio.AccessKey))
 			}
-			fmt.Println()
-			fmt.Printf("MinIO Console: %s\n", color.CyanString("http://localhost:%d (minioadmin/minioadmin)", cfg.MinioConsole))
 
 			return nil
 		},
@@ -490,6 +969,11 @@ func newPlaygroundStartCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&withMonitor, "with-monitor", false, "Start with Prometheus and Grafana")
 	cmd.Flags().StringVar(&milvusVer, "milvus.version", "latest", "Milvus version to use")
 	cmd.Flags().IntVar(&milvusPort, "port", 19530, "Milvus port")
+	cmd.Flags().StringVar(&pull, "pull", "missing", "Image pull policy: always, never, missing")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Assume images are pre-loaded (e.g. via 'miup mirror load') and never pull")
+	cmd.Flags().StringVar(&credsFile, "minio-credentials-file", "", "Read MINIO_ACCESS_KEY and MINIO_SECRET_KEY from a KEY=VALUE file instead of the environment")
+	cmd.Flags().DurationVar(&ttl, "ttl", 0, "Expire the playground after this duration (e.g. 2h) so 'miup playground reap' cleans it up")
+	cmd.Flags().StringVar(&importPath, "import", "", "Take version, monitoring and ports from an instance topology file")
 
 	return cmd
 }
@@ -513,7 +997,7 @@ func newPlaygroundStopCmd() *cobra.Command {
 				tag = "default"
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
+			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
 			manager := playground.NewManager(profile)
@@ -546,7 +1030,7 @@ func newPlaygroundStatusCmd() *cobra.Command {
 				tag = "default"
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			manager := playground.NewManager(profile)
 
 			status, err := manager.Status(ctx, tag)
@@ -589,6 +1073,52 @@ func newPlaygroundStatusCmd() *cobra.Command {
 	return cmd
 }
 
+func newPlaygroundDiagnoseCmd() *cobra.Command {
+	var (
+		tag        string
+		outputJSON bool
+		strict     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diagnose",
+		Short: "Run health diagnostics on the playground",
+		Long: `Perform health diagnostics on a local playground instance.
+
+This command checks:
+  - Docker container state and healthcheck status of each service
+  - etcd endpoint health from inside its container
+  - Milvus and MinIO port connectivity and the Milvus health endpoint
+  - Existence of the MinIO bucket Milvus stores data in
+
+Examples:
+  miup playground diagnose
+  miup playground diagnose --tag dev --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			manager := playground.NewManager(profile)
+
+			result, err := manager.Diagnose(ctx, tag)
+			if err != nil {
+				return err
+			}
+
+			return printDiagnosis(tag, result, outputJSON, strict)
+		},
+	}
+
+	cmd.Flags().StringVar(&tag, "tag", "default", "Tag name of the playground instance")
+	cmd.Flags().BoolVar(&outputJSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Exit with code 2 if any check warns")
+
+	return cmd
+}
+
 func newPlaygroundListCmd() *cobra.Command {
 	var jsonOutput bool
 
@@ -601,7 +1131,7 @@ func newPlaygroundListCmd() *cobra.Command {
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			manager := playground.NewManager(profile)
 
 			instances, err := manager.List(ctx)
@@ -656,6 +1186,8 @@ func newPlaygroundLogsCmd() *cobra.Command {
 		tag     string
 		service string
 		tail    int
+		since   time.Duration
+		grep    string
 	)
 
 	cmd := &cobra.Command{
@@ -670,11 +1202,19 @@ func newPlaygroundLogsCmd() *cobra.Command {
 			if tag == "" {
 				tag = "default"
 			}
+			if since < 0 {
+				return fmt.Errorf("--since must not be negative")
+			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			manager := playground.NewManager(profile)
 
-			logs, err := manager.Logs(ctx, tag, service, tail)
+			logs, err := manager.Logs(ctx, tag, playground.LogsOptions{
+				Service: service,
+				Tail:    tail,
+				Since:   since,
+				Grep:    grep,
+			})
 			if err != nil {
 				return err
 			}
@@ -687,6 +1227,8 @@ func newPlaygroundLogsCmd() *cobra.Command {
 	cmd.Flags().StringVar(&tag, "tag", "default", "Tag name of the playground instance")
 	cmd.Flags().StringVarP(&service, "service", "s", "", "Service name (e.g., standalone, etcd, minio)")
 	cmd.Flags().IntVarP(&tail, "tail", "n", 100, "Number of lines to show")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show logs newer than a relative duration (e.g., 10m, 1h)")
+	cmd.Flags().StringVar(&grep, "grep", "", "Only show lines matching a regular expression")
 
 	return cmd
 }
@@ -707,7 +1249,7 @@ func newPlaygroundCleanCmd() *cobra.Command {
 				tag = "default"
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			manager := playground.NewManager(profile)
 
 			return manager.Clean(ctx, tag)
@@ -719,16 +1261,75 @@ func newPlaygroundCleanCmd() *cobra.Command {
 	return cmd
 }
 
-func formatStatus(status playground.Status) string {
-	switch status {
-	case playground.StatusRunning:
-		return color.GreenString("running")
-	case playground.StatusStopped:
-		return color.YellowString("stopped")
-	default:
-		return color.RedString("unknown")
-	}
-}
+func newPlaygroundReapCmd() *cobra.Command {
+	var (
+		dryRun bool
+		yes    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "reap",
+		Short: "Clean up playgrounds whose TTL has expired",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			manager := playground.NewManager(profile)
+
+			expired, err := manager.Expired(time.Now())
+			if err != nil {
+				return err
+			}
+			if len(expired) == 0 {
+				fmt.Println("No expired playgrounds")
+				return nil
+			}
+
+			fmt.Printf("The following %d playground(s) have expired:\n", len(expired))
+			for _, meta := range expired {
+				fmt.Printf("  %s (expired %s)\n", meta.Tag, formatExpiry(meta.ExpiresAt))
+			}
+			if dryRun {
+				return nil
+			}
+			if !yes && !confirm(fmt.Sprintf("Clean up %d playground(s)?", len(expired))) {
+				return fmt.Errorf("aborted")
+			}
+
+			failed := 0
+			for _, meta := range expired {
+				if err := manager.Clean(ctx, meta.Tag); err != nil {
+					logger.Error("Failed to clean up playground '%s': %v", meta.Tag, err)
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d playground(s) failed to clean up", failed, len(expired))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list the expired playgrounds")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation")
+
+	return cmd
+}
+
+func formatStatus(status playground.Status) string {
+	switch status {
+	case playground.StatusRunning:
+		return color.GreenString("running")
+	case playground.StatusStopped:
+		return color.YellowString("stopped")
+	default:
+		return color.RedString("unknown")
+	}
+}
 
 func newClusterCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -745,30 +1346,50 @@ Examples:
   miup instance start prod                             Start an instance
   miup instance stop prod                              Stop an instance
   miup instance scale prod --component querynode --replicas 3   Scale a component
+  miup instance resize-pvc prod -c minio --size 200Gi Expand dependency volumes
+  miup instance describe prod                          Show operator conditions and components
   miup instance replicas prod                          Show current replicas
   miup instance upgrade prod v2.5.5                    Upgrade to a new version
   miup instance config show prod                       Show configuration
   miup instance config set prod key=value              Set configuration
   miup instance diagnose prod                          Health diagnostics
+  miup instance events prod --watch                    Follow Kubernetes events live
   miup instance destroy prod                           Destroy an instance
+  miup instance destroy -l env=ci -y                   Destroy all instances labeled env=ci
+  miup instance reap -y                                Destroy instances past their --ttl
   miup instance check                                  Pre-deployment environment check`,
 	}
 
 	cmd.AddCommand(newInstanceCheckCmd())
 	cmd.AddCommand(newInstanceAuditCmd())
 	cmd.AddCommand(newInstanceDeployCmd())
+	cmd.AddCommand(newInstanceValidateCmd())
 	cmd.AddCommand(newInstanceListCmd())
 	cmd.AddCommand(newInstanceDisplayCmd())
+	cmd.AddCommand(newInstanceDescribeCmd())
+	cmd.AddCommand(newInstanceDescribeOperatorCmd())
+	cmd.AddCommand(newInstanceGetEndpointCmd())
 	cmd.AddCommand(newInstanceStartCmd())
 	cmd.AddCommand(newInstanceStopCmd())
 	cmd.AddCommand(newInstanceScaleCmd())
+	cmd.AddCommand(newInstanceResizePVCCmd())
+	cmd.AddCommand(newInstanceMaintenanceCmd())
 	cmd.AddCommand(newInstanceReplicasCmd())
+	cmd.AddCommand(newInstanceCostCmd())
+	cmd.AddCommand(newInstancePortForwardAllCmd())
 	cmd.AddCommand(newInstanceUpgradeCmd())
+	cmd.AddCommand(newInstanceSetImageCmd())
+	cmd.AddCommand(newInstanceApplyCmd())
 	cmd.AddCommand(newInstanceConfigCmd())
+	cmd.AddCommand(newInstanceSnapshotConfigCmd())
 	cmd.AddCommand(newInstanceReloadCmd())
 	cmd.AddCommand(newInstanceDiagnoseCmd())
+	cmd.AddCommand(newInstanceRepairCmd())
+	cmd.AddCommand(newInstancePruneCmd())
 	cmd.AddCommand(newInstanceDestroyCmd())
+	cmd.AddCommand(newInstanceReapCmd())
 	cmd.AddCommand(newInstanceLogsCmd())
+	cmd.AddCommand(newInstanceEventsCmd())
 	cmd.AddCommand(newInstanceTemplateCmd())
 
 	return cmd
@@ -782,16 +1403,57 @@ func newInstanceDeployCmd() *cobra.Command {
 		kubecontext   string
 		namespace     string
 		withMonitor   bool
+		envPairs      []string
+		spreadZones   bool
+		apply         bool
+		labelPairs    []string
+		ttl           time.Duration
+		sets          []string
+		createNS      bool
+		nsPerInstance bool
+		strictEnv     bool
 	)
 
 	cmd := &cobra.Command{
 		Use:   "deploy <instance-name> <topology.yaml>",
 		Short: "Deploy a Milvus instance to Kubernetes",
-		Args:  cobra.ExactArgs(2),
+		Long: `Deploy a Milvus instance to Kubernetes from a topology file.
+
+The topology may also be read from standard input with "-" or fetched from
+an http(s) URL; either way it is validated like a local file.
+
+${VAR} and ${VAR:-default} in the topology are replaced with environment
+variables, so one topology can serve several environments; $$ is a literal $.
+Unset variables expand to an empty string, or fail the deploy with
+--strict-env.
+
+Examples:
+  miup instance deploy prod topology.yaml
+  generate-topology | miup instance deploy prod -
+  miup instance deploy staging topology.yaml --set milvus_servers[0].components.queryNode.replicas=5
+  miup instance deploy prod https://example.com/topologies/prod.yaml
+  miup instance deploy prod topology.yaml --namespace-per-instance
+  STORAGE_CLASS=gp3 miup instance deploy prod topology.yaml --strict-env`,
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			instanceName := args[0]
 			topoFile := args[1]
 
+			// One namespace per instance, named after it, for isolation
+			if nsPerInstance {
+				namespace = instanceName
+				createNS = true
+			}
+
+			env, err := spec.ParseEnv(envPairs)
+			if err != nil {
+				return err
+			}
+			labels, err := spec.ParseLabels(labelPairs)
+			if err != nil {
+				return err
+			}
+
 			profile, err := localdata.DefaultProfile()
 			if err != nil {
 				return err
@@ -800,7 +1462,7 @@ func newInstanceDeployCmd() *cobra.Command {
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
+			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
 			sigCh := make(chan os.Signal, 1)
@@ -810,14 +1472,24 @@ func newInstanceDeployCmd() *cobra.Command {
 				cancel()
 			}()
 
+			warnIfOutdatedMilvus(ctx, milvusVersion)
+
 			mgr := manager.NewManager(profile)
 			opts := manager.DeployOptions{
-				MilvusVersion: milvusVersion,
-				SkipConfirm:   skipConfirm,
-				Kubeconfig:    kubeconfig,
-				KubeContext:   kubecontext,
-				Namespace:     namespace,
-				WithMonitor:   withMonitor,
+				MilvusVersion:   milvusVersion,
+				SkipConfirm:     skipConfirm,
+				Kubeconfig:      kubeconfig,
+				KubeContext:     kubecontext,
+				Namespace:       namespace,
+				WithMonitor:     withMonitor,
+				Env:             env,
+				SpreadZones:     spreadZones,
+				Apply:           apply,
+				Labels:          labels,
+				TTL:             ttl,
+				Set:             sets,
+				CreateNamespace: createNS,
+				StrictEnv:       strictEnv,
 			}
 
 			start := time.Now()
@@ -834,12 +1506,14 @@ func newInstanceDeployCmd() *cobra.Command {
 				if ns == "" {
 					ns = info.Meta.Namespace
 				}
-				fmt.Println()
-				fmt.Println("Connect to Milvus:")
-				fmt.Printf("  %s\n", color.CyanString("Namespace: %s", ns))
-				fmt.Printf("  %s\n", color.CyanString("Use: kubectl port-forward svc/%s-milvus -n %s 19530:19530", instanceName, ns))
-				fmt.Printf("  %s\n", color.CyanString("SDK:      from pymilvus import MilvusClient"))
-				fmt.Printf("  %s\n", color.CyanString("          client = MilvusClient('http://localhost:19530')"))
+				// Keep stdout to the events if they are written there
+				out := commandOutput()
+				fmt.Fprintln(out)
+				fmt.Fprintln(out, "Connect to Milvus:")
+				fmt.Fprintf(out, "  %s\n", color.CyanString("Namespace: %s", ns))
+				fmt.Fprintf(out, "  %s\n", color.CyanString("Use: kubectl port-forward svc/%s-milvus -n %s 19530:19530", instanceName, ns))
+				fmt.Fprintf(out, "  %s\n", color.CyanString("SDK:      from pymilvus import MilvusClient"))
+				fmt.Fprintf(out, "  %s\n", color.CyanString("          client = MilvusClient('http://localhost:19530')"))
 			}
 
 			return nil
@@ -847,35 +1521,160 @@ func newInstanceDeployCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation")
-	cmd.Flags().StringVar(&milvusVersion, "milvus.version", "v2.5.4", "Milvus version to use")
+	cmd.Flags().StringVar(&milvusVersion, "milvus.version", version.MilvusDefault(), "Milvus version to use")
 	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (defaults to ~/.kube/config)")
 	cmd.Flags().StringVar(&kubecontext, "context", "", "Kubernetes context to use")
-	cmd.Flags().StringVar(&namespace, "namespace", "milvus", "Kubernetes namespace for deployment")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Kubernetes namespace for deployment (default: global.namespace of the topology, or milvus)")
 	cmd.Flags().BoolVar(&withMonitor, "with-monitor", false, "Enable monitoring (creates PodMonitor for Prometheus Operator)")
+	cmd.Flags().StringArrayVar(&sets, "set", nil, "Override a topology value as PATH=VALUE, e.g. milvus_servers[0].components.queryNode.replicas=5 (repeatable)")
+	cmd.Flags().StringArrayVar(&envPairs, "env", nil, "Environment variable for all Milvus components as KEY=VALUE (repeatable)")
+	cmd.Flags().BoolVar(&spreadZones, "spread-zones", false, "Spread component pods across availability zones (sets anti_affinity: zone)")
+	cmd.Flags().StringArrayVar(&labelPairs, "label", nil, "Label for selecting the instance in bulk operations as KEY=VALUE (repeatable)")
+	cmd.Flags().DurationVar(&ttl, "ttl", 0, "Expire the instance after this duration (e.g. 2h) so 'miup instance reap' destroys it")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Update the Milvus resource if it already exists in Kubernetes instead of failing")
+	cmd.Flags().BoolVar(&createNS, "create-namespace", false, "Create the namespace if it does not exist, labelled with the instance")
+	cmd.Flags().BoolVar(&nsPerInstance, "namespace-per-instance", false, "Deploy into a namespace named after the instance, creating it if needed")
+	cmd.Flags().BoolVar(&strictEnv, "strict-env", false, "Fail if the topology references an environment variable that is not set")
+	cmd.MarkFlagsMutuallyExclusive("namespace", "namespace-per-instance")
+
+	return cmd
+}
+
+func newInstanceValidateCmd() *cobra.Command {
+	var (
+		jsonOutput bool
+		strictEnv  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "validate <topology.yaml>",
+		Short: "Validate a topology file without deploying",
+		Long: `Validate a topology file the way deploy does, without touching any cluster,
+and report every problem found:
+
+  - unknown keys (typos such as 'queryNod' are otherwise silently ignored)
+  - invalid values, e.g. resource and storage quantities
+  - missing required fields
+  - with --strict-env, undefined ${VAR} environment variables
+
+The command exits non-zero if the topology is invalid, so it can be used in
+pre-commit hooks and CI. The topology may also be '-' (stdin) or a URL.
+
+Examples:
+  miup instance validate topology.yaml
+  miup instance validate topology.yaml --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			topologyFile := args[0]
+
+			problems, err := spec.ValidateTopology(topologyFile, spec.LoadOptions{StrictEnv: strictEnv})
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				result := struct {
+					Topology string         `json:"topology"`
+					Valid    bool           `json:"valid"`
+					Problems []spec.Problem `json:"problems"`
+				}{topologyFile, len(problems) == 0, problems}
+				if result.Problems == nil {
+					result.Problems = []spec.Problem{}
+				}
+				if err := output.PrintJSON(os.Stdout, output.NewSuccessResult(result)); err != nil {
+					return err
+				}
+			} else if len(problems) == 0 {
+				fmt.Printf("%s %s is valid\n", color.GreenString("✓"), topologyFile)
+			} else {
+				fmt.Printf("%s %s is invalid:\n", color.RedString("✗"), topologyFile)
+				for _, p := range problems {
+					fmt.Printf("  - %s\n", p)
+				}
+			}
+
+			if len(problems) > 0 {
+				return fmt.Errorf("%w: %d problem(s) in %s", manager.ErrInvalidTopology, len(problems), topologyFile)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&strictEnv, "strict-env", false, "Report environment variables the topology references but are not set")
 
 	return cmd
 }
 
 func newInstanceListCmd() *cobra.Command {
-	var jsonOutput bool
+	var (
+		jsonOutput    bool
+		allNamespaces bool
+		outputFormat  string
+		kubeconfig    string
+		kubecontext   string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all instances",
+		Long: `List instances managed by miup.
+
+With --all-namespaces, list every Milvus resource in the Kubernetes cluster,
+including ones miup did not create. The MANAGED column shows whether each one
+is tracked locally (miup), labelled by miup but missing local metadata
+(untracked, recoverable with 'miup instance repair'), or external.
+
+With -o wide, NAMESPACE and ENDPOINT columns are added. Endpoints are
+fetched from each instance's Milvus service, several at a time; instances
+whose service can't be reached show "-".
+
+Examples:
+  miup instance list
+  miup instance list -o wide
+  miup instance list --all-namespaces --context prod-cluster`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != "" && outputFormat != "wide" {
+				return fmt.Errorf("unknown output format: %s (supported: wide)", outputFormat)
+			}
+			wide := outputFormat == "wide"
+			if wide && allNamespaces {
+				return fmt.Errorf("-o wide cannot be used with --all-namespaces")
+			}
+
 			profile, err := localdata.DefaultProfile()
 			if err != nil {
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			mgr := manager.NewManager(profile)
 
+			if allNamespaces {
+				clusters, err := mgr.Discover(ctx, manager.DiscoverOptions{
+					Kubeconfig:  kubeconfig,
+					KubeContext: kubecontext,
+				})
+				if err != nil {
+					return err
+				}
+				return printDiscoveredClusters(clusters, jsonOutput)
+			}
+
 			instances, err := mgr.List(ctx)
 			if err != nil {
 				return err
 			}
 
+			var endpoints map[string]*executor.Endpoint
+			if wide {
+				names := make([]string, 0, len(instances))
+				for _, c := range instances {
+					names = append(names, c.Name)
+				}
+				endpoints = mgr.Endpoints(ctx, names)
+			}
+
 			if jsonOutput {
 				var instList []output.InstanceSummary
 				for _, c := range instances {
@@ -887,6 +1686,7 @@ func newInstanceListCmd() *cobra.Command {
 						Version:   c.MilvusVersion,
 						Port:      c.MilvusPort,
 						Namespace: c.Namespace,
+						Endpoint:  endpointAddress(endpoints[c.Name]),
 						CreatedAt: c.CreatedAt,
 					})
 				}
@@ -899,9 +1699,27 @@ func newInstanceListCmd() *cobra.Command {
 			}
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			fmt.Fprintln(w, "NAME\tSTATUS\tMODE\tBACKEND\tVERSION\tPORT\tCREATED")
+			if wide {
+				fmt.Fprintln(w, "NAME\tNAMESPACE\tSTATUS\tMODE\tBACKEND\tVERSION\tPORT\tENDPOINT\tCREATED")
+			} else {
+				fmt.Fprintln(w, "NAME\tSTATUS\tMODE\tBACKEND\tVERSION\tPORT\tCREATED")
+			}
 
 			for _, c := range instances {
+				if wide {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+						c.Name,
+						orDash(c.Namespace),
+						c.Status,
+						c.Mode,
+						c.Backend,
+						c.MilvusVersion,
+						c.MilvusPort,
+						orDash(endpointAddress(endpoints[c.Name])),
+						c.CreatedAt.Format("2006-01-02 15:04"),
+					)
+					continue
+				}
 				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
 					c.Name,
 					c.Status,
@@ -918,9 +1736,82 @@ func newInstanceListCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format: wide adds namespace and endpoint columns")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List Milvus resources in all namespaces of the Kubernetes cluster")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file for --all-namespaces (defaults to ~/.kube/config)")
+	cmd.Flags().StringVar(&kubecontext, "context", "", "Kubernetes context to use for --all-namespaces")
 	return cmd
 }
 
+// endpointAddress returns the cluster address of an endpoint, or "" if it is
+// unknown
+func endpointAddress(endpoint *executor.Endpoint) string {
+	if endpoint == nil {
+		return ""
+	}
+	return endpoint.Address
+}
+
+// orDash returns s, or "-" if it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// printDiscoveredClusters prints Milvus resources found across namespaces
+func printDiscoveredClusters(clusters []manager.DiscoveredCluster, jsonOutput bool) error {
+	if jsonOutput {
+		instList := make([]output.InstanceSummary, 0, len(clusters))
+		for _, c := range clusters {
+			instList = append(instList, output.InstanceSummary{
+				Name:      c.Name,
+				Status:    c.Status,
+				Mode:      c.Mode,
+				Backend:   string(spec.BackendKubernetes),
+				Version:   c.Version,
+				Namespace: c.Namespace,
+				CreatedAt: c.CreatedAt,
+				Managed:   managedState(c),
+			})
+		}
+		return output.PrintJSON(os.Stdout, output.NewSuccessResult(output.InstanceList{Instances: instList}))
+	}
+
+	if len(clusters) == 0 {
+		fmt.Println("No Milvus resources found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tSTATUS\tMODE\tVERSION\tMANAGED\tCREATED")
+	for _, c := range clusters {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Namespace,
+			c.Name,
+			c.Status,
+			c.Mode,
+			c.Version,
+			managedState(c),
+			c.CreatedAt.Format("2006-01-02 15:04"),
+		)
+	}
+	return w.Flush()
+}
+
+// managedState describes whether miup manages a discovered Milvus resource
+func managedState(c manager.DiscoveredCluster) string {
+	switch {
+	case c.Managed:
+		return "miup"
+	case c.ManagedBy == "miup":
+		return "untracked"
+	default:
+		return "external"
+	}
+}
+
 func newInstanceDisplayCmd() *cobra.Command {
 	var jsonOutput bool
 
@@ -936,7 +1827,7 @@ func newInstanceDisplayCmd() *cobra.Command {
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			mgr := manager.NewManager(profile)
 
 			info, err := mgr.Display(ctx, instanceName)
@@ -953,6 +1844,7 @@ func newInstanceDisplayCmd() *cobra.Command {
 					Mode:      string(meta.Mode),
 					Backend:   string(meta.Backend),
 					Version:   meta.MilvusVersion,
+					Image:     meta.Image,
 					Port:      meta.MilvusPort,
 					Namespace: meta.Namespace,
 					CreatedAt: meta.CreatedAt,
@@ -965,8 +1857,14 @@ func newInstanceDisplayCmd() *cobra.Command {
 			fmt.Printf("Mode:     %s\n", meta.Mode)
 			fmt.Printf("Backend:  %s\n", meta.Backend)
 			fmt.Printf("Version:  %s\n", meta.MilvusVersion)
+			if meta.Image != "" {
+				fmt.Printf("Image:    %s\n", meta.Image)
+			}
 			fmt.Printf("Port:     %d\n", meta.MilvusPort)
 			fmt.Printf("Created:  %s\n", meta.CreatedAt.Format("2006-01-02 15:04:05"))
+			if meta.ExpiresAt != nil {
+				fmt.Printf("Expires:  %s (%s)\n", meta.ExpiresAt.Format("2006-01-02 15:04:05"), formatExpiry(meta.ExpiresAt))
+			}
 
 			if info.ContainerStatus != "" {
 				fmt.Println()
@@ -981,11 +1879,16 @@ func newInstanceDisplayCmd() *cobra.Command {
 	return cmd
 }
 
-func newInstanceStartCmd() *cobra.Command {
+func newInstanceDescribeCmd() *cobra.Command {
+	var jsonOutput bool
+
 	cmd := &cobra.Command{
-		Use:   "start <instance-name>",
-		Short: "Start an instance",
-		Args:  cobra.ExactArgs(1),
+		Use:   "describe <instance-name>",
+		Short: "Show the operator-reported status of an instance",
+		Long: `Show the full Milvus CRD status reported by the Milvus Operator: the
+status conditions with their transition times, the endpoint, and the image
+and replica counts of each component.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			instanceName := args[0]
 
@@ -994,96 +1897,413 @@ func newInstanceStartCmd() *cobra.Command {
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-
+			ctx := cmd.Context()
 			mgr := manager.NewManager(profile)
-			start := time.Now()
-			startErr := mgr.Start(ctx, instanceName)
-			auditLog(instanceName, "start", nil, startErr, time.Since(start))
-			return startErr
+
+			desc, err := mgr.Describe(ctx, instanceName)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(os.Stdout, output.NewSuccessResult(desc))
+			}
+
+			status := desc.Status
+			if desc.StatusSince != nil {
+				status = fmt.Sprintf("%s (for %s)", status, formatAge(*desc.StatusSince))
+			}
+
+			fmt.Printf("Cluster:    %s\n", color.CyanString(desc.Name))
+			fmt.Printf("Namespace:  %s\n", desc.Namespace)
+			fmt.Printf("Status:     %s\n", status)
+			if desc.Endpoint != "" {
+				fmt.Printf("Endpoint:   %s\n", desc.Endpoint)
+			}
+			if desc.Image != "" {
+				fmt.Printf("Image:      %s\n", desc.Image)
+			}
+			if !desc.CreatedAt.IsZero() {
+				fmt.Printf("Created:    %s (%s ago)\n", desc.CreatedAt.Format("2006-01-02 15:04:05"), formatAge(desc.CreatedAt))
+			}
+
+			fmt.Println()
+			printConditions(desc.Conditions)
+
+			fmt.Println()
+			fmt.Println("Components:")
+			if len(desc.Components) == 0 {
+				fmt.Println("  (none reported)")
+			} else {
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "  COMPONENT\tREADY\tUP-TO-DATE\tAVAILABLE\tIMAGE")
+				for _, c := range desc.Components {
+					fmt.Fprintf(w, "  %s\t%d/%d\t%d\t%d\t%s\n", c.Name, c.Ready, c.Replicas, c.Updated, c.Available, c.Image)
+				}
+				w.Flush()
+			}
+
+			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	return cmd
 }
 
-func newInstanceStopCmd() *cobra.Command {
+// printConditions prints status conditions with their age
+func printConditions(conditions []executor.ConditionInfo) {
+	fmt.Println("Conditions:")
+	if len(conditions) == 0 {
+		fmt.Println("  (none reported)")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tAGE\tMESSAGE")
+	for _, c := range conditions {
+		age := "-"
+		if !c.LastTransitionTime.IsZero() {
+			age = formatAge(c.LastTransitionTime)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, age, c.Message)
+	}
+	w.Flush()
+}
+
+func newInstanceDescribeOperatorCmd() *cobra.Command {
+	var (
+		jsonOutput  bool
+		kubeconfig  string
+		kubecontext string
+	)
+
 	cmd := &cobra.Command{
-		Use:   "stop <instance-name>",
-		Short: "Stop an instance",
-		Args:  cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			instanceName := args[0]
+		Use:   "describe-operator",
+		Short: "Show Milvus Operator health and the instances it manages",
+		Long: `Show the state of the Milvus Operator and of every Milvus resource in the
+Kubernetes cluster: a fleet-level view to start from when the operator
+misbehaves, complementing the per-instance diagnose.
 
+The operator deployment is looked up like 'miup instance check' does, in the
+namespaces ` + strings.Join(k8s.MilvusOperatorNamespaces, ", ") + `. Its version, ready replicas and
+deployment conditions are shown, followed by the Milvus resources in all
+namespaces with their status and whether miup tracks them.
+
+Examples:
+  miup instance describe-operator
+  miup instance describe-operator --context prod --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			profile, err := localdata.DefaultProfile()
 			if err != nil {
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-
+			ctx := cmd.Context()
 			mgr := manager.NewManager(profile)
-			start := time.Now()
-			stopErr := mgr.Stop(ctx, instanceName)
-			auditLog(instanceName, "stop", nil, stopErr, time.Since(start))
-			return stopErr
+
+			overview, err := mgr.DescribeOperator(ctx, manager.DiscoverOptions{
+				Kubeconfig:  kubeconfig,
+				KubeContext: kubecontext,
+			})
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(os.Stdout, output.NewSuccessResult(overview))
+			}
+
+			op := overview.Operator
+			if op == nil {
+				fmt.Printf("%s Milvus CRD found but no %s deployment in namespaces %s\n",
+					color.YellowString("!"), k8s.MilvusOperatorDeployment, strings.Join(k8s.MilvusOperatorNamespaces, ", "))
+			} else {
+				health := color.GreenString("Healthy")
+				if !op.Healthy() {
+					health = color.RedString("Unhealthy")
+				}
+				fmt.Printf("Operator:   %s\n", color.CyanString(k8s.MilvusOperatorDeployment))
+				fmt.Printf("Namespace:  %s\n", op.Namespace)
+				fmt.Printf("Status:     %s\n", health)
+				fmt.Printf("Version:    %s\n", op.Version)
+				fmt.Printf("Image:      %s\n", op.Image)
+				fmt.Printf("Replicas:   %d/%d ready, %d up-to-date, %d available\n", op.Ready, op.Replicas, op.Updated, op.Available)
+				fmt.Println()
+				printConditions(op.Conditions)
+			}
+
+			fmt.Println()
+			fmt.Printf("Instances (%d):\n", len(overview.Instances))
+			return printDiscoveredClusters(overview.Instances, false)
 		},
 	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (defaults to ~/.kube/config)")
+	cmd.Flags().StringVar(&kubecontext, "context", "", "Kubernetes context to use")
 	return cmd
 }
 
-func newInstanceScaleCmd() *cobra.Command {
+func newInstanceGetEndpointCmd() *cobra.Command {
 	var (
-		component     string
-		replicas      int
-		cpuRequest    string
-		cpuLimit      string
-		memoryRequest string
-		memoryLimit   string
+		external   bool
+		jsonOutput bool
 	)
 
 	cmd := &cobra.Command{
-		Use:   "scale <instance-name>",
-		Short: "Scale a component in the instance",
-		Long: `Scale a Milvus component by changing replicas and/or resources.
+		Use:   "get-endpoint <instance-name>",
+		Short: "Print the Milvus endpoint of an instance",
+		Long: `Print the host:port of an instance's Milvus service and nothing else, for
+use in scripts.
 
-This command only works with Kubernetes deployments (distributed mode).
-Local deployments (standalone mode) do not support scaling.
-
-You can perform:
-  - Horizontal scaling: change the number of replicas
-  - Vertical scaling: change CPU/memory resources
+By default the service's cluster IP is printed (its DNS name for a headless
+service), which is reachable from inside the cluster. With --external, the
+LoadBalancer ingress address or, for a NodePort service, a node address and
+the node port is printed instead; a ClusterIP service has no external
+address, so its cluster IP is printed with a warning.
 
-Available components for distributed mode:
-  proxy       Milvus proxy (API gateway)
-  querynode   Query node (handles search requests)
-  datanode    Data node (handles data writes)
-  indexnode   Index node (builds indexes)
-  rootcoord   Root coordinator
-  querycoord  Query coordinator
-  datacoord   Data coordinator
-  indexcoord  Index coordinator
+--json prints the full connection details: service, namespace, type, both
+addresses and whether TLS is enabled.
 
 Examples:
-  # Scale replicas (horizontal scaling)
-  miup instance scale prod --component querynode --replicas 5
-  miup instance scale prod -c datanode -r 3
-
-  # Update resources (vertical scaling)
-  miup instance scale prod -c querynode --cpu-request 2 --memory-request 8Gi
-  miup instance scale prod -c querynode --cpu-limit 4 --memory-limit 16Gi
-
-  # Combined scaling (both replicas and resources)
-  miup instance scale prod -c querynode -r 5 --cpu-request 4 --memory-request 16Gi`,
+  ENDPOINT=$(miup instance get-endpoint prod)
+  miup instance get-endpoint prod --external
+  miup instance get-endpoint prod --json`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			instanceName := args[0]
 
-			if component == "" {
-				return fmt.Errorf("--component is required")
-			}
-
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			mgr := manager.NewManager(profile)
+
+			endpoint, err := mgr.Endpoint(ctx, instanceName)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(os.Stdout, output.NewSuccessResult(endpoint))
+			}
+
+			address := endpoint.Address
+			if external {
+				if endpoint.External != "" {
+					address = endpoint.External
+				} else {
+					logger.Warn("Service %s is of type %s and has no external address, printing its cluster IP", endpoint.Service, endpoint.Type)
+				}
+			}
+			if address == "" {
+				return fmt.Errorf("service %s has no address", endpoint.Service)
+			}
+
+			fmt.Println(address)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&external, "external", false, "Prefer the LoadBalancer or NodePort address")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+// formatAge formats the time since t like kubectl, e.g. "45s", "12m", "3h" or "2d"
+func formatAge(t time.Time) string {
+	return formatDurationShort(time.Since(t))
+}
+
+// formatDurationShort formats d in its largest whole unit, e.g. "45s", "12m", "3h" or "2d"
+func formatDurationShort(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+func newInstanceStartCmd() *cobra.Command {
+	var (
+		bulk bulkFlags
+		ttl  time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "start <instance-name> | --selector <selector>",
+		Short: "Start an instance",
+		Args:  bulk.args,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			// --ttl resets the expiry from now; --ttl 0 removes it
+			setTTL := cmd.Flags().Changed("ttl")
+
+			mgr := manager.NewManager(profile)
+			startWithTTL := func(ctx context.Context, name string) error {
+				if err := mgr.Start(ctx, name); err != nil {
+					return err
+				}
+				if setTTL {
+					return mgr.SetTTL(ctx, name, ttl)
+				}
+				return nil
+			}
+
+			if bulk.selector != "" {
+				return runBulk(mgr, "start", bulk, startWithTTL)
+			}
+
+			instanceName := args[0]
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			start := time.Now()
+			startErr := startWithTTL(ctx, instanceName)
+			auditLog(instanceName, "start", nil, startErr, time.Since(start))
+			return startErr
+		},
+	}
+	bulk.register(cmd)
+	cmd.Flags().DurationVar(&ttl, "ttl", 0, "Expire the instance this long from now (0 removes the expiry)")
+	return cmd
+}
+
+func newInstanceStopCmd() *cobra.Command {
+	var bulk bulkFlags
+
+	cmd := &cobra.Command{
+		Use:   "stop <instance-name> | --selector <selector>",
+		Short: "Stop an instance",
+		Args:  bulk.args,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			mgr := manager.NewManager(profile)
+			if bulk.selector != "" {
+				return runBulk(mgr, "stop", bulk, func(ctx context.Context, name string) error {
+					return mgr.Stop(ctx, name)
+				})
+			}
+
+			instanceName := args[0]
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			start := time.Now()
+			stopErr := mgr.Stop(ctx, instanceName)
+			auditLog(instanceName, "stop", nil, stopErr, time.Since(start))
+			return stopErr
+		},
+	}
+	bulk.register(cmd)
+	return cmd
+}
+
+func newInstanceScaleCmd() *cobra.Command {
+	var (
+		component     string
+		replicas      int
+		cpuRequest    string
+		cpuLimit      string
+		memoryRequest string
+		memoryLimit   string
+		envPairs      []string
+		dryRun        bool
+		fromMetrics   bool
+		targetCPU     float64
+		minReplicas   int
+		maxReplicas   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scale <instance-name>",
+		Short: "Scale a component in the instance",
+		Long: `Scale a Milvus component by changing replicas and/or resources.
+
+This command only works with Kubernetes deployments (distributed mode).
+Local deployments (standalone mode) do not support scaling.
+
+You can perform:
+  - Horizontal scaling: change the number of replicas
+  - Vertical scaling: change CPU/memory resources
+  - Environment: set or override environment variables
+
+Available components for distributed mode:
+  proxy       Milvus proxy (API gateway)
+  querynode   Query node (handles search requests)
+  datanode    Data node (handles data writes)
+  indexnode   Index node (builds indexes)
+  rootcoord   Root coordinator
+  querycoord  Query coordinator
+  datacoord   Data coordinator
+  indexcoord  Index coordinator
+  mixcoord       Combined coordinator (Milvus 2.5+)
+  streamingnode  Streaming node (Milvus 2.5+)
+
+Any other component the installed Milvus Operator supports (read from its
+CRD) is accepted too.
+
+Examples:
+  # Scale replicas (horizontal scaling)
+  miup instance scale prod --component querynode --replicas 5
+  miup instance scale prod -c datanode -r 3
+
+  # Update resources (vertical scaling)
+  miup instance scale prod -c querynode --cpu-request 2 --memory-request 8Gi
+  miup instance scale prod -c querynode --cpu-limit 4 --memory-limit 16Gi
+
+  # Combined scaling (both replicas and resources)
+  miup instance scale prod -c querynode -r 5 --cpu-request 4 --memory-request 16Gi
+
+  # Set environment variables
+  miup instance scale prod -c querynode --env GOGC=200 --env GODEBUG=madvdontneed=1
+
+  # Review the change to the Milvus resource without applying it
+  miup instance scale prod -c querynode -r 5 --dry-run
+
+  # Right-size query nodes for 60% CPU utilization from their current usage
+  miup instance scale prod -c querynode --from-metrics --target-cpu 60 --max-replicas 10
+
+Scaling from metrics:
+  --from-metrics makes a one-shot scaling decision, like a HorizontalPodAutoscaler
+  that runs once. It reads the component's current CPU usage from the metrics
+  API (metrics-server) and divides it by the pods' CPU requests. The replica
+  count is then scaled by the ratio of that utilization to --target-cpu and
+  rounded up. It is left unchanged if the utilization is within 10% of the
+  target, and is kept within --min-replicas and --max-replicas. The pods need a
+  CPU request; set one with a separate scale --cpu-request first, since
+  --from-metrics can't be combined with --cpu-request or --cpu-limit. Use
+  --dry-run to see the decision without applying it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			if component == "" {
+				return fmt.Errorf("--component is required")
+			}
+
+			env, err := spec.ParseEnv(envPairs)
+			if err != nil {
+				return err
+			}
+
 			// Build scale options
 			opts := executor.ScaleOptions{
 				Replicas:      replicas,
@@ -1091,11 +2311,23 @@ Examples:
 				CPULimit:      cpuLimit,
 				MemoryRequest: memoryRequest,
 				MemoryLimit:   memoryLimit,
+				Env:           env,
 			}
 
 			// Check that at least one scaling option is specified
-			if !opts.HasReplicaChange() && !opts.HasResourceChange() {
-				return fmt.Errorf("at least one of --replicas, --cpu-request, --cpu-limit, --memory-request, or --memory-limit must be specified")
+			if fromMetrics && opts.HasReplicaChange() {
+				return fmt.Errorf("--from-metrics computes the replicas; it can't be combined with --replicas")
+			}
+			// The utilization is measured against the current CPU request, so
+			// replicas sized from it would be wrong for a new one
+			if fromMetrics && (cpuRequest != "" || cpuLimit != "") {
+				return fmt.Errorf("--from-metrics sizes for the current CPU request; change --cpu-request or --cpu-limit in a separate scale first")
+			}
+			if !fromMetrics && (cmd.Flags().Changed("target-cpu") || minReplicas != 0 || maxReplicas != 0) {
+				return fmt.Errorf("--target-cpu, --min-replicas and --max-replicas apply to --from-metrics")
+			}
+			if !fromMetrics && !opts.HasReplicaChange() && !opts.HasResourceChange() && !opts.HasEnvChange() {
+				return fmt.Errorf("at least one of --replicas, --from-metrics, --cpu-request, --cpu-limit, --memory-request, --memory-limit, or --env must be specified")
 			}
 
 			profile, err := localdata.DefaultProfile()
@@ -1103,7 +2335,7 @@ Examples:
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
+			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
 			sigCh := make(chan os.Signal, 1)
@@ -1114,10 +2346,40 @@ Examples:
 			}()
 
 			mgr := manager.NewManager(profile)
+			if fromMetrics {
+				rec, err := mgr.RecommendScale(ctx, instanceName, component, executor.AutoscaleOptions{
+					TargetCPU:   targetCPU,
+					MinReplicas: minReplicas,
+					MaxReplicas: maxReplicas,
+				})
+				if err != nil {
+					return err
+				}
+				logger.Info("%s: %d -> %d replicas (%s)", rec.Component, rec.CurrentReplicas, rec.TargetReplicas, rec.Reason)
+				if rec.Changed() {
+					opts.Replicas = rec.TargetReplicas
+				}
+				if !opts.HasReplicaChange() && !opts.HasResourceChange() && !opts.HasEnvChange() {
+					logger.Success("%s is right-sized at %d replicas", rec.Component, rec.CurrentReplicas)
+					return nil
+				}
+			}
+			if dryRun {
+				plan, err := mgr.PlanScale(ctx, instanceName, component, opts)
+				if err != nil {
+					return err
+				}
+				printPlan(plan)
+				return nil
+			}
+
 			start := time.Now()
 			scaleArgs := []string{fmt.Sprintf("--component=%s", component)}
+			if fromMetrics {
+				scaleArgs = append(scaleArgs, "--from-metrics", fmt.Sprintf("--target-cpu=%g", targetCPU))
+			}
 			if opts.HasReplicaChange() {
-				scaleArgs = append(scaleArgs, fmt.Sprintf("--replicas=%d", replicas))
+				scaleArgs = append(scaleArgs, fmt.Sprintf("--replicas=%d", opts.Replicas))
 			}
 			scaleErr := mgr.Scale(ctx, instanceName, component, opts)
 			auditLog(instanceName, "scale", scaleArgs, scaleErr, time.Since(start))
@@ -1131,19 +2393,221 @@ Examples:
 	cmd.Flags().StringVar(&cpuLimit, "cpu-limit", "", "CPU limit (e.g., '4', '1000m')")
 	cmd.Flags().StringVar(&memoryRequest, "memory-request", "", "Memory request (e.g., '4Gi', '512Mi')")
 	cmd.Flags().StringVar(&memoryLimit, "memory-limit", "", "Memory limit (e.g., '8Gi', '1024Mi')")
+	cmd.Flags().StringArrayVar(&envPairs, "env", nil, "Environment variable as KEY=VALUE (repeatable)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the change to the Milvus resource without applying it")
+	cmd.Flags().BoolVar(&fromMetrics, "from-metrics", false, "Set the replicas from the current CPU usage to reach --target-cpu")
+	cmd.Flags().Float64Var(&targetCPU, "target-cpu", executor.DefaultTargetCPU, "CPU utilization to size for with --from-metrics, in percent of the CPU request")
+	cmd.Flags().IntVar(&minReplicas, "min-replicas", 0, "Fewest replicas --from-metrics may choose (default 1)")
+	cmd.Flags().IntVar(&maxReplicas, "max-replicas", 0, "Most replicas --from-metrics may choose (0 for no maximum)")
 	_ = cmd.MarkFlagRequired("component")
 
 	return cmd
 }
 
+func newInstanceResizePVCCmd() *cobra.Command {
+	var (
+		component  string
+		size       string
+		timeout    time.Duration
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "resize-pvc <instance-name>",
+		Short: "Expand the persistent volumes of in-cluster etcd or MinIO",
+		Long: `Expand the persistent volume claims of an in-cluster dependency online.
+
+The storage class of each PVC must have allowVolumeExpansion enabled.
+Volumes can only grow. Some storage drivers finish the file system resize
+when the pod restarts; such volumes are reported as pending.
+
+Examples:
+  miup instance resize-pvc prod --component minio --size 200Gi
+  miup instance resize-pvc prod -c etcd --size 20Gi --timeout 15m`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-sigCh
+				cancel()
+			}()
+
+			mgr := manager.NewManager(profile)
+			start := time.Now()
+			results, resizeErr := mgr.ResizeVolumes(ctx, instanceName, executor.ResizeVolumesOptions{
+				Component: component,
+				Size:      size,
+				Timeout:   timeout,
+			})
+			auditLog(instanceName, "resize-pvc", []string{fmt.Sprintf("--component=%s", component), fmt.Sprintf("--size=%s", size)}, resizeErr, time.Since(start))
+
+			if jsonOutput && resizeErr == nil {
+				return output.PrintJSON(os.Stdout, output.NewSuccessResult(results))
+			}
+
+			if len(results) > 0 {
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "PVC\tSTORAGE CLASS\tOLD SIZE\tCAPACITY")
+				for _, r := range results {
+					capacity := r.Capacity
+					switch {
+					case capacity == "":
+						capacity = "resizing"
+					case r.Pending:
+						capacity += " (pending pod restart)"
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, r.StorageClass, r.OldSize, capacity)
+				}
+				w.Flush()
+			}
+			if resizeErr != nil {
+				return resizeErr
+			}
+
+			logger.Success("Resized %d %s volume(s) of instance '%s'", len(results), component, instanceName)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&component, "component", "c", "", "Dependency whose volumes to expand: etcd or minio (required)")
+	cmd.Flags().StringVar(&size, "size", "", "New volume size (e.g., '200Gi') (required)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "Maximum time to wait for the resize to complete")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	_ = cmd.MarkFlagRequired("component")
+	_ = cmd.MarkFlagRequired("size")
+
+	return cmd
+}
+
+func newInstanceMaintenanceCmd() *cobra.Command {
+	var (
+		node       string
+		drain      bool
+		yes        bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "maintenance <instance-name>",
+		Short: "Plan or move an instance off a Kubernetes node before maintenance",
+		Long: `List the pods of an instance scheduled on a Kubernetes node, to plan node
+maintenance.
+
+With --drain, the node is cordoned and the instance's pods are moved off it
+one at a time. Proxy, querynode, datanode and indexnode pods are replaced
+gracefully: the component is scaled up by one, the pod is deleted once the
+extra replica is ready, and the component is scaled back, so queries are not
+disrupted. Other pods (coordinators, standalone) have a single replica: each
+is deleted only once confirmed (or with --yes), and its component is
+unavailable until it is rescheduled; a declined pod is left on the node and
+the command fails. Afterwards, run 'kubectl drain' for the rest
+of the node's pods; 'kubectl uncordon' once maintenance is done.
+
+Examples:
+  miup instance maintenance prod --node worker-3
+  miup instance maintenance prod --node worker-3 --drain
+  miup instance maintenance prod --node worker-3 --drain --yes --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+			if drain && jsonOutput && !yes {
+				return fmt.Errorf("--drain with --json needs --yes, as single-replica pods can't be confirmed")
+			}
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-sigCh
+				cancel()
+			}()
+
+			mgr := manager.NewManager(profile)
+
+			var (
+				pods     []executor.NodePod
+				drainErr error
+			)
+			if drain {
+				start := time.Now()
+				confirmDelete := func(pod executor.NodePod) bool {
+					return yes || confirm(fmt.Sprintf("Delete %s pod %s? It is unavailable until rescheduled", pod.Component, pod.Name))
+				}
+				pods, drainErr = mgr.DrainNode(ctx, instanceName, node, confirmDelete)
+				auditLog(instanceName, "maintenance", []string{"--node=" + node, "--drain"}, drainErr, time.Since(start))
+			} else if pods, err = mgr.PodsOnNode(ctx, instanceName, node); err != nil {
+				return err
+			}
+
+			if jsonOutput && drainErr == nil {
+				if pods == nil {
+					pods = []executor.NodePod{}
+				}
+				return output.PrintJSON(os.Stdout, output.NewSuccessResult(pods))
+			}
+
+			if len(pods) == 0 {
+				fmt.Printf("Instance '%s' has no pods on node %s\n", instanceName, node)
+				return drainErr
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			if drain {
+				fmt.Fprintln(w, "POD\tCOMPONENT\tMOVED")
+			} else {
+				fmt.Fprintln(w, "POD\tCOMPONENT\tREADY")
+			}
+			for _, p := range pods {
+				state := p.Ready
+				if drain {
+					state = p.Moved
+				}
+				fmt.Fprintf(w, "%s\t%s\t%t\n", p.Name, p.Component, state)
+			}
+			w.Flush()
+			return drainErr
+		},
+	}
+
+	cmd.Flags().StringVar(&node, "node", "", "Kubernetes node to list or drain the instance's pods from (required)")
+	cmd.Flags().BoolVar(&drain, "drain", false, "Cordon the node and move the instance's pods off it")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Delete single-replica pods without confirmation (with --drain)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	_ = cmd.MarkFlagRequired("node")
+
+	return cmd
+}
+
 func newInstanceReplicasCmd() *cobra.Command {
+	var jsonOutput bool
+
 	cmd := &cobra.Command{
 		Use:   "replicas <instance-name>",
 		Short: "Show current replica counts",
-		Long: `Show the current replica count for each component in the instance.
+		Long: `Show the desired and ready replica count for each component in the instance.
+
+The desired count comes from the Milvus resource, the ready count from the
+pods reported ready by the Milvus Operator.
 
-For Kubernetes deployments, this shows actual running pod counts.
-For local deployments, this shows standalone replica count (always 1 when running).`,
+With --json, the data is a map from component to {"desired": N, "ready": N}.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			instanceName := args[0]
@@ -1153,32 +2617,232 @@ For local deployments, this shows standalone replica count (always 1 when runnin
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			mgr := manager.NewManager(profile)
 
-			replicas, err := mgr.GetReplicas(ctx, instanceName)
+			replicas, err := mgr.GetReplicaCounts(ctx, instanceName)
 			if err != nil {
 				return err
 			}
 
+			if jsonOutput {
+				return output.PrintJSON(os.Stdout, output.NewSuccessResult(replicas))
+			}
+
 			fmt.Printf("Instance: %s\n", color.CyanString(instanceName))
 			fmt.Println("Replicas:")
 
 			// Order components for consistent output
-			components := []string{"standalone", "proxy", "rootcoord", "querycoord", "datacoord", "indexcoord", "querynode", "datanode", "indexnode"}
+			components := make([]string, 0, len(replicas))
+			for comp := range replicas {
+				components = append(components, comp)
+			}
+			executor.SortComponents(components)
 			for _, comp := range components {
-				if count, ok := replicas[comp]; ok {
-					fmt.Printf("  %-12s %d\n", comp+":", count)
+				count := replicas[comp]
+				fmt.Printf("  %-14s %d desired / %d ready\n", comp+":", count.Desired, count.Ready)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func newInstancePortForwardAllCmd() *cobra.Command {
+	var (
+		address     string
+		milvusPort  int
+		metricsPort int
+		minioPort   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "port-forward-all <instance-name>",
+		Short: "Forward Milvus, metrics and MinIO console ports for debugging",
+		Long: `Forward the ports of a debugging session in one process, until Ctrl-C:
+
+  - Milvus (19530) from the <instance>-milvus service
+  - Metrics (9091) from the <instance>-milvus service
+  - MinIO console (9001) from the <instance>-minio service, unless MinIO is external
+
+Each local port defaults to the remote port. If any forward fails, all are
+stopped.
+
+Examples:
+  miup instance port-forward-all prod
+  miup instance port-forward-all prod --milvus-port 29530
+  miup instance port-forward-all prod --address 0.0.0.0`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-sigCh
+				cancel()
+			}()
+
+			mgr := manager.NewManager(profile)
+			logger.Info("Forwarding ports of %s (press Ctrl-C to stop):", instanceName)
+			err = mgr.PortForwardAll(ctx, instanceName, manager.PortForwardOptions{
+				Address: address,
+				LocalPorts: map[int]int{
+					19530: milvusPort,
+					9091:  metricsPort,
+					9001:  minioPort,
+				},
+				Ready: func(f executor.Forward) {
+					fmt.Printf("  %-14s %s  ->  svc/%s:%d\n", f.Name, color.CyanString("%s:%d", address, f.LocalPort), f.Service, f.Port)
+				},
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println()
+			logger.Info("Port forwarding stopped")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "localhost", "Local address to listen on")
+	cmd.Flags().IntVar(&milvusPort, "milvus-port", 19530, "Local port for Milvus")
+	cmd.Flags().IntVar(&metricsPort, "metrics-port", 9091, "Local port for the metrics endpoint")
+	cmd.Flags().IntVar(&minioPort, "minio-port", 9001, "Local port for the MinIO console")
+
+	return cmd
+}
+
+func newInstanceCostCmd() *cobra.Command {
+	var (
+		prices     string
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cost <instance-name>",
+		Short: "Estimate the resource footprint of an instance",
+		Long: `Sum the CPU and memory requests and limits of all Milvus components (from the
+Milvus resource) and the sizes of the etcd and MinIO volumes.
+
+Components without resource requests are marked as unset; they add nothing to
+the totals, so the footprint is a lower bound.
+
+With --price, a rough monthly cost of the requested resources is estimated
+from unit prices per core, per GiB of memory and per GiB of storage.
+
+Examples:
+  miup instance cost prod
+  miup instance cost prod --price cpu=20,mem=3,storage=0.1
+  miup instance cost prod --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			priceTable, err := manager.ParsePrices(prices)
+			if err != nil {
+				return err
+			}
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			mgr := manager.NewManager(profile)
+
+			footprint, err := mgr.Footprint(ctx, instanceName)
+			if err != nil {
+				return err
+			}
+
+			var cost *manager.Cost
+			if prices != "" {
+				c := manager.EstimateCost(footprint, priceTable)
+				cost = &c
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(os.Stdout, output.NewSuccessResult(struct {
+					*executor.Footprint
+					Cost *manager.Cost `json:"cost,omitempty"`
+				}{footprint, cost}))
+			}
+
+			fmt.Printf("Instance: %s\n\n", color.CyanString(instanceName))
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "COMPONENT\tREPLICAS\tCPU REQUEST\tCPU LIMIT\tMEMORY REQUEST\tMEMORY LIMIT")
+			for _, c := range footprint.Components {
+				if c.Unset {
+					fmt.Fprintf(w, "%s\t%d\tunset\t-\tunset\t-\n", c.Name, c.Replicas)
+					continue
+				}
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n", c.Name, c.Replicas,
+					formatCores(c.CPURequest), formatCores(c.CPULimit), formatMemory(c.MemoryRequest), formatMemory(c.MemoryLimit))
+			}
+			fmt.Fprintf(w, "TOTAL\t\t%s\t%s\t%s\t%s\n",
+				formatCores(footprint.Total.CPURequest), formatCores(footprint.Total.CPULimit),
+				formatMemory(footprint.Total.MemoryRequest), formatMemory(footprint.Total.MemoryLimit))
+			w.Flush()
+
+			if len(footprint.Volumes) > 0 {
+				fmt.Println()
+				w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "VOLUME\tSIZE")
+				for _, v := range footprint.Volumes {
+					fmt.Fprintf(w, "%s\t%s\n", v.Name, formatMemory(v.Size))
 				}
+				fmt.Fprintf(w, "TOTAL\t%s\n", formatMemory(footprint.Storage))
+				w.Flush()
 			}
 
+			if cost != nil {
+				fmt.Println()
+				fmt.Printf("Estimated monthly cost of requests: %.2f (CPU %.2f, memory %.2f, storage %.2f)\n",
+					cost.Total, cost.CPU, cost.Memory, cost.Storage)
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&prices, "price", "", "Monthly unit prices as cpu=PER_CORE,mem=PER_GIB,storage=PER_GIB to estimate a cost")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
 	return cmd
 }
 
+// formatCores formats a CPU amount in cores, "-" for none
+func formatCores(cores float64) string {
+	if cores == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(cores, 'f', -1, 64)
+}
+
+// formatMemory formats a byte count as a Kubernetes quantity, "-" for none
+func formatMemory(bytes int64) string {
+	if bytes == 0 {
+		return "-"
+	}
+	return resource.NewQuantity(bytes, resource.BinarySI).String()
+}
+
 func newInstanceUpgradeCmd() *cobra.Command {
+	var dryRun bool
+
 	cmd := &cobra.Command{
 		Use:   "upgrade <instance-name> <version>",
 		Short: "Upgrade Milvus to a new version",
@@ -1197,19 +2861,176 @@ Examples:
   miup instance upgrade prod v2.5.5
   miup instance upgrade prod 2.5.5
 
-  # Show current version before upgrading
-  miup instance display prod`,
-		Args: cobra.ExactArgs(2),
+  # Show current version before upgrading
+  miup instance display prod
+
+  # Review the image change without applying it
+  miup instance upgrade prod v2.5.5 --dry-run`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+			version := args[1]
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-sigCh
+				cancel()
+			}()
+
+			mgr := manager.NewManager(profile)
+			if dryRun {
+				plan, err := mgr.PlanUpgrade(ctx, instanceName, version)
+				if err != nil {
+					return err
+				}
+				printPlan(plan)
+				return nil
+			}
+
+			start := time.Now()
+			upgradeErr := mgr.Upgrade(ctx, instanceName, version)
+			auditLog(instanceName, "upgrade", []string{version}, upgradeErr, time.Since(start))
+			return upgradeErr
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the change to the Milvus resource without applying it")
+	return cmd
+}
+
+func newInstanceSetImageCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "set-image <instance-name> <image>",
+		Short: "Run a custom Milvus image",
+		Long: `Set the full Milvus image reference of an instance, independent of the
+release version, e.g. to run a nightly or patched build. Like upgrade, this
+triggers a rolling update managed by the Milvus Operator.
+
+The image's tag is shown as the instance version; 'miup instance upgrade'
+switches back to the stock milvusdb/milvus image.
+
+Examples:
+  miup instance set-image prod myrepo/milvus:pr-1234
+  miup instance set-image prod milvusdb/milvus:master-20250110-abc1234
+  miup instance set-image prod myrepo/milvus:pr-1234 --dry-run`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+			image := args[1]
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-sigCh
+				cancel()
+			}()
+
+			mgr := manager.NewManager(profile)
+			if dryRun {
+				plan, err := mgr.PlanSetImage(ctx, instanceName, image)
+				if err != nil {
+					return err
+				}
+				printPlan(plan)
+				return nil
+			}
+
+			start := time.Now()
+			setErr := mgr.SetImage(ctx, instanceName, image)
+			auditLog(instanceName, "set-image", []string{image}, setErr, time.Since(start))
+			return setErr
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the change to the Milvus resource without applying it")
+	return cmd
+}
+
+// printPlan prints the changes a dry run would make to a Milvus resource
+func printPlan(plan *executor.Plan) {
+	if len(plan.Changes) == 0 {
+		fmt.Printf("Dry run: no changes to %s\n", plan.Resource)
+		return
+	}
+
+	fmt.Printf("Dry run: would update %s\n", plan.Resource)
+	for _, c := range plan.Changes {
+		fmt.Printf("  %s: %s → %s\n", c.Path, planValue(c.Old), planValue(c.New))
+	}
+}
+
+// planValue formats a value of a planned change as JSON
+func planValue(v any) string {
+	if v == nil {
+		return "<unset>"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func newInstanceApplyCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "apply <instance-name>",
+		Short: "Update an instance to its stored topology",
+		Long: `Update a Milvus instance to match its stored topology, e.g. after editing
+~/.miup/clusters/<name>/topology.yaml by hand.
+
+The topology is validated, the Milvus resource it describes is compared with
+the live one, and the difference (replicas, resources, config, environment,
+image of the recorded version) is applied. The command then waits for the
+instance to be healthy. If nothing differs, nothing is updated.
+
+The topology is merged over the live resource rather than replacing it:
+  - values the topology sets win, so replicas, resources or config keys
+    changed with scale or config set are reverted to the topology's values
+  - maps such as spec.config are merged key by key, so live keys the
+    topology doesn't set are kept, including keys deleted from the topology
+    since they were applied
+  - lists such as environment variables are replaced as a whole
+
+A stopped instance is not applied to, since that would start it again; start
+it first with 'miup instance start'.
+
+Examples:
+  # Review what would change
+  miup instance apply prod --dry-run
+
+  # Apply the edited topology
+  miup instance apply prod`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			instanceName := args[0]
-			version := args[1]
 
 			profile, err := localdata.DefaultProfile()
 			if err != nil {
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
+			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
 			sigCh := make(chan os.Signal, 1)
@@ -1220,50 +3041,293 @@ Examples:
 			}()
 
 			mgr := manager.NewManager(profile)
+			if dryRun {
+				plan, err := mgr.PlanApply(ctx, instanceName)
+				if err != nil {
+					return err
+				}
+				printPlan(plan)
+				return nil
+			}
+
 			start := time.Now()
-			upgradeErr := mgr.Upgrade(ctx, instanceName, version)
-			auditLog(instanceName, "upgrade", []string{version}, upgradeErr, time.Since(start))
-			return upgradeErr
+			applyErr := mgr.Apply(ctx, instanceName)
+			auditLog(instanceName, "apply", nil, applyErr, time.Since(start))
+			return applyErr
 		},
 	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the changes to the Milvus resource without applying them")
 	return cmd
 }
 
 func newInstanceDestroyCmd() *cobra.Command {
-	var force bool
+	var (
+		force           bool
+		deleteNamespace bool
+		wait            bool
+		timeout         time.Duration
+		bulk            bulkFlags
+	)
 
 	cmd := &cobra.Command{
-		Use:   "destroy <instance-name>",
+		Use:   "destroy <instance-name> | --selector <selector>",
 		Short: "Destroy an instance",
-		Args:  cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			instanceName := args[0]
+		Long: `Destroy an instance: delete its Milvus resource and remove its local state.
+
+The Milvus Operator deletes the pods of the instance, and the etcd and MinIO
+it deployed unless the deletion policy keeps them, in the background after
+destroy returns. Use --wait in scripts that deploy an instance with the same
+name right after, so the deploy does not collide with what is left; if the
+wait times out, destroy exits with 7 (timeout):
 
+  miup instance destroy test --wait && miup instance deploy test topology.yaml -y`,
+		Args: bulk.args,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			profile, err := localdata.DefaultProfile()
 			if err != nil {
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
+			mgr := manager.NewManager(profile)
+			opts := manager.DestroyOptions{Force: force, DeleteNamespace: deleteNamespace, Wait: wait, Timeout: timeout}
+			if bulk.selector != "" {
+				return runBulk(mgr, "destroy", bulk, func(ctx context.Context, name string) error {
+					return mgr.Destroy(ctx, name, opts)
+				})
+			}
+
+			instanceName := args[0]
+
+			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
-			mgr := manager.NewManager(profile)
 			start := time.Now()
-			destroyErr := mgr.Destroy(ctx, instanceName, force)
+			destroyErr := mgr.Destroy(ctx, instanceName, opts)
 			auditLog(instanceName, "destroy", nil, destroyErr, time.Since(start))
 			return destroyErr
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Force destroy without confirmation")
+	cmd.Flags().BoolVar(&deleteNamespace, "delete-namespace", false, "Also delete the namespace if miup created it on deploy and nothing else uses it")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for the Milvus resource and what the operator deletes with it to be gone")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "Timeout when waiting for the resources to be deleted")
+	bulk.register(cmd)
+
+	return cmd
+}
+
+func newInstanceReapCmd() *cobra.Command {
+	var (
+		dryRun          bool
+		force           bool
+		deleteNamespace bool
+		bulk            bulkFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "reap",
+		Short: "Destroy instances whose TTL has expired",
+		Long: `Destroy every instance whose TTL (set with 'deploy --ttl' or 'start --ttl')
+has run out. Run it periodically, e.g. from a CI cleanup job, to keep test
+instances from piling up.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			mgr := manager.NewManager(profile)
+			clusters, err := mgr.Expired(time.Now())
+			if err != nil {
+				return err
+			}
+			if len(clusters) == 0 {
+				fmt.Println("No expired instances")
+				return nil
+			}
+
+			if dryRun {
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "NAME\tSTATUS\tNAMESPACE\tEXPIRED")
+				for _, c := range clusters {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Name, c.Status, c.Namespace, formatExpiry(c.ExpiresAt))
+				}
+				w.Flush()
+				return nil
+			}
+
+			return runOnClusters(clusters, "destroy", "have expired", []string{"reap"}, bulk, func(ctx context.Context, name string) error {
+				return mgr.Destroy(ctx, name, manager.DestroyOptions{Force: force, DeleteNamespace: deleteNamespace})
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list the expired instances")
+	cmd.Flags().BoolVar(&deleteNamespace, "delete-namespace", false, "Also delete namespaces miup created for the instances if nothing else uses them")
+	cmd.Flags().BoolVar(&force, "force", false, "Remove local state even if deleting the Kubernetes resources fails")
+	bulk.registerRun(cmd)
 
 	return cmd
 }
 
+// bulkFlags are the flags of commands that can work on every instance
+// matching a label selector instead of a single named instance
+type bulkFlags struct {
+	selector    string
+	concurrency int
+	yes         bool
+}
+
+func (b *bulkFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&b.selector, "selector", "l", "", "Operate on all instances whose labels match this selector (e.g. env=ci,!keep)")
+	b.registerRun(cmd)
+}
+
+// registerRun registers the flags controlling how a bulk operation runs
+func (b *bulkFlags) registerRun(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&b.concurrency, "concurrency", manager.DefaultBulkConcurrency, "Number of instances to operate on at once")
+	cmd.Flags().BoolVarP(&b.yes, "yes", "y", false, "Skip confirmation")
+}
+
+// args requires either one instance name or --selector
+func (b *bulkFlags) args(cmd *cobra.Command, args []string) error {
+	if b.selector != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("cannot use an instance name together with --selector")
+		}
+		return nil
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// runBulk runs op on every instance matching the selector
+func runBulk(mgr *manager.Manager, operation string, flags bulkFlags, op func(ctx context.Context, name string) error) error {
+	clusters, err := mgr.Select(flags.selector)
+	if err != nil {
+		return err
+	}
+	if len(clusters) == 0 {
+		fmt.Printf("No instances match selector '%s'\n", flags.selector)
+		return nil
+	}
+
+	reason := fmt.Sprintf("match '%s'", flags.selector)
+	return runOnClusters(clusters, operation, reason, []string{"--selector", flags.selector}, flags, op)
+}
+
+// runOnClusters lists the clusters and why they were selected, asks for
+// confirmation, runs op on them in parallel and prints a per-instance result
+// table
+func runOnClusters(clusters []*spec.ClusterMeta, operation, reason string, auditArgs []string, flags bulkFlags, op func(ctx context.Context, name string) error) error {
+	fmt.Printf("The following %d instance(s) %s and will be %s:\n", len(clusters), reason, pastTense(operation))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tSTATUS\tNAMESPACE\tLABELS\tEXPIRES")
+	names := make([]string, len(clusters))
+	for i, c := range clusters {
+		names[i] = c.Name
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", c.Name, c.Status, c.Namespace, formatLabels(c.Labels), formatExpiry(c.ExpiresAt))
+	}
+	w.Flush()
+
+	if !flags.yes && !confirm(fmt.Sprintf("%s %d instance(s)?", strings.ToUpper(operation[:1])+operation[1:], len(clusters))) {
+		return fmt.Errorf("aborted")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	results := executor.RunBulk(ctx, names, flags.concurrency, func(ctx context.Context, name string) error {
+		start := time.Now()
+		err := op(ctx, name)
+		auditLog(name, operation, auditArgs, err, time.Since(start))
+		return err
+	})
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tRESULT\tDURATION\tERROR")
+	failed := 0
+	for _, r := range results {
+		result, errMsg := color.GreenString("ok"), ""
+		if r.Err != nil {
+			failed++
+			result, errMsg = color.RedString("failed"), r.Err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, result, r.Duration.Round(time.Second), errMsg)
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d instance(s) failed to %s", failed, len(results), operation)
+	}
+	return nil
+}
+
+// formatExpiry formats a TTL expiry relative to now, e.g. "in 1h" or "3h ago"
+func formatExpiry(expiresAt *time.Time) string {
+	if expiresAt == nil {
+		return "-"
+	}
+	if time.Now().Before(*expiresAt) {
+		return "in " + formatDurationShort(time.Until(*expiresAt))
+	}
+	return formatAge(*expiresAt) + " ago"
+}
+
+// pastTense returns the past tense of a bulk operation name
+func pastTense(operation string) string {
+	if operation == "stop" {
+		return "stopped"
+	}
+	return operation + "ed"
+}
+
+// formatLabels formats labels as sorted key=value pairs
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// confirm asks a yes/no question on stdin and reports whether it was answered yes
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	var answer string
+	fmt.Scanln(&answer)
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
 func newInstanceLogsCmd() *cobra.Command {
 	var (
-		service string
-		tail    int
+		service      string
+		tail         int
+		byComponent  bool
+		merge        bool
+		since        time.Duration
+		grep         string
+		outputDir    string
+		archiveLogs  bool
+		operator     bool
+		timestamps   bool
+		timezone     string
+		sinceRestart bool
 	)
 
 	cmd := &cobra.Command{
@@ -1273,15 +3337,82 @@ func newInstanceLogsCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			instanceName := args[0]
 
+			if byComponent && merge {
+				return fmt.Errorf("--by-component and --merge cannot be used together")
+			}
+			if archiveLogs && outputDir == "" {
+				return fmt.Errorf("--archive requires --output-dir")
+			}
+			if operator && (service != "" || byComponent) {
+				return fmt.Errorf("--operator cannot be used with --service or --by-component")
+			}
+			if sinceRestart && since > 0 {
+				return fmt.Errorf("--since and --since-restart cannot be used together")
+			}
+			if since < 0 {
+				return fmt.Errorf("--since must not be negative")
+			}
+			if timezone != "" && !timestamps {
+				return fmt.Errorf("--timezone requires --timestamps")
+			}
+			var location *time.Location
+			if timezone != "" {
+				var err error
+				if location, err = time.LoadLocation(timezone); err != nil {
+					return fmt.Errorf("invalid --timezone: %w", err)
+				}
+			}
+
 			profile, err := localdata.DefaultProfile()
 			if err != nil {
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			mgr := manager.NewManager(profile)
 
-			logs, err := mgr.Logs(ctx, instanceName, service, tail)
+			opts := executor.LogsOptions{
+				Service:      service,
+				Tail:         tail,
+				ByComponent:  byComponent,
+				Merge:        merge,
+				Since:        since,
+				Grep:         grep,
+				Operator:     operator,
+				Timestamps:   timestamps,
+				Location:     location,
+				SinceRestart: sinceRestart,
+			}
+
+			// Write one file per pod instead of printing to stdout
+			if outputDir != "" {
+				podLogs, err := mgr.PodLogs(ctx, instanceName, opts)
+				if err != nil {
+					return err
+				}
+				for _, l := range podLogs {
+					if l.Err != nil {
+						logger.Warn("Failed to get logs for pod %s: %v", l.Pod, l.Err)
+					}
+				}
+
+				files, err := executor.WriteLogsToDir(outputDir, podLogs)
+				if err != nil {
+					return err
+				}
+				logger.Success("Wrote logs for %d pod(s) to %s", len(files), outputDir)
+
+				if archiveLogs {
+					archivePath := filepath.Join(outputDir, "logs.tar.gz")
+					if err := archive.CreateTarGz(archivePath, outputDir); err != nil {
+						return err
+					}
+					logger.Success("Created archive %s", archivePath)
+				}
+				return nil
+			}
+
+			logs, err := mgr.Logs(ctx, instanceName, opts)
 			if err != nil {
 				return err
 			}
@@ -1293,10 +3424,120 @@ func newInstanceLogsCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&service, "service", "s", "", "Service name (e.g., standalone, etcd, minio)")
 	cmd.Flags().IntVarP(&tail, "tail", "n", 100, "Number of lines to show")
+	cmd.Flags().BoolVar(&byComponent, "by-component", false, "Group logs under component headers")
+	cmd.Flags().BoolVar(&merge, "merge", false, "Interleave lines from all pods sorted by timestamp")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show logs newer than a relative duration (e.g., 10m, 1h)")
+	cmd.Flags().StringVar(&grep, "grep", "", "Only show lines matching a regular expression")
+	cmd.Flags().StringVar(&outputDir, "output-dir", "", "Write each pod's logs to <dir>/<pod>.log instead of stdout")
+	cmd.Flags().BoolVar(&archiveLogs, "archive", false, "Also bundle the written logs into <output-dir>/logs.tar.gz")
+	cmd.Flags().BoolVar(&operator, "operator", false, "Show the Milvus Operator's logs instead, e.g. to debug a stuck reconcile")
+	cmd.Flags().BoolVar(&sinceRestart, "since-restart", false, "Only show logs since each pod's container last (re)started, e.g. after a crash loop")
+	cmd.Flags().BoolVar(&timestamps, "timestamps", false, "Prefix each line with the time Kubernetes recorded it at; --merge then orders by it")
+	cmd.Flags().StringVar(&timezone, "timezone", "", "Show --timestamps in this timezone, e.g. Local or Asia/Shanghai (default UTC)")
+
+	return cmd
+}
+
+func newInstanceEventsCmd() *cobra.Command {
+	var (
+		watch      bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "events <instance-name>",
+		Short: "Show Kubernetes events of an instance",
+		Long: `Show the Kubernetes events of an instance's Milvus resource, pods and
+dependencies, oldest first.
+
+With --watch, the events are followed instead: each one is printed as it
+occurs (scheduling, image pulls, container starts, probe failures) until
+Ctrl-C, e.g. to watch a deploy or upgrade unfold. With --json, each event is
+then printed as a line of JSON.
+
+Examples:
+  miup instance events prod
+  miup instance events prod --watch
+  miup instance events prod --watch --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+			mgr := manager.NewManager(profile)
+
+			if !watch {
+				events, err := mgr.Events(cmd.Context(), instanceName)
+				if err != nil {
+					return err
+				}
+
+				if jsonOutput {
+					return output.PrintJSON(os.Stdout, output.NewSuccessResult(events))
+				}
+				if len(events) == 0 {
+					fmt.Println("No events found")
+					return nil
+				}
+
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "AGE\tTYPE\tREASON\tOBJECT\tMESSAGE")
+				for _, ev := range events {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", formatAge(ev.Time), ev.Type, ev.Reason, ev.Object, ev.Message)
+				}
+				w.Flush()
+				return nil
+			}
+
+			ctx, cancel := context.WithCancel(cmd.Context())
+			defer cancel()
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+			go func() {
+				<-sigCh
+				cancel()
+			}()
+
+			if !jsonOutput {
+				logger.Info("Watching events of %s (press Ctrl-C to stop):", instanceName)
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			err = mgr.WatchEvents(ctx, instanceName, func(ev executor.Event) {
+				if jsonOutput {
+					_ = encoder.Encode(ev)
+					return
+				}
+				fmt.Printf("%s  %-7s  %-20s  %s  %s\n", ev.Time.Local().Format("15:04:05"), formatEventType(ev.Type), ev.Reason, ev.Object, ev.Message)
+			})
+			if err != nil {
+				return err
+			}
+			if !jsonOutput {
+				fmt.Println()
+				logger.Info("Stopped watching events")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Follow events as they occur until Ctrl-C")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format (JSON lines with --watch)")
 
 	return cmd
 }
 
+// formatEventType highlights Warning events
+func formatEventType(eventType string) string {
+	if eventType == "Warning" {
+		return color.YellowString(eventType)
+	}
+	return eventType
+}
+
 func newInstanceTemplateCmd() *cobra.Command {
 	var (
 		mode    string
@@ -1362,6 +3603,7 @@ milvus_servers:
 etcd_servers:
   - host: 127.0.0.1
     client_port: 2379
+    # storage: "10Gi"  # PVC size for in-cluster etcd
 
 # In-cluster MinIO (managed by Milvus Operator)
 minio_servers:
@@ -1369,6 +3611,7 @@ minio_servers:
     port: 9000
     access_key: "minioadmin"
     secret_key: "minioadmin"
+    # storage: "100Gi"  # PVC size for in-cluster MinIO
 `
 
 const kubernetesDistributedTemplate = `# MiUp Kubernetes Topology - Distributed Mode
@@ -1402,6 +3645,11 @@ milvus_servers:
         resources:
           cpu: "2"
           memory: "4Gi"
+        # Environment variables (components.env applies to all components)
+        # env:
+        #   GOGC: "200"
+        # Spread replicas across availability zones ("zone") or nodes ("host")
+        # anti_affinity: zone
       dataNode:
         replicas: 2
         resources:
@@ -1417,6 +3665,7 @@ milvus_servers:
 etcd_servers:
   - host: 127.0.0.1
     client_port: 2379
+    # storage: "10Gi"  # PVC size for in-cluster etcd
 
 # In-cluster MinIO (managed by Milvus Operator)
 minio_servers:
@@ -1424,6 +3673,7 @@ minio_servers:
     port: 9000
     access_key: "minioadmin"
     secret_key: "minioadmin"
+    # storage: "100Gi"  # PVC size for in-cluster MinIO
 
 # External etcd example (uncomment to use):
 # etcd_servers:
@@ -1529,6 +3779,8 @@ func newMirrorPullCmd() *cobra.Command {
 		milvusVersion string
 		all           bool
 		registry      string
+		topology      string
+		platforms     []string
 	)
 
 	cmd := &cobra.Command{
@@ -1536,22 +3788,50 @@ func newMirrorPullCmd() *cobra.Command {
 		Short: "Pull Docker images for offline deployment",
 		Long: `Pull all required Docker images for Milvus deployment.
 
-This command pulls the following images:
+By default this command pulls the following images:
   - milvusdb/milvus (Milvus server)
   - quay.io/coreos/etcd (etcd)
   - minio/minio (MinIO object storage)
   - prom/prometheus (optional, for monitoring)
   - grafana/grafana (optional, for monitoring)
 
+With --topology, the image set is derived from the topology file instead:
+etcd and MinIO are skipped when they are external, Pulsar or Kafka is added
+when the Milvus Operator deploys one for the configured message queue, and
+Prometheus and Grafana are included only if the topology has them.
+
 Examples:
   miup mirror pull                                    Pull from public registries
-  miup mirror pull --registry harbor.milvus.io       Pull from internal Harbor`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			images := getMilvusImages(milvusVersion, all, registry)
+  miup mirror pull --registry harbor.milvus.io       Pull from internal Harbor
+  miup mirror pull --topology topology.yaml          Pull what topology.yaml deploys
+  miup mirror pull --arch linux/arm64                Pull arm64 images on an amd64 host`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if err := validatePlatforms(platforms); err != nil {
+				return err
+			}
+			if err := localexec.RequireDocker(false); err != nil {
+				return err
+			}
+			images, err := mirrorImages(milvusVersion, all, registry, topology)
+			if err != nil {
+				return err
+			}
+
+			fields := map[string]string{"version": milvusVersion, "images": strconv.Itoa(len(images))}
+			logger.PhaseStart("mirror_pull", fields)
+			defer func() { logger.PhaseEnd("mirror_pull", err, fields) }()
+
+			if len(platforms) > 0 {
+				if _, err := pullPlatformImages(images, platforms); err != nil {
+					return err
+				}
+				logger.Success("All images pulled successfully for %s!", strings.Join(platforms, ", "))
+				return nil
+			}
 
 			for _, img := range images {
 				logger.Info("Pulling image: %s", img)
-				if err := pullImage(img); err != nil {
+				if err := pullImage(img, ""); err != nil {
 					return fmt.Errorf("failed to pull %s: %w", img, err)
 				}
 				logger.Success("Pulled: %s", img)
@@ -1562,8 +3842,11 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVar(&milvusVersion, "milvus.version", "v2.5.4", "Milvus version")
+	cmd.Flags().StringVar(&milvusVersion, "milvus.version", version.MilvusDefault(), "Milvus version")
 	cmd.Flags().BoolVar(&all, "all", false, "Include monitoring images (Prometheus, Grafana)")
+	cmd.Flags().StringVar(&topology, "topology", "", "Mirror exactly the images this topology file deploys")
+	cmd.MarkFlagsMutuallyExclusive("all", "topology")
+	cmd.Flags().StringSliceVar(&platforms, "arch", nil, "Platform to pull images for, e.g. linux/arm64 (repeatable; default: the host's)")
 	cmd.Flags().StringVar(&registry, "registry", "", "Private registry address (e.g., harbor.milvus.io)")
 
 	return cmd
@@ -1575,6 +3858,9 @@ func newMirrorSaveCmd() *cobra.Command {
 		milvusVersion string
 		all           bool
 		registry      string
+		topology      string
+		platforms     []string
+		signKey       string
 	)
 
 	cmd := &cobra.Command{
@@ -1585,60 +3871,172 @@ func newMirrorSaveCmd() *cobra.Command {
 The tar archive can be transferred to air-gapped environments and loaded using:
   miup mirror load -i <archive.tar>
 
+A manifest listing the images and architectures is written next to the
+archive (<archive>.manifest.json); transfer it along with the archive.
+
+With --arch, the images of that platform are pulled and saved instead of the
+host's, so an amd64 workstation can bundle images for an arm64 cluster. With
+more than one --arch, each platform is saved under an arch-suffixed tag
+(e.g. milvusdb/milvus:v2.5.4-arm64) and 'mirror push --arch' joins them into
+multi-arch images again.
+
+The SHA-256 of the archive and of its manifest are written next to them
+(<file>.sha256, in sha256sum format); 'mirror load' and 'mirror push --from'
+check them before use. With --sign-key, both are also signed with a cosign
+key into <file>.sig, which 'mirror load --verify-key' and 'mirror push
+--from --verify-key' check against the public key. Send the checksum or
+public key through a separate channel so the bundle can't be tampered with
+along with them.
+
 Examples:
   miup mirror save -o milvus.tar                           Save from public registries
-  miup mirror save -o milvus.tar --registry harbor.milvus.io  Save from internal Harbor`,
+  miup mirror save -o milvus.tar --registry harbor.milvus.io  Save from internal Harbor
+  miup mirror save -o milvus.tar --arch linux/arm64        Save arm64 images
+  miup mirror save -o milvus.tar --sign-key cosign.key     Save and sign with cosign`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if output == "" {
 				output = fmt.Sprintf("milvus-images-%s.tar", milvusVersion)
 			}
 
-			images := getMilvusImages(milvusVersion, all, registry)
+			if err := validatePlatforms(platforms); err != nil {
+				return err
+			}
+			if err := localexec.RequireDocker(false); err != nil {
+				return err
+			}
+			images, err := mirrorImages(milvusVersion, all, registry, topology)
+			if err != nil {
+				return err
+			}
+
+			// The local images are of the host's platform; pull the target
+			// platforms so the archive matches the cluster it's loaded into
+			tags := images
+			if len(platforms) > 0 {
+				if tags, err = pullPlatformImages(images, platforms); err != nil {
+					return err
+				}
+			}
 
-			logger.Info("Saving %d images to %s...", len(images), output)
-			if err := saveImages(images, output); err != nil {
+			logger.Info("Saving %d images to %s...", len(tags), output)
+			if err := saveImages(tags, output); err != nil {
 				return fmt.Errorf("failed to save images: %w", err)
 			}
+			manifest := mirrorManifest{MilvusVersion: milvusVersion, Architectures: platforms, Images: images}
+			if err := writeMirrorManifest(output, manifest); err != nil {
+				return fmt.Errorf("failed to write manifest: %w", err)
+			}
+			sum, err := archive.WriteChecksum(output)
+			if err != nil {
+				return err
+			}
+			if signKey != "" {
+				for _, path := range []string{output, manifestPath(output)} {
+					logger.Info("Signing %s...", path)
+					if err := archive.Sign(path, signKey); err != nil {
+						return err
+					}
+				}
+			}
 
-			logger.Success("Images saved to: %s", output)
+			logger.Success("Images saved to: %s (manifest: %s)", output, manifestPath(output))
+			logger.Info("SHA-256: %s", sum)
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&output, "output", "o", "", "Output tar file (default: milvus-images-<version>.tar)")
-	cmd.Flags().StringVar(&milvusVersion, "milvus.version", "v2.5.4", "Milvus version")
+	cmd.Flags().StringVar(&milvusVersion, "milvus.version", version.MilvusDefault(), "Milvus version")
 	cmd.Flags().BoolVar(&all, "all", false, "Include monitoring images (Prometheus, Grafana)")
+	cmd.Flags().StringVar(&topology, "topology", "", "Mirror exactly the images this topology file deploys")
+	cmd.MarkFlagsMutuallyExclusive("all", "topology")
+	cmd.Flags().StringSliceVar(&platforms, "arch", nil, "Platform to pull images for, e.g. linux/arm64 (repeatable; default: the host's)")
 	cmd.Flags().StringVar(&registry, "registry", "", "Private registry address (e.g., harbor.milvus.io)")
+	cmd.Flags().StringVar(&signKey, "sign-key", "", "Sign the archive with this cosign private key")
 
 	return cmd
 }
 
 func newMirrorLoadCmd() *cobra.Command {
-	var input string
+	var (
+		input     string
+		push      string
+		retries   int
+		checksum  string
+		verifyKey string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "load",
 		Short: "Load Docker images from a tar archive",
 		Long: `Load Docker images from a tar archive created by 'miup mirror save'.
 
-This is typically used in air-gapped environments after transferring the tar archive.`,
+This is typically used in air-gapped environments after transferring the tar archive.
+If the archive's manifest (<archive>.manifest.json) is next to it, the
+architectures it was saved for are reported; 'miup mirror push --from'
+pushes them as they were saved.
+
+The archive is checked before loading and not loaded if it doesn't match:
+against --checksum (a SHA-256 digest or a sha256sum file) if given, else
+against <archive>.sha256 if present; and with --verify-key, the cosign
+signatures of the archive and its manifest against that public key. The
+manifest is checked against its own .sha256 if present.
+
+With --push, the loaded images are then pushed to a registry as
+'miup mirror push <registry> --from <archive>' would. This needs the
+manifest, and every image it lists must be present once the archive is
+loaded.
+
+Examples:
+  miup mirror load -i milvus.tar
+  miup mirror load -i milvus.tar --checksum 3f2a...e9 --verify-key cosign.pub
+  miup mirror load -i milvus.tar --push registry.internal:5000`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if input == "" {
 				return fmt.Errorf("input file is required (-i)")
 			}
 
+			manifest, err := readMirrorManifest(input, verifyKey)
+			if err != nil {
+				return err
+			}
+			if push != "" && manifest == nil {
+				return fmt.Errorf("--push needs the archive's manifest (expected %s)", manifestPath(input))
+			}
+			if err := verifyMirrorArchive(input, checksum, verifyKey); err != nil {
+				return err
+			}
+			if err := localexec.RequireDocker(false); err != nil {
+				return err
+			}
+
 			logger.Info("Loading images from %s...", input)
 			if err := loadImages(input); err != nil {
 				return fmt.Errorf("failed to load images: %w", err)
 			}
 
 			logger.Success("Images loaded successfully!")
-			return nil
+			if push == "" {
+				if manifest != nil && len(manifest.Architectures) > 0 {
+					logger.Info("The images are for %s; push them with: miup mirror push <registry> --from %s",
+						strings.Join(manifest.Architectures, ", "), input)
+				}
+				return nil
+			}
+
+			if missing := localexec.MissingImages(cmd.Context(), manifestTags(manifest)); len(missing) > 0 {
+				return fmt.Errorf("the archive is missing images listed in its manifest: %s", strings.Join(missing, ", "))
+			}
+			return pushMirrorImages(manifest.Images, manifest.Architectures, push, retries)
 		},
 	}
 
 	cmd.Flags().StringVarP(&input, "input", "i", "", "Input tar file (required)")
 	_ = cmd.MarkFlagRequired("input")
+	cmd.Flags().StringVar(&push, "push", "", "Push the loaded images to this registry")
+	cmd.Flags().IntVar(&retries, "retries", 3, "Times to retry a push that fails on a network or registry error (with --push)")
+	cmd.Flags().StringVar(&checksum, "checksum", "", "Expected SHA-256 of the archive, or a file in sha256sum format (default: <archive>.sha256 if present)")
+	cmd.Flags().StringVar(&verifyKey, "verify-key", "", "Verify the cosign signatures of the archive and its manifest with this public key")
 
 	return cmd
 }
@@ -1648,6 +4046,11 @@ func newMirrorPushCmd() *cobra.Command {
 		milvusVersion  string
 		all            bool
 		sourceRegistry string
+		retries        int
+		topology       string
+		platforms      []string
+		from           string
+		verifyKey      string
 	)
 
 	cmd := &cobra.Command{
@@ -1656,43 +4059,127 @@ func newMirrorPushCmd() *cobra.Command {
 		Long: `Push all Milvus images to a private Docker registry.
 
 This re-tags and pushes images to your private registry for use in air-gapped environments.
+Pushes that fail on a network or registry error (a timeout, a dropped
+connection, a 5xx response) are retried with backoff, up to --retries times;
+other failures, such as "unauthorized", are not. The remaining images are
+still pushed and a summary lists any that failed. Re-running the command is
+safe.
+
+With --from, the images and architectures are read from the manifest of an
+archive loaded with 'miup mirror load'. Images pulled or saved for several
+architectures (--arch) are pushed per architecture and joined into a
+multi-arch manifest list, so each node pulls the image of its platform.
+The manifest is checked against its .sha256 if present, and with
+--verify-key against its cosign signature.
 
 Examples:
   miup mirror push registry.local:5000
   miup mirror push harbor.example.com/milvus
-  miup mirror push registry.local:5000 --source-registry harbor.milvus.io`,
+  miup mirror push registry.local:5000 --source-registry harbor.milvus.io
+  miup mirror push registry.local:5000 --from milvus.tar --verify-key cosign.pub`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			targetRegistry := args[0]
-			images := getMilvusImages(milvusVersion, all, sourceRegistry)
-
-			for _, img := range images {
-				newTag := retagImage(img, targetRegistry)
-				logger.Info("Pushing %s -> %s", img, newTag)
-
-				if err := tagAndPushImage(img, newTag); err != nil {
-					return fmt.Errorf("failed to push %s: %w", newTag, err)
+			var images []string
+			if verifyKey != "" && from == "" {
+				return fmt.Errorf("--verify-key needs --from")
+			}
+			if from != "" {
+				manifest, err := readMirrorManifest(from, verifyKey)
+				if err != nil {
+					return err
+				}
+				if manifest == nil {
+					return fmt.Errorf("no manifest found for %s (expected %s)", from, manifestPath(from))
+				}
+				images, platforms = manifest.Images, manifest.Architectures
+			} else {
+				if err := validatePlatforms(platforms); err != nil {
+					return err
 				}
-				logger.Success("Pushed: %s", newTag)
+				var err error
+				if images, err = mirrorImages(milvusVersion, all, sourceRegistry, topology); err != nil {
+					return err
+				}
+			}
+
+			if err := localexec.RequireDocker(false); err != nil {
+				return err
 			}
 
-			logger.Success("All images pushed to %s", targetRegistry)
-			return nil
+			return pushMirrorImages(images, platforms, targetRegistry, retries)
 		},
 	}
 
-	cmd.Flags().StringVar(&milvusVersion, "milvus.version", "v2.5.4", "Milvus version")
+	cmd.Flags().StringVar(&milvusVersion, "milvus.version", version.MilvusDefault(), "Milvus version")
 	cmd.Flags().BoolVar(&all, "all", false, "Include monitoring images (Prometheus, Grafana)")
+	cmd.Flags().StringVar(&topology, "topology", "", "Mirror exactly the images this topology file deploys")
+	cmd.MarkFlagsMutuallyExclusive("all", "topology")
 	cmd.Flags().StringVar(&sourceRegistry, "source-registry", "", "Source registry to pull images from (e.g., harbor.milvus.io)")
+	cmd.Flags().IntVar(&retries, "retries", 3, "Times to retry a push that fails on a network or registry error")
+	cmd.Flags().StringSliceVar(&platforms, "arch", nil, "Platforms the images were pulled for with 'mirror pull --arch' (repeatable)")
+	cmd.Flags().StringVar(&from, "from", "", "Push the images of an archive loaded with 'mirror load', as recorded in its manifest")
+	cmd.Flags().StringVar(&verifyKey, "verify-key", "", "Verify the cosign signature of the --from archive's manifest with this public key")
+	cmd.MarkFlagsMutuallyExclusive("from", "arch")
+	cmd.MarkFlagsMutuallyExclusive("from", "topology")
+	cmd.MarkFlagsMutuallyExclusive("from", "all")
 
 	return cmd
 }
 
+// pushMirrorImages retags each image for targetRegistry and pushes it,
+// joining the images of several platforms into multi-arch manifest lists,
+// and prints a summary of the results
+func pushMirrorImages(images, platforms []string, targetRegistry string, retries int) error {
+	// Keep going on failure so one bad image doesn't hide the state of the
+	// rest; tagging and pushing are idempotent, so re-running after a partial
+	// failure only redoes the failed images
+	results := make([]pushResult, 0, len(images))
+	for _, img := range images {
+		newTag := retagImage(img, targetRegistry)
+		logger.Info("Pushing %s -> %s", img, newTag)
+
+		var r pushResult
+		if len(platforms) > 1 {
+			r = pushPlatformsWithRetry(img, newTag, platforms, retries)
+		} else {
+			r = pushImageWithRetry(img, newTag, retries)
+		}
+		if r.err != nil {
+			logger.Warn("Failed to push %s: %v", newTag, r.err)
+		} else {
+			logger.Success("Pushed: %s", newTag)
+		}
+		results = append(results, r)
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "IMAGE\tTARGET\tRESULT\tATTEMPTS\tERROR")
+	failed := 0
+	for _, r := range results {
+		result, errMsg := color.GreenString("pushed"), ""
+		if r.err != nil {
+			failed++
+			result, errMsg = color.RedString("failed"), r.err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", r.source, r.target, result, r.attempts, errMsg)
+	}
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d image(s) failed to push to %s; re-run the command to retry them", failed, len(results), targetRegistry)
+	}
+	logger.Success("All images pushed to %s", targetRegistry)
+	return nil
+}
+
 func newMirrorListCmd() *cobra.Command {
 	var (
 		milvusVersion string
 		all           bool
 		registry      string
+		topology      string
 	)
 
 	cmd := &cobra.Command{
@@ -1704,7 +4191,10 @@ Examples:
   miup mirror list                               List images from public registries
   miup mirror list --registry harbor.milvus.io  List images from internal Harbor`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			images := getMilvusImages(milvusVersion, all, registry)
+			images, err := mirrorImages(milvusVersion, all, registry, topology)
+			if err != nil {
+				return err
+			}
 
 			fmt.Println("Required images for Milvus deployment:")
 			for _, img := range images {
@@ -1714,63 +4204,212 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVar(&milvusVersion, "milvus.version", "v2.5.4", "Milvus version")
+	cmd.Flags().StringVar(&milvusVersion, "milvus.version", version.MilvusDefault(), "Milvus version")
 	cmd.Flags().BoolVar(&all, "all", false, "Include monitoring images (Prometheus, Grafana)")
+	cmd.Flags().StringVar(&topology, "topology", "", "Mirror exactly the images this topology file deploys")
+	cmd.MarkFlagsMutuallyExclusive("all", "topology")
 	cmd.Flags().StringVar(&registry, "registry", "", "Private registry address (e.g., harbor.milvus.io)")
 
 	return cmd
 }
 
-// getMilvusImages returns the list of Docker images required for Milvus deployment
-// If registry is provided, images will be prefixed with the registry address
-func getMilvusImages(milvusVersion string, includeMonitoring bool, registry string) []string {
-	var images []string
+// mirrorImages returns the images to mirror: exactly those a topology
+// deploys if one is given, the default image set otherwise. If registry is
+// provided, images are prefixed with the registry address.
+func mirrorImages(milvusVersion string, includeMonitoring bool, registry, topology string) ([]string, error) {
+	if topology == "" {
+		return spec.DefaultImages(milvusVersion, includeMonitoring, registry), nil
+	}
+
+	specification, err := spec.LoadUserSpecification(topology)
+	if err != nil {
+		return nil, err
+	}
+	if err := specification.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid topology: %w", err)
+	}
+	return specification.Images(milvusVersion, registry), nil
+}
+
+// pullImage pulls a Docker image, for platform if one is given
+func pullImage(image, platform string) error {
+	args := []string{"pull", image}
+	if platform != "" {
+		args = []string{"pull", "--platform", platform, image}
+	}
+	cmd := exec.Command("docker", args...)
+	cmd.Stdout = commandOutput()
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+	logger.Emit(logger.Event{Type: logger.EventImagePulled, Fields: map[string]string{"image": image, "platform": platform}})
+	return nil
+}
+
+// pullPlatformImages pulls images for each platform. The local image store
+// holds one image per tag, so with more than one platform each pull is also
+// tagged with platformTag to keep the platforms side by side. It returns the
+// local tags to save.
+func pullPlatformImages(images, platforms []string) ([]string, error) {
+	var tags []string
+	for _, img := range images {
+		for _, platform := range platforms {
+			logger.Info("Pulling image: %s (%s)", img, platform)
+			if err := pullImage(img, platform); err != nil {
+				return nil, fmt.Errorf("failed to pull %s for %s: %w", img, platform, err)
+			}
+			if len(platforms) == 1 {
+				tags = append(tags, img)
+				continue
+			}
+			tag := platformTag(img, platform)
+			if err := tagImage(img, tag); err != nil {
+				return nil, err
+			}
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// platformTag returns the local tag of an image pulled for one of several
+// platforms, e.g. milvusdb/milvus:v2.5.4-arm64 for linux/arm64. An image
+// pinned by digest is tagged with the digest, as a tag can't hold its colon:
+// milvusdb/milvus@sha256:3f2a... becomes milvusdb/milvus:sha256-3f2a...-arm64.
+func platformTag(image, platform string) string {
+	repo, tag := image, "latest"
+	if i := strings.Index(image, "@"); i >= 0 {
+		repo, tag = image[:i], strings.ReplaceAll(image[i+1:], ":", "-")
+		if j := strings.LastIndex(repo, ":"); j > strings.LastIndex(repo, "/") {
+			repo = repo[:j]
+		}
+	} else if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		repo, tag = image[:i], image[i+1:]
+	}
+	arch := strings.ReplaceAll(strings.TrimPrefix(platform, "linux/"), "/", "-")
+	return fmt.Sprintf("%s:%s-%s", repo, tag, arch)
+}
 
-	if registry != "" {
-		// Use internal registry (e.g., harbor.milvus.io)
-		// Format: registry/project/image:tag
-		images = []string{
-			fmt.Sprintf("%s/milvus/milvus:%s", registry, milvusVersion),
-			fmt.Sprintf("%s/milvus-ci/etcd:3.5.18-r0", registry),
-			fmt.Sprintf("%s/milvus-ci/minio:RELEASE.2023-03-20T20-16-18Z", registry),
+// validatePlatforms checks that each platform is os/arch[/variant]
+func validatePlatforms(platforms []string) error {
+	for _, platform := range platforms {
+		parts := strings.Split(platform, "/")
+		if len(parts) < 2 || len(parts) > 3 || slices.Contains(parts, "") {
+			return fmt.Errorf("invalid --arch '%s': expected os/arch[/variant], e.g. linux/arm64", platform)
 		}
-		if includeMonitoring {
-			images = append(images,
-				fmt.Sprintf("%s/milvus-ci/prometheus:latest", registry),
-				fmt.Sprintf("%s/milvus-ci/grafana:latest", registry),
-			)
+	}
+	return nil
+}
+
+// mirrorManifest describes a tar archive written by mirror save. It is
+// written next to the archive so load and push know which images and
+// platforms the archive holds.
+type mirrorManifest struct {
+	MilvusVersion string   `json:"milvus_version"`
+	Architectures []string `json:"architectures,omitempty"`
+	Images        []string `json:"images"`
+}
+
+// verifyMirrorArchive checks a tar archive against the checksum given, or
+// the one saved next to it, and its signature if a key is given. Its
+// manifest is checked by readMirrorManifest.
+func verifyMirrorArchive(tarFile, checksum, key string) error {
+	if checksum == "" {
+		if _, err := os.Stat(tarFile + archive.ChecksumSuffix); err == nil {
+			checksum = tarFile + archive.ChecksumSuffix
+		}
+	}
+	if checksum != "" {
+		expected, err := archive.ParseChecksum(checksum)
+		if err != nil {
+			return err
+		}
+		logger.Info("Verifying checksum of %s...", tarFile)
+		if err := archive.VerifyChecksum(tarFile, expected); err != nil {
+			return fmt.Errorf("%w; refusing to load", err)
 		}
 	} else {
-		// Use public registries
-		images = []string{
-			fmt.Sprintf("milvusdb/milvus:%s", milvusVersion),
-			"quay.io/coreos/etcd:v3.5.18",
-			"minio/minio:RELEASE.2023-03-20T20-16-18Z",
+		logger.Warn("No checksum for %s; loading it unverified", tarFile)
+	}
+
+	if key != "" {
+		logger.Info("Verifying signature of %s...", tarFile)
+		if err := archive.VerifySignature(tarFile, key); err != nil {
+			return fmt.Errorf("%w; refusing to load", err)
 		}
-		if includeMonitoring {
-			images = append(images,
-				"prom/prometheus:latest",
-				"grafana/grafana:latest",
-			)
+	}
+	return nil
+}
+
+// manifestTags returns the local tags of the images an archive holds: the
+// images themselves, or their per-platform tags if it holds several
+// platforms
+func manifestTags(manifest *mirrorManifest) []string {
+	if len(manifest.Architectures) <= 1 {
+		return manifest.Images
+	}
+	var tags []string
+	for _, img := range manifest.Images {
+		for _, platform := range manifest.Architectures {
+			tags = append(tags, platformTag(img, platform))
 		}
 	}
+	return tags
+}
 
-	return images
+// manifestPath returns the path of the manifest of a tar archive
+func manifestPath(tarFile string) string {
+	return strings.TrimSuffix(tarFile, ".tar") + ".manifest.json"
 }
 
-// pullImage pulls a Docker image
-func pullImage(image string) error {
-	cmd := exec.Command("docker", "pull", image)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+// writeMirrorManifest writes the manifest of a tar archive and its checksum
+func writeMirrorManifest(tarFile string, manifest mirrorManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(manifestPath(tarFile), append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	_, err = archive.WriteChecksum(manifestPath(tarFile))
+	return err
+}
+
+// readMirrorManifest reads the manifest of a tar archive, checked against
+// its checksum if it has one and, if a key is given, against its cosign
+// signature; it returns nil if the archive has no manifest (e.g. it
+// predates manifests)
+func readMirrorManifest(tarFile, key string) (*mirrorManifest, error) {
+	path := manifestPath(tarFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if _, err := archive.VerifySavedChecksum(path); err != nil {
+		return nil, fmt.Errorf("%w; refusing to use it", err)
+	}
+	if key != "" {
+		logger.Info("Verifying signature of %s...", path)
+		if err := archive.VerifySignature(path, key); err != nil {
+			return nil, fmt.Errorf("%w; refusing to use it", err)
+		}
+	}
+	var manifest mirrorManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return &manifest, nil
 }
 
 // saveImages saves Docker images to a tar file
 func saveImages(images []string, output string) error {
 	args := append([]string{"save", "-o", output}, images...)
 	cmd := exec.Command("docker", args...)
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = commandOutput()
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
@@ -1778,7 +4417,7 @@ func saveImages(images []string, output string) error {
 // loadImages loads Docker images from a tar file
 func loadImages(input string) error {
 	cmd := exec.Command("docker", "load", "-i", input)
-	cmd.Stdout = os.Stdout
+	cmd.Stdout = commandOutput()
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
@@ -1796,19 +4435,141 @@ func retagImage(image, registry string) string {
 	return fmt.Sprintf("%s/%s", registry, imageName)
 }
 
-// tagAndPushImage tags and pushes an image to a registry
-func tagAndPushImage(source, target string) error {
-	// Tag the image
-	tagCmd := exec.Command("docker", "tag", source, target)
+// pushRetryDelay is the wait before the first retry of a push; it doubles
+// after every further failure. Tests shorten it.
+var pushRetryDelay = 2 * time.Second
+
+// transientPushErrors are substrings of push errors worth retrying: network
+// failures and an overloaded registry. Anything else, e.g. "unauthorized" or
+// "denied", fails the same way every time.
+var transientPushErrors = []string{
+	"timeout",
+	"timed out",
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"eof",
+	"no such host",
+	"temporary failure",
+	"too many requests",
+	"toomanyrequests",
+	"internal server error",
+	"bad gateway",
+	"service unavailable",
+}
+
+// dockerCommand creates a docker command for the mirror push helpers; tests
+// replace it
+var dockerCommand = func(args ...string) *exec.Cmd {
+	return exec.Command("docker", args...)
+}
+
+// pushResult is the outcome of pushing one image
+type pushResult struct {
+	source   string
+	target   string
+	attempts int
+	err      error
+}
+
+// pushImageWithRetry tags source as target and pushes it, retrying a push
+// that fails on a transient error up to retries times. A failed tag means the
+// source image is missing locally and is not retried.
+func pushImageWithRetry(source, target string, retries int) pushResult {
+	r := pushResult{source: source, target: target}
+	if err := tagImage(source, target); err != nil {
+		r.err = err
+		return r
+	}
+
+	delay := pushRetryDelay
+	for {
+		r.attempts++
+		if r.err = pushImage(target); r.err == nil {
+			return r
+		}
+		if r.attempts > retries || !isTransientPushError(r.err) {
+			return r
+		}
+		logger.Warn("Push of %s failed: %v; retrying in %s (retry %d/%d)", target, r.err, delay, r.attempts, retries)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// isTransientPushError reports whether a failed push may succeed if retried
+func isTransientPushError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range transientPushErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// pushPlatformsWithRetry pushes the platformTag images of source for each
+// platform and joins them under target as a multi-arch manifest list
+func pushPlatformsWithRetry(source, target string, platforms []string, retries int) pushResult {
+	r := pushResult{source: source, target: target}
+	var targets []string
+	for _, platform := range platforms {
+		pr := pushImageWithRetry(platformTag(source, platform), platformTag(target, platform), retries)
+		r.attempts += pr.attempts
+		if pr.err != nil {
+			r.err = fmt.Errorf("%s: %w", platform, pr.err)
+			return r
+		}
+		targets = append(targets, pr.target)
+	}
+
+	var stderr bytes.Buffer
+	createCmd := dockerCommand(append([]string{"manifest", "create", "--amend", target}, targets...)...)
+	createCmd.Stderr = &stderr
+	if err := createCmd.Run(); err != nil {
+		r.err = fmt.Errorf("failed to create manifest list: %w", commandError(err, stderr.String()))
+		return r
+	}
+	stderr.Reset()
+	pushCmd := dockerCommand("manifest", "push", "--purge", target)
+	pushCmd.Stderr = &stderr
+	if err := pushCmd.Run(); err != nil {
+		r.err = fmt.Errorf("failed to push manifest list: %w", commandError(err, stderr.String()))
+	}
+	return r
+}
+
+// tagImage tags a local image; it's a no-op if the tag already exists
+func tagImage(source, target string) error {
+	var stderr bytes.Buffer
+	tagCmd := dockerCommand("tag", source, target)
+	tagCmd.Stderr = &stderr
 	if err := tagCmd.Run(); err != nil {
-		return fmt.Errorf("failed to tag: %w", err)
+		return fmt.Errorf("failed to tag: %w", commandError(err, stderr.String()))
 	}
+	return nil
+}
 
-	// Push the image
-	pushCmd := exec.Command("docker", "push", target)
+// pushImage pushes an image, streaming docker's progress output
+func pushImage(target string) error {
+	var stderr bytes.Buffer
+	pushCmd := dockerCommand("push", target)
 	pushCmd.Stdout = os.Stdout
-	pushCmd.Stderr = os.Stderr
-	return pushCmd.Run()
+	pushCmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	if err := pushCmd.Run(); err != nil {
+		return commandError(err, stderr.String())
+	}
+	return nil
+}
+
+// commandError adds the last line a command wrote to stderr to its error,
+// which is more useful than "exit status 1" in a summary
+func commandError(err error, stderr string) error {
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return fmt.Errorf("%w: %s", err, last)
+	}
+	return err
 }
 
 const kubernetesTLSTemplate = `# MiUp Kubernetes Topology - Standalone Mode with TLS
@@ -1867,6 +4628,7 @@ Examples:
   miup bench milvus prepare --uri localhost:19530              # Prepare test data
   miup bench milvus search --uri localhost:19530               # Run search benchmark
   miup bench milvus insert --uri localhost:19530               # Run insert benchmark
+  miup bench milvus search --monitor                           # Show bench and Milvus metrics on one dashboard
   miup bench milvus cleanup --uri localhost:19530              # Clean up test data`,
 	}
 
@@ -1912,6 +4674,14 @@ type benchFlags struct {
 	batchSize   int
 	topK        int
 	indexType   string
+
+	// Run options (search and insert only)
+	warmup      time.Duration
+	buckets     string
+	jsonOutput  bool
+	monitor     bool
+	tag         string
+	metricsPort int
 }
 
 func addBenchFlags(cmd *cobra.Command, flags *benchFlags) {
@@ -1930,6 +4700,46 @@ func addBenchFlags(cmd *cobra.Command, flags *benchFlags) {
 	cmd.Flags().StringVar(&flags.indexType, "index-type", "IVF_FLAT", "Index type (FLAT, IVF_FLAT, HNSW)")
 }
 
+func addBenchRunFlags(cmd *cobra.Command, flags *benchFlags) {
+	cmd.Flags().DurationVar(&flags.warmup, "warmup", 0, "Run for this long before measuring, excluding cold-cache operations (e.g., '30s')")
+	cmd.Flags().StringVar(&flags.buckets, "histogram-buckets", "", "Latency histogram upper bounds (e.g., '1ms,5ms,10ms,50ms')")
+	cmd.Flags().BoolVar(&flags.jsonOutput, "json", false, "Output results in JSON format, including the latency histogram")
+	cmd.Flags().BoolVar(&flags.monitor, "monitor", false, "Scrape benchmark metrics into the playground's Prometheus and show them on a combined Grafana dashboard")
+	cmd.Flags().StringVar(&flags.tag, "tag", "default", "Playground instance tag to monitor with")
+	cmd.Flags().IntVar(&flags.metricsPort, "metrics-port", playground.DefaultBenchMetricsPort, "Host port for the benchmark metrics exporter")
+}
+
+// runMonitoredBench runs a benchmark, first wiring its metrics exporter into
+// the playground's monitoring stack if --monitor is set
+func runMonitoredBench(subcmd string, flags *benchFlags) error {
+	args := buildVdbbenchArgs(subcmd, flags)
+	if !flags.monitor {
+		return runGoVdbbench(args)
+	}
+
+	profile, err := localdata.DefaultProfile()
+	if err != nil {
+		return err
+	}
+
+	mgr := playground.NewManager(profile)
+	mon, err := mgr.MonitorBench(context.Background(), flags.tag, flags.metricsPort)
+	if err != nil {
+		return err
+	}
+
+	// Keep stdout clean for --json results
+	out := os.Stdout
+	if flags.jsonOutput {
+		out = os.Stderr
+	}
+	fmt.Fprintf(out, "Prometheus: %s\n", color.CyanString(mon.PrometheusURL))
+	fmt.Fprintf(out, "Dashboard:  %s\n", color.CyanString("%s (admin/admin)", mon.DashboardURL))
+
+	args = append(args, "--metrics-addr", fmt.Sprintf(":%d", flags.metricsPort))
+	return runGoVdbbench(args)
+}
+
 func buildVdbbenchArgs(subcmd string, flags *benchFlags) []string {
 	args := []string{"milvus", subcmd}
 	args = append(args, "--uri", flags.uri)
@@ -1955,6 +4765,15 @@ func buildVdbbenchArgs(subcmd string, flags *benchFlags) []string {
 	args = append(args, "--batch-size", fmt.Sprintf("%d", flags.batchSize))
 	args = append(args, "--top-k", fmt.Sprintf("%d", flags.topK))
 	args = append(args, "--index-type", flags.indexType)
+	if flags.warmup > 0 {
+		args = append(args, "--warmup", flags.warmup.String())
+	}
+	if flags.buckets != "" {
+		args = append(args, "--histogram-buckets", flags.buckets)
+	}
+	if flags.jsonOutput {
+		args = append(args, "--json")
+	}
 	return args
 }
 
@@ -1983,11 +4802,11 @@ func findVdbbenchBinary() string {
 		"go-vdbbench",
 	}
 
-	// Check if MIUP_HOME is set
-	if home := os.Getenv("MIUP_HOME"); home != "" {
+	// Check the miup profile ($MIUP_HOME or ~/.miup)
+	if profile, err := localdata.DefaultProfile(); err == nil {
 		locations = append([]string{
-			home + "/bin/go-vdbbench",
-			home + "/tools/go-vdbbench/go-vdbbench",
+			profile.Path("bin", "go-vdbbench"),
+			profile.Path("tools", "go-vdbbench", "go-vdbbench"),
 		}, locations...)
 	}
 
@@ -2056,17 +4875,19 @@ func newBenchMilvusSearchCmd() *cobra.Command {
 
 The test will execute concurrent vector similarity searches and measure:
   - QPS (queries per second)
-  - Latency (avg, p50, p95, p99)
+  - Latency (avg, p50, p95, p99) and a latency histogram
   - Error rate
 
+Use --warmup to exclude cold-cache operations at the start of the run.
+
 Note: Requires data to be prepared first using 'miup bench milvus prepare'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			vdbbenchArgs := buildVdbbenchArgs("search", &flags)
-			return runGoVdbbench(vdbbenchArgs)
+			return runMonitoredBench("search", &flags)
 		},
 	}
 
 	addBenchFlags(cmd, &flags)
+	addBenchRunFlags(cmd, &flags)
 	return cmd
 }
 
@@ -2080,15 +4901,15 @@ func newBenchMilvusInsertCmd() *cobra.Command {
 
 The test will execute concurrent batch inserts and measure:
   - Throughput (batches per second)
-  - Latency (avg, p50, p95, p99)
+  - Latency (avg, p50, p95, p99) and a latency histogram
   - Error rate`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			vdbbenchArgs := buildVdbbenchArgs("insert", &flags)
-			return runGoVdbbench(vdbbenchArgs)
+			return runMonitoredBench("insert", &flags)
 		},
 	}
 
 	addBenchFlags(cmd, &flags)
+	addBenchRunFlags(cmd, &flags)
 	return cmd
 }
 
@@ -2120,21 +4941,27 @@ For local deployments, configuration is stored in the user.yaml file.
 
 Subcommands:
   show    Show current configuration
+  get     Print the value of a single key
   set     Set configuration values
   import  Import configuration from a YAML file
   export  Export configuration to stdout (YAML format)
+  diff    Compare configuration with a YAML file
 
 Examples:
   miup instance config show prod
+  miup instance config get prod common.security.tlsMode
   miup instance config set prod common.security.tlsMode=1
   miup instance config import prod config.yaml
-  miup instance config export prod > config.yaml`,
+  miup instance config export prod > config.yaml
+  miup instance config diff prod config.yaml`,
 	}
 
 	cmd.AddCommand(newConfigShowCmd())
+	cmd.AddCommand(newConfigGetCmd())
 	cmd.AddCommand(newConfigSetCmd())
 	cmd.AddCommand(newConfigImportCmd())
 	cmd.AddCommand(newConfigExportCmd())
+	cmd.AddCommand(newConfigDiffCmd())
 
 	return cmd
 }
@@ -2162,7 +4989,7 @@ Examples:
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			mgr := manager.NewManager(profile)
 
 			config, err := mgr.GetConfig(ctx, instanceName)
@@ -2203,6 +5030,63 @@ Examples:
 	return cmd
 }
 
+func newConfigGetCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "get <instance-name> <key>",
+		Short: "Print the value of a single key",
+		Long: `Print the value of a configuration key, in dot notation, for scripting.
+
+Scalars are printed as-is and sections as YAML; with --json the value is
+printed JSON-encoded. Exits with code 3 if the key is not set.
+
+Examples:
+  miup instance config get prod common.security.tlsMode
+  miup instance config get prod proxy --json | jq .maxTaskNum`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName, key := args[0], args[1]
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			mgr := manager.NewManager(profile)
+			value, err := mgr.GetConfigValue(cmd.Context(), instanceName, key)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				data, err := json.Marshal(value)
+				if err != nil {
+					return fmt.Errorf("failed to format value: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			switch value.(type) {
+			case map[string]interface{}, []interface{}:
+				data, err := yaml.Marshal(value)
+				if err != nil {
+					return fmt.Errorf("failed to format value: %w", err)
+				}
+				fmt.Print(string(data))
+			default:
+				fmt.Println(value)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the value JSON-encoded")
+
+	return cmd
+}
+
 func newConfigSetCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set <instance-name> <key=value>...",
@@ -2250,7 +5134,7 @@ Examples:
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
+			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
 			sigCh := make(chan os.Signal, 1)
@@ -2294,23 +5178,30 @@ func newConfigImportCmd() *cobra.Command {
 The configuration will be merged with existing configuration.
 After importing, the instance will be restarted to apply changes.
 
+A snapshot taken with 'miup instance snapshot-config' (.tar.gz) is restored
+rather than merged: the configuration is replaced with the saved one, so keys
+set after the snapshot was taken are removed, and the stored topology is
+replaced with the saved topology.
+
 Examples:
   miup instance config import prod config.yaml
-  miup instance config import prod /path/to/milvus.yaml`,
+  miup instance config import prod /path/to/milvus.yaml
+  miup instance config import prod ~/.miup/clusters/prod/snapshots/prod-config-20250101-120000.tar.gz`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			instanceName := args[0]
 			configFile := args[1]
 
-			// Read config file
-			data, err := os.ReadFile(configFile)
-			if err != nil {
-				return fmt.Errorf("failed to read config file: %w", err)
-			}
-
 			var config map[string]interface{}
-			if err := yaml.Unmarshal(data, &config); err != nil {
-				return fmt.Errorf("failed to parse config file: %w", err)
+			snapshot := manager.IsConfigSnapshot(configFile)
+			if !snapshot {
+				data, err := os.ReadFile(configFile)
+				if err != nil {
+					return fmt.Errorf("failed to read config file: %w", err)
+				}
+				if err := yaml.Unmarshal(data, &config); err != nil {
+					return fmt.Errorf("failed to parse config file: %w", err)
+				}
 			}
 
 			profile, err := localdata.DefaultProfile()
@@ -2318,7 +5209,7 @@ Examples:
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
+			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
 			sigCh := make(chan os.Signal, 1)
@@ -2329,6 +5220,9 @@ Examples:
 			}()
 
 			mgr := manager.NewManager(profile)
+			if snapshot {
+				return mgr.RestoreConfigSnapshot(ctx, instanceName, configFile)
+			}
 			return mgr.SetConfig(ctx, instanceName, config)
 		},
 	}
@@ -2336,6 +5230,43 @@ Examples:
 	return cmd
 }
 
+func newInstanceSnapshotConfigCmd() *cobra.Command {
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "snapshot-config <instance-name>",
+		Short: "Back up the configuration and topology of an instance",
+		Long: `Save the live Milvus configuration and the topology of an instance to a
+tar.gz snapshot before changing them.
+
+The snapshot is written to a timestamped file in the instance directory
+(~/.miup/clusters/<name>/snapshots/) unless -o is given. Restore the
+configuration and topology with 'miup instance config import <name>
+<snapshot>', which replaces both, so keys set after the snapshot was taken
+are removed.
+
+Examples:
+  miup instance snapshot-config prod
+  miup instance snapshot-config prod -o prod-before-tls.tar.gz
+  miup instance config import prod prod-before-tls.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			mgr := manager.NewManager(profile)
+			_, err = mgr.SnapshotConfig(cmd.Context(), args[0], outputPath)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file (default: <instance-dir>/snapshots/<name>-config-<timestamp>.tar.gz)")
+
+	return cmd
+}
+
 func newConfigExportCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "export <instance-name>",
@@ -2356,7 +5287,7 @@ Examples:
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			mgr := manager.NewManager(profile)
 
 			config, err := mgr.GetConfig(ctx, instanceName)
@@ -2384,6 +5315,96 @@ Examples:
 	return cmd
 }
 
+func newConfigDiffCmd() *cobra.Command {
+	var (
+		jsonOutput bool
+		exitCode   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff <instance-name> <config-file>",
+		Short: "Compare configuration with a YAML file",
+		Long: `Compare the live Milvus configuration of an instance with a YAML file, e.g. a
+known-good config kept in version control, and print the keys the file adds,
+removes or changes. 'config import' with the same file applies the changes
+that are added or changed.
+
+Examples:
+  miup instance config diff prod config.yaml
+  miup instance config diff prod config.yaml --exit-code || miup instance config import prod config.yaml`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName, configFile := args[0], args[1]
+
+			data, err := os.ReadFile(configFile)
+			if err != nil {
+				return fmt.Errorf("failed to read config file: %w", err)
+			}
+			var reference map[string]interface{}
+			if err := yaml.Unmarshal(data, &reference); err != nil {
+				return fmt.Errorf("failed to parse config file: %w", err)
+			}
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			mgr := manager.NewManager(profile)
+			live, err := mgr.GetConfig(cmd.Context(), instanceName)
+			if err != nil {
+				return err
+			}
+
+			changes, err := manager.DiffConfig(live, reference)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				if changes == nil {
+					changes = []manager.ConfigChange{}
+				}
+				if err := output.PrintJSON(os.Stdout, output.NewSuccessResult(changes)); err != nil {
+					return err
+				}
+			} else if len(changes) == 0 {
+				fmt.Printf("Configuration of %s matches %s\n", instanceName, configFile)
+			} else {
+				for _, c := range changes {
+					switch c.Kind {
+					case manager.ConfigAdded:
+						fmt.Println(color.GreenString("+ %s: %s", c.Key, formatConfigValue(c.New)))
+					case manager.ConfigRemoved:
+						fmt.Println(color.RedString("- %s: %s", c.Key, formatConfigValue(c.Old)))
+					default:
+						fmt.Println(color.YellowString("~ %s: %s → %s", c.Key, formatConfigValue(c.Old), formatConfigValue(c.New)))
+					}
+				}
+			}
+
+			if exitCode && len(changes) > 0 {
+				return fmt.Errorf("configuration of %s differs from %s in %d key(s)", instanceName, configFile, len(changes))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output the changes in JSON format")
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "Exit with a non-zero code if the configuration differs")
+
+	return cmd
+}
+
+// formatConfigValue renders a configuration value on one line
+func formatConfigValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
 func newInstanceReloadCmd() *cobra.Command {
 	var (
 		configFile string
@@ -2423,7 +5444,7 @@ Examples:
 				return err
 			}
 
-			ctx, cancel := context.WithCancel(context.Background())
+			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
 			sigCh := make(chan os.Signal, 1)
@@ -2468,7 +5489,10 @@ Examples:
 }
 
 func newInstanceDiagnoseCmd() *cobra.Command {
-	var outputJSON bool
+	var (
+		outputJSON bool
+		strict     bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "diagnose <instance-name>",
@@ -2481,12 +5505,13 @@ This command checks:
   - Resource usage and limits
   - Common issues and provides suggestions
 
-For Kubernetes deployments, it inspects the Milvus CRD status and conditions.
-For local deployments, it checks Docker container health.
+It inspects the Milvus CRD status and conditions. For local playgrounds,
+use 'miup playground diagnose'.
 
 Examples:
   miup instance diagnose prod
-  miup instance diagnose prod --json`,
+  miup instance diagnose prod --json
+  miup instance diagnose prod --strict     Fail CI on warnings too`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			instanceName := args[0]
@@ -2496,7 +5521,7 @@ Examples:
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			mgr := manager.NewManager(profile)
 
 			result, err := mgr.Diagnose(ctx, instanceName)
@@ -2504,15 +5529,192 @@ Examples:
 				return err
 			}
 
-			if outputJSON {
-				return printDiagnoseJSON(result)
+			return printDiagnosis(instanceName, result, outputJSON, strict)
+		},
+	}
+
+	cmd.Flags().BoolVar(&outputJSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Exit with code 2 if any check warns")
+
+	return cmd
+}
+
+func newInstanceRepairCmd() *cobra.Command {
+	var (
+		kubeconfig  string
+		kubecontext string
+		namespace   string
+		force       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "repair <instance-name>",
+		Short: "Rebuild local metadata from the Milvus CRD",
+		Long: `Rebuild meta.json and topology.yaml for an instance from its live Milvus CRD.
+
+Use this when local metadata is lost or corrupt, or to manage an instance
+from another machine. The instance name must match the Milvus resource name.
+
+Examples:
+  miup instance repair prod
+  miup instance repair prod --namespace milvus --context prod-cluster
+  miup instance repair prod --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
 			}
 
-			return printDiagnoseResult(instanceName, result)
+			ctx := cmd.Context()
+			mgr := manager.NewManager(profile)
+
+			return mgr.Repair(ctx, args[0], manager.RepairOptions{
+				Kubeconfig:  kubeconfig,
+				KubeContext: kubecontext,
+				Namespace:   namespace,
+				Force:       force,
+			})
 		},
 	}
 
-	cmd.Flags().BoolVar(&outputJSON, "json", false, "Output in JSON format")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (defaults to ~/.kube/config)")
+	cmd.Flags().StringVar(&kubecontext, "context", "", "Kubernetes context to use")
+	cmd.Flags().StringVar(&namespace, "namespace", "milvus", "Kubernetes namespace of the Milvus resource")
+	cmd.Flags().BoolVar(&force, "force", false, "Rebuild even if existing metadata is readable")
+
+	return cmd
+}
+
+func newInstancePruneCmd() *cobra.Command {
+	var (
+		kubeconfig  string
+		kubecontext string
+		dryRun      bool
+		yes         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Reconcile local instances with the Milvus resources in Kubernetes",
+		Long: `Find local instances whose Milvus resource no longer exists, and Milvus
+resources created by miup that no local instance tracks.
+
+This happens when a destroy deletes the resource but fails to remove the
+local state, or the other way round, or when a resource is deleted or
+created with kubectl. Prune lists both and offers to remove the local state
+of the former and to adopt the latter (like 'miup instance repair').
+
+Each local instance is checked against the Kubernetes cluster it was
+deployed to. Instances that can't be checked, e.g. because the API is
+unreachable, are listed and left alone. Untracked resources are looked up
+with --kubeconfig and --context.
+
+Examples:
+  miup instance prune --dry-run
+  miup instance prune -y
+  miup instance prune --context prod-cluster`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			mgr := manager.NewManager(profile)
+
+			orphaned, unchecked, err := mgr.FindOrphans(ctx)
+			if err != nil {
+				return err
+			}
+			discoverOpts := manager.DiscoverOptions{Kubeconfig: kubeconfig, KubeContext: kubecontext}
+			untracked, err := mgr.Untracked(ctx, discoverOpts)
+			if err != nil {
+				logger.Warn("Could not list Milvus resources to adopt: %v", err)
+			}
+
+			if len(unchecked) > 0 {
+				fmt.Println("Could not check these instances:")
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "  NAME\tREASON")
+				for _, u := range unchecked {
+					fmt.Fprintf(w, "  %s\t%s\n", u.Name, u.Reason)
+				}
+				w.Flush()
+				fmt.Println()
+			}
+			if len(orphaned) == 0 && len(untracked) == 0 {
+				fmt.Println("Nothing to prune")
+				return nil
+			}
+
+			failed := 0
+			if len(orphaned) > 0 {
+				fmt.Printf("%d local instance(s) have no Milvus resource:\n", len(orphaned))
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "  NAME\tNAMESPACE\tCONTEXT")
+				for _, o := range orphaned {
+					fmt.Fprintf(w, "  %s\t%s\t%s\n", o.Name, o.Namespace, orDash(o.KubeContext))
+				}
+				w.Flush()
+
+				if !dryRun && (yes || confirm(fmt.Sprintf("Remove the local state of %d instance(s)?", len(orphaned)))) {
+					for _, o := range orphaned {
+						start := time.Now()
+						err := mgr.RemoveOrphan(ctx, o.Name)
+						auditLog(o.Name, "prune", nil, err, time.Since(start))
+						if err != nil {
+							logger.Error("Failed to remove '%s': %v", o.Name, err)
+							failed++
+						}
+					}
+				}
+				fmt.Println()
+			}
+
+			if len(untracked) > 0 {
+				fmt.Printf("%d Milvus resource(s) created by miup have no local instance:\n", len(untracked))
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "  NAMESPACE\tNAME\tSTATUS\tVERSION")
+				for _, r := range untracked {
+					fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", r.Namespace, r.Name, orDash(r.Status), orDash(r.Version))
+				}
+				w.Flush()
+
+				if !dryRun && (yes || confirm(fmt.Sprintf("Adopt %d Milvus resource(s)?", len(untracked)))) {
+					for _, r := range untracked {
+						// Local instances are keyed by name alone
+						if mgr.Exists(r.Name) {
+							logger.Warn("Cannot adopt %s/%s: a local instance named '%s' tracks another namespace", r.Namespace, r.Name, r.Name)
+							continue
+						}
+						start := time.Now()
+						err := mgr.Repair(ctx, r.Name, manager.RepairOptions{
+							Kubeconfig:  kubeconfig,
+							KubeContext: kubecontext,
+							Namespace:   r.Namespace,
+						})
+						auditLog(r.Name, "prune", []string{"adopt"}, err, time.Since(start))
+						if err != nil {
+							logger.Error("Failed to adopt %s/%s: %v", r.Namespace, r.Name, err)
+							failed++
+						}
+					}
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d instance(s) could not be pruned or adopted", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file to look for untracked resources with (defaults to ~/.kube/config)")
+	cmd.Flags().StringVar(&kubecontext, "context", "", "Kubernetes context to look for untracked resources in")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only list what would be removed or adopted")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Remove and adopt without confirmation")
 
 	return cmd
 }
@@ -2594,13 +5796,89 @@ func getStatusIcon(status executor.CheckStatus) string {
 	}
 }
 
-func printDiagnoseJSON(result *executor.DiagnoseResult) error {
-	data, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to format JSON: %w", err)
+// diagnoseReport converts the diagnosis of an instance or playground into a
+// report: one result per component, connectivity and resource check, with
+// the suggestion of the issue found for it. Issues that belong to no check
+// become results of their own.
+func diagnoseReport(diagnosis *executor.DiagnoseResult) *check.Report {
+	var results []check.Result
+	byComponent := make(map[string]int)
+	add := func(component string, r check.Result) {
+		if _, ok := byComponent[component]; !ok {
+			byComponent[component] = len(results)
+		}
+		results = append(results, r)
 	}
-	fmt.Println(string(data))
-	return nil
+
+	for _, c := range diagnosis.Components {
+		add(c.Name, check.Result{
+			Name:    "Component " + c.Name,
+			Status:  diagnoseStatus(c.Status),
+			Message: c.Message,
+			Details: map[string]any{"kind": "component", "replicas": c.Replicas, "ready": c.Ready},
+		})
+	}
+	for _, c := range diagnosis.Connectivity {
+		add(c.Name, check.Result{
+			Name:    "Connectivity " + c.Name,
+			Status:  diagnoseStatus(c.Status),
+			Message: c.Message,
+			Details: map[string]any{"kind": "connectivity", "target": c.Target, "latency": c.Latency},
+		})
+	}
+	for _, c := range diagnosis.Resources {
+		add(c.Name, check.Result{
+			Name:    "Resources " + c.Name,
+			Status:  diagnoseStatus(c.Status),
+			Message: c.Message,
+			Details: map[string]any{"kind": "resources", "usage": c.Usage, "limit": c.Limit},
+		})
+	}
+
+	for _, issue := range diagnosis.Issues {
+		if i, ok := byComponent[issue.Component]; ok && results[i].Status != check.StatusPass && results[i].Suggest == "" {
+			results[i].Suggest = issue.Suggestion
+			continue
+		}
+		results = append(results, check.Result{
+			Name:    issue.Component,
+			Status:  diagnoseStatus(issue.Severity),
+			Message: issue.Description,
+			Suggest: issue.Suggestion,
+			Details: map[string]any{"kind": "issue"},
+		})
+	}
+
+	return check.NewReport(results)
+}
+
+// diagnoseStatus maps the status of a diagnose check to a check status
+func diagnoseStatus(status executor.CheckStatus) check.Status {
+	switch status {
+	case executor.CheckStatusOK:
+		return check.StatusPass
+	case executor.CheckStatusWarning:
+		return check.StatusWarn
+	default:
+		return check.StatusFail
+	}
+}
+
+// printDiagnosis prints a diagnosis, as a check report with --json, and
+// returns the exit status shared with check and doctor
+func printDiagnosis(name string, result *executor.DiagnoseResult, outputJSON, strict bool) error {
+	report := diagnoseReport(result)
+	if outputJSON {
+		if err := printCheckJSON(report); err != nil {
+			return err
+		}
+		return report.Err(strict)
+	}
+
+	if err := printDiagnoseResult(name, result); err != nil {
+		return err
+	}
+	return report.Err(strict)
 }
 
 func newInstanceCheckCmd() *cobra.Command {
@@ -2610,6 +5888,7 @@ func newInstanceCheckCmd() *cobra.Command {
 		namespace    string
 		storageClass string
 		outputJSON   bool
+		strict       bool
 	)
 
 	cmd := &cobra.Command{
@@ -2622,6 +5901,8 @@ This command verifies:
   - Kubernetes version compatibility (requires 1.20+)
   - Milvus Operator installation status
   - Target namespace existence
+  - RBAC permissions to create, update and delete Milvus resources and
+    secrets in the namespace (and to create it if it doesn't exist)
   - Storage class availability
   - Resource quota capacity
 
@@ -2643,17 +5924,21 @@ Examples:
 				return err
 			}
 
-			ctx := context.Background()
+			ctx := cmd.Context()
 			report, err := checker.Run(ctx)
 			if err != nil {
 				return err
 			}
 
 			if outputJSON {
-				return printCheckJSON(report)
+				if err := printCheckJSON(report); err != nil {
+					return err
+				}
+				return report.Err(strict)
 			}
 
-			return printCheckReport(report)
+			printCheckReport(report)
+			return report.Err(strict)
 		},
 	}
 
@@ -2662,13 +5947,25 @@ Examples:
 	cmd.Flags().StringVarP(&namespace, "namespace", "n", "milvus", "Target namespace for deployment")
 	cmd.Flags().StringVar(&storageClass, "storage-class", "", "Storage class to verify")
 	cmd.Flags().BoolVar(&outputJSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Exit with code 2 if any check warns")
 
 	return cmd
 }
 
-func printCheckReport(report *check.Report) error {
+func printCheckReport(report *check.Report) {
+	printCheckResults("Kubernetes Environment Check", report)
+
+	if report.CanDeploy {
+		fmt.Println(color.GreenString("Environment is ready for deployment!"))
+	} else {
+		fmt.Println(color.RedString("Environment is NOT ready. Please fix the failed checks."))
+	}
+}
+
+// printCheckResults prints a titled pass/warn/fail list and its summary
+func printCheckResults(title string, report *check.Report) {
 	// Header
-	fmt.Println(color.CyanString("Kubernetes Environment Check"))
+	fmt.Println(color.CyanString(title))
 	fmt.Println(strings.Repeat("-", 50))
 
 	// Results
@@ -2694,15 +5991,6 @@ func printCheckReport(report *check.Report) error {
 	fmt.Println(strings.Repeat("-", 50))
 	fmt.Printf("Summary: %d passed, %d warnings, %d failed\n",
 		report.Summary.Passed, report.Summary.Warned, report.Summary.Failed)
-
-	if report.CanDeploy {
-		fmt.Println(color.GreenString("Environment is ready for deployment!"))
-	} else {
-		fmt.Println(color.RedString("Environment is NOT ready. Please fix the failed checks."))
-		return fmt.Errorf("environment check failed")
-	}
-
-	return nil
 }
 
 func printCheckJSON(report *check.Report) error {
@@ -2858,6 +6146,204 @@ func printAuditJSON(entries []audit.Entry) error {
 
 // Skill commands for Claude Code integration
 
+func newSupportBundleCmd() *cobra.Command {
+	var (
+		outputPath string
+		tail       int
+		since      time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "support-bundle <instance-name>",
+		Short: "Collect a diagnostics archive for an instance",
+		Long: `Collect diagnostics for an instance into a single tar.gz archive.
+
+The bundle contains:
+  - miup version information
+  - Local instance metadata and topology
+  - The Milvus custom resource (YAML)
+  - Kubernetes events for the instance
+  - Pod manifests and logs
+  - Diagnose results (JSON)
+
+Credentials in the topology and the custom resource, such as MinIO keys
+and the Grafana admin password, are replaced with REDACTED.
+
+Examples:
+  miup support-bundle my-milvus
+  miup support-bundle my-milvus -o bundle.tar.gz --since 1h`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			if outputPath == "" {
+				outputPath = manager.DefaultSupportBundlePath(instanceName, time.Now())
+			}
+
+			ctx := cmd.Context()
+			mgr := manager.NewManager(profile)
+
+			return mgr.SupportBundle(ctx, instanceName, manager.SupportBundleOptions{
+				OutputPath: outputPath,
+				Logs: executor.LogsOptions{
+					Tail:  tail,
+					Since: since,
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file (default: miup-support-<name>-<timestamp>.tar.gz)")
+	cmd.Flags().IntVarP(&tail, "tail", "n", 1000, "Number of log lines to collect per pod")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only collect logs newer than a relative duration (e.g., 1h)")
+
+	return cmd
+}
+
+func newDoctorCmd() *cobra.Command {
+	var (
+		kubeconfig  string
+		kubeContext string
+		outputJSON  bool
+		strict      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the local environment for common problems",
+		Long: `Check that the local environment is set up for miup.
+
+This command verifies:
+  - Docker and Docker Compose are installed and the daemon is running (playgrounds)
+  - A kubeconfig exists and its cluster is reachable (instances)
+  - MIUP_HOME is writable
+  - miup is on PATH
+  - Installed components can be executed
+  - go-vdbbench is available (benchmarks)
+
+Missing optional tools are reported as warnings; the command fails only if
+something miup itself depends on is broken.
+
+Examples:
+  miup doctor
+  miup doctor --context kind-milvus
+  miup doctor --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			doctor := check.NewDoctor(check.DoctorOptions{
+				Kubeconfig:   kubeconfig,
+				Context:      kubeContext,
+				Profile:      profile,
+				VdbbenchPath: findVdbbenchBinary(),
+			})
+			report := doctor.Run(cmd.Context())
+
+			if outputJSON {
+				if err := printCheckJSON(report); err != nil {
+					return err
+				}
+				return report.Err(strict)
+			}
+
+			printCheckResults("miup Environment Check", report)
+			switch {
+			case !report.CanDeploy:
+				fmt.Println(color.RedString("Some checks failed. Please fix them before using miup."))
+			case report.Summary.Warned > 0:
+				fmt.Println(color.YellowString("miup is usable, but some features are unavailable (see warnings above)."))
+			default:
+				fmt.Println(color.GreenString("Your environment looks good!"))
+			}
+			return report.Err(strict)
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file")
+	cmd.Flags().StringVar(&kubeContext, "context", "", "Kubernetes context to use")
+	cmd.Flags().BoolVar(&outputJSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Exit with code 2 if any check warns")
+
+	return cmd
+}
+
+func newEnvCmd() *cobra.Command {
+	var (
+		shell      string
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "env <instance-name>",
+		Short: "Print shell exports for connecting to an instance",
+		Long: `Print environment variables describing how to reach an instance, as
+statements for your shell:
+
+  MIUP_INSTANCE, MIUP_NAMESPACE   The instance and its namespace
+  MILVUS_URI                      Milvus endpoint, e.g. http://prod-milvus.milvus:19530
+  MILVUS_HOST, MILVUS_PORT        The same endpoint split into host and port
+  ETCD_ENDPOINTS                  Comma-separated etcd endpoints
+  MINIO_ENDPOINT                  MinIO/S3 endpoint
+
+Endpoints are Kubernetes service addresses; from outside the cluster, use
+them with a port-forward or VPN.
+
+Examples:
+  eval "$(miup env prod)"
+  miup env prod --shell fish | source
+  miup env prod --shell powershell | Invoke-Expression
+  miup env prod --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			instanceName := args[0]
+
+			// Reject an unknown --shell before contacting the cluster
+			if _, err := manager.FormatEnv(nil, shell); err != nil {
+				return err
+			}
+
+			profile, err := localdata.DefaultProfile()
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			mgr := manager.NewManager(profile)
+
+			env, err := mgr.Env(ctx, instanceName)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(os.Stdout, output.NewSuccessResult(env))
+			}
+
+			exports, err := manager.FormatEnv(env, shell)
+			if err != nil {
+				return err
+			}
+			fmt.Print(exports)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&shell, "shell", "bash", fmt.Sprintf("Shell syntax (%s)", strings.Join(manager.EnvShells, ", ")))
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	_ = cmd.RegisterFlagCompletionFunc("shell", cobra.FixedCompletions(manager.EnvShells, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
 func newSkillCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "skill",
@@ -2998,9 +6484,120 @@ func newSkillUninstallCmd() *cobra.Command {
 	return cmd
 }
 
+// Exit codes for errors that scripts may want to branch on
+var exitCodes = map[output.ErrorCode]int{
+	output.ErrInternal:      1,
+	output.ErrNotFound:      3,
+	output.ErrAlreadyExists: 4,
+	output.ErrInvalidInput:  5,
+	output.ErrConflict:      6,
+	output.ErrTimeout:       7,
+	output.ErrPermission:    8,
+	output.ErrCheckFailed:   1,
+	output.ErrCheckWarned:   2,
+}
+
+// errorCode classifies an error returned by a command
+func errorCode(err error) output.ErrorCode {
+	switch {
+	case errors.Is(err, manager.ErrClusterNotFound), errors.Is(err, manager.ErrConfigKeyNotFound):
+		return output.ErrNotFound
+	case errors.Is(err, manager.ErrClusterExists):
+		return output.ErrAlreadyExists
+	case errors.Is(err, manager.ErrInvalidTopology), errors.Is(err, executor.ErrInvalidComponent),
+		errors.Is(err, executor.ErrStorageClassNotFound):
+		return output.ErrInvalidInput
+	case errors.Is(err, manager.ErrOperationInProgress), errors.Is(err, manager.ErrClusterStopped),
+		errors.Is(err, executor.ErrAlreadyAtVersion):
+		return output.ErrConflict
+	case errors.Is(err, executor.ErrTimeout), errors.Is(err, executor.ErrDeleteTimeout),
+		errors.Is(err, context.DeadlineExceeded):
+		return output.ErrTimeout
+	case errors.Is(err, executor.ErrPermissionDenied):
+		return output.ErrPermission
+	case errors.Is(err, check.ErrChecksFailed):
+		return output.ErrCheckFailed
+	case errors.Is(err, check.ErrChecksWarned):
+		return output.ErrCheckWarned
+	default:
+		return output.ErrInternal
+	}
+}
+
+// openEventsOutput opens the destination of --events-json: "-" for stdout,
+// "fd:N" for an inherited file descriptor, or a file to append to
+func openEventsOutput(dest string) (io.Writer, error) {
+	if dest == "-" {
+		return os.Stdout, nil
+	}
+	if n, ok := strings.CutPrefix(dest, "fd:"); ok {
+		fd, err := strconv.Atoi(n)
+		if err != nil || fd < 0 {
+			return nil, fmt.Errorf("invalid --events-json file descriptor: %s", dest)
+		}
+		return os.NewFile(uintptr(fd), "events"), nil
+	}
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open --events-json file: %w", err)
+	}
+	return f, nil
+}
+
+// commandOutput is where the output of tools miup runs goes: stdout, unless
+// events are written there
+func commandOutput() io.Writer {
+	if eventsStdout {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// printTimings prints the phases recorded during the command with their
+// elapsed time and share of the total. The total includes time not spent in
+// any recorded phase, e.g. loading the topology or waiting for a prompt.
+func printTimings(w io.Writer, r *timing.Recorder) {
+	total := r.Total()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Timings:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, p := range r.Phases() {
+		name := p.Name
+		if p.Count > 1 {
+			name = fmt.Sprintf("%s (x%d)", p.Name, p.Count)
+		}
+		share := 0.0
+		if total > 0 {
+			share = 100 * float64(p.Duration) / float64(total)
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%.0f%%\n", name, formatElapsed(p.Duration), share)
+	}
+	fmt.Fprintf(tw, "  total\t%s\t\n", formatElapsed(total))
+	tw.Flush()
+}
+
+// formatElapsed rounds d for display: milliseconds below a second, tenths
+// of a second below a minute, whole seconds above
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	case d < time.Minute:
+		return d.Round(100 * time.Millisecond).String()
+	default:
+		return d.Round(time.Second).String()
+	}
+}
+
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	recorder := timing.NewRecorder()
+	err := rootCmd.ExecuteContext(timing.WithRecorder(context.Background(), recorder))
+	stopEvents()
+	if timings {
+		printTimings(os.Stderr, recorder)
+	}
+	if err != nil {
 		fmt.Fprintln(os.Stderr, color.RedString("Error: %v", err))
-		os.Exit(1)
+		os.Exit(exitCodes[errorCode(err)])
 	}
 }
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/archive"
+)
+
+// fakeDocker replaces dockerCommand with a shell running the next script
+// given for the docker subcommand (e.g. "push"), repeating the last one once
+// they run out; subcommands without scripts succeed. It returns the
+// commands run, as "docker <args>".
+func fakeDocker(t *testing.T, scripts map[string][]string) *[]string {
+	t.Helper()
+	var calls []string
+	runs := make(map[string]int)
+
+	original, delay := dockerCommand, pushRetryDelay
+	dockerCommand = func(args ...string) *exec.Cmd {
+		calls = append(calls, "docker "+strings.Join(args, " "))
+		script := "exit 0"
+		if queue := scripts[args[0]]; len(queue) > 0 {
+			script = queue[min(runs[args[0]], len(queue)-1)]
+		}
+		runs[args[0]]++
+		return exec.Command("sh", "-c", script)
+	}
+	pushRetryDelay = 0
+	t.Cleanup(func() {
+		dockerCommand, pushRetryDelay = original, delay
+	})
+	return &calls
+}
+
+// fail is a script that fails writing msg to stderr, as docker does
+func fail(msg string) string {
+	return "echo '" + msg + "' >&2; exit 1"
+}
+
+func TestPushImageWithRetry(t *testing.T) {
+	const timeout = "net/http: TLS handshake timeout"
+	const unauthorized = "unauthorized: authentication required"
+
+	tests := []struct {
+		name         string
+		tag          []string
+		push         []string
+		retries      int
+		wantAttempts int
+		wantErr      string
+	}{
+		{
+			name:         "pushed at once",
+			retries:      3,
+			wantAttempts: 1,
+		},
+		{
+			name:         "transient failure retried",
+			push:         []string{fail(timeout), "exit 0"},
+			retries:      3,
+			wantAttempts: 2,
+		},
+		{
+			name:         "retries run out",
+			push:         []string{fail(timeout)},
+			retries:      2,
+			wantAttempts: 3,
+			wantErr:      "handshake timeout",
+		},
+		{
+			name:         "no retries",
+			push:         []string{fail(timeout)},
+			wantAttempts: 1,
+			wantErr:      "handshake timeout",
+		},
+		{
+			name:         "unauthorized not retried",
+			push:         []string{fail(unauthorized)},
+			retries:      3,
+			wantAttempts: 1,
+			wantErr:      "unauthorized",
+		},
+		{
+			name:    "missing source not pushed",
+			tag:     []string{fail("No such image: milvusdb/milvus:v2.5.4")},
+			retries: 3,
+			wantErr: "failed to tag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDocker(t, map[string][]string{"tag": tt.tag, "push": tt.push})
+
+			r := pushImageWithRetry("milvusdb/milvus:v2.5.4", "registry.local/milvusdb/milvus:v2.5.4", tt.retries)
+			if r.attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", r.attempts, tt.wantAttempts)
+			}
+			if tt.wantErr == "" {
+				if r.err != nil {
+					t.Errorf("err = %v, want nil", r.err)
+				}
+			} else if r.err == nil || !strings.Contains(r.err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want one containing %q", r.err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlatformTag(t *testing.T) {
+	const digest = "sha256:3f2ae9c1"
+
+	tests := []struct {
+		image    string
+		platform string
+		want     string
+	}{
+		{"milvusdb/milvus:v2.5.4", "linux/arm64", "milvusdb/milvus:v2.5.4-arm64"},
+		{"milvusdb/milvus", "linux/amd64", "milvusdb/milvus:latest-amd64"},
+		{"registry.local:5000/milvusdb/milvus", "linux/arm64", "registry.local:5000/milvusdb/milvus:latest-arm64"},
+		{"registry.local:5000/milvusdb/milvus:v2.5.4", "linux/arm/v7", "registry.local:5000/milvusdb/milvus:v2.5.4-arm-v7"},
+		{"milvusdb/milvus@" + digest, "linux/arm64", "milvusdb/milvus:sha256-3f2ae9c1-arm64"},
+		{"milvusdb/milvus:v2.5.4@" + digest, "linux/arm64", "milvusdb/milvus:sha256-3f2ae9c1-arm64"},
+		{"registry.local:5000/milvusdb/milvus@" + digest, "linux/amd64", "registry.local:5000/milvusdb/milvus:sha256-3f2ae9c1-amd64"},
+	}
+
+	for _, tt := range tests {
+		if got := platformTag(tt.image, tt.platform); got != tt.want {
+			t.Errorf("platformTag(%s, %s) = %s, want %s", tt.image, tt.platform, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePlatforms(t *testing.T) {
+	tests := []struct {
+		platforms []string
+		wantErr   bool
+	}{
+		{nil, false},
+		{[]string{"linux/amd64", "linux/arm64", "linux/arm/v7"}, false},
+		{[]string{"arm64"}, true},
+		{[]string{"linux/"}, true},
+		{[]string{"linux/arm/v7/extra"}, true},
+		{[]string{"linux/amd64", "linux//v7"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := validatePlatforms(tt.platforms); (err != nil) != tt.wantErr {
+			t.Errorf("validatePlatforms(%v) error = %v, wantErr %v", tt.platforms, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMirrorManifest(t *testing.T) {
+	tarFile := filepath.Join(t.TempDir(), "milvus.tar")
+
+	manifest, err := readMirrorManifest(tarFile, "")
+	if err != nil || manifest != nil {
+		t.Fatalf("readMirrorManifest() = %v, %v, want nil for an archive without one", manifest, err)
+	}
+
+	want := mirrorManifest{
+		MilvusVersion: "v2.5.4",
+		Architectures: []string{"linux/amd64", "linux/arm64"},
+		Images:        []string{"milvusdb/milvus:v2.5.4"},
+	}
+	if err := writeMirrorManifest(tarFile, want); err != nil {
+		t.Fatalf("writeMirrorManifest() error = %v", err)
+	}
+	if _, err := os.Stat(strings.TrimSuffix(tarFile, ".tar") + ".manifest.json"); err != nil {
+		t.Errorf("manifest not written next to the archive: %v", err)
+	}
+	manifest, err = readMirrorManifest(tarFile, "")
+	if err != nil {
+		t.Fatalf("readMirrorManifest() error = %v", err)
+	}
+	if !reflect.DeepEqual(*manifest, want) {
+		t.Errorf("readMirrorManifest() = %+v, want %+v", *manifest, want)
+	}
+	if _, err := readMirrorManifest(tarFile, "cosign.pub"); err == nil || !strings.Contains(err.Error(), "no signature found") {
+		t.Errorf("readMirrorManifest() with a key of an unsigned manifest error = %v, want no signature found", err)
+	}
+
+	if err := os.WriteFile(manifestPath(tarFile), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readMirrorManifest(tarFile, ""); !errors.Is(err, archive.ErrChecksumMismatch) {
+		t.Errorf("readMirrorManifest() of a tampered manifest error = %v, want ErrChecksumMismatch", err)
+	}
+
+	if err := os.Remove(manifestPath(tarFile) + archive.ChecksumSuffix); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readMirrorManifest(tarFile, ""); err == nil || !strings.Contains(err.Error(), "invalid manifest") {
+		t.Errorf("readMirrorManifest() error = %v, want an invalid manifest", err)
+	}
+}
+
+func TestPushPlatformsWithRetry(t *testing.T) {
+	const source = "milvusdb/milvus:v2.5.4"
+	const target = "registry.local/milvusdb/milvus:v2.5.4"
+	platforms := []string{"linux/amd64", "linux/arm64"}
+
+	tests := []struct {
+		name      string
+		scripts   map[string][]string
+		wantCalls []string
+		wantErr   string
+	}{
+		{
+			name: "joined into a manifest list",
+			wantCalls: []string{
+				"docker tag milvusdb/milvus:v2.5.4-amd64 registry.local/milvusdb/milvus:v2.5.4-amd64",
+				"docker push registry.local/milvusdb/milvus:v2.5.4-amd64",
+				"docker tag milvusdb/milvus:v2.5.4-arm64 registry.local/milvusdb/milvus:v2.5.4-arm64",
+				"docker push registry.local/milvusdb/milvus:v2.5.4-arm64",
+				"docker manifest create --amend " + target +
+					" registry.local/milvusdb/milvus:v2.5.4-amd64 registry.local/milvusdb/milvus:v2.5.4-arm64",
+				"docker manifest push --purge " + target,
+			},
+		},
+		{
+			name:    "a failed platform stops the push",
+			scripts: map[string][]string{"push": {fail("denied: requested access to the resource is denied")}},
+			wantCalls: []string{
+				"docker tag milvusdb/milvus:v2.5.4-amd64 registry.local/milvusdb/milvus:v2.5.4-amd64",
+				"docker push registry.local/milvusdb/milvus:v2.5.4-amd64",
+			},
+			wantErr: "linux/amd64: exit status 1: denied",
+		},
+		{
+			name:    "manifest list rejected",
+			scripts: map[string][]string{"manifest": {fail("manifest create is experimental")}},
+			wantErr: "failed to create manifest list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := fakeDocker(t, tt.scripts)
+
+			r := pushPlatformsWithRetry(source, target, platforms, 3)
+			if tt.wantErr == "" {
+				if r.err != nil {
+					t.Errorf("err = %v, want nil", r.err)
+				}
+			} else if r.err == nil || !strings.Contains(r.err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want one containing %q", r.err, tt.wantErr)
+			}
+			if tt.wantCalls != nil && !slices.Equal(*calls, tt.wantCalls) {
+				t.Errorf("calls = %q, want %q", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestManifestTags(t *testing.T) {
+	images := []string{"milvusdb/milvus:v2.5.4", "milvusdb/etcd:3.5.5"}
+
+	tests := []struct {
+		name          string
+		architectures []string
+		want          []string
+	}{
+		{
+			name: "host platform",
+			want: images,
+		},
+		{
+			name:          "one platform",
+			architectures: []string{"linux/arm64"},
+			want:          images,
+		},
+		{
+			name:          "several platforms",
+			architectures: []string{"linux/amd64", "linux/arm64"},
+			want: []string{
+				"milvusdb/milvus:v2.5.4-amd64", "milvusdb/milvus:v2.5.4-arm64",
+				"milvusdb/etcd:3.5.5-amd64", "milvusdb/etcd:3.5.5-arm64",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := manifestTags(&mirrorManifest{Images: images, Architectures: tt.architectures})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("manifestTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPushMirrorImages(t *testing.T) {
+	images := []string{"milvusdb/milvus:v2.5.4", "milvusdb/etcd:3.5.5"}
+
+	tests := []struct {
+		name      string
+		platforms []string
+		scripts   map[string][]string
+		wantPush  []string
+		wantErr   string
+	}{
+		{
+			name: "all pushed",
+			wantPush: []string{
+				"docker push registry.local/milvusdb/milvus:v2.5.4",
+				"docker push registry.local/milvusdb/etcd:3.5.5",
+			},
+		},
+		{
+			name:    "a failure doesn't stop the rest",
+			scripts: map[string][]string{"push": {fail("unauthorized: authentication required"), "exit 0"}},
+			wantPush: []string{
+				"docker push registry.local/milvusdb/milvus:v2.5.4",
+				"docker push registry.local/milvusdb/etcd:3.5.5",
+			},
+			wantErr: "1 of 2 image(s) failed to push to registry.local",
+		},
+		{
+			name:      "several platforms",
+			platforms: []string{"linux/amd64", "linux/arm64"},
+			wantPush: []string{
+				"docker push registry.local/milvusdb/milvus:v2.5.4-amd64",
+				"docker push registry.local/milvusdb/milvus:v2.5.4-arm64",
+				"docker manifest push --purge registry.local/milvusdb/milvus:v2.5.4",
+				"docker push registry.local/milvusdb/etcd:3.5.5-amd64",
+				"docker push registry.local/milvusdb/etcd:3.5.5-arm64",
+				"docker manifest push --purge registry.local/milvusdb/etcd:3.5.5",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := fakeDocker(t, tt.scripts)
+
+			err := pushMirrorImages(images, tt.platforms, "registry.local", 3)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("pushMirrorImages() error = %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("pushMirrorImages() error = %v, want one containing %q", err, tt.wantErr)
+			}
+
+			var pushes []string
+			for _, call := range *calls {
+				if strings.HasPrefix(call, "docker push") || strings.HasPrefix(call, "docker manifest push") {
+					pushes = append(pushes, call)
+				}
+			}
+			if !slices.Equal(pushes, tt.wantPush) {
+				t.Errorf("pushes = %q, want %q", pushes, tt.wantPush)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/playground"
+	"github.com/mmga-lab/miup/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// TestMilvusVersionDefaultsAgree guards against the playground, cluster and
+// mirror commands drifting apart from version.DefaultMilvusVersion.
+func TestMilvusVersionDefaultsAgree(t *testing.T) {
+	t.Setenv(version.DefaultMilvusVersionEnv, "")
+
+	if got := playground.DefaultConfig().MilvusVersion; got != version.DefaultMilvusVersion {
+		t.Errorf("playground default = %s, want %s", got, version.DefaultMilvusVersion)
+	}
+	cfg := &playground.Config{}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if cfg.MilvusVersion != version.DefaultMilvusVersion {
+		t.Errorf("playground validated default = %s, want %s", cfg.MilvusVersion, version.DefaultMilvusVersion)
+	}
+
+	cmds := map[string]func() *cobra.Command{
+		"instance deploy": newInstanceDeployCmd,
+		"mirror pull":     newMirrorPullCmd,
+		"mirror save":     newMirrorSaveCmd,
+		"mirror push":     newMirrorPushCmd,
+		"mirror list":     newMirrorListCmd,
+	}
+	for name, newCmd := range cmds {
+		t.Run(name, func(t *testing.T) {
+			flag := newCmd().Flags().Lookup("milvus.version")
+			if flag == nil {
+				t.Fatal("no --milvus.version flag")
+			}
+			if flag.DefValue != version.DefaultMilvusVersion {
+				t.Errorf("--milvus.version default = %s, want %s", flag.DefValue, version.DefaultMilvusVersion)
+			}
+		})
+	}
+}
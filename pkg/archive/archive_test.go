@@ -0,0 +1,96 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestCreateTarGz(t *testing.T) {
+	srcDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(srcDir, "a.log"), []byte("alpha"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(srcDir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "sub", "b.log"), []byte("beta"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Archive inside the source directory must not include itself
+	destPath := filepath.Join(srcDir, "logs.tar.gz")
+	if err := CreateTarGz(destPath, srcDir); err != nil {
+		t.Fatalf("CreateTarGz() error = %v", err)
+	}
+
+	f, err := os.Open(destPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	tr := tar.NewReader(gzr)
+
+	contents := make(map[string]string)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar read error = %v", err)
+		}
+		data, _ := io.ReadAll(tr)
+		contents[header.Name] = string(data)
+		names = append(names, header.Name)
+	}
+	sort.Strings(names)
+
+	if len(names) != 2 || names[0] != "a.log" || names[1] != "sub/b.log" {
+		t.Fatalf("archive entries = %v, want [a.log sub/b.log]", names)
+	}
+	if contents["a.log"] != "alpha" {
+		t.Errorf("a.log = %q, want alpha", contents["a.log"])
+	}
+	if contents["sub/b.log"] != "beta" {
+		t.Errorf("sub/b.log = %q, want beta", contents["sub/b.log"])
+	}
+}
+
+func TestReadTarGzFile(t *testing.T) {
+	srcDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(srcDir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "sub", "b.yaml"), []byte("beta"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	destPath := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	if err := CreateTarGz(destPath, srcDir); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ReadTarGzFile(destPath, "sub/b.yaml")
+	if err != nil {
+		t.Fatalf("ReadTarGzFile() error = %v", err)
+	}
+	if string(data) != "beta" {
+		t.Errorf("ReadTarGzFile() = %q, want beta", data)
+	}
+
+	if _, err := ReadTarGzFile(destPath, "missing.yaml"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ReadTarGzFile() error = %v, want os.ErrNotExist", err)
+	}
+}
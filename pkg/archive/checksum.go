@@ -0,0 +1,143 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ChecksumSuffix is appended to an archive path to name its SHA-256 file
+	ChecksumSuffix = ".sha256"
+
+	// SignatureSuffix is appended to an archive path to name its cosign
+	// signature
+	SignatureSuffix = ".sig"
+)
+
+// ErrChecksumMismatch is returned when an archive doesn't match its checksum
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// FileSHA256 returns the hex-encoded SHA-256 of a file
+func FileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// WriteChecksum writes the SHA-256 of path next to it in sha256sum format,
+// so 'sha256sum -c' can check it too, and returns the checksum
+func WriteChecksum(path string) (string, error) {
+	sum, err := FileSHA256(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to checksum %s: %w", path, err)
+	}
+	line := fmt.Sprintf("%s  %s\n", sum, filepath.Base(path))
+	if err := os.WriteFile(path+ChecksumSuffix, []byte(line), 0644); err != nil {
+		return "", fmt.Errorf("failed to write checksum: %w", err)
+	}
+	return sum, nil
+}
+
+// ParseChecksum returns the SHA-256 given by s: either a hex digest,
+// optionally prefixed with "sha256:", or the path of a file in sha256sum
+// format
+func ParseChecksum(s string) (string, error) {
+	if sum, ok := hexDigest(strings.TrimPrefix(s, "sha256:")); ok {
+		return sum, nil
+	}
+
+	data, err := os.ReadFile(s)
+	if err != nil {
+		return "", fmt.Errorf("checksum %q is neither a SHA-256 digest nor a readable file: %w", s, err)
+	}
+	if fields := strings.Fields(string(data)); len(fields) > 0 {
+		if sum, ok := hexDigest(fields[0]); ok {
+			return sum, nil
+		}
+	}
+	return "", fmt.Errorf("%s does not contain a SHA-256 digest", s)
+}
+
+// hexDigest reports whether s is a hex-encoded SHA-256, returning it in
+// lower case
+func hexDigest(s string) (string, bool) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != sha256.Size {
+		return "", false
+	}
+	return strings.ToLower(s), true
+}
+
+// VerifyChecksum checks that path has the given SHA-256
+func VerifyChecksum(path, expected string) error {
+	sum, err := FileSHA256(path)
+	if err != nil {
+		return fmt.Errorf("failed to checksum %s: %w", path, err)
+	}
+	if sum != expected {
+		return fmt.Errorf("%w for %s: expected %s, got %s", ErrChecksumMismatch, path, expected, sum)
+	}
+	return nil
+}
+
+// VerifySavedChecksum checks path against the checksum written next to it
+// by WriteChecksum. It reports whether there was one to check.
+func VerifySavedChecksum(path string) (bool, error) {
+	if _, err := os.Stat(path + ChecksumSuffix); os.IsNotExist(err) {
+		return false, nil
+	}
+	expected, err := ParseChecksum(path + ChecksumSuffix)
+	if err != nil {
+		return true, err
+	}
+	return true, VerifyChecksum(path, expected)
+}
+
+// Sign signs path with a cosign private key, writing the signature next to
+// it. Nothing is uploaded to a transparency log, so the archive can be
+// verified in an air-gapped environment. cosign prompts for the key's
+// password unless COSIGN_PASSWORD is set.
+func Sign(path, key string) error {
+	return runCosign("sign-blob", "--yes", "--tlog-upload=false", "--key", key,
+		"--output-signature", path+SignatureSuffix, path)
+}
+
+// VerifySignature checks the cosign signature next to path against a public
+// key
+func VerifySignature(path, key string) error {
+	if _, err := os.Stat(path + SignatureSuffix); err != nil {
+		return fmt.Errorf("no signature found for %s: %w", path, err)
+	}
+	return runCosign("verify-blob", "--insecure-ignore-tlog", "--key", key,
+		"--signature", path+SignatureSuffix, path)
+}
+
+// runCosign runs the cosign CLI, which must be installed
+func runCosign(args ...string) error {
+	if _, err := exec.LookPath("cosign"); err != nil {
+		return fmt.Errorf("cosign is not installed; see https://docs.sigstore.dev/cosign/system_config/installation/")
+	}
+
+	cmd := exec.Command("cosign", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("cosign %s failed: %w", args[0], err)
+	}
+	return nil
+}
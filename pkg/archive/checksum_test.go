@@ -0,0 +1,107 @@
+package archive
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// sha256 of "alpha"
+const alphaSum = "8ed3f6ad685b959ead7022518e1af76cd816f8e8ec7ccdda1ed4018e8f2223f8"
+
+func TestWriteAndVerifyChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "images.tar")
+	if err := os.WriteFile(path, []byte("alpha"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sum, err := WriteChecksum(path)
+	if err != nil {
+		t.Fatalf("WriteChecksum() error = %v", err)
+	}
+	if sum != alphaSum {
+		t.Errorf("WriteChecksum() = %s, want %s", sum, alphaSum)
+	}
+
+	data, err := os.ReadFile(path + ChecksumSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := alphaSum + "  images.tar\n"; string(data) != want {
+		t.Errorf("checksum file = %q, want %q", data, want)
+	}
+
+	if err := VerifyChecksum(path, sum); err != nil {
+		t.Errorf("VerifyChecksum() error = %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyChecksum(path, sum); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("VerifyChecksum() after tampering error = %v, want ErrChecksumMismatch", err)
+	}
+}
+
+func TestVerifySavedChecksum(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "images.manifest.json")
+	if err := os.WriteFile(path, []byte("alpha"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if checked, err := VerifySavedChecksum(path); checked || err != nil {
+		t.Errorf("VerifySavedChecksum() = %t, %v, want nothing to check", checked, err)
+	}
+
+	if _, err := WriteChecksum(path); err != nil {
+		t.Fatal(err)
+	}
+	if checked, err := VerifySavedChecksum(path); !checked || err != nil {
+		t.Errorf("VerifySavedChecksum() = %t, %v, want checked", checked, err)
+	}
+
+	if err := os.WriteFile(path, []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifySavedChecksum(path); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("VerifySavedChecksum() after tampering error = %v, want ErrChecksumMismatch", err)
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	dir := t.TempDir()
+	sumFile := filepath.Join(dir, "images.tar.sha256")
+	if err := os.WriteFile(sumFile, []byte(alphaSum+"  images.tar\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	badFile := filepath.Join(dir, "bad.sha256")
+	if err := os.WriteFile(badFile, []byte("not a digest\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "digest", input: alphaSum},
+		{name: "upper case with prefix", input: "sha256:" + strings.ToUpper(alphaSum)},
+		{name: "sha256sum file", input: sumFile},
+		{name: "file without digest", input: badFile, wantErr: true},
+		{name: "short digest", input: alphaSum[:10], wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseChecksum(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseChecksum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != alphaSum {
+				t.Errorf("ParseChecksum() = %s, want %s", got, alphaSum)
+			}
+		})
+	}
+}
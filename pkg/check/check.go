@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/version"
@@ -30,10 +31,17 @@ type Result struct {
 	Status  Status `json:"status"`
 	Message string `json:"message"`
 	Suggest string `json:"suggest,omitempty"`
+
+	// Details holds the data behind the verdict (versions, storage class
+	// names, quota numbers, ...) for tools that act on specifics
+	Details map[string]any `json:"details,omitempty"`
 }
 
-// Report represents the complete check report
+// Report represents the complete check report. It is the result model
+// shared by check, doctor and diagnose.
 type Report struct {
+	// Status is the most severe status of the results
+	Status   Status   `json:"status"`
 	Results  []Result `json:"results"`
 	Summary  Summary  `json:"summary"`
 	CanDeploy bool    `json:"can_deploy"`
@@ -59,7 +67,7 @@ type Options struct {
 type Checker struct {
 	opts      Options
 	config    *rest.Config
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
 // NewChecker creates a new checker
@@ -68,6 +76,7 @@ func NewChecker(opts Options) (*Checker, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to build kubernetes config: %w", err)
 	}
+	k8s.UseDefaultRateLimit(config)
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -91,6 +100,7 @@ func (c *Checker) Run(ctx context.Context) (*Report, error) {
 		c.checkKubernetesVersion,
 		c.checkMilvusOperator,
 		c.checkNamespace,
+		c.checkPermissions,
 		c.checkStorageClass,
 		c.checkResourceQuota,
 	}
@@ -99,7 +109,11 @@ func (c *Checker) Run(ctx context.Context) (*Report, error) {
 		results = append(results, check(ctx))
 	}
 
-	// Build summary
+	return NewReport(results), nil
+}
+
+// NewReport summarizes results; the environment is usable if nothing failed
+func NewReport(results []Result) *Report {
 	summary := Summary{Total: len(results)}
 	canDeploy := true
 	for _, r := range results {
@@ -115,27 +129,32 @@ func (c *Checker) Run(ctx context.Context) (*Report, error) {
 	}
 
 	return &Report{
+		Status:    worstStatus(results),
 		Results:   results,
 		Summary:   summary,
 		CanDeploy: canDeploy,
-	}, nil
+	}
 }
 
 // checkConnection checks if we can connect to the Kubernetes cluster
 func (c *Checker) checkConnection(ctx context.Context) Result {
+	details := map[string]any{"host": c.config.Host}
 	_, err := c.clientset.Discovery().ServerVersion()
 	if err != nil {
+		details["error"] = err.Error()
 		return Result{
 			Name:    "Kubernetes Connection",
 			Status:  StatusFail,
 			Message: fmt.Sprintf("Cannot connect to Kubernetes cluster: %v", err),
 			Suggest: "Check your kubeconfig file and network connectivity",
+			Details: details,
 		}
 	}
 	return Result{
 		Name:    "Kubernetes Connection",
 		Status:  StatusPass,
 		Message: "Successfully connected to Kubernetes cluster",
+		Details: details,
 	}
 }
 
@@ -152,6 +171,13 @@ func (c *Checker) checkKubernetesVersion(ctx context.Context) Result {
 
 	// Parse version
 	major, minor := parseVersion(serverVersion)
+	details := map[string]any{
+		"git_version":         serverVersion.GitVersion,
+		"major":               major,
+		"minor":               minor,
+		"minimum_version":     "1.20",
+		"recommended_version": "1.25",
+	}
 
 	// Milvus Operator requires Kubernetes 1.20+
 	if major < 1 || (major == 1 && minor < 20) {
@@ -160,6 +186,7 @@ func (c *Checker) checkKubernetesVersion(ctx context.Context) Result {
 			Status:  StatusFail,
 			Message: fmt.Sprintf("Kubernetes %s is not supported (requires 1.20+)", serverVersion.GitVersion),
 			Suggest: "Upgrade your Kubernetes cluster to version 1.20 or later",
+			Details: details,
 		}
 	}
 
@@ -169,6 +196,7 @@ func (c *Checker) checkKubernetesVersion(ctx context.Context) Result {
 			Name:    "Kubernetes Version",
 			Status:  StatusWarn,
 			Message: fmt.Sprintf("Kubernetes %s is supported but consider upgrading to 1.25+", serverVersion.GitVersion),
+			Details: details,
 		}
 	}
 
@@ -176,36 +204,35 @@ func (c *Checker) checkKubernetesVersion(ctx context.Context) Result {
 		Name:    "Kubernetes Version",
 		Status:  StatusPass,
 		Message: fmt.Sprintf("Kubernetes %s is supported", serverVersion.GitVersion),
+		Details: details,
 	}
 }
 
 // checkMilvusOperator checks if Milvus Operator is installed
 func (c *Checker) checkMilvusOperator(ctx context.Context) Result {
 	// Check if Milvus CRD exists
-	_, err := c.clientset.Discovery().ServerResourcesForGroupVersion(k8s.MilvusGroup + "/" + k8s.MilvusVersion)
+	groupVersion := k8s.MilvusGroup + "/" + k8s.MilvusVersion
+	details := map[string]any{"crd_group_version": groupVersion, "crd_installed": false}
+	_, err := c.clientset.Discovery().ServerResourcesForGroupVersion(groupVersion)
 	if err != nil {
 		return Result{
 			Name:    "Milvus Operator",
 			Status:  StatusFail,
 			Message: "Milvus Operator is not installed (CRD not found)",
 			Suggest: "Install Milvus Operator: kubectl apply -f https://raw.githubusercontent.com/zilliztech/milvus-operator/main/deploy/manifests/deployment.yaml",
+			Details: details,
 		}
 	}
+	details["crd_installed"] = true
 
 	// Check if operator deployment exists
-	deployments := []string{"milvus-operator"}
-	namespaces := []string{"milvus-operator", "default", "kube-system"}
-
 	operatorFound := false
-	for _, ns := range namespaces {
-		for _, name := range deployments {
-			deploy, err := c.clientset.AppsV1().Deployments(ns).Get(ctx, name, metav1.GetOptions{})
-			if err == nil && deploy.Status.ReadyReplicas > 0 {
-				operatorFound = true
-				break
-			}
-		}
-		if operatorFound {
+	for _, ns := range k8s.MilvusOperatorNamespaces {
+		deploy, err := c.clientset.AppsV1().Deployments(ns).Get(ctx, k8s.MilvusOperatorDeployment, metav1.GetOptions{})
+		if err == nil && deploy.Status.ReadyReplicas > 0 {
+			operatorFound = true
+			details["operator_namespace"] = ns
+			details["operator_ready_replicas"] = deploy.Status.ReadyReplicas
 			break
 		}
 	}
@@ -215,6 +242,7 @@ func (c *Checker) checkMilvusOperator(ctx context.Context) Result {
 			Name:    "Milvus Operator",
 			Status:  StatusWarn,
 			Message: "Milvus CRD found but operator deployment not detected (may be in different namespace)",
+			Details: details,
 		}
 	}
 
@@ -222,6 +250,7 @@ func (c *Checker) checkMilvusOperator(ctx context.Context) Result {
 		Name:    "Milvus Operator",
 		Status:  StatusPass,
 		Message: "Milvus Operator is installed and running",
+		Details: details,
 	}
 }
 
@@ -240,6 +269,7 @@ func (c *Checker) checkNamespace(ctx context.Context) Result {
 			Name:    "Namespace",
 			Status:  StatusWarn,
 			Message: fmt.Sprintf("Namespace '%s' does not exist (will be created during deployment)", namespace),
+			Details: map[string]any{"namespace": namespace, "exists": false},
 		}
 	}
 
@@ -247,6 +277,44 @@ func (c *Checker) checkNamespace(ctx context.Context) Result {
 		Name:    "Namespace",
 		Status:  StatusPass,
 		Message: fmt.Sprintf("Namespace '%s' exists", namespace),
+		Details: map[string]any{"namespace": namespace, "exists": true},
+	}
+}
+
+// checkPermissions checks that the current user may deploy into the target
+// namespace, and create it if it does not exist
+func (c *Checker) checkPermissions(ctx context.Context) Result {
+	namespace := c.opts.Namespace
+	if namespace == "" {
+		namespace = "milvus"
+	}
+
+	perms, missing, err := k8s.CheckDeployPermissions(ctx, c.clientset, namespace, true)
+	if err != nil {
+		return Result{
+			Name:    "Permissions",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("Failed to check permissions: %v", err),
+			Details: map[string]any{"namespace": namespace, "error": err.Error()},
+		}
+	}
+
+	details := map[string]any{"namespace": namespace, "checked": k8s.PermissionNames(perms), "missing": k8s.PermissionNames(missing)}
+	if len(missing) > 0 {
+		return Result{
+			Name:    "Permissions",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("Missing permissions: %s", strings.Join(k8s.PermissionNames(missing), ", ")),
+			Suggest: "Ask a cluster administrator to grant these permissions (a Role in the namespace; creating namespaces needs a ClusterRole)",
+			Details: details,
+		}
+	}
+
+	return Result{
+		Name:    "Permissions",
+		Status:  StatusPass,
+		Message: fmt.Sprintf("Can manage Milvus instances and secrets in namespace '%s'", namespace),
+		Details: details,
 	}
 }
 
@@ -261,12 +329,14 @@ func (c *Checker) checkStorageClass(ctx context.Context) Result {
 		}
 	}
 
+	details := storageClassDetails(storageClasses.Items, c.opts.StorageClass)
 	if len(storageClasses.Items) == 0 {
 		return Result{
 			Name:    "Storage Class",
 			Status:  StatusFail,
 			Message: "No storage classes available",
 			Suggest: "Create a storage class or use a managed Kubernetes service with default storage",
+			Details: details,
 		}
 	}
 
@@ -278,6 +348,7 @@ func (c *Checker) checkStorageClass(ctx context.Context) Result {
 					Name:    "Storage Class",
 					Status:  StatusPass,
 					Message: fmt.Sprintf("Storage class '%s' is available", c.opts.StorageClass),
+					Details: details,
 				}
 			}
 		}
@@ -286,6 +357,7 @@ func (c *Checker) checkStorageClass(ctx context.Context) Result {
 			Status:  StatusFail,
 			Message: fmt.Sprintf("Storage class '%s' not found", c.opts.StorageClass),
 			Suggest: fmt.Sprintf("Available storage classes: %s", getStorageClassNames(storageClasses.Items)),
+			Details: details,
 		}
 	}
 
@@ -296,6 +368,7 @@ func (c *Checker) checkStorageClass(ctx context.Context) Result {
 				Name:    "Storage Class",
 				Status:  StatusPass,
 				Message: fmt.Sprintf("Default storage class '%s' is available", sc.Name),
+				Details: details,
 			}
 		}
 	}
@@ -305,6 +378,7 @@ func (c *Checker) checkStorageClass(ctx context.Context) Result {
 		Status:  StatusWarn,
 		Message: fmt.Sprintf("No default storage class found (%d storage classes available)", len(storageClasses.Items)),
 		Suggest: "Specify a storage class in your topology or set a default storage class",
+		Details: details,
 	}
 }
 
@@ -322,6 +396,7 @@ func (c *Checker) checkResourceQuota(ctx context.Context) Result {
 			Name:    "Resource Quota",
 			Status:  StatusPass,
 			Message: "No resource quota restrictions (namespace not yet created)",
+			Details: map[string]any{"namespace": namespace, "quotas": []quotaDetail{}},
 		}
 	}
 
@@ -331,14 +406,17 @@ func (c *Checker) checkResourceQuota(ctx context.Context) Result {
 			Name:    "Resource Quota",
 			Status:  StatusWarn,
 			Message: fmt.Sprintf("Failed to check resource quotas: %v", err),
+			Details: map[string]any{"namespace": namespace, "error": err.Error()},
 		}
 	}
 
+	details := map[string]any{"namespace": namespace, "quotas": quotaDetails(quotas.Items)}
 	if len(quotas.Items) == 0 {
 		return Result{
 			Name:    "Resource Quota",
 			Status:  StatusPass,
 			Message: "No resource quota restrictions in namespace",
+			Details: details,
 		}
 	}
 
@@ -356,6 +434,7 @@ func (c *Checker) checkResourceQuota(ctx context.Context) Result {
 						Status:  StatusWarn,
 						Message: fmt.Sprintf("CPU quota nearly exhausted in namespace '%s'", namespace),
 						Suggest: "Request more CPU quota or reduce resource requests",
+						Details: details,
 					}
 				}
 			}
@@ -370,6 +449,7 @@ func (c *Checker) checkResourceQuota(ctx context.Context) Result {
 						Status:  StatusWarn,
 						Message: fmt.Sprintf("Memory quota nearly exhausted in namespace '%s'", namespace),
 						Suggest: "Request more memory quota or reduce resource requests",
+						Details: details,
 					}
 				}
 			}
@@ -380,6 +460,7 @@ func (c *Checker) checkResourceQuota(ctx context.Context) Result {
 		Name:    "Resource Quota",
 		Status:  StatusPass,
 		Message: "Resource quota has sufficient capacity",
+		Details: details,
 	}
 }
 
@@ -438,3 +519,44 @@ func getStorageClassNames(classes []storagev1.StorageClass) string {
 	}
 	return strings.Join(names, ", ")
 }
+
+// storageClassDetails lists the storage classes, the default one and the
+// requested one (if any)
+func storageClassDetails(classes []storagev1.StorageClass, requested string) map[string]any {
+	names := make([]string, 0, len(classes))
+	details := map[string]any{}
+	for i := range classes {
+		names = append(names, classes[i].Name)
+		if isDefaultStorageClass(&classes[i]) {
+			details["default"] = classes[i].Name
+		}
+	}
+	details["storage_classes"] = names
+	if requested != "" {
+		details["requested"] = requested
+	}
+	return details
+}
+
+// quotaDetail is the hard limits and usage of one resource quota
+type quotaDetail struct {
+	Name string            `json:"name"`
+	Hard map[string]string `json:"hard"`
+	Used map[string]string `json:"used"`
+}
+
+// quotaDetails converts resource quotas into their limits and usage
+func quotaDetails(quotas []corev1.ResourceQuota) []quotaDetail {
+	details := make([]quotaDetail, 0, len(quotas))
+	for _, quota := range quotas {
+		d := quotaDetail{Name: quota.Name, Hard: map[string]string{}, Used: map[string]string{}}
+		for resource, q := range quota.Status.Hard {
+			d.Hard[string(resource)] = q.String()
+		}
+		for resource, q := range quota.Status.Used {
+			d.Used[string(resource)] = q.String()
+		}
+		details = append(details, d)
+	}
+	return details
+}
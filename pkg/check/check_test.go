@@ -1,9 +1,16 @@
 package check
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestStatusValues(t *testing.T) {
@@ -187,3 +194,67 @@ func TestCanDeployLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestResultDetailsJSON(t *testing.T) {
+	// Results without details keep their previous JSON shape
+	data, err := json.Marshal(Result{Name: "Namespace", Status: StatusPass, Message: "ok"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "details") {
+		t.Errorf("JSON = %s, want no details field", data)
+	}
+
+	data, err = json.Marshal(Result{Name: "Namespace", Details: map[string]any{"exists": true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"details":{"exists":true}`) {
+		t.Errorf("JSON = %s, want details", data)
+	}
+}
+
+func TestStorageClassDetails(t *testing.T) {
+	classes := []storagev1.StorageClass{
+		{ObjectMeta: metav1.ObjectMeta{Name: "standard"}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:        "fast",
+			Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"},
+		}},
+	}
+
+	got := storageClassDetails(classes, "ssd")
+	want := map[string]any{
+		"storage_classes": []string{"standard", "fast"},
+		"default":         "fast",
+		"requested":       "ssd",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("storageClassDetails() = %v, want %v", got, want)
+	}
+
+	got = storageClassDetails(nil, "")
+	if !reflect.DeepEqual(got, map[string]any{"storage_classes": []string{}}) {
+		t.Errorf("storageClassDetails(nil) = %v", got)
+	}
+}
+
+func TestQuotaDetails(t *testing.T) {
+	quotas := []corev1.ResourceQuota{{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{"requests.cpu": resource.MustParse("8"), "requests.memory": resource.MustParse("16Gi")},
+			Used: corev1.ResourceList{"requests.cpu": resource.MustParse("2500m")},
+		},
+	}}
+
+	got := quotaDetails(quotas)
+	want := []quotaDetail{{
+		Name: "compute",
+		Hard: map[string]string{"requests.cpu": "8", "requests.memory": "16Gi"},
+		Used: map[string]string{"requests.cpu": "2500m"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("quotaDetails() = %+v, want %+v", got, want)
+	}
+}
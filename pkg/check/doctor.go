@@ -0,0 +1,271 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/mmga-lab/miup/pkg/component"
+	"github.com/mmga-lab/miup/pkg/executor"
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+// kubeTimeout bounds the Kubernetes reachability check
+const kubeTimeout = 5 * time.Second
+
+// DoctorOptions contains options for the local environment checks
+type DoctorOptions struct {
+	Kubeconfig string
+	Context    string
+
+	// Profile is the miup profile ($MIUP_HOME) to check
+	Profile *localdata.Profile
+
+	// VdbbenchPath is the go-vdbbench binary found by the caller, if any
+	VdbbenchPath string
+}
+
+// Doctor checks the local environment miup depends on: docker for
+// playgrounds, a reachable Kubernetes cluster for instances, the miup
+// profile, installed components and go-vdbbench
+type Doctor struct {
+	opts DoctorOptions
+}
+
+// NewDoctor creates a new doctor
+func NewDoctor(opts DoctorOptions) *Doctor {
+	return &Doctor{opts: opts}
+}
+
+// Run runs all checks and returns a report
+func (d *Doctor) Run(ctx context.Context) *Report {
+	checks := []func(context.Context) Result{
+		d.checkDocker,
+		d.checkDockerDaemon,
+		d.checkDockerCompose,
+		d.checkKubernetes,
+		d.checkMiupHome,
+		d.checkPath,
+		d.checkComponents,
+		d.checkVdbbench,
+	}
+
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, check(ctx))
+	}
+	return NewReport(results)
+}
+
+// checkDocker checks that the docker CLI is installed
+func (d *Doctor) checkDocker(ctx context.Context) Result {
+	if err := executor.CheckDockerAvailable(); err != nil {
+		return Result{
+			Name:    "Docker",
+			Status:  StatusWarn,
+			Message: "Docker is not installed; playgrounds are unavailable",
+			Suggest: "Install Docker: https://docs.docker.com/get-docker/",
+		}
+	}
+	return Result{
+		Name:    "Docker",
+		Status:  StatusPass,
+		Message: "Docker is installed",
+	}
+}
+
+// checkDockerDaemon checks that the docker daemon is running
+func (d *Doctor) checkDockerDaemon(ctx context.Context) Result {
+	if err := executor.CheckDockerRunning(); err != nil {
+		return Result{
+			Name:    "Docker Daemon",
+			Status:  StatusWarn,
+			Message: "Docker daemon is not running; playgrounds are unavailable",
+			Suggest: "Start Docker Desktop or run 'sudo systemctl start docker'",
+		}
+	}
+	return Result{
+		Name:    "Docker Daemon",
+		Status:  StatusPass,
+		Message: "Docker daemon is running",
+	}
+}
+
+// checkDockerCompose checks that the docker compose plugin is installed
+func (d *Doctor) checkDockerCompose(ctx context.Context) Result {
+	if err := executor.CheckDockerComposeAvailable(); err != nil {
+		return Result{
+			Name:    "Docker Compose",
+			Status:  StatusWarn,
+			Message: "Docker Compose is not available; playgrounds are unavailable",
+			Suggest: "Install the Docker Compose plugin: https://docs.docker.com/compose/install/",
+		}
+	}
+	return Result{
+		Name:    "Docker Compose",
+		Status:  StatusPass,
+		Message: "Docker Compose is available",
+	}
+}
+
+// checkKubernetes checks that a kubeconfig exists and its cluster is reachable
+func (d *Doctor) checkKubernetes(ctx context.Context) Result {
+	config, err := buildConfig(d.opts.Kubeconfig, d.opts.Context)
+	if err != nil {
+		return Result{
+			Name:    "Kubernetes",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("No usable kubeconfig; instances are unavailable: %v", err),
+			Suggest: "Create a kubeconfig or pass --kubeconfig",
+		}
+	}
+
+	config.Timeout = kubeTimeout
+	clientset, err := kubernetes.NewForConfig(config)
+	if err == nil {
+		_, err = clientset.Discovery().ServerVersion()
+	}
+	if err != nil {
+		return Result{
+			Name:    "Kubernetes",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("Cannot reach Kubernetes cluster at %s: %v", config.Host, err),
+			Suggest: "Check your kubeconfig context and network connectivity",
+		}
+	}
+	return Result{
+		Name:    "Kubernetes",
+		Status:  StatusPass,
+		Message: fmt.Sprintf("Kubernetes cluster at %s is reachable", config.Host),
+	}
+}
+
+// checkMiupHome checks that the miup profile directory is writable
+func (d *Doctor) checkMiupHome(ctx context.Context) Result {
+	root := d.opts.Profile.Root()
+	err := os.MkdirAll(root, 0755)
+	if err == nil {
+		var f *os.File
+		if f, err = os.CreateTemp(root, ".doctor-*"); err == nil {
+			f.Close()
+			err = os.Remove(f.Name())
+		}
+	}
+	if err != nil {
+		return Result{
+			Name:    "MIUP_HOME",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("%s is not writable: %v", root, err),
+			Suggest: fmt.Sprintf("Fix the permissions of %s or set %s to a writable directory", root, localdata.HomeEnv),
+		}
+	}
+	return Result{
+		Name:    "MIUP_HOME",
+		Status:  StatusPass,
+		Message: fmt.Sprintf("%s is writable", root),
+	}
+}
+
+// checkPath checks that miup itself can be found on PATH
+func (d *Doctor) checkPath(ctx context.Context) Result {
+	if _, err := exec.LookPath("miup"); err != nil {
+		return Result{
+			Name:    "PATH",
+			Status:  StatusWarn,
+			Message: "miup is not on PATH",
+			Suggest: fmt.Sprintf("Add it to your shell profile: export PATH=\"$PATH:%s\"", d.opts.Profile.Path("bin")),
+		}
+	}
+	return Result{
+		Name:    "PATH",
+		Status:  StatusPass,
+		Message: "miup is on PATH",
+	}
+}
+
+// checkComponents smoke tests the active version of every installed component
+func (d *Doctor) checkComponents(ctx context.Context) Result {
+	mgr := component.NewManager(d.opts.Profile)
+	metas, err := mgr.List(ctx)
+	if err != nil {
+		return Result{
+			Name:    "Components",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("Failed to list installed components: %v", err),
+		}
+	}
+	if len(metas) == 0 {
+		return Result{
+			Name:    "Components",
+			Status:  StatusPass,
+			Message: "No components installed",
+		}
+	}
+
+	status := StatusPass
+	var ok, problems []string
+	for _, meta := range metas {
+		if meta.Active == "" {
+			continue
+		}
+		name := fmt.Sprintf("%s %s", meta.Name, meta.Active)
+		_, err := mgr.Verify(ctx, meta.Name, meta.Active)
+		switch {
+		case errors.Is(err, component.ErrBinaryNotRunnable):
+			status = StatusFail
+			problems = append(problems, fmt.Sprintf("%s is broken (%v)", name, err))
+		case err != nil:
+			if status == StatusPass {
+				status = StatusWarn
+			}
+			problems = append(problems, fmt.Sprintf("%s could not be verified (%v)", name, err))
+		default:
+			ok = append(ok, name)
+		}
+	}
+
+	if len(problems) > 0 {
+		return Result{
+			Name:    "Components",
+			Status:  status,
+			Message: strings.Join(problems, "; "),
+			Suggest: "Reinstall the component with 'miup install <component>:<version>'",
+		}
+	}
+	if len(ok) == 0 {
+		return Result{
+			Name:    "Components",
+			Status:  StatusPass,
+			Message: "No active component versions",
+		}
+	}
+	return Result{
+		Name:    "Components",
+		Status:  StatusPass,
+		Message: fmt.Sprintf("Installed components run: %s", strings.Join(ok, ", ")),
+	}
+}
+
+// checkVdbbench checks that go-vdbbench is available for miup bench
+func (d *Doctor) checkVdbbench(ctx context.Context) Result {
+	if d.opts.VdbbenchPath == "" {
+		return Result{
+			Name:    "go-vdbbench",
+			Status:  StatusWarn,
+			Message: "go-vdbbench not found; 'miup bench' is unavailable",
+			Suggest: fmt.Sprintf("Build it with 'cd tools/go-vdbbench && go build -o %s ./cmd/go-vdbbench'",
+				d.opts.Profile.Path("bin", "go-vdbbench")),
+		}
+	}
+	return Result{
+		Name:    "go-vdbbench",
+		Status:  StatusPass,
+		Message: fmt.Sprintf("go-vdbbench found at %s", d.opts.VdbbenchPath),
+	}
+}
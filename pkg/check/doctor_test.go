@@ -0,0 +1,99 @@
+package check
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/component"
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+func TestNewReport(t *testing.T) {
+	report := NewReport([]Result{
+		{Name: "a", Status: StatusPass},
+		{Name: "b", Status: StatusWarn},
+	})
+	if report.Summary != (Summary{Total: 2, Passed: 1, Warned: 1}) || !report.CanDeploy || report.Status != StatusWarn {
+		t.Errorf("report = %+v, want 1 passed, 1 warned and usable", report)
+	}
+
+	report = NewReport([]Result{{Name: "a", Status: StatusFail}})
+	if report.Summary.Failed != 1 || report.CanDeploy {
+		t.Errorf("report = %+v, want 1 failed and not usable", report)
+	}
+}
+
+func TestDoctorCheckMiupHome(t *testing.T) {
+	d := NewDoctor(DoctorOptions{Profile: localdata.NewProfile(filepath.Join(t.TempDir(), "miup"))})
+	if r := d.checkMiupHome(context.Background()); r.Status != StatusPass {
+		t.Errorf("status = %s (%s), want pass", r.Status, r.Message)
+	}
+
+	// The profile root can't be created below a regular file
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	d = NewDoctor(DoctorOptions{Profile: localdata.NewProfile(filepath.Join(file, "miup"))})
+	if r := d.checkMiupHome(context.Background()); r.Status != StatusFail {
+		t.Errorf("status = %s (%s), want fail", r.Status, r.Message)
+	}
+}
+
+func TestDoctorCheckComponents(t *testing.T) {
+	profile := localdata.NewProfile(t.TempDir())
+	d := NewDoctor(DoctorOptions{Profile: profile})
+
+	if r := d.checkComponents(context.Background()); r.Status != StatusPass {
+		t.Errorf("no components: status = %s (%s), want pass", r.Status, r.Message)
+	}
+
+	install := func(name, version, script string) {
+		t.Helper()
+		mgr := component.NewManager(profile)
+		path := mgr.BinaryPath(name, version)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+		meta := &component.ComponentMeta{
+			Name:     name,
+			Active:   version,
+			Versions: map[string]*component.InstalledVersion{version: {Version: version, BinaryPath: path}},
+		}
+		if err := component.SaveMeta(meta, filepath.Join(mgr.ComponentDir(name), component.MetaFileName)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	install("birdwatcher", "v1.0.0", "#!/bin/sh\necho v1.0.0\n")
+	if r := d.checkComponents(context.Background()); r.Status != StatusPass {
+		t.Errorf("working component: status = %s (%s), want pass", r.Status, r.Message)
+	}
+
+	// A binary that can't be executed, e.g. for another architecture
+	install("milvus-backup", "v0.5.0", "\x7fELF garbage")
+	r := d.checkComponents(context.Background())
+	if r.Status != StatusFail || !strings.Contains(r.Message, "milvus-backup v0.5.0") {
+		t.Errorf("broken component: status = %s (%s), want fail naming milvus-backup", r.Status, r.Message)
+	}
+}
+
+func TestDoctorCheckVdbbench(t *testing.T) {
+	profile := localdata.NewProfile(t.TempDir())
+
+	r := NewDoctor(DoctorOptions{Profile: profile}).checkVdbbench(context.Background())
+	if r.Status != StatusWarn || r.Suggest == "" {
+		t.Errorf("missing: result = %+v, want warn with a suggestion", r)
+	}
+
+	r = NewDoctor(DoctorOptions{Profile: profile, VdbbenchPath: "/usr/local/bin/go-vdbbench"}).checkVdbbench(context.Background())
+	if r.Status != StatusPass {
+		t.Errorf("found: status = %s, want pass", r.Status)
+	}
+}
@@ -0,0 +1,44 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by Report.Err, so that every diagnostic command exits the
+// same way: 1 if a check failed, 2 if one warned and warnings are strict
+var (
+	// ErrChecksFailed is returned when at least one check failed
+	ErrChecksFailed = errors.New("checks failed")
+
+	// ErrChecksWarned is returned in strict mode when no check failed but
+	// at least one warned
+	ErrChecksWarned = errors.New("checks passed with warnings")
+)
+
+// Err returns ErrChecksFailed if any check failed, ErrChecksWarned if strict
+// and any check warned, and nil otherwise
+func (r *Report) Err(strict bool) error {
+	switch {
+	case r.Summary.Failed > 0:
+		return fmt.Errorf("%w: %d of %d", ErrChecksFailed, r.Summary.Failed, r.Summary.Total)
+	case strict && r.Summary.Warned > 0:
+		return fmt.Errorf("%w: %d of %d warned", ErrChecksWarned, r.Summary.Warned, r.Summary.Total)
+	default:
+		return nil
+	}
+}
+
+// worstStatus returns the most severe status of the results, pass if none
+func worstStatus(results []Result) Status {
+	status := StatusPass
+	for _, r := range results {
+		if r.Status == StatusFail {
+			return StatusFail
+		}
+		if r.Status == StatusWarn {
+			status = StatusWarn
+		}
+	}
+	return status
+}
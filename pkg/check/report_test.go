@@ -0,0 +1,41 @@
+package check
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestReportErr(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []Status
+		strict     bool
+		wantStatus Status
+		wantErr    error
+	}{
+		{"all pass", []Status{StatusPass, StatusPass}, true, StatusPass, nil},
+		{"warning", []Status{StatusPass, StatusWarn}, false, StatusWarn, nil},
+		{"strict warning", []Status{StatusPass, StatusWarn}, true, StatusWarn, ErrChecksWarned},
+		{"failure", []Status{StatusFail, StatusWarn}, false, StatusFail, ErrChecksFailed},
+		{"strict failure", []Status{StatusWarn, StatusFail}, true, StatusFail, ErrChecksFailed},
+		{"no checks", nil, true, StatusPass, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []Result
+			for _, s := range tt.statuses {
+				results = append(results, Result{Name: string(s), Status: s})
+			}
+			report := NewReport(results)
+
+			if report.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", report.Status, tt.wantStatus)
+			}
+			err := report.Err(tt.strict)
+			if (tt.wantErr == nil) != (err == nil) || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Err(%v) = %v, want %v", tt.strict, err, tt.wantErr)
+			}
+		})
+	}
+}
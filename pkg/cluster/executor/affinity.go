@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podAntiAffinity builds a preferred pod anti-affinity that spreads the pods
+// of a component by the topology key of an anti_affinity shorthand. A
+// preference rather than a requirement keeps pods schedulable when there are
+// more replicas than zones or nodes.
+func podAntiAffinity(clusterName, component, antiAffinity string) *corev1.Affinity {
+	key := spec.AntiAffinityTopologyKey(antiAffinity)
+	if key == "" {
+		return nil
+	}
+
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app.kubernetes.io/instance":  clusterName,
+							"app.kubernetes.io/component": component,
+						},
+					},
+					TopologyKey: key,
+				},
+			}},
+		},
+	}
+}
+
+// antiAffinityFromCRD recovers the anti_affinity shorthand from a component's
+// affinity, the inverse of podAntiAffinity
+func antiAffinityFromCRD(affinity interface{}) string {
+	if affinity == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(affinity)
+	if err != nil {
+		return ""
+	}
+	var a corev1.Affinity
+	if err := json.Unmarshal(data, &a); err != nil || a.PodAntiAffinity == nil {
+		return ""
+	}
+
+	for _, term := range a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		if v := spec.AntiAffinityFromTopologyKey(term.PodAffinityTerm.TopologyKey); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// checkZones verifies that the cluster's nodes span at least two zones, so
+// zone anti-affinity can take effect
+func (e *KubernetesExecutor) checkZones(ctx context.Context) error {
+	nodes, err := e.client.ListNodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	zones := nodeZones(nodes)
+	switch len(zones) {
+	case 0:
+		return fmt.Errorf("no nodes have the %s label; label the nodes or remove anti_affinity: zone", spec.ZoneLabel)
+	case 1:
+		return fmt.Errorf("all nodes are in zone '%s'; spreading across zones needs at least 2 zones", zones[0])
+	}
+	return nil
+}
+
+// nodeZones returns the distinct zones of the nodes, sorted
+func nodeZones(nodes []corev1.Node) []string {
+	seen := make(map[string]bool)
+	var zones []string
+	for _, node := range nodes {
+		zone := node.Labels[spec.ZoneLabel]
+		if zone == "" || seen[zone] {
+			continue
+		}
+		seen[zone] = true
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones
+}
@@ -0,0 +1,74 @@
+package executor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodAntiAffinity(t *testing.T) {
+	if a := podAntiAffinity("prod", "querynode", ""); a != nil {
+		t.Errorf("podAntiAffinity() with no shorthand = %v, want nil", a)
+	}
+
+	a := podAntiAffinity("prod", "querynode", spec.AntiAffinityZone)
+	if a == nil || a.PodAntiAffinity == nil {
+		t.Fatal("podAntiAffinity() returned no pod anti-affinity")
+	}
+	terms := a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != 1 {
+		t.Fatalf("got %d terms, want 1", len(terms))
+	}
+	term := terms[0].PodAffinityTerm
+	if term.TopologyKey != spec.ZoneLabel {
+		t.Errorf("TopologyKey = %q, want %q", term.TopologyKey, spec.ZoneLabel)
+	}
+	if got := term.LabelSelector.MatchLabels["app.kubernetes.io/component"]; got != "querynode" {
+		t.Errorf("component label = %q, want querynode", got)
+	}
+}
+
+func TestAntiAffinityRoundTrip(t *testing.T) {
+	for _, v := range []string{spec.AntiAffinityZone, spec.AntiAffinityHost} {
+		// The CRD is read back as untyped JSON
+		data, err := json.Marshal(podAntiAffinity("prod", "proxy", v))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var raw interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := antiAffinityFromCRD(raw); got != v {
+			t.Errorf("antiAffinityFromCRD() = %q, want %q", got, v)
+		}
+	}
+
+	if got := antiAffinityFromCRD(nil); got != "" {
+		t.Errorf("antiAffinityFromCRD(nil) = %q, want empty", got)
+	}
+}
+
+func TestNodeZones(t *testing.T) {
+	node := func(zone string) corev1.Node {
+		n := corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}}}
+		if zone != "" {
+			n.Labels[spec.ZoneLabel] = zone
+		}
+		return n
+	}
+
+	nodes := []corev1.Node{node("us-east-1b"), node(""), node("us-east-1a"), node("us-east-1b")}
+	zones := nodeZones(nodes)
+	if len(zones) != 2 || zones[0] != "us-east-1a" || zones[1] != "us-east-1b" {
+		t.Errorf("nodeZones() = %v, want [us-east-1a us-east-1b]", zones)
+	}
+
+	if zones := nodeZones([]corev1.Node{node("")}); len(zones) != 0 {
+		t.Errorf("nodeZones() = %v, want none", zones)
+	}
+}
@@ -0,0 +1,72 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+// Apply updates the Milvus resource to what the topology describes and waits
+// for the cluster to be ready again. Fields the topology sets (replicas,
+// resources, config, image, dependencies) replace the live ones, so changes
+// made with scale or config set are reverted unless the topology has them
+// too; fields it doesn't set, such as defaults filled in by the operator,
+// are kept. Nothing is updated if the resource already matches.
+func (e *KubernetesExecutor) Apply(ctx context.Context) error {
+	current, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get Milvus cluster: %w", err)
+	}
+
+	milvus, err := overlayMilvus(current, e.specToMilvus())
+	if err != nil {
+		return err
+	}
+
+	if !e.dryRun() {
+		changes, err := diffMilvus(current, milvus)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+	}
+
+	if err := e.updateMilvus(ctx, milvus); err != nil {
+		return fmt.Errorf("failed to update Milvus cluster: %w", err)
+	}
+	if e.dryRun() {
+		return nil
+	}
+	return e.waitForReady(ctx, 10*time.Minute)
+}
+
+// overlayMilvus returns a copy of current with the fields set in desired
+// merged over it. Maps are merged key by key, lists are replaced as a whole.
+func overlayMilvus(current, desired *k8s.Milvus) (*k8s.Milvus, error) {
+	fields, err := milvusFields(current)
+	if err != nil {
+		return nil, err
+	}
+	overlay, err := milvusFields(desired)
+	if err != nil {
+		return nil, err
+	}
+	mergeConfigAny(fields, overlay)
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Milvus resource: %w", err)
+	}
+	merged := &k8s.Milvus{}
+	if err := json.Unmarshal(data, merged); err != nil {
+		return nil, fmt.Errorf("failed to decode Milvus resource: %w", err)
+	}
+	merged.ResourceVersion = current.ResourceVersion
+	merged.Status = current.Status
+	return merged, nil
+}
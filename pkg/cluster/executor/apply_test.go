@@ -0,0 +1,83 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOverlayMilvus(t *testing.T) {
+	two, three, five := int32(2), int32(3), int32(5)
+	current := &k8s.Milvus{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", ResourceVersion: "7", Labels: map[string]string{"team": "search"}},
+		Spec: k8s.MilvusSpec{
+			Mode: k8s.MilvusModeCluster,
+			Components: k8s.MilvusComponents{
+				Image:           "milvusdb/milvus:v2.5.4",
+				ImagePullPolicy: "IfNotPresent",
+				QueryNode:       &k8s.ComponentSpec{Replicas: &five},
+				DataNode:        &k8s.ComponentSpec{Replicas: &two},
+			},
+			Config: map[string]interface{}{"proxy": map[string]interface{}{"maxNameLength": 255, "timeTickInterval": 200}},
+		},
+		Status: k8s.MilvusStatus{Status: "Healthy"},
+	}
+	desired := &k8s.Milvus{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"app": "milvus"}},
+		Spec: k8s.MilvusSpec{
+			Mode: k8s.MilvusModeCluster,
+			Components: k8s.MilvusComponents{
+				Image:     "milvusdb/milvus:v2.5.4",
+				QueryNode: &k8s.ComponentSpec{Replicas: &three},
+			},
+			Config: map[string]interface{}{"proxy": map[string]interface{}{"maxNameLength": 512}},
+		},
+	}
+
+	merged, err := overlayMilvus(current, desired)
+	if err != nil {
+		t.Fatalf("overlayMilvus() error = %v", err)
+	}
+
+	if got := *merged.Spec.Components.QueryNode.Replicas; got != 3 {
+		t.Errorf("queryNode replicas = %d, want 3 from the topology", got)
+	}
+	if merged.Spec.Components.DataNode == nil || *merged.Spec.Components.DataNode.Replicas != 2 {
+		t.Errorf("dataNode = %+v, want the live one kept", merged.Spec.Components.DataNode)
+	}
+	if merged.Spec.Components.ImagePullPolicy != "IfNotPresent" {
+		t.Errorf("imagePullPolicy = %q, want the live one kept", merged.Spec.Components.ImagePullPolicy)
+	}
+	proxy, _ := merged.Spec.Config["proxy"].(map[string]interface{})
+	if proxy["maxNameLength"] != float64(512) || proxy["timeTickInterval"] != float64(200) {
+		t.Errorf("proxy config = %v, want maxNameLength from the topology and timeTickInterval kept", proxy)
+	}
+	if merged.Labels["team"] != "search" || merged.Labels["app"] != "milvus" {
+		t.Errorf("labels = %v, want both the live and the topology labels", merged.Labels)
+	}
+	if merged.ResourceVersion != "7" {
+		t.Errorf("resourceVersion = %q, want the live 7", merged.ResourceVersion)
+	}
+
+	changes, err := diffMilvus(current, merged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 3 {
+		t.Errorf("changes = %+v, want replicas, config and label", changes)
+	}
+
+	if changes, _ := diffMilvus(merged, mustOverlay(t, merged, desired)); len(changes) != 0 {
+		t.Errorf("applying twice changes %+v, want nothing", changes)
+	}
+}
+
+func mustOverlay(t *testing.T, current, desired *k8s.Milvus) *k8s.Milvus {
+	t.Helper()
+	merged, err := overlayMilvus(current, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return merged
+}
@@ -0,0 +1,189 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultTargetCPU is the CPU utilization, in percent of the CPU request,
+// that 'instance scale --from-metrics' sizes a component for
+const DefaultTargetCPU = 60
+
+// autoscaleTolerance is how far the utilization may be from the target, as
+// a fraction of it, before the replica count changes; the same as the
+// HorizontalPodAutoscaler's default
+const autoscaleTolerance = 0.1
+
+// PodCPU is the current CPU usage and the CPU request of a pod
+type PodCPU struct {
+	Name              string `json:"name"`
+	UsageMillicores   int64  `json:"usageMillicores"`
+	RequestMillicores int64  `json:"requestMillicores"`
+}
+
+// CPULoad is the current CPU usage of the pods of a component
+type CPULoad struct {
+	Component string `json:"component"`
+
+	// Replicas is the desired replica count in the Milvus resource
+	Replicas int `json:"replicas"`
+
+	// Pods are the pods the metrics API reports usage for
+	Pods []PodCPU `json:"pods"`
+}
+
+// Utilization returns the CPU usage of the pods in percent of their
+// requests, or false if no pod with metrics has a CPU request
+func (l *CPULoad) Utilization() (float64, bool) {
+	var usage, request int64
+	for _, p := range l.Pods {
+		if p.RequestMillicores > 0 {
+			usage += p.UsageMillicores
+			request += p.RequestMillicores
+		}
+	}
+	if request == 0 {
+		return 0, false
+	}
+	return 100 * float64(usage) / float64(request), true
+}
+
+// AutoscaleOptions contains options for sizing a component from its load
+type AutoscaleOptions struct {
+	// TargetCPU is the CPU utilization to size for, in percent of the CPU
+	// request
+	TargetCPU float64
+
+	// MinReplicas and MaxReplicas bound the replica count; MinReplicas
+	// defaults to 1 and MaxReplicas 0 means no maximum
+	MinReplicas int
+	MaxReplicas int
+}
+
+// ScaleRecommendation is the replica count that brings a component to the
+// target CPU utilization
+type ScaleRecommendation struct {
+	Component       string  `json:"component"`
+	CurrentReplicas int     `json:"currentReplicas"`
+	TargetReplicas  int     `json:"targetReplicas"`
+	CPUUtilization  float64 `json:"cpuUtilization"`
+	TargetCPU       float64 `json:"targetCpu"`
+
+	// Reason explains the target, e.g. that the utilization is within the
+	// tolerance or the count was capped by MaxReplicas
+	Reason string `json:"reason"`
+}
+
+// Changed reports whether the recommended replica count differs from the
+// current one
+func (r *ScaleRecommendation) Changed() bool {
+	return r.TargetReplicas != r.CurrentReplicas
+}
+
+// RecommendReplicas sizes a component for a target CPU utilization the way
+// the HorizontalPodAutoscaler does, once: the replica count is scaled by the
+// ratio of the current to the target utilization and rounded up, left
+// unchanged if the ratio is within 10% of 1, and kept within the bounds.
+func RecommendReplicas(load *CPULoad, opts AutoscaleOptions) (*ScaleRecommendation, error) {
+	if opts.TargetCPU <= 0 {
+		return nil, fmt.Errorf("target CPU utilization must be positive, got %g", opts.TargetCPU)
+	}
+	minReplicas := max(opts.MinReplicas, 1)
+	if opts.MaxReplicas > 0 && opts.MaxReplicas < minReplicas {
+		return nil, fmt.Errorf("max replicas %d is below min replicas %d", opts.MaxReplicas, minReplicas)
+	}
+	if load.Replicas == 0 {
+		return nil, fmt.Errorf("%s has no replicas to measure; start the instance first", load.Component)
+	}
+	utilization, ok := load.Utilization()
+	if !ok {
+		return nil, fmt.Errorf("no %s pod reports CPU usage against a CPU request; set one with 'miup instance scale --cpu-request'", load.Component)
+	}
+
+	rec := &ScaleRecommendation{
+		Component:       load.Component,
+		CurrentReplicas: load.Replicas,
+		TargetReplicas:  load.Replicas,
+		CPUUtilization:  utilization,
+		TargetCPU:       opts.TargetCPU,
+	}
+	ratio := utilization / opts.TargetCPU
+	if math.Abs(ratio-1) <= autoscaleTolerance {
+		rec.Reason = fmt.Sprintf("CPU at %.0f%% of request is within %.0f%% of the %.0f%% target", utilization, autoscaleTolerance*100, opts.TargetCPU)
+	} else {
+		rec.TargetReplicas = int(math.Ceil(float64(load.Replicas) * ratio))
+		rec.Reason = fmt.Sprintf("CPU at %.0f%% of request, target %.0f%%", utilization, opts.TargetCPU)
+	}
+
+	switch {
+	case rec.TargetReplicas < minReplicas:
+		rec.TargetReplicas = minReplicas
+		rec.Reason += fmt.Sprintf("; raised to the minimum of %d", minReplicas)
+	case opts.MaxReplicas > 0 && rec.TargetReplicas > opts.MaxReplicas:
+		rec.TargetReplicas = opts.MaxReplicas
+		rec.Reason += fmt.Sprintf("; capped at the maximum of %d", opts.MaxReplicas)
+	}
+	return rec, nil
+}
+
+// CPULoad returns the current CPU usage and requests of a component's pods.
+// It fails with an error wrapping k8s.ErrMetricsUnavailable if the cluster
+// has no metrics-server.
+func (e *KubernetesExecutor) CPULoad(ctx context.Context, component string) (*CPULoad, error) {
+	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Milvus cluster: %w", err)
+	}
+
+	component = strings.ToLower(component)
+	if component == "standalone" {
+		return nil, fmt.Errorf("%w: standalone runs as a single replica", ErrInvalidComponent)
+	}
+	if _, err := e.getComponentSpec(ctx, milvus, component); err != nil {
+		return nil, err
+	}
+
+	selector := fmt.Sprintf("app.kubernetes.io/instance=%s,app.kubernetes.io/component=%s", e.clusterName, component)
+	usage, err := e.client.ListPodUsage(ctx, e.namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := e.client.ListMilvusPodObjects(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, err
+	}
+	return cpuLoad(component, replicaCounts(milvus)[component].Desired, usage, pods), nil
+}
+
+// cpuLoad combines the usage of a component's pods with their CPU requests,
+// summed over their containers
+func cpuLoad(component string, replicas int, usage []k8s.PodUsage, pods []corev1.Pod) *CPULoad {
+	requests := make(map[string]int64)
+	for _, pod := range pods {
+		if pod.Labels["app.kubernetes.io/component"] != component {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			if q, ok := c.Resources.Requests[corev1.ResourceCPU]; ok {
+				requests[pod.Name] += q.MilliValue()
+			}
+		}
+	}
+
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Name < usage[j].Name })
+	load := &CPULoad{Component: component, Replicas: replicas, Pods: []PodCPU{}}
+	for _, u := range usage {
+		load.Pods = append(load.Pods, PodCPU{
+			Name:              u.Name,
+			UsageMillicores:   u.CPU.MilliValue(),
+			RequestMillicores: requests[u.Name],
+		})
+	}
+	return load
+}
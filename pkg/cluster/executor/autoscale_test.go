@@ -0,0 +1,163 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// loadAt returns the load of replicas pods each using usage of a 1000m request
+func loadAt(replicas int, usage int64) *CPULoad {
+	load := &CPULoad{Component: "querynode", Replicas: replicas}
+	for range replicas {
+		load.Pods = append(load.Pods, PodCPU{UsageMillicores: usage, RequestMillicores: 1000})
+	}
+	return load
+}
+
+func TestRecommendReplicas(t *testing.T) {
+	tests := []struct {
+		name       string
+		load       *CPULoad
+		opts       AutoscaleOptions
+		want       int
+		wantReason string
+		wantErr    string
+	}{
+		{
+			name: "scale out",
+			load: loadAt(2, 900),
+			opts: AutoscaleOptions{TargetCPU: 60},
+			want: 3,
+		},
+		{
+			name: "scale in",
+			load: loadAt(4, 200),
+			opts: AutoscaleOptions{TargetCPU: 60},
+			want: 2,
+		},
+		{
+			name:       "within tolerance",
+			load:       loadAt(3, 640),
+			opts:       AutoscaleOptions{TargetCPU: 60},
+			want:       3,
+			wantReason: "within 10%",
+		},
+		{
+			name:       "capped at max",
+			load:       loadAt(2, 1800),
+			opts:       AutoscaleOptions{TargetCPU: 60, MaxReplicas: 4},
+			want:       4,
+			wantReason: "capped at the maximum of 4",
+		},
+		{
+			name:       "raised to min",
+			load:       loadAt(3, 10),
+			opts:       AutoscaleOptions{TargetCPU: 60, MinReplicas: 2},
+			want:       2,
+			wantReason: "raised to the minimum of 2",
+		},
+		{
+			name: "pods without a request are ignored",
+			load: &CPULoad{Component: "querynode", Replicas: 2, Pods: []PodCPU{
+				{UsageMillicores: 1200, RequestMillicores: 1000},
+				{UsageMillicores: 5000},
+			}},
+			opts: AutoscaleOptions{TargetCPU: 60},
+			want: 4,
+		},
+		{
+			name:    "no requests",
+			load:    &CPULoad{Component: "querynode", Replicas: 2, Pods: []PodCPU{{UsageMillicores: 500}}},
+			opts:    AutoscaleOptions{TargetCPU: 60},
+			wantErr: "--cpu-request",
+		},
+		{
+			name:    "stopped",
+			load:    &CPULoad{Component: "querynode"},
+			opts:    AutoscaleOptions{TargetCPU: 60},
+			wantErr: "no replicas",
+		},
+		{
+			name:    "invalid target",
+			load:    loadAt(2, 500),
+			opts:    AutoscaleOptions{},
+			wantErr: "must be positive",
+		},
+		{
+			name:    "max below min",
+			load:    loadAt(2, 500),
+			opts:    AutoscaleOptions{TargetCPU: 60, MinReplicas: 3, MaxReplicas: 2},
+			wantErr: "below min replicas",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, err := RecommendReplicas(tt.load, tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RecommendReplicas() error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RecommendReplicas() error = %v", err)
+			}
+			if rec.TargetReplicas != tt.want {
+				t.Errorf("TargetReplicas = %d, want %d (%s)", rec.TargetReplicas, tt.want, rec.Reason)
+			}
+			if rec.Changed() != (tt.want != tt.load.Replicas) {
+				t.Errorf("Changed() = %v with %d -> %d", rec.Changed(), rec.CurrentReplicas, rec.TargetReplicas)
+			}
+			if !strings.Contains(rec.Reason, tt.wantReason) {
+				t.Errorf("Reason = %q, want it to contain %q", rec.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestCPULoad(t *testing.T) {
+	pod := func(name, component string, requests ...string) corev1.Pod {
+		p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"app.kubernetes.io/component": component},
+		}}
+		for _, r := range requests {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(r)}},
+			})
+		}
+		return p
+	}
+	pods := []corev1.Pod{
+		pod("q-1", "querynode", "1", "250m"),
+		pod("q-0", "querynode", "500m"),
+		pod("p-0", "proxy", "2"),
+	}
+	usage := []k8s.PodUsage{
+		{Name: "q-1", CPU: resource.MustParse("1")},
+		{Name: "q-0", CPU: resource.MustParse("300m")},
+	}
+
+	load := cpuLoad("querynode", 2, usage, pods)
+	want := []PodCPU{
+		{Name: "q-0", UsageMillicores: 300, RequestMillicores: 500},
+		{Name: "q-1", UsageMillicores: 1000, RequestMillicores: 1250},
+	}
+	if load.Replicas != 2 || len(load.Pods) != len(want) {
+		t.Fatalf("cpuLoad() = %+v", load)
+	}
+	for i := range want {
+		if load.Pods[i] != want[i] {
+			t.Errorf("Pods[%d] = %+v, want %+v", i, load.Pods[i], want[i])
+		}
+	}
+	if u, ok := load.Utilization(); !ok || int(u) != 74 {
+		t.Errorf("Utilization() = %v, %v, want 74%% (1300m of 1750m)", u, ok)
+	}
+}
@@ -0,0 +1,56 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BulkResult is the outcome of an operation run by RunBulk on one name
+type BulkResult struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// RunBulk runs op on each name, e.g. of a cluster or a pod, at most
+// concurrency at a time, and returns the results in the order of names. A
+// failing op only records its error; names not yet started when ctx is
+// cancelled fail with the context error.
+func RunBulk(ctx context.Context, names []string, concurrency int, op func(ctx context.Context, name string) error) []BulkResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]BulkResult, len(names))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, name := range names {
+		results[i].Name = name
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *BulkResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			start := time.Now()
+			r.Err = op(ctx, r.Name)
+			r.Duration = time.Since(start)
+		}(&results[i])
+	}
+
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,60 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBulk(t *testing.T) {
+	var running, maxRunning int32
+	errFailed := errors.New("failed")
+
+	results := RunBulk(context.Background(), []string{"a", "b", "c", "d", "e"}, 2, func(ctx context.Context, name string) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if name == "c" {
+			return errFailed
+		}
+		return nil
+	})
+
+	if maxRunning > 2 {
+		t.Errorf("ran %d operations at once, want at most 2", maxRunning)
+	}
+	if len(results) != 5 {
+		t.Fatalf("got %d results, want 5", len(results))
+	}
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		if results[i].Name != name {
+			t.Errorf("results[%d].Name = %s, want %s", i, results[i].Name, name)
+		}
+		if wantErr := name == "c"; (results[i].Err != nil) != wantErr {
+			t.Errorf("results[%d].Err = %v", i, results[i].Err)
+		}
+	}
+}
+
+func TestRunBulkCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := RunBulk(ctx, []string{"a", "b", "c"}, 1, func(ctx context.Context, name string) error {
+		t.Errorf("op should not run for %s after cancellation", name)
+		return nil
+	})
+	for _, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("%s: Err = %v, want context.Canceled", r.Name, r.Err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+// readyConditionType is the condition the Milvus Operator sets when the
+// cluster becomes ready or unready
+const readyConditionType = "MilvusReady"
+
+// Description is a detailed view of the Milvus CRD status
+type Description struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	CreatedAt time.Time `json:"created_at"`
+	Status    string    `json:"status"`
+
+	// StatusSince is when the cluster entered its current state, taken from
+	// the ready condition (or the latest condition transition)
+	StatusSince *time.Time `json:"status_since,omitempty"`
+
+	Endpoint   string                 `json:"endpoint,omitempty"`
+	Image      string                 `json:"image,omitempty"`
+	Conditions []ConditionInfo        `json:"conditions"`
+	Components []ComponentDescription `json:"components"`
+}
+
+// ConditionInfo is a Milvus CRD status condition
+type ConditionInfo struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"last_transition_time"`
+}
+
+// ComponentDescription is the operator-reported deployment status of a component
+type ComponentDescription struct {
+	Name       string `json:"name"`
+	Image      string `json:"image,omitempty"`
+	Replicas   int32  `json:"replicas"`
+	Ready      int32  `json:"ready"`
+	Available  int32  `json:"available"`
+	Updated    int32  `json:"updated"`
+	Generation int64  `json:"generation,omitempty"`
+}
+
+// Describe returns the full status of the Milvus CRD
+func (e *KubernetesExecutor) Describe(ctx context.Context) (*Description, error) {
+	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Milvus cluster: %w", err)
+	}
+	return describeMilvus(milvus), nil
+}
+
+// describeMilvus converts a Milvus CRD into a Description
+func describeMilvus(milvus *k8s.Milvus) *Description {
+	d := &Description{
+		Name:       milvus.Name,
+		Namespace:  milvus.Namespace,
+		CreatedAt:  milvus.CreationTimestamp.Time,
+		Status:     milvus.Status.Status,
+		Endpoint:   milvus.Status.Endpoint,
+		Image:      milvus.Spec.Components.Image,
+		Conditions: []ConditionInfo{},
+		Components: []ComponentDescription{},
+	}
+	if d.Status == "" {
+		d.Status = "Unknown"
+	}
+
+	var latest, ready *time.Time
+	for _, cond := range milvus.Status.Conditions {
+		t := cond.LastTransitionTime.Time
+		d.Conditions = append(d.Conditions, ConditionInfo{
+			Type:               cond.Type,
+			Status:             string(cond.Status),
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastTransitionTime: t,
+		})
+		if t.IsZero() {
+			continue
+		}
+		if cond.Type == readyConditionType {
+			ready = &t
+		}
+		if latest == nil || t.After(*latest) {
+			latest = &t
+		}
+	}
+	d.StatusSince = ready
+	if d.StatusSince == nil {
+		d.StatusSince = latest
+	}
+
+	for name, status := range milvus.Status.ComponentsDeployStatus {
+		d.Components = append(d.Components, ComponentDescription{
+			Name:       name,
+			Image:      status.Image,
+			Replicas:   status.Status.Replicas,
+			Ready:      status.Status.ReadyReplicas,
+			Available:  status.Status.AvailableReplicas,
+			Updated:    status.Status.UpdatedReplicas,
+			Generation: status.Generation,
+		})
+	}
+	sort.Slice(d.Components, func(i, j int) bool { return d.Components[i].Name < d.Components[j].Name })
+
+	return d
+}
@@ -0,0 +1,81 @@
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDescribeMilvus(t *testing.T) {
+	created := time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC)
+	ready := created.Add(4 * time.Minute)
+	updated := created.Add(time.Hour)
+
+	milvus := &k8s.Milvus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "prod",
+			Namespace:         "milvus",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: k8s.MilvusSpec{
+			Components: k8s.MilvusComponents{Image: "milvusdb/milvus:v2.5.4"},
+		},
+		Status: k8s.MilvusStatus{
+			Status:   "Healthy",
+			Endpoint: "prod-milvus.milvus:19530",
+			Conditions: []metav1.Condition{
+				{Type: "MilvusReady", Status: metav1.ConditionTrue, Reason: "AllComponentsReady", LastTransitionTime: metav1.NewTime(ready)},
+				{Type: "MilvusUpdated", Status: metav1.ConditionTrue, Reason: "MilvusComponentsUpdated", LastTransitionTime: metav1.NewTime(updated)},
+			},
+			ComponentsDeployStatus: map[string]k8s.ComponentDeployStatus{
+				"querynode": {Generation: 2, Image: "milvusdb/milvus:v2.5.4"},
+				"datanode":  {Generation: 1, Image: "milvusdb/milvus:v2.5.4"},
+			},
+		},
+	}
+
+	d := describeMilvus(milvus)
+	if d.Name != "prod" || d.Namespace != "milvus" || d.Status != "Healthy" {
+		t.Errorf("describeMilvus() = %+v", d)
+	}
+	if d.Image != "milvusdb/milvus:v2.5.4" || d.Endpoint != "prod-milvus.milvus:19530" {
+		t.Errorf("Image = %q, Endpoint = %q", d.Image, d.Endpoint)
+	}
+	if !d.CreatedAt.Equal(created) {
+		t.Errorf("CreatedAt = %v, want %v", d.CreatedAt, created)
+	}
+	if d.StatusSince == nil || !d.StatusSince.Equal(ready) {
+		t.Errorf("StatusSince = %v, want the MilvusReady transition %v", d.StatusSince, ready)
+	}
+	if len(d.Conditions) != 2 || d.Conditions[0].Reason != "AllComponentsReady" {
+		t.Errorf("Conditions = %+v", d.Conditions)
+	}
+	if len(d.Components) != 2 || d.Components[0].Name != "datanode" || d.Components[1].Generation != 2 {
+		t.Errorf("Components should be sorted by name, got %+v", d.Components)
+	}
+}
+
+func TestDescribeMilvusWithoutReadyCondition(t *testing.T) {
+	latest := time.Date(2025, 1, 10, 11, 0, 0, 0, time.UTC)
+	milvus := &k8s.Milvus{
+		Status: k8s.MilvusStatus{
+			Conditions: []metav1.Condition{
+				{Type: "EtcdReady", Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(latest.Add(-time.Hour))},
+				{Type: "StorageReady", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(latest)},
+			},
+		},
+	}
+
+	d := describeMilvus(milvus)
+	if d.Status != "Unknown" {
+		t.Errorf("Status = %q, want Unknown", d.Status)
+	}
+	if d.StatusSince == nil || !d.StatusSince.Equal(latest) {
+		t.Errorf("StatusSince = %v, want the latest transition %v", d.StatusSince, latest)
+	}
+	if d.Components == nil {
+		t.Error("Components should be empty, not nil")
+	}
+}
@@ -0,0 +1,174 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	"github.com/mmga-lab/miup/pkg/logger"
+	"github.com/mmga-lab/miup/pkg/timing"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// deletePollInterval is how often WaitDeleted checks what is left
+const deletePollInterval = 2 * time.Second
+
+// operatorCleanup is what the Milvus Operator deletes along with a Milvus
+// resource: always the Milvus pods, and the release and PVCs of an in-cluster
+// etcd or MinIO only if its deletionPolicy is Delete and pvcDeletion is set.
+// The operator's default is to retain both.
+type operatorCleanup struct {
+	// releases are the dependencies whose pods are deleted
+	releases []string
+
+	// volumes are the dependencies whose PVCs are deleted
+	volumes []string
+
+	// kept describes what stays, e.g. "etcd PVCs"
+	kept []string
+}
+
+// cleanupOf returns what the operator deletes along with milvus
+func cleanupOf(milvus *k8s.Milvus) *operatorCleanup {
+	c := &operatorCleanup{}
+	deps := milvus.Spec.Dependencies
+	for _, dep := range VolumeComponents {
+		var external bool
+		var inCluster *k8s.InClusterConfig
+		if dep == "etcd" {
+			external, inCluster = deps.Etcd.External, deps.Etcd.InCluster
+		} else {
+			external, inCluster = deps.Storage.External, deps.Storage.InCluster
+		}
+
+		switch {
+		case external:
+		case inCluster == nil || inCluster.DeletionPolicy != "Delete":
+			c.kept = append(c.kept, dep+" release and PVCs")
+		case !inCluster.PVCDeletion:
+			c.releases = append(c.releases, dep)
+			c.kept = append(c.kept, dep+" PVCs")
+		default:
+			c.releases = append(c.releases, dep)
+			c.volumes = append(c.volumes, dep)
+		}
+	}
+	return c
+}
+
+// WaitDeleted waits until the Milvus resource and its pods are gone, and the
+// pods and PVCs of the etcd and MinIO the operator deletes with it as read by
+// Destroy. Deleting the Milvus resource returns at once while the operator's
+// finalizers remove the rest, so a deploy with the same name right after
+// Destroy can collide with what is left. Dependencies the deletion policy
+// keeps are not waited for. If ctx is cancelled the returned error wraps both
+// ErrWaitCancelled and ctx.Err().
+func (e *KubernetesExecutor) WaitDeleted(ctx context.Context, timeout time.Duration) error {
+	defer timing.Start(ctx, "wait for delete")()
+
+	if e.cleanup != nil && len(e.cleanup.kept) > 0 {
+		logger.Warn("Not waiting for the %s of '%s': the deletion policy of the Milvus resource keeps them",
+			strings.Join(e.cleanup.kept, " and "), e.clusterName)
+	}
+
+	deadline := time.Now().Add(timeout)
+	var left []string
+
+	for {
+		var err error
+		left, err = e.remainingResources(ctx)
+		if err == nil && len(left) == 0 {
+			return nil
+		}
+		if err != nil {
+			logger.Debug("Failed to list the remaining resources of '%s': %v", e.clusterName, err)
+		}
+
+		if !time.Now().Add(deletePollInterval).Before(deadline) {
+			break
+		}
+		if err := sleepContext(ctx, deletePollInterval); err != nil {
+			return fmt.Errorf("%w (still deleting: %s): %w", ErrWaitCancelled, summarize(left), err)
+		}
+	}
+
+	return fmt.Errorf("%w after %s; still deleting: %s", ErrDeleteTimeout, timeout, summarize(left))
+}
+
+// remainingResources lists what is left in the namespace of the resources
+// the operator deletes with the cluster. Without a cleanup read by Destroy,
+// only the Milvus resource and its pods are known to go.
+func (e *KubernetesExecutor) remainingResources(ctx context.Context) ([]string, error) {
+	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		if !k8s.IsNotFound(err) {
+			return nil, err
+		}
+		milvus = nil
+	}
+
+	cleanup := e.cleanup
+	if cleanup == nil {
+		cleanup = &operatorCleanup{}
+	}
+
+	pods, err := e.client.ListMilvusPodObjects(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range cleanup.releases {
+		deps, err := e.client.ListSelectedPods(ctx, e.namespace, dependencySelector(e.clusterName, dep))
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, deps...)
+	}
+
+	var volumes []corev1.PersistentVolumeClaim
+	if len(cleanup.volumes) > 0 {
+		pvcs, err := e.client.ListPVCs(ctx, e.namespace)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range cleanup.volumes {
+			volumes = append(volumes, selectPVCs(pvcs, e.clusterName, dep)...)
+		}
+	}
+
+	return remaining(milvus, pods, volumes), nil
+}
+
+// remaining names the resources left of a cluster being deleted, the Milvus
+// resource first and then the pods and PVCs sorted by name
+func remaining(milvus *k8s.Milvus, pods []corev1.Pod, pvcs []corev1.PersistentVolumeClaim) []string {
+	var left []string
+	if milvus != nil {
+		left = append(left, "milvus/"+milvus.Name)
+	}
+
+	var names []string
+	for _, pod := range pods {
+		names = append(names, "pod/"+pod.Name)
+	}
+	for _, pvc := range pvcs {
+		names = append(names, "pvc/"+pvc.Name)
+	}
+	sort.Strings(names)
+	return append(left, names...)
+}
+
+// summarize lists up to three resources and how many more there are
+func summarize(left []string) string {
+	const shown = 3
+	switch {
+	case len(left) == 0:
+		return "unknown"
+	case len(left) <= shown:
+		return strings.Join(left, ", ")
+	default:
+		return fmt.Sprintf("%s and %d more", strings.Join(left[:shown], ", "), len(left)-shown)
+	}
+}
@@ -0,0 +1,189 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRemaining(t *testing.T) {
+	milvus := &k8s.Milvus{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "prod-milvus-standalone-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "prod-etcd-0"}},
+	}
+	pvcs := []corev1.PersistentVolumeClaim{
+		{ObjectMeta: metav1.ObjectMeta{Name: "data-prod-etcd-0"}},
+	}
+
+	tests := []struct {
+		name   string
+		milvus *k8s.Milvus
+		pods   []corev1.Pod
+		pvcs   []corev1.PersistentVolumeClaim
+		want   []string
+	}{
+		{
+			name:   "being deleted",
+			milvus: milvus,
+			pods:   pods,
+			pvcs:   pvcs,
+			want:   []string{"milvus/prod", "pod/prod-etcd-0", "pod/prod-milvus-standalone-0", "pvc/data-prod-etcd-0"},
+		},
+		{
+			name: "only volumes left",
+			pvcs: pvcs,
+			want: []string{"pvc/data-prod-etcd-0"},
+		},
+		{
+			name: "gone",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remaining(tt.milvus, tt.pods, tt.pvcs); !slices.Equal(got, tt.want) {
+				t.Errorf("remaining() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		left []string
+		want string
+	}{
+		{nil, "unknown"},
+		{[]string{"milvus/prod", "pod/prod-etcd-0"}, "milvus/prod, pod/prod-etcd-0"},
+		{[]string{"pod/a", "pod/b", "pod/c", "pvc/a", "pvc/b"}, "pod/a, pod/b, pod/c and 2 more"},
+	}
+
+	for _, tt := range tests {
+		if got := summarize(tt.left); got != tt.want {
+			t.Errorf("summarize(%v) = %q, want %q", tt.left, got, tt.want)
+		}
+	}
+}
+
+func TestCleanupOf(t *testing.T) {
+	inCluster := func(policy string, pvcDeletion bool) *k8s.InClusterConfig {
+		return &k8s.InClusterConfig{DeletionPolicy: policy, PVCDeletion: pvcDeletion}
+	}
+
+	tests := []struct {
+		name     string
+		deps     k8s.MilvusDependencies
+		releases []string
+		volumes  []string
+		kept     []string
+	}{
+		{
+			name: "deleted with the resource",
+			deps: k8s.MilvusDependencies{
+				Etcd:    k8s.EtcdConfig{InCluster: inCluster("Delete", true)},
+				Storage: k8s.StorageConfig{InCluster: inCluster("Delete", true)},
+			},
+			releases: []string{"etcd", "minio"},
+			volumes:  []string{"etcd", "minio"},
+		},
+		{
+			name: "operator defaults",
+			deps: k8s.MilvusDependencies{
+				Etcd:    k8s.EtcdConfig{InCluster: &k8s.InClusterConfig{}},
+				Storage: k8s.StorageConfig{},
+			},
+			kept: []string{"etcd release and PVCs", "minio release and PVCs"},
+		},
+		{
+			name: "volumes kept and external storage",
+			deps: k8s.MilvusDependencies{
+				Etcd:    k8s.EtcdConfig{InCluster: inCluster("Delete", false)},
+				Storage: k8s.StorageConfig{External: true},
+			},
+			releases: []string{"etcd"},
+			kept:     []string{"etcd PVCs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cleanupOf(&k8s.Milvus{Spec: k8s.MilvusSpec{Dependencies: tt.deps}})
+			if !slices.Equal(c.releases, tt.releases) || !slices.Equal(c.volumes, tt.volumes) || !slices.Equal(c.kept, tt.kept) {
+				t.Errorf("cleanupOf() = %+v, want releases %v, volumes %v, kept %v", c, tt.releases, tt.volumes, tt.kept)
+			}
+		})
+	}
+}
+
+func TestDestroyWaitDeleted(t *testing.T) {
+	ctx := context.Background()
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Name:      "data-prod-etcd-0",
+		Namespace: "milvus",
+		Labels:    map[string]string{"app.kubernetes.io/instance": "prod-etcd"},
+	}}
+	milvus := func(pvcDeletion bool) *k8s.Milvus {
+		etcd := &k8s.InClusterConfig{DeletionPolicy: "Delete", PVCDeletion: pvcDeletion}
+		return &k8s.Milvus{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "milvus"},
+			Spec: k8s.MilvusSpec{Dependencies: k8s.MilvusDependencies{
+				Etcd:    k8s.EtcdConfig{InCluster: etcd},
+				Storage: k8s.StorageConfig{External: true},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		milvus   *k8s.Milvus
+		wantLeft string
+	}{
+		{
+			name:   "PVCs kept by the deletion policy",
+			milvus: milvus(false),
+		},
+		{
+			name:     "PVCs deleted with the resource",
+			milvus:   milvus(true),
+			wantLeft: "pvc/data-prod-etcd-0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := newFakeKubernetesExecutor(t, []*k8s.Milvus{tt.milvus}, pvc.DeepCopy())
+
+			if err := e.Destroy(ctx); err != nil {
+				t.Fatalf("Destroy() error = %v", err)
+			}
+			err := e.WaitDeleted(ctx, 0)
+			if tt.wantLeft == "" {
+				if err != nil {
+					t.Errorf("WaitDeleted() error = %v, want the kept PVC not waited for", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrDeleteTimeout) || !strings.Contains(err.Error(), tt.wantLeft) {
+				t.Errorf("WaitDeleted() error = %v, want a timeout naming %s", err, tt.wantLeft)
+			}
+		})
+	}
+}
+
+func TestDestroyAlreadyDeleted(t *testing.T) {
+	e, _ := newFakeKubernetesExecutor(t, nil)
+	if err := e.Destroy(context.Background()); err != nil {
+		t.Fatalf("Destroy() error = %v, want a missing resource to count as deleted", err)
+	}
+	// Without the policy read by Destroy only Milvus itself is waited for
+	if err := e.WaitDeleted(context.Background(), 0); err != nil {
+		t.Errorf("WaitDeleted() error = %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+// ManagedByLabel is the label miup sets on the Milvus resources it creates
+const ManagedByLabel = "app.kubernetes.io/managed-by"
+
+// MilvusResource summarizes a Milvus CRD found in the Kubernetes cluster
+type MilvusResource struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	Mode      string `json:"mode"`
+	Version   string `json:"version"`
+	// ManagedBy is the value of the app.kubernetes.io/managed-by label
+	ManagedBy string    `json:"managed_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListAllMilvus lists Milvus resources in every namespace, including ones
+// that were not created by miup. Namespace and ClusterName in opts are ignored.
+func ListAllMilvus(ctx context.Context, opts KubernetesOptions) ([]MilvusResource, error) {
+	client, err := k8s.NewClient(k8s.ClientOptions{
+		Kubeconfig: opts.Kubeconfig,
+		Context:    opts.Context,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	list, err := client.ListAllMilvus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]MilvusResource, 0, len(list.Items))
+	for i := range list.Items {
+		resources = append(resources, summarizeMilvus(&list.Items[i]))
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Namespace != resources[j].Namespace {
+			return resources[i].Namespace < resources[j].Namespace
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	return resources, nil
+}
+
+func summarizeMilvus(milvus *k8s.Milvus) MilvusResource {
+	mode := string(milvus.Spec.Mode)
+	if mode == "" {
+		mode = string(k8s.MilvusModeStandalone)
+	}
+
+	return MilvusResource{
+		Name:      milvus.Name,
+		Namespace: milvus.Namespace,
+		Status:    milvus.Status.Status,
+		Mode:      mode,
+		Version:   ImageVersion(milvus.Spec.Components.Image),
+		ManagedBy: milvus.Labels[ManagedByLabel],
+		CreatedAt: milvus.CreationTimestamp.Time,
+	}
+}
@@ -0,0 +1,32 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+func TestSummarizeMilvus(t *testing.T) {
+	milvus := &k8s.Milvus{
+		Spec: k8s.MilvusSpec{
+			Components: k8s.MilvusComponents{Image: "milvusdb/milvus:v2.5.4"},
+		},
+		Status: k8s.MilvusStatus{Status: "Healthy"},
+	}
+	milvus.Name = "prod"
+	milvus.Namespace = "milvus"
+	milvus.Labels = map[string]string{ManagedByLabel: "miup"}
+
+	got := summarizeMilvus(milvus)
+	want := MilvusResource{
+		Name:      "prod",
+		Namespace: "milvus",
+		Status:    "Healthy",
+		Mode:      "standalone",
+		Version:   "v2.5.4",
+		ManagedBy: "miup",
+	}
+	if got != want {
+		t.Errorf("summarizeMilvus() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Endpoint describes how clients connect to a cluster's Milvus service
+type Endpoint struct {
+	Service   string `json:"service"`
+	Namespace string `json:"namespace"`
+	Type      string `json:"type"`
+
+	// Address is the cluster IP and port, or the DNS name and port of a
+	// headless service, reachable from inside the cluster
+	Address string `json:"address"`
+
+	// External is the LoadBalancer ingress or NodePort address, if any
+	External string `json:"external,omitempty"`
+
+	TLS bool `json:"tls"`
+}
+
+// Endpoint returns the connection details of the Milvus service. A NodePort
+// service's external address uses the first node with an external IP (or
+// internal IP, if none has one).
+func (e *KubernetesExecutor) Endpoint(ctx context.Context) (*Endpoint, error) {
+	svc, err := e.client.GetMilvusServiceObject(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var node string
+	if svc.Spec.Type == corev1.ServiceTypeNodePort {
+		if node, err = e.client.NodeAddress(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint := serviceEndpoint(svc, node)
+	endpoint.TLS = e.spec.HasTLS()
+	return endpoint, nil
+}
+
+// serviceEndpoint returns the endpoint of the Milvus port of svc. node is the
+// address NodePort services are reached on.
+func serviceEndpoint(svc *corev1.Service, node string) *Endpoint {
+	endpoint := &Endpoint{
+		Service:   svc.Name,
+		Namespace: svc.Namespace,
+		Type:      string(svc.Spec.Type),
+	}
+	if endpoint.Type == "" {
+		endpoint.Type = string(corev1.ServiceTypeClusterIP)
+	}
+
+	if port := k8s.MilvusServicePort(svc); port != nil {
+		endpoint.Address = k8s.ClusterAddress(svc, port)
+		endpoint.External = k8s.ExternalAddress(svc, port, node)
+	}
+	return endpoint
+}
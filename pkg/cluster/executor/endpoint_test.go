@@ -0,0 +1,91 @@
+package executor
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceEndpoint(t *testing.T) {
+	ports := []corev1.ServicePort{
+		{Name: "metrics", Port: 9091, NodePort: 30091},
+		{Name: "milvus", Port: 19530, NodePort: 30530},
+	}
+
+	tests := []struct {
+		name         string
+		spec         corev1.ServiceSpec
+		status       corev1.ServiceStatus
+		node         string
+		wantType     string
+		wantAddress  string
+		wantExternal string
+	}{
+		{
+			name:        "cluster IP",
+			spec:        corev1.ServiceSpec{ClusterIP: "10.0.0.5", Ports: ports},
+			wantType:    "ClusterIP",
+			wantAddress: "10.0.0.5:19530",
+		},
+		{
+			name: "load balancer hostname",
+			spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.5", Ports: ports},
+			status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}},
+			}},
+			wantType:     "LoadBalancer",
+			wantAddress:  "10.0.0.5:19530",
+			wantExternal: "lb.example.com:19530",
+		},
+		{
+			name:        "pending load balancer",
+			spec:        corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer, ClusterIP: "10.0.0.5", Ports: ports},
+			wantType:    "LoadBalancer",
+			wantAddress: "10.0.0.5:19530",
+		},
+		{
+			name:         "node port",
+			spec:         corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, ClusterIP: "10.0.0.5", Ports: ports},
+			node:         "203.0.113.7",
+			wantType:     "NodePort",
+			wantAddress:  "10.0.0.5:19530",
+			wantExternal: "203.0.113.7:30530",
+		},
+		{
+			name:        "headless",
+			spec:        corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone, Ports: ports},
+			wantType:    "ClusterIP",
+			wantAddress: "prod-milvus.milvus.svc:19530",
+		},
+		{
+			name:     "no ports",
+			spec:     corev1.ServiceSpec{ClusterIP: "10.0.0.5"},
+			wantType: "ClusterIP",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod-milvus", Namespace: "milvus"},
+				Spec:       tt.spec,
+				Status:     tt.status,
+			}
+
+			got := serviceEndpoint(svc, tt.node)
+			if got.Service != "prod-milvus" || got.Namespace != "milvus" {
+				t.Errorf("service = %s/%s, want milvus/prod-milvus", got.Namespace, got.Service)
+			}
+			if got.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", got.Type, tt.wantType)
+			}
+			if got.Address != tt.wantAddress {
+				t.Errorf("Address = %q, want %q", got.Address, tt.wantAddress)
+			}
+			if got.External != tt.wantExternal {
+				t.Errorf("External = %q, want %q", got.External, tt.wantExternal)
+			}
+		})
+	}
+}
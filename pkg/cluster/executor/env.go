@@ -0,0 +1,48 @@
+package executor
+
+import (
+	"sort"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+// envVars converts an env map to CRD env vars sorted by name
+func envVars(env map[string]string) []k8s.EnvVar {
+	if len(env) == 0 {
+		return nil
+	}
+
+	vars := make([]k8s.EnvVar, 0, len(env))
+	for name, value := range env {
+		vars = append(vars, k8s.EnvVar{Name: name, Value: value})
+	}
+	sort.Slice(vars, func(i, j int) bool {
+		return vars[i].Name < vars[j].Name
+	})
+	return vars
+}
+
+// envMap converts CRD env vars back to a map
+func envMap(vars []k8s.EnvVar) map[string]string {
+	if len(vars) == 0 {
+		return nil
+	}
+
+	env := make(map[string]string, len(vars))
+	for _, v := range vars {
+		env[v.Name] = v.Value
+	}
+	return env
+}
+
+// mergeEnv overrides or adds the updated variables, keeping the others
+func mergeEnv(vars []k8s.EnvVar, updates map[string]string) []k8s.EnvVar {
+	env := envMap(vars)
+	if env == nil {
+		env = make(map[string]string, len(updates))
+	}
+	for name, value := range updates {
+		env[name] = value
+	}
+	return envVars(env)
+}
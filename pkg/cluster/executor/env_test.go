@@ -0,0 +1,36 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+func TestMergeEnv(t *testing.T) {
+	existing := []k8s.EnvVar{{Name: "GOGC", Value: "100"}, {Name: "TZ", Value: "UTC"}}
+
+	got := mergeEnv(existing, map[string]string{"GOGC": "200", "GODEBUG": "madvdontneed=1"})
+	want := []k8s.EnvVar{
+		{Name: "GODEBUG", Value: "madvdontneed=1"},
+		{Name: "GOGC", Value: "200"},
+		{Name: "TZ", Value: "UTC"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("mergeEnv() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("mergeEnv()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEnvVars(t *testing.T) {
+	if got := envVars(nil); got != nil {
+		t.Errorf("envVars(nil) = %v, want nil", got)
+	}
+	if got := envMap(envVars(map[string]string{"GOGC": "200"})); got["GOGC"] != "200" {
+		t.Errorf("round trip = %v, want GOGC=200", got)
+	}
+}
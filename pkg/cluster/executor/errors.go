@@ -0,0 +1,59 @@
+package executor
+
+import (
+	"errors"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+// Errors returned by executors. They are wrapped with details, so match
+// them with errors.Is.
+var (
+	// ErrOperatorNotInstalled is returned when the Milvus Operator CRD is missing
+	ErrOperatorNotInstalled = errors.New("Milvus Operator is not installed")
+
+	// ErrInvalidComponent is returned for unknown components or components
+	// that do not exist in the cluster's deployment mode
+	ErrInvalidComponent = errors.New("invalid component")
+
+	// ErrAlreadyAtVersion is returned when upgrading to the running version
+	ErrAlreadyAtVersion = errors.New("cluster is already running this version")
+
+	// ErrMilvusExists is returned by Deploy when the Milvus resource already
+	// exists, e.g. after a deploy that failed before saving its metadata
+	ErrMilvusExists = k8s.ErrMilvusExists
+
+	// ErrNoPods is returned when a cluster has no pods
+	ErrNoPods = errors.New("no pods found")
+
+	// ErrTimeout is returned when waiting for the cluster to become healthy times out
+	ErrTimeout = errors.New("timeout waiting for cluster to become healthy")
+
+	// ErrDeleteTimeout is returned when waiting for the resources of a
+	// destroyed cluster to be deleted times out
+	ErrDeleteTimeout = errors.New("timeout waiting for cluster resources to be deleted")
+
+	// ErrNamespaceNotEmpty is returned by DeleteNamespace when the namespace
+	// holds resources of something other than the cluster
+	ErrNamespaceNotEmpty = errors.New("namespace is not empty")
+
+	// ErrNamespaceNotOwned is returned by DeleteNamespace for a namespace
+	// that was not created for the cluster
+	ErrNamespaceNotOwned = errors.New("namespace was not created by miup for this instance")
+
+	// ErrStorageClassNotFound is returned by CheckStorageClass when the
+	// topology's storage class does not exist in the cluster
+	ErrStorageClassNotFound = errors.New("storage class not found")
+
+	// ErrPermissionDenied is returned when the current user lacks RBAC
+	// permissions an operation needs
+	ErrPermissionDenied = errors.New("missing Kubernetes permissions")
+
+	// ErrDrainDeclined is returned by DrainNode when deleting a
+	// single-replica pod was declined, leaving it on the node
+	ErrDrainDeclined = errors.New("deleting a single-replica pod was declined")
+
+	// ErrWaitCancelled is returned when the context is cancelled while
+	// waiting for the cluster to become healthy
+	ErrWaitCancelled = errors.New("cancelled while waiting; cluster may still be deploying")
+)
@@ -19,24 +19,54 @@ type Executor interface {
 	// Destroy destroys the cluster and removes all data
 	Destroy(ctx context.Context) error
 
+	// WaitDeleted waits until the Milvus resource, its pods and its PVCs
+	// are gone after Destroy
+	WaitDeleted(ctx context.Context, timeout time.Duration) error
+
 	// Status returns the cluster status
 	Status(ctx context.Context) (string, error)
 
 	// IsRunning checks if the cluster is running
 	IsRunning(ctx context.Context) (bool, error)
 
-	// Logs retrieves logs from a service
-	Logs(ctx context.Context, service string, tail int) (string, error)
+	// Exists checks if the Milvus resource exists in the cluster
+	Exists(ctx context.Context) (bool, error)
+
+	// EnsureNamespace creates the cluster's namespace if it is missing and
+	// reports whether it did
+	EnsureNamespace(ctx context.Context) (bool, error)
+
+	// DeleteNamespace deletes the cluster's namespace if it was created for
+	// the cluster and nothing else uses it
+	DeleteNamespace(ctx context.Context) error
+
+	// Logs retrieves logs with the specified options
+	Logs(ctx context.Context, opts LogsOptions) (string, error)
+
+	// PodLogs retrieves logs for each pod separately
+	PodLogs(ctx context.Context, opts LogsOptions) ([]PodLogs, error)
 
 	// Scale scales a component with the specified options
 	Scale(ctx context.Context, component string, opts ScaleOptions) error
 
-	// GetReplicas returns the current replica count for each component
+	// GetReplicas returns the ready replica count for each component
 	GetReplicas(ctx context.Context) (map[string]int, error)
 
+	// GetReplicaCounts returns the desired and ready replica count for each component
+	GetReplicaCounts(ctx context.Context) (map[string]ReplicaCount, error)
+
+	// CPULoad returns the current CPU usage and requests of a component's pods
+	CPULoad(ctx context.Context, component string) (*CPULoad, error)
+
 	// Upgrade upgrades Milvus to the specified version
 	Upgrade(ctx context.Context, version string) error
 
+	// SetImage updates Milvus to the specified image reference
+	SetImage(ctx context.Context, image string) error
+
+	// Apply updates the running cluster to match the topology
+	Apply(ctx context.Context) error
+
 	// GetVersion returns the current Milvus version
 	GetVersion(ctx context.Context) (string, error)
 
@@ -46,13 +76,111 @@ type Executor interface {
 	// SetConfig updates the Milvus configuration
 	SetConfig(ctx context.Context, config map[string]interface{}) error
 
+	// ReplaceConfig replaces the Milvus configuration as a whole
+	ReplaceConfig(ctx context.Context, config map[string]interface{}) error
+
 	// Diagnose performs health diagnostics on the cluster
 	Diagnose(ctx context.Context) (*DiagnoseResult, error)
 
+	// Describe returns the full operator-reported status of the cluster
+	Describe(ctx context.Context) (*Description, error)
+
+	// Endpoint returns the connection details of the Milvus service
+	Endpoint(ctx context.Context) (*Endpoint, error)
+
+	// PodsOnNode returns the cluster's pods scheduled on a node
+	PodsOnNode(ctx context.Context, node string) ([]NodePod, error)
+
+	// DrainNode cordons a node and moves the cluster's pods off it, asking
+	// confirm before deleting a single-replica pod
+	DrainNode(ctx context.Context, node string, confirm func(pod NodePod) bool) ([]NodePod, error)
+
+	// SetExpiry records when the cluster's TTL runs out (nil for never)
+	SetExpiry(ctx context.Context, expiresAt *time.Time) error
+
 	// Reload triggers a configuration reload
 	// If config is provided, it merges the config before reloading
 	// If wait is true, it waits for all pods to become ready
 	Reload(ctx context.Context, opts ReloadOptions) error
+
+	// ExportCRD returns the Milvus custom resource as YAML
+	ExportCRD(ctx context.Context) ([]byte, error)
+
+	// CheckPermissions returns ErrPermissionDenied, listing what is missing,
+	// if the current user may not deploy and manage the cluster, including
+	// creating its namespace if createNamespace
+	CheckPermissions(ctx context.Context, createNamespace bool) error
+
+	// CheckStorageClass returns ErrStorageClassNotFound if the topology sets
+	// a storage class the cluster doesn't have
+	CheckStorageClass(ctx context.Context) error
+
+	// Events returns cluster-related events sorted by time
+	Events(ctx context.Context) ([]Event, error)
+
+	// WatchEvents calls fn for each cluster-related event as it occurs,
+	// until ctx is cancelled
+	WatchEvents(ctx context.Context, fn func(Event)) error
+
+	// PodManifests returns the YAML manifest of each pod keyed by pod name
+	PodManifests(ctx context.Context) (map[string][]byte, error)
+
+	// Footprint sums the CPU, memory and storage the cluster requests
+	Footprint(ctx context.Context) (*Footprint, error)
+
+	// ResizeVolumes expands the persistent volumes of an in-cluster dependency
+	ResizeVolumes(ctx context.Context, opts ResizeVolumesOptions) ([]VolumeResize, error)
+
+	// DebugForwards returns the ports forwarded by a debugging session
+	DebugForwards() []Forward
+
+	// PortForward forwards local ports to cluster services until ctx is cancelled
+	PortForward(ctx context.Context, address string, forwards []Forward, ready func(Forward)) error
+}
+
+// Event represents a cluster event
+type Event struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Reason  string    `json:"reason"`
+	Object  string    `json:"object"`
+	Message string    `json:"message"`
+	Count   int32     `json:"count,omitempty"`
+}
+
+// LogsOptions defines options for retrieving logs
+type LogsOptions struct {
+	// Service filters pods by service/component name (optional)
+	Service string
+
+	// Tail is the number of lines to show from the end of each pod's logs
+	Tail int
+
+	// ByComponent groups output under component headers
+	ByComponent bool
+
+	// Merge interleaves lines from all pods sorted by log timestamp
+	Merge bool
+
+	// Since only returns logs newer than this duration (0 means no limit)
+	Since time.Duration
+
+	// Grep only returns lines matching this regular expression (optional)
+	Grep string
+
+	// Operator returns the Milvus Operator's logs instead of the cluster's
+	Operator bool
+
+	// Timestamps prefixes each line with the time the kubelet recorded it
+	// at, which lines up logs of all pods and components
+	Timestamps bool
+
+	// Location is the timezone timestamps are shown in (UTC if nil)
+	Location *time.Location
+
+	// SinceRestart only returns the logs of each pod's current container,
+	// written since it last (re)started
+	SinceRestart bool
 }
 
 // ReloadOptions defines options for reloading configuration
@@ -148,6 +276,9 @@ type ScaleOptions struct {
 
 	// MemoryLimit is the memory limit (e.g., "8Gi", "1024Mi")
 	MemoryLimit string
+
+	// Env sets or overrides environment variables on the component
+	Env map[string]string
 }
 
 // HasReplicaChange returns true if replicas should be changed
@@ -160,7 +291,13 @@ func (o ScaleOptions) HasResourceChange() bool {
 	return o.CPURequest != "" || o.CPULimit != "" || o.MemoryRequest != "" || o.MemoryLimit != ""
 }
 
-// ComponentNames defines valid component names for scaling
+// HasEnvChange returns true if any environment variable should be changed
+func (o ScaleOptions) HasEnvChange() bool {
+	return len(o.Env) > 0
+}
+
+// ComponentNames defines valid component names for scaling. Components of
+// newer Milvus versions that the operator's CRD has are accepted as well.
 var ComponentNames = []string{
 	"proxy",
 	"querynode",
@@ -170,5 +307,7 @@ var ComponentNames = []string{
 	"querycoord",
 	"datacoord",
 	"indexcoord",
+	"mixcoord",
+	"streamingnode",
 	"standalone",
 }
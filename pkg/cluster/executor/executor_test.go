@@ -1,9 +1,17 @@
 package executor
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"slices"
 	"testing"
 	"time"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestCheckStatusConstants(t *testing.T) {
@@ -77,6 +85,8 @@ func TestComponentNames(t *testing.T) {
 		"querycoord",
 		"datacoord",
 		"indexcoord",
+		"mixcoord",
+		"streamingnode",
 		"standalone",
 	}
 
@@ -249,3 +259,218 @@ func TestReloadOptions(t *testing.T) {
 		t.Errorf("Timeout = %v, want 5m", opts.Timeout)
 	}
 }
+
+func TestSleepContext(t *testing.T) {
+	if err := sleepContext(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("sleepContext() error = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := sleepContext(ctx, time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("sleepContext() error = %v, want context.Canceled", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("sleepContext() should return promptly when cancelled")
+	}
+}
+
+func TestUnreadyPod(t *testing.T) {
+	pod := func(name string, ready corev1.ConditionStatus) corev1.Pod {
+		p := corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}}}
+		p.Name = name
+		return p
+	}
+
+	tests := []struct {
+		name string
+		pods []corev1.Pod
+		want string
+	}{
+		{name: "no pods", want: "etcd has no pods yet"},
+		{name: "ready", pods: []corev1.Pod{pod("etcd-0", corev1.ConditionTrue), pod("etcd-1", corev1.ConditionTrue)}},
+		{name: "initializing", pods: []corev1.Pod{pod("etcd-0", corev1.ConditionTrue), pod("etcd-1", corev1.ConditionFalse)}, want: "etcd pod etcd-1 is not ready"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unreadyPod("etcd", tt.pods); got != tt.want {
+				t.Errorf("unreadyPod() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependencySelectors(t *testing.T) {
+	e := &KubernetesExecutor{clusterName: "prod", spec: &spec.Specification{
+		MinioServers: []spec.MinioSpec{{Host: "s3.example.com"}},
+	}}
+
+	got := e.dependencySelectors()
+	if len(got) != 1 || got["etcd"] != "app.kubernetes.io/instance=prod-etcd" {
+		t.Errorf("dependencySelectors() = %v, want only the operator's etcd", got)
+	}
+}
+
+func TestResourceCheckUsage(t *testing.T) {
+	pod := func(cpu, memory string) *corev1.Pod {
+		return &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			}},
+		}}}}
+	}
+	usage := k8s.PodUsage{
+		Name:   "prod-milvus-querynode-0",
+		CPU:    resource.MustParse("500m"),
+		Memory: resource.MustParse("3800Mi"),
+	}
+
+	tests := []struct {
+		name   string
+		pod    *corev1.Pod
+		status CheckStatus
+		limit  string
+	}{
+		{"no pod", nil, CheckStatusOK, "none"},
+		{"within limits", pod("2", "8Gi"), CheckStatusOK, "cpu 2, memory 8Gi"},
+		{"memory near limit", pod("2", "4Gi"), CheckStatusWarning, "cpu 2, memory 4Gi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := resourceCheck(usage, tt.pod)
+			if check.Status != tt.status {
+				t.Errorf("Status = %s, want %s (%s)", check.Status, tt.status, check.Message)
+			}
+			if check.Limit != tt.limit {
+				t.Errorf("Limit = %q, want %q", check.Limit, tt.limit)
+			}
+		})
+	}
+}
+
+func TestSpecToMilvusConfig(t *testing.T) {
+	serverConfigs := map[string]any{
+		"common": map[string]any{"security": map[string]any{"authorizationEnabled": true}},
+		"proxy":  map[string]any{"maxNameLength": 255},
+	}
+	specification := &spec.Specification{
+		ServerConfigs: spec.ServerConfigs{Milvus: serverConfigs},
+		MilvusServers: []spec.MilvusSpec{{
+			Host:   "milvus",
+			Config: map[string]any{"proxy": map[string]any{"maxNameLength": 512}},
+		}},
+	}
+	specification.Global.TLS.Enabled = true
+
+	e := &KubernetesExecutor{clusterName: "prod", namespace: "milvus", spec: specification}
+	config := e.specToMilvus().Spec.Config
+
+	proxy, _ := config["proxy"].(map[string]any)
+	if proxy["maxNameLength"] != 512 {
+		t.Errorf("proxy.maxNameLength = %v, want the server's 512", proxy["maxNameLength"])
+	}
+	security, _ := config["common"].(map[string]any)["security"].(map[string]any)
+	if security["authorizationEnabled"] != true {
+		t.Errorf("common.security = %v, want authorizationEnabled from server_configs", security)
+	}
+	if security["tlsMode"] != 1 {
+		t.Errorf("common.security.tlsMode = %v, want 1 from TLS", security["tlsMode"])
+	}
+	if _, ok := config["tls"]; !ok {
+		t.Error("tls paths missing")
+	}
+
+	if _, ok := serverConfigs["common"].(map[string]any)["security"].(map[string]any)["tlsMode"]; ok {
+		t.Error("server_configs of the topology should not be modified")
+	}
+}
+
+func TestBuildComponentsResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      spec.DeployMode
+		component func(*spec.MilvusComponents) *spec.ComponentSpec
+		built     func(*k8s.MilvusComponents) *k8s.ComponentSpec
+	}{
+		{
+			name:      "distributed",
+			mode:      spec.ModeDistributed,
+			component: func(c *spec.MilvusComponents) *spec.ComponentSpec { return &c.QueryNode },
+			built:     func(c *k8s.MilvusComponents) *k8s.ComponentSpec { return c.QueryNode },
+		},
+		{
+			name:      "standalone",
+			mode:      spec.ModeStandalone,
+			component: func(c *spec.MilvusComponents) *spec.ComponentSpec { return &c.Standalone },
+			built:     func(c *k8s.MilvusComponents) *k8s.ComponentSpec { return c.Standalone },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := spec.MilvusSpec{Host: "milvus", Mode: tt.mode}
+			*tt.component(&server.Components) = spec.ComponentSpec{
+				Replicas:  2,
+				Resources: spec.ResourceSpec{CPU: "2", Memory: "4Gi"},
+			}
+			e := &KubernetesExecutor{clusterName: "prod", spec: &spec.Specification{MilvusServers: []spec.MilvusSpec{server}}}
+
+			components := e.buildComponents()
+			resources := tt.built(&components).Resources
+			if resources == nil || resources.Requests["cpu"] != "2" || resources.Requests["memory"] != "4Gi" {
+				t.Errorf("Resources = %+v, want requests cpu 2 and memory 4Gi", resources)
+			}
+			if resources != nil && resources.Limits != nil {
+				t.Errorf("Limits = %v, want none", resources.Limits)
+			}
+		})
+	}
+
+	e := &KubernetesExecutor{clusterName: "prod", spec: &spec.Specification{MilvusServers: []spec.MilvusSpec{{Host: "milvus", Mode: spec.ModeDistributed}}}}
+	if components := e.buildComponents(); components.Proxy.Resources != nil {
+		t.Errorf("Proxy.Resources = %+v, want nil without topology resources", components.Proxy.Resources)
+	}
+}
+
+func TestSpecToMilvusPersistence(t *testing.T) {
+	tests := []struct {
+		name         string
+		storageClass string
+		size         string
+		want         map[string]interface{}
+	}{
+		{"defaults", "", "", nil},
+		{"size", "", "20Gi", map[string]interface{}{"size": "20Gi"}},
+		{"storage class", "gp3", "", map[string]interface{}{"storageClass": "gp3"}},
+		{"both", "gp3", "20Gi", map[string]interface{}{"size": "20Gi", "storageClass": "gp3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specification := &spec.Specification{
+				MilvusServers: []spec.MilvusSpec{{Host: "milvus"}},
+				EtcdServers:   []spec.EtcdSpec{{Host: "localhost", Storage: tt.size}},
+				MinioServers:  []spec.MinioSpec{{Host: "localhost", Storage: tt.size}},
+			}
+			specification.Global.StorageClass = tt.storageClass
+			e := &KubernetesExecutor{clusterName: "prod", namespace: "milvus", spec: specification}
+
+			deps := e.specToMilvus().Spec.Dependencies
+			for name, values := range map[string]map[string]interface{}{
+				"etcd":  deps.Etcd.InCluster.Values,
+				"minio": deps.Storage.InCluster.Values,
+			} {
+				got, _ := values["persistence"].(map[string]interface{})
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("%s persistence = %v, want %v", name, got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+// ExpiresAtAnnotation records on the Milvus resource when the cluster's TTL
+// runs out (RFC 3339), so controllers outside miup can clean it up too
+const ExpiresAtAnnotation = "miup.io/expires-at"
+
+// setExpiryAnnotation sets or, for a nil expiry, removes the expiry annotation
+func setExpiryAnnotation(milvus *k8s.Milvus, expiresAt *time.Time) {
+	if expiresAt == nil {
+		delete(milvus.Annotations, ExpiresAtAnnotation)
+		return
+	}
+	if milvus.Annotations == nil {
+		milvus.Annotations = make(map[string]string)
+	}
+	milvus.Annotations[ExpiresAtAnnotation] = expiresAt.UTC().Format(time.RFC3339)
+}
+
+// SetExpiry updates the expiry annotation of the Milvus resource
+func (e *KubernetesExecutor) SetExpiry(ctx context.Context, expiresAt *time.Time) error {
+	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get Milvus cluster: %w", err)
+	}
+
+	setExpiryAnnotation(milvus, expiresAt)
+	if err := e.updateMilvus(ctx, milvus); err != nil {
+		return fmt.Errorf("failed to update Milvus cluster: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+func TestSetExpiryAnnotation(t *testing.T) {
+	milvus := &k8s.Milvus{}
+	expiresAt := time.Date(2025, 1, 10, 14, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	setExpiryAnnotation(milvus, &expiresAt)
+	if got := milvus.Annotations[ExpiresAtAnnotation]; got != "2025-01-10T13:00:00Z" {
+		t.Errorf("annotation = %q, want 2025-01-10T13:00:00Z", got)
+	}
+
+	setExpiryAnnotation(milvus, nil)
+	if _, ok := milvus.Annotations[ExpiresAtAnnotation]; ok {
+		t.Error("annotation should be removed for a nil expiry")
+	}
+}
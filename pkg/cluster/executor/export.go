@@ -0,0 +1,203 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+// tlsVolumeName is the volume configureTLS mounts the certificate secret as
+const tlsVolumeName = "tls-certs"
+
+// ExportSpec reconstructs the topology specification and Milvus version
+// from the live Milvus CRD, e.g. to restore lost local metadata
+func (e *KubernetesExecutor) ExportSpec(ctx context.Context) (*spec.Specification, string, error) {
+	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get Milvus cluster: %w", err)
+	}
+
+	specification, milvusVersion := MilvusToSpec(milvus)
+	return specification, milvusVersion, nil
+}
+
+// MilvusToSpec converts a Milvus CRD into a topology specification, the
+// inverse of the conversion used by Deploy. In-cluster etcd and MinIO are
+// represented by 127.0.0.1 hosts as in the generated templates.
+func MilvusToSpec(milvus *k8s.Milvus) (*spec.Specification, string) {
+	mode := spec.ModeStandalone
+	if milvus.Spec.Mode == k8s.MilvusModeCluster {
+		mode = spec.ModeDistributed
+	}
+
+	components := milvus.Spec.Components
+	s := &spec.Specification{
+		Global: spec.GlobalOptions{
+			Namespace: milvus.Namespace,
+		},
+		ServerConfigs: spec.ServerConfigs{
+			Milvus: milvus.Spec.Config,
+		},
+		MilvusServers: []spec.MilvusSpec{{
+			Host: "127.0.0.1",
+			Mode: mode,
+			Components: spec.MilvusComponents{
+				Env:        envMap(components.Env),
+				Standalone: componentFromCRD(components.Standalone),
+				Proxy:      componentFromCRD(components.Proxy),
+				RootCoord:  componentFromCRD(components.RootCoord),
+				QueryCoord: componentFromCRD(components.QueryCoord),
+				DataCoord:  componentFromCRD(components.DataCoord),
+				IndexCoord: componentFromCRD(components.IndexCoord),
+				QueryNode:  componentFromCRD(components.QueryNode),
+				DataNode:   componentFromCRD(components.DataNode),
+				IndexNode:  componentFromCRD(components.IndexNode),
+			},
+		}},
+	}
+
+	etcd := milvus.Spec.Dependencies.Etcd
+	if etcd.External && len(etcd.Endpoints) > 0 {
+		for _, endpoint := range etcd.Endpoints {
+			host, port := splitEndpoint(endpoint)
+			s.EtcdServers = append(s.EtcdServers, spec.EtcdSpec{Host: host, ClientPort: port})
+		}
+	} else {
+		s.EtcdServers = []spec.EtcdSpec{{Host: "127.0.0.1", Storage: persistenceSize(etcd.InCluster)}}
+	}
+
+	storage := milvus.Spec.Dependencies.Storage
+	if storage.External && storage.Endpoint != "" {
+		host, port := splitEndpoint(storage.Endpoint)
+		s.MinioServers = []spec.MinioSpec{{Host: host, Port: port, SecretRef: storage.SecretRef}}
+	} else {
+		s.MinioServers = []spec.MinioSpec{{Host: "127.0.0.1", Storage: persistenceSize(storage.InCluster)}}
+	}
+	minioFromCRD(&s.MinioServers[0], milvus)
+
+	for _, v := range components.Volumes {
+		if v.Name == tlsVolumeName && v.Secret != nil {
+			s.Global.TLS = spec.TLSConfig{
+				Enabled:    true,
+				SecretName: v.Secret.SecretName,
+				Mode:       tlsModeFromConfig(milvus.Spec.Config),
+			}
+			if _, ok := milvus.Spec.Config["internaltls"]; ok {
+				s.Global.TLS.InternalEnabled = true
+			}
+		}
+	}
+
+	return s, ImageVersion(components.Image)
+}
+
+// ImageVersion extracts the tag from a Milvus image reference
+// (e.g. "milvusdb/milvus:v2.5.4" -> "v2.5.4"). Custom builds may have tags
+// that aren't versions (e.g. "pr-1234"), which are returned as they are; an
+// image pinned by digest returns the digest.
+func ImageVersion(image string) string {
+	if image == "" {
+		return "unknown"
+	}
+	if i := strings.Index(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return "latest"
+	}
+	return image[i+1:]
+}
+
+func componentFromCRD(c *k8s.ComponentSpec) spec.ComponentSpec {
+	var out spec.ComponentSpec
+	if c == nil {
+		return out
+	}
+	if c.Replicas != nil {
+		out.Replicas = int(*c.Replicas)
+	}
+	if c.Resources != nil {
+		out.Resources.CPU = c.Resources.Requests["cpu"]
+		out.Resources.Memory = c.Resources.Requests["memory"]
+	}
+	out.Env = envMap(c.Env)
+	out.AntiAffinity = antiAffinityFromCRD(c.Affinity)
+	return out
+}
+
+// minioFromCRD sets the bucket and credentials of the MinIO server from the
+// minio section of the Milvus config, falling back to the credentials in the
+// in-cluster MinIO chart values. Credentials held in a secret are left there.
+func minioFromCRD(minio *spec.MinioSpec, milvus *k8s.Milvus) {
+	config, _ := milvus.Spec.Config["minio"].(map[string]interface{})
+	minio.Bucket = stringValue(config, "bucketName")
+	if minio.SecretRef != "" {
+		return
+	}
+	minio.AccessKey = stringValue(config, "accessKeyID")
+	minio.SecretKey = stringValue(config, "secretAccessKey")
+
+	if inCluster := milvus.Spec.Dependencies.Storage.InCluster; inCluster != nil {
+		if minio.AccessKey == "" {
+			minio.AccessKey = firstString(inCluster.Values, "accessKey", "rootUser")
+		}
+		if minio.SecretKey == "" {
+			minio.SecretKey = firstString(inCluster.Values, "secretKey", "rootPassword")
+		}
+	}
+}
+
+// stringValue returns the string under key in values, or "" if there is none
+func stringValue(values map[string]interface{}, key string) string {
+	value, _ := values[key].(string)
+	return value
+}
+
+// firstString returns the first of keys set to a string in values
+func firstString(values map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value := stringValue(values, key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// persistenceSize reads the PVC size from in-cluster dependency chart values
+func persistenceSize(inCluster *k8s.InClusterConfig) string {
+	if inCluster == nil {
+		return ""
+	}
+	persistence, _ := inCluster.Values["persistence"].(map[string]interface{})
+	size, _ := persistence["size"].(string)
+	return size
+}
+
+func splitEndpoint(endpoint string) (string, int) {
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint, 0
+	}
+	port, _ := strconv.Atoi(portStr)
+	return host, port
+}
+
+func tlsModeFromConfig(config map[string]interface{}) int {
+	common, _ := config["common"].(map[string]interface{})
+	security, _ := common["security"].(map[string]interface{})
+	switch mode := security["tlsMode"].(type) {
+	case int:
+		return mode
+	case int64:
+		return int(mode)
+	case float64:
+		return int(mode)
+	}
+	return 0
+}
@@ -0,0 +1,173 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+func TestImageVersion(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"milvusdb/milvus:v2.5.4", "v2.5.4"},
+		{"registry.local:5000/milvusdb/milvus:v2.4.0", "v2.4.0"},
+		{"registry.local:5000/milvusdb/milvus", "latest"},
+		{"milvusdb/milvus", "latest"},
+		{"myrepo/milvus:pr-1234", "pr-1234"},
+		{"myrepo/milvus@sha256:0123abcd", "sha256:0123abcd"},
+		{"", "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := ImageVersion(tt.image); got != tt.want {
+				t.Errorf("ImageVersion(%q) = %s, want %s", tt.image, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMilvusToSpec(t *testing.T) {
+	two := int32(2)
+	milvus := &k8s.Milvus{
+		Spec: k8s.MilvusSpec{
+			Mode: k8s.MilvusModeCluster,
+			Dependencies: k8s.MilvusDependencies{
+				Etcd: k8s.EtcdConfig{
+					External:  true,
+					Endpoints: []string{"etcd-0:2379", "etcd-1:2379"},
+				},
+			},
+			Components: k8s.MilvusComponents{
+				Image:     "milvusdb/milvus:v2.5.4",
+				QueryNode: &k8s.ComponentSpec{Replicas: &two},
+			},
+		},
+	}
+	milvus.Namespace = "prod"
+
+	s, milvusVersion := MilvusToSpec(milvus)
+
+	if milvusVersion != "v2.5.4" {
+		t.Errorf("version = %s, want v2.5.4", milvusVersion)
+	}
+	if s.Global.Namespace != "prod" {
+		t.Errorf("namespace = %s, want prod", s.Global.Namespace)
+	}
+	if s.GetMode() != spec.ModeDistributed {
+		t.Errorf("mode = %s, want %s", s.GetMode(), spec.ModeDistributed)
+	}
+	if got := s.MilvusServers[0].Components.QueryNode.Replicas; got != 2 {
+		t.Errorf("querynode replicas = %d, want 2", got)
+	}
+	if len(s.EtcdServers) != 2 || s.EtcdServers[1].Host != "etcd-1" || s.EtcdServers[1].ClientPort != 2379 {
+		t.Errorf("etcd servers = %+v, want external endpoints", s.EtcdServers)
+	}
+	if len(s.MinioServers) != 1 || s.MinioServers[0].Host != "127.0.0.1" {
+		t.Errorf("minio servers = %+v, want in-cluster", s.MinioServers)
+	}
+	if err := s.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestMinioFromCRD(t *testing.T) {
+	config := map[string]interface{}{"minio": map[string]interface{}{
+		"bucketName":      "vectors",
+		"accessKeyID":     "milvus",
+		"secretAccessKey": "s3cret",
+	}}
+
+	tests := []struct {
+		name    string
+		storage k8s.StorageConfig
+		config  map[string]interface{}
+		want    spec.MinioSpec
+	}{
+		{
+			name:    "from the Milvus config",
+			storage: k8s.StorageConfig{InCluster: &k8s.InClusterConfig{}},
+			config:  config,
+			want:    spec.MinioSpec{Host: "127.0.0.1", Bucket: "vectors", AccessKey: "milvus", SecretKey: "s3cret"},
+		},
+		{
+			name: "from the chart values",
+			storage: k8s.StorageConfig{InCluster: &k8s.InClusterConfig{Values: map[string]interface{}{
+				"rootUser": "admin", "rootPassword": "hunter2",
+			}}},
+			want: spec.MinioSpec{Host: "127.0.0.1", AccessKey: "admin", SecretKey: "hunter2"},
+		},
+		{
+			name:    "credentials in a secret",
+			storage: k8s.StorageConfig{External: true, Endpoint: "minio.infra.svc:9000", SecretRef: "minio-creds"},
+			config:  config,
+			want:    spec.MinioSpec{Host: "minio.infra.svc", Port: 9000, SecretRef: "minio-creds", Bucket: "vectors"},
+		},
+		{
+			name: "operator defaults",
+			want: spec.MinioSpec{Host: "127.0.0.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := MilvusToSpec(&k8s.Milvus{Spec: k8s.MilvusSpec{
+				Config:       tt.config,
+				Dependencies: k8s.MilvusDependencies{Storage: tt.storage},
+			}})
+			if got := s.MinioServers[0]; got != tt.want {
+				t.Errorf("minio server = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPersistenceSizeRoundTrip(t *testing.T) {
+	e := &KubernetesExecutor{spec: &spec.Specification{
+		MilvusServers: []spec.MilvusSpec{{Host: "127.0.0.1", Mode: spec.ModeStandalone}},
+		EtcdServers:   []spec.EtcdSpec{{Host: "127.0.0.1", Storage: "20Gi"}},
+		MinioServers:  []spec.MinioSpec{{Host: "127.0.0.1", Storage: "200Gi"}},
+	}}
+
+	milvus := &k8s.Milvus{Spec: k8s.MilvusSpec{
+		Dependencies: k8s.MilvusDependencies{
+			Etcd:    e.buildEtcdConfig(),
+			Storage: e.buildStorageConfig(),
+		},
+	}}
+
+	s, _ := MilvusToSpec(milvus)
+	if got := s.EtcdServers[0].Storage; got != "20Gi" {
+		t.Errorf("etcd storage = %s, want 20Gi", got)
+	}
+	if got := s.MinioServers[0].Storage; got != "200Gi" {
+		t.Errorf("minio storage = %s, want 200Gi", got)
+	}
+}
+
+func TestHelmDependencies(t *testing.T) {
+	e := &KubernetesExecutor{spec: &spec.Specification{
+		MilvusServers: []spec.MilvusSpec{{Host: "127.0.0.1", Mode: spec.ModeStandalone}},
+		EtcdServers:   []spec.EtcdSpec{{Service: "etcd.infra.svc", ClientPort: 2379}},
+		MinioServers:  []spec.MinioSpec{{Service: "minio.infra.svc", Port: 9000, SecretRef: "minio-creds"}},
+	}}
+
+	etcd := e.buildEtcdConfig()
+	if !etcd.External || len(etcd.Endpoints) != 1 || etcd.Endpoints[0] != "etcd.infra.svc:2379" {
+		t.Errorf("etcd config = %+v, want external etcd.infra.svc:2379", etcd)
+	}
+	storage := e.buildStorageConfig()
+	if !storage.External || storage.Endpoint != "minio.infra.svc:9000" || storage.SecretRef != "minio-creds" {
+		t.Errorf("storage config = %+v, want external minio.infra.svc:9000 with secret minio-creds", storage)
+	}
+
+	s, _ := MilvusToSpec(&k8s.Milvus{Spec: k8s.MilvusSpec{
+		Dependencies: k8s.MilvusDependencies{Etcd: etcd, Storage: storage},
+	}})
+	if got := s.MinioServers[0]; got.Address() != "minio.infra.svc:9000" || got.SecretRef != "minio-creds" {
+		t.Errorf("exported minio = %+v, want the service and secret", got)
+	}
+}
@@ -0,0 +1,43 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/k8s"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newFakeKubernetesExecutor returns an executor for cluster prod in namespace
+// milvus backed by fake clientsets, holding the Milvus resources in milvuses
+// and the other objects in objects
+func newFakeKubernetesExecutor(t *testing.T, milvuses []*k8s.Milvus, objects ...runtime.Object) (*KubernetesExecutor, *fake.Clientset) {
+	t.Helper()
+	e, clientset, _ := newFakeExecutorClients(t, milvuses, objects...)
+	return e, clientset
+}
+
+// newFakeExecutorClients is newFakeKubernetesExecutor also returning the fake
+// dynamic client holding the Milvus resources, e.g. to add reactors
+func newFakeExecutorClients(t *testing.T, milvuses []*k8s.Milvus, objects ...runtime.Object) (*KubernetesExecutor, *fake.Clientset, *dynamicfake.FakeDynamicClient) {
+	t.Helper()
+
+	clientset := fake.NewSimpleClientset(objects...)
+	gvr := schema.GroupVersionResource{Group: k8s.MilvusGroup, Version: k8s.MilvusVersion, Resource: k8s.MilvusResource}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: k8s.MilvusKind + "List"})
+
+	client := k8s.NewClientFromInterfaces(clientset, dynamicClient, "milvus")
+	for _, m := range milvuses {
+		if err := client.CreateMilvus(context.Background(), m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e := &KubernetesExecutor{client: client, clusterName: "prod", namespace: "milvus", spec: &spec.Specification{}}
+	return e, clientset, dynamicClient
+}
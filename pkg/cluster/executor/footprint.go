@@ -0,0 +1,175 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// componentOrder is the display order of Milvus components
+var componentOrder = []string{"standalone", "proxy", "mixcoord", "rootcoord", "querycoord", "datacoord", "indexcoord", "querynode", "datanode", "indexnode", "streamingnode"}
+
+// SortComponents sorts component names into display order; components
+// missing from it follow in alphabetical order
+func SortComponents(names []string) {
+	rank := func(name string) int {
+		if i := slices.Index(componentOrder, name); i >= 0 {
+			return i
+		}
+		return len(componentOrder)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if ri, rj := rank(names[i]), rank(names[j]); ri != rj {
+			return ri < rj
+		}
+		return names[i] < names[j]
+	})
+}
+
+// Resources are CPU (in cores) and memory (in bytes) requests and limits
+type Resources struct {
+	CPURequest    float64 `json:"cpu_request"`
+	CPULimit      float64 `json:"cpu_limit"`
+	MemoryRequest int64   `json:"memory_request"`
+	MemoryLimit   int64   `json:"memory_limit"`
+}
+
+func (r *Resources) add(o Resources) {
+	r.CPURequest += o.CPURequest
+	r.CPULimit += o.CPULimit
+	r.MemoryRequest += o.MemoryRequest
+	r.MemoryLimit += o.MemoryLimit
+}
+
+func (r Resources) times(n int) Resources {
+	return Resources{
+		CPURequest:    r.CPURequest * float64(n),
+		CPULimit:      r.CPULimit * float64(n),
+		MemoryRequest: r.MemoryRequest * int64(n),
+		MemoryLimit:   r.MemoryLimit * int64(n),
+	}
+}
+
+// ComponentFootprint is the resources of all replicas of a component
+type ComponentFootprint struct {
+	Name     string `json:"name"`
+	Replicas int    `json:"replicas"`
+	Resources
+
+	// Unset is true when the component has no resource requests, so it
+	// adds nothing to the totals although its pods still use resources
+	Unset bool `json:"unset,omitempty"`
+}
+
+// VolumeFootprint is the requested size of a persistent volume claim
+type VolumeFootprint struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// Footprint is the resources an instance requests from the Kubernetes
+// cluster: the CPU and memory of the Milvus components (from the CRD) and
+// the volumes of its in-cluster dependencies
+type Footprint struct {
+	Components []ComponentFootprint `json:"components"`
+	Volumes    []VolumeFootprint    `json:"volumes"`
+
+	Total   Resources `json:"total"`
+	Storage int64     `json:"storage"`
+}
+
+// Footprint sums the resource requests and limits of the cluster
+func (e *KubernetesExecutor) Footprint(ctx context.Context) (*Footprint, error) {
+	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Milvus cluster: %w", err)
+	}
+
+	pvcs, err := e.client.ListPVCs(ctx, e.namespace)
+	if err != nil {
+		return nil, err
+	}
+	var volumes []corev1.PersistentVolumeClaim
+	for _, component := range VolumeComponents {
+		volumes = append(volumes, selectPVCs(pvcs, e.clusterName, component)...)
+	}
+
+	return footprint(milvus, volumes)
+}
+
+// footprint computes the footprint of a Milvus resource and its volumes
+func footprint(milvus *k8s.Milvus, pvcs []corev1.PersistentVolumeClaim) (*Footprint, error) {
+	f := &Footprint{Components: []ComponentFootprint{}, Volumes: []VolumeFootprint{}}
+
+	specs := componentSpecs(milvus)
+	names := make([]string, 0, len(specs))
+	for name, s := range specs {
+		if s != nil {
+			names = append(names, name)
+		}
+	}
+	SortComponents(names)
+
+	for _, name := range names {
+		s := specs[name]
+		c := ComponentFootprint{Name: name, Replicas: 1}
+		if s.Replicas != nil {
+			c.Replicas = int(*s.Replicas)
+		}
+
+		var perReplica Resources
+		if s.Resources != nil {
+			var err error
+			if perReplica, err = parseResources(s.Resources); err != nil {
+				return nil, fmt.Errorf("invalid resources of %s: %w", name, err)
+			}
+		}
+		c.Unset = perReplica.CPURequest == 0 && perReplica.MemoryRequest == 0
+		c.Resources = perReplica.times(c.Replicas)
+
+		f.Total.add(c.Resources)
+		f.Components = append(f.Components, c)
+	}
+
+	for _, pvc := range pvcs {
+		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		f.Volumes = append(f.Volumes, VolumeFootprint{Name: pvc.Name, Size: size.Value()})
+		f.Storage += size.Value()
+	}
+
+	return f, nil
+}
+
+// parseResources parses the cpu and memory quantities of a component
+func parseResources(r *k8s.ResourceRequirements) (Resources, error) {
+	var out Resources
+	for _, q := range []struct {
+		value string
+		cpu   *float64
+		mem   *int64
+	}{
+		{value: r.Requests["cpu"], cpu: &out.CPURequest},
+		{value: r.Limits["cpu"], cpu: &out.CPULimit},
+		{value: r.Requests["memory"], mem: &out.MemoryRequest},
+		{value: r.Limits["memory"], mem: &out.MemoryLimit},
+	} {
+		if q.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(q.value)
+		if err != nil {
+			return out, fmt.Errorf("invalid quantity '%s': %w", q.value, err)
+		}
+		if q.cpu != nil {
+			*q.cpu = float64(quantity.MilliValue()) / 1000
+		} else {
+			*q.mem = quantity.Value()
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,83 @@
+package executor
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFootprint(t *testing.T) {
+	two := int32(2)
+	milvus := &k8s.Milvus{
+		Spec: k8s.MilvusSpec{
+			Mode: k8s.MilvusModeCluster,
+			Components: k8s.MilvusComponents{
+				Proxy: &k8s.ComponentSpec{
+					Resources: &k8s.ResourceRequirements{
+						Requests: map[string]string{"cpu": "500m", "memory": "1Gi"},
+						Limits:   map[string]string{"cpu": "1", "memory": "2Gi"},
+					},
+				},
+				QueryNode: &k8s.ComponentSpec{
+					Replicas: &two,
+					Resources: &k8s.ResourceRequirements{
+						Requests: map[string]string{"cpu": "2", "memory": "8Gi"},
+					},
+				},
+				DataNode: &k8s.ComponentSpec{},
+			},
+		},
+	}
+	pvc := func(name, size string) corev1.PersistentVolumeClaim {
+		p := corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		p.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)}
+		return p
+	}
+
+	f, err := footprint(milvus, []corev1.PersistentVolumeClaim{pvc("data-prod-etcd-0", "10Gi"), pvc("prod-minio", "100Gi")})
+	if err != nil {
+		t.Fatalf("footprint() error = %v", err)
+	}
+
+	var names []string
+	for _, c := range f.Components {
+		names = append(names, c.Name)
+	}
+	if len(names) != 3 || names[0] != "proxy" || names[1] != "querynode" || names[2] != "datanode" {
+		t.Errorf("components = %v, want proxy, querynode, datanode", names)
+	}
+
+	querynode := f.Components[1]
+	if querynode.Replicas != 2 || querynode.CPURequest != 4 || querynode.MemoryRequest != 16<<30 {
+		t.Errorf("querynode = %+v, want 2 replicas requesting 4 cores and 16Gi", querynode)
+	}
+	if !f.Components[2].Unset || f.Components[0].Unset {
+		t.Errorf("only datanode should be unset: %+v", f.Components)
+	}
+
+	want := Resources{CPURequest: 4.5, CPULimit: 1, MemoryRequest: 17 << 30, MemoryLimit: 2 << 30}
+	if f.Total != want {
+		t.Errorf("total = %+v, want %+v", f.Total, want)
+	}
+	if f.Storage != 110<<30 || len(f.Volumes) != 2 {
+		t.Errorf("storage = %d in %d volumes, want 110Gi in 2", f.Storage, len(f.Volumes))
+	}
+
+	milvus.Spec.Components.Proxy.Resources.Requests["cpu"] = "lots"
+	if _, err := footprint(milvus, nil); err == nil {
+		t.Error("footprint() with an invalid quantity should fail")
+	}
+}
+
+func TestSortComponents(t *testing.T) {
+	names := []string{"zetanode", "querynode", "streamingnode", "alphanode", "proxy", "mixcoord"}
+	SortComponents(names)
+	want := []string{"proxy", "mixcoord", "querynode", "streamingnode", "alphanode", "zetanode"}
+	if !slices.Equal(names, want) {
+		t.Errorf("SortComponents() = %v, want %v", names, want)
+	}
+}
@@ -2,13 +2,24 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	localexec "github.com/mmga-lab/miup/pkg/executor"
 	"github.com/mmga-lab/miup/pkg/k8s"
+	"github.com/mmga-lab/miup/pkg/logger"
+	"github.com/mmga-lab/miup/pkg/timing"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // KubernetesExecutor executes cluster operations on Kubernetes using Milvus Operator
@@ -18,7 +29,17 @@ type KubernetesExecutor struct {
 	namespace     string
 	spec          *spec.Specification
 	milvusVersion string
+	image         string
 	withMonitor   bool
+	apply         bool
+	expiresAt     *time.Time
+
+	// plan, if set, records updates instead of applying them
+	plan *Plan
+
+	// cleanup is what the operator deletes along with the Milvus resource,
+	// read by Destroy for WaitDeleted
+	cleanup *operatorCleanup
 }
 
 // KubernetesOptions contains options for creating a Kubernetes executor
@@ -30,6 +51,20 @@ type KubernetesOptions struct {
 	Spec          *spec.Specification
 	MilvusVersion string
 	WithMonitor   bool
+
+	// Image is a full Milvus image reference that takes precedence over
+	// MilvusVersion, e.g. one set with 'miup instance set-image'
+	Image string
+
+	// Apply makes Deploy update an existing Milvus resource instead of failing
+	Apply bool
+
+	// ExpiresAt is recorded in the ExpiresAtAnnotation of a deployed cluster
+	ExpiresAt *time.Time
+
+	// Plan makes updates of the Milvus resource a dry run: they are recorded
+	// in it instead of applied
+	Plan *Plan
 }
 
 // NewKubernetesExecutor creates a new Kubernetes executor
@@ -54,27 +89,32 @@ func NewKubernetesExecutor(opts KubernetesOptions) (*KubernetesExecutor, error)
 		namespace:     namespace,
 		spec:          opts.Spec,
 		milvusVersion: opts.MilvusVersion,
+		image:         opts.Image,
 		withMonitor:   opts.WithMonitor,
+		apply:         opts.Apply,
+		expiresAt:     opts.ExpiresAt,
+		plan:          opts.Plan,
 	}, nil
 }
 
 // Deploy deploys the Milvus cluster using Milvus Operator
 func (e *KubernetesExecutor) Deploy(ctx context.Context) error {
-	// Check if Milvus Operator is installed
-	installed, err := e.client.CheckMilvusOperatorInstalled(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to check Milvus Operator: %w", err)
-	}
-	if !installed {
-		return fmt.Errorf("Milvus Operator is not installed. Please install it first:\n" +
-			"  kubectl apply -f https://raw.githubusercontent.com/zilliztech/milvus-operator/main/deploy/manifests/deployment.yaml")
+	if err := e.preflight(ctx); err != nil {
+		return err
 	}
 
 	// Convert spec to Milvus CRD
 	milvus := e.specToMilvus()
 
 	// Create the Milvus resource
-	if err := e.client.CreateMilvus(ctx, milvus); err != nil {
+	create := e.client.CreateMilvus
+	if e.apply {
+		create = e.client.ApplyMilvus
+	}
+	endCreate := timing.Start(ctx, "create Milvus resource")
+	err := create(ctx, milvus)
+	endCreate()
+	if err != nil {
 		return fmt.Errorf("failed to create Milvus cluster: %w", err)
 	}
 
@@ -82,6 +122,28 @@ func (e *KubernetesExecutor) Deploy(ctx context.Context) error {
 	return e.waitForReady(ctx, 10*time.Minute)
 }
 
+// preflight checks that the cluster can take the deployment: the operator
+// is installed and the zones the topology needs exist. The storage class is
+// checked by CheckStorageClass before any local state is written.
+func (e *KubernetesExecutor) preflight(ctx context.Context) error {
+	defer timing.Start(ctx, "preflight checks")()
+
+	// Check if Milvus Operator is installed
+	installed, err := e.client.CheckMilvusOperatorInstalled(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check Milvus Operator: %w", err)
+	}
+	if !installed {
+		return fmt.Errorf("%w. Please install it first:\n"+
+			"  kubectl apply -f https://raw.githubusercontent.com/zilliztech/milvus-operator/main/deploy/manifests/deployment.yaml", ErrOperatorNotInstalled)
+	}
+
+	if e.spec.UsesZoneAntiAffinity() {
+		return e.checkZones(ctx)
+	}
+	return nil
+}
+
 // Start is a no-op for Kubernetes (Operator manages state)
 func (e *KubernetesExecutor) Start(ctx context.Context) error {
 	// Check current status
@@ -99,6 +161,12 @@ func (e *KubernetesExecutor) Start(ctx context.Context) error {
 	return e.waitForReady(ctx, 5*time.Minute)
 }
 
+// stopComponents are the components Stop scales to zero in cluster mode
+var stopComponents = []string{"proxy", "querynode", "datanode", "indexnode", "streamingnode"}
+
+// newerComponents are the components of Milvus 2.5+
+var newerComponents = []string{"mixcoord", "streamingnode"}
+
 // Stop scales down the Milvus cluster (set replicas to 0)
 func (e *KubernetesExecutor) Stop(ctx context.Context) error {
 	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
@@ -109,38 +177,39 @@ func (e *KubernetesExecutor) Stop(ctx context.Context) error {
 	// Scale down all components to 0
 	zero := int32(0)
 	if milvus.Spec.Mode == k8s.MilvusModeStandalone {
-		if milvus.Spec.Components.Standalone == nil {
-			milvus.Spec.Components.Standalone = &k8s.ComponentSpec{}
-		}
-		milvus.Spec.Components.Standalone.Replicas = &zero
+		milvus.Spec.Components.Component("standalone").Replicas = &zero
 	} else {
-		if milvus.Spec.Components.Proxy == nil {
-			milvus.Spec.Components.Proxy = &k8s.ComponentSpec{}
-		}
-		milvus.Spec.Components.Proxy.Replicas = &zero
-
-		if milvus.Spec.Components.QueryNode == nil {
-			milvus.Spec.Components.QueryNode = &k8s.ComponentSpec{}
-		}
-		milvus.Spec.Components.QueryNode.Replicas = &zero
-
-		if milvus.Spec.Components.DataNode == nil {
-			milvus.Spec.Components.DataNode = &k8s.ComponentSpec{}
-		}
-		milvus.Spec.Components.DataNode.Replicas = &zero
-
-		if milvus.Spec.Components.IndexNode == nil {
-			milvus.Spec.Components.IndexNode = &k8s.ComponentSpec{}
+		specs := milvus.Spec.Components.ComponentSpecs()
+		for _, name := range stopComponents {
+			// Older operators reject components of newer Milvus versions,
+			// so those are only scaled down if the resource has them
+			if specs[name] != nil || !slices.Contains(newerComponents, name) {
+				milvus.Spec.Components.Component(name).Replicas = &zero
+			}
 		}
-		milvus.Spec.Components.IndexNode.Replicas = &zero
 	}
 
-	return e.client.UpdateMilvus(ctx, milvus)
+	return e.updateMilvus(ctx, milvus)
 }
 
-// Destroy deletes the Milvus cluster
+// Destroy deletes the Milvus cluster. A Milvus resource that is already gone,
+// e.g. after a destroy whose wait timed out, counts as deleted.
 func (e *KubernetesExecutor) Destroy(ctx context.Context) error {
-	return e.client.DeleteMilvus(ctx, e.clusterName, e.namespace)
+	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	switch {
+	case k8s.IsNotFound(err):
+		logger.Info("Milvus resource '%s' is already deleted", e.clusterName)
+		return nil
+	case err != nil:
+		logger.Debug("Failed to read the deletion policy of '%s': %v", e.clusterName, err)
+	default:
+		e.cleanup = cleanupOf(milvus)
+	}
+
+	if err := e.client.DeleteMilvus(ctx, e.clusterName, e.namespace); err != nil && !k8s.IsNotFound(err) {
+		return err
+	}
+	return nil
 }
 
 // Status returns the cluster status
@@ -183,67 +252,266 @@ func (e *KubernetesExecutor) Status(ctx context.Context) (string, error) {
 }
 
 // IsRunning checks if the cluster is running
+// A missing Milvus resource is reported as not running; other API errors
+// (after retries) are returned so callers can tell "gone" from "unknown".
 func (e *KubernetesExecutor) IsRunning(ctx context.Context) (bool, error) {
 	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
 	if err != nil {
-		return false, nil
+		if k8s.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
 	}
 	return milvus.Status.Status == "Healthy", nil
 }
 
+// Exists checks if the Milvus resource exists, e.g. one created outside miup
+func (e *KubernetesExecutor) Exists(ctx context.Context) (bool, error) {
+	_, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		if k8s.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // Logs retrieves logs from a service
-func (e *KubernetesExecutor) Logs(ctx context.Context, service string, tail int) (string, error) {
-	pods, err := e.client.GetMilvusPods(ctx, e.clusterName, e.namespace)
+func (e *KubernetesExecutor) Logs(ctx context.Context, opts LogsOptions) (string, error) {
+	logs, err := e.PodLogs(ctx, opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to get pods: %w", err)
+		return "", err
 	}
 
-	if len(pods) == 0 {
-		return "", fmt.Errorf("no pods found for cluster %s", e.clusterName)
+	switch {
+	case opts.Merge:
+		return MergeLogs(logs), nil
+	case opts.ByComponent && !opts.Operator:
+		return FormatLogsByComponent(e.clusterName, logs), nil
+	default:
+		return FormatLogsByPod(logs), nil
 	}
+}
 
-	var sb strings.Builder
+// PodLogs retrieves logs for each pod of the cluster
+func (e *KubernetesExecutor) PodLogs(ctx context.Context, opts LogsOptions) ([]PodLogs, error) {
+	namespace := e.namespace
+	var pods []string
+	var err error
+	if opts.Operator {
+		namespace, pods, err = e.operatorPods(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		pods, err = e.client.GetMilvusPods(ctx, e.clusterName, e.namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pods: %w", err)
+		}
+		if len(pods) == 0 {
+			return nil, fmt.Errorf("%w for cluster %s", ErrNoPods, e.clusterName)
+		}
+	}
+
+	var grep *regexp.Regexp
+	if opts.Grep != "" {
+		grep, err = regexp.Compile(opts.Grep)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grep pattern: %w", err)
+		}
+	}
+
+	var selected []string
 	for _, pod := range pods {
 		// Filter by service if specified
-		if service != "" && !strings.Contains(pod, service) {
+		if opts.Service != "" && !strings.Contains(pod, opts.Service) {
 			continue
 		}
+		selected = append(selected, pod)
+	}
+	sort.Strings(selected)
+
+	return fetchPodLogs(ctx, selected, logFetchConcurrency, func(ctx context.Context, pod string) PodLogs {
+		return e.podLogs(ctx, namespace, pod, opts, grep)
+	}), nil
+}
 
-		logs, err := e.client.GetPodLogs(ctx, e.namespace, pod, "", int64(tail))
+// podLogs retrieves the logs of one pod
+func (e *KubernetesExecutor) podLogs(ctx context.Context, namespace, pod string, opts LogsOptions, grep *regexp.Regexp) PodLogs {
+	logOpts := k8s.LogOptions{
+		TailLines:    int64(opts.Tail),
+		SinceSeconds: sinceSeconds(opts.Since),
+		Timestamps:   opts.Timestamps,
+	}
+	if opts.SinceRestart {
+		started, err := e.client.ContainerStartTime(ctx, namespace, pod, "")
 		if err != nil {
-			sb.WriteString(fmt.Sprintf("--- %s (error: %v) ---\n", pod, err))
-			continue
+			return PodLogs{Pod: pod, Err: err}
 		}
+		logOpts.SinceTime = &started
+	}
 
-		sb.WriteString(fmt.Sprintf("--- %s ---\n%s\n", pod, logs))
+	podLogs, err := e.client.GetPodLogs(ctx, namespace, pod, logOpts)
+	if opts.Timestamps {
+		podLogs = NormalizeTimestamps(podLogs, opts.Location)
 	}
+	return PodLogs{Pod: pod, Logs: localexec.FilterLines(podLogs, grep), Err: err}
+}
 
-	return sb.String(), nil
+// sinceSeconds converts a --since duration to whole seconds for the log API,
+// rounding up so that a duration below a second doesn't become 0, which
+// means no limit
+func sinceSeconds(since time.Duration) int64 {
+	return int64((since + time.Second - 1) / time.Second)
 }
 
-// waitForReady waits for the cluster to become healthy
+// operatorPods returns the namespace and pods of the Milvus Operator
+// deployment, found the same way 'miup instance check' finds it
+func (e *KubernetesExecutor) operatorPods(ctx context.Context) (string, []string, error) {
+	deploy, err := e.client.FindMilvusOperator(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	if deploy == nil {
+		return "", nil, fmt.Errorf("%w: no %s deployment in namespaces %s", ErrOperatorNotInstalled,
+			k8s.MilvusOperatorDeployment, strings.Join(k8s.MilvusOperatorNamespaces, ", "))
+	}
+
+	pods, err := e.client.GetDeploymentPods(ctx, deploy)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get operator pods: %w", err)
+	}
+	if len(pods) == 0 {
+		return "", nil, fmt.Errorf("%w for Milvus Operator in namespace %s", ErrNoPods, deploy.Namespace)
+	}
+	return deploy.Namespace, pods, nil
+}
+
+// waitForReady waits for the cluster to become healthy and the pods of the
+// dependencies the operator deployed to be ready: the operator can report
+// the cluster healthy while etcd or MinIO are still initializing, and
+// connecting fails until they are. If ctx is cancelled the returned error
+// wraps both ErrWaitCancelled and ctx.Err(), and reports the last status
+// observed.
 func (e *KubernetesExecutor) waitForReady(ctx context.Context, timeout time.Duration) error {
+	defer timing.Start(ctx, "wait for ready")()
+
 	deadline := time.Now().Add(timeout)
+	lastStatus := "unknown"
+	ready := make(map[string]bool)
 
 	for time.Now().Before(deadline) {
 		milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
-		if err != nil {
-			time.Sleep(5 * time.Second)
+		if err == nil {
+			e.emitReadyComponents(milvus, ready)
+			if milvus.Status.Status == "Healthy" {
+				waiting, err := e.unreadyDependency(ctx)
+				if err == nil && waiting == "" {
+					return nil
+				}
+				if waiting != "" {
+					lastStatus = "Healthy, but " + waiting
+				}
+			} else if milvus.Status.Status != "" {
+				lastStatus = milvus.Status.Status
+			}
+		}
+
+		if err := sleepContext(ctx, 5*time.Second); err != nil {
+			return fmt.Errorf("%w (last status: %s): %w", ErrWaitCancelled, lastStatus, err)
+		}
+	}
+
+	return fmt.Errorf("%w (last status: %s)", ErrTimeout, lastStatus)
+}
+
+// emitReadyComponents emits a component_ready event for each component
+// whose replicas are all ready and that isn't in ready yet, adding it
+func (e *KubernetesExecutor) emitReadyComponents(milvus *k8s.Milvus, ready map[string]bool) {
+	for _, c := range describeMilvus(milvus).Components {
+		if ready[c.Name] || c.Replicas == 0 || c.Ready < c.Replicas {
 			continue
 		}
+		ready[c.Name] = true
+		logger.Emit(logger.Event{
+			Type:   logger.EventComponentReady,
+			Fields: map[string]string{"cluster": e.clusterName, "component": c.Name, "replicas": strconv.Itoa(int(c.Replicas))},
+		})
+	}
+}
+
+// dependencySelectors returns the label selectors of the etcd and MinIO pods
+// the Milvus Operator deploys for a cluster, keyed by dependency. External
+// dependencies are left out.
+func (e *KubernetesExecutor) dependencySelectors() map[string]string {
+	selectors := make(map[string]string)
+	if !e.spec.ExternalEtcd() {
+		selectors["etcd"] = dependencySelector(e.clusterName, "etcd")
+	}
+	if !e.spec.ExternalMinio() {
+		selectors["minio"] = dependencySelector(e.clusterName, "minio")
+	}
+	return selectors
+}
+
+// dependencySelector returns the label selector of the release the Milvus
+// Operator deploys for a cluster's etcd or MinIO, which labels its pods and
+// PVCs
+func dependencySelector(clusterName, dep string) string {
+	if dep == "minio" {
+		return fmt.Sprintf("release=%s-minio", clusterName)
+	}
+	return fmt.Sprintf("app.kubernetes.io/instance=%s-%s", clusterName, dep)
+}
+
+// unreadyDependency describes the first dependency deployed by the operator
+// that has no pods or a pod that isn't ready, or returns "" if all are ready
+func (e *KubernetesExecutor) unreadyDependency(ctx context.Context) (string, error) {
+	selectors := e.dependencySelectors()
+	deps := make([]string, 0, len(selectors))
+	for dep := range selectors {
+		deps = append(deps, dep)
+	}
+	sort.Strings(deps)
 
-		if milvus.Status.Status == "Healthy" {
-			return nil
+	for _, dep := range deps {
+		pods, err := e.client.ListSelectedPods(ctx, e.namespace, selectors[dep])
+		if err != nil {
+			return "", err
+		}
+		if waiting := unreadyPod(dep, pods); waiting != "" {
+			return waiting, nil
 		}
+	}
+	return "", nil
+}
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(5 * time.Second):
+// unreadyPod describes why the pods of a dependency aren't ready, or
+// returns "" if they all are
+func unreadyPod(dep string, pods []corev1.Pod) string {
+	if len(pods) == 0 {
+		return fmt.Sprintf("%s has no pods yet", dep)
+	}
+	for i := range pods {
+		if !k8s.IsPodReady(&pods[i]) {
+			return fmt.Sprintf("%s pod %s is not ready", dep, pods[i].Name)
 		}
 	}
+	return ""
+}
 
-	return fmt.Errorf("timeout waiting for cluster to become healthy")
+// sleepContext sleeps for d, returning early with ctx.Err() if ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // specToMilvus converts the specification to a Milvus CRD
@@ -268,14 +536,17 @@ func (e *KubernetesExecutor) specToMilvus() *k8s.Milvus {
 	milvus.Name = e.clusterName
 	milvus.Namespace = e.namespace
 	milvus.Labels = map[string]string{
-		"app":                          "milvus",
-		"app.kubernetes.io/name":       "milvus",
-		"app.kubernetes.io/instance":   e.clusterName,
-		"app.kubernetes.io/managed-by": "miup",
+		"app":                        "milvus",
+		"app.kubernetes.io/name":     "milvus",
+		"app.kubernetes.io/instance": e.clusterName,
+		ManagedByLabel:               "miup",
 	}
+	setExpiryAnnotation(milvus, e.expiresAt)
 
 	// Set image version
-	if e.milvusVersion != "" {
+	if e.image != "" {
+		milvus.Spec.Components.Image = e.image
+	} else if e.milvusVersion != "" {
 		milvus.Spec.Components.Image = fmt.Sprintf("milvusdb/milvus:%s", e.milvusVersion)
 	}
 
@@ -286,6 +557,11 @@ func (e *KubernetesExecutor) specToMilvus() *k8s.Milvus {
 		milvus.Spec.Components.MetricInterval = "15s"
 	}
 
+	// Milvus configuration from the topology; TLS settings take precedence
+	if config := e.topologyConfig(); len(config) > 0 {
+		milvus.Spec.Config = config
+	}
+
 	// Configure TLS if enabled
 	if e.spec.HasTLS() {
 		e.configureTLS(milvus)
@@ -322,46 +598,73 @@ func (e *KubernetesExecutor) configureTLS(milvus *k8s.Milvus) {
 		},
 	}
 
-	// Set TLS configuration in milvus config
+	// Set TLS configuration in milvus config, over any from the topology
 	if milvus.Spec.Config == nil {
 		milvus.Spec.Config = make(map[string]interface{})
 	}
 
-	// TLS paths
-	milvus.Spec.Config["tls"] = map[string]interface{}{
-		"serverPemPath": "/milvus/tls/server.pem",
-		"serverKeyPath": "/milvus/tls/server.key",
-		"caPemPath":     "/milvus/tls/ca.pem",
-	}
-
-	// TLS mode in common.security
-	milvus.Spec.Config["common"] = map[string]interface{}{
-		"security": map[string]interface{}{
-			"tlsMode": tlsMode,
+	config := map[string]interface{}{
+		// TLS paths
+		"tls": map[string]interface{}{
+			"serverPemPath": "/milvus/tls/server.pem",
+			"serverKeyPath": "/milvus/tls/server.key",
+			"caPemPath":     "/milvus/tls/ca.pem",
+		},
+		// TLS mode in common.security
+		"common": map[string]interface{}{
+			"security": map[string]interface{}{
+				"tlsMode": tlsMode,
+			},
 		},
 	}
 
 	// Internal TLS if enabled
 	if tlsConfig.InternalEnabled {
-		common := milvus.Spec.Config["common"].(map[string]interface{})
+		common := config["common"].(map[string]interface{})
 		security := common["security"].(map[string]interface{})
 		security["internaltlsEnabled"] = true
 
-		milvus.Spec.Config["internaltls"] = map[string]interface{}{
+		config["internaltls"] = map[string]interface{}{
 			"serverPemPath": "/milvus/tls/server.pem",
 			"serverKeyPath": "/milvus/tls/server.key",
 			"caPemPath":     "/milvus/tls/ca.pem",
 		}
 	}
+
+	mergeConfig(milvus.Spec.Config, config)
+}
+
+// topologyConfig returns the Milvus configuration of the topology:
+// server_configs.milvus with the config of each Milvus server merged over it
+func (e *KubernetesExecutor) topologyConfig() map[string]interface{} {
+	config := make(map[string]interface{})
+	mergeConfig(config, copyConfig(e.spec.ServerConfigs.Milvus))
+	for _, server := range e.spec.MilvusServers {
+		mergeConfig(config, copyConfig(server.Config))
+	}
+	return config
+}
+
+// copyConfig returns a deep copy of the nested maps of a configuration, so
+// that merging into the copy leaves the original untouched
+func copyConfig(config map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		if m, ok := value.(map[string]interface{}); ok {
+			value = copyConfig(m)
+		}
+		copied[key] = value
+	}
+	return copied
 }
 
 // buildEtcdConfig builds etcd configuration
 func (e *KubernetesExecutor) buildEtcdConfig() k8s.EtcdConfig {
 	// Check if external etcd is configured
-	if len(e.spec.EtcdServers) > 0 && e.spec.EtcdServers[0].Host != "127.0.0.1" && e.spec.EtcdServers[0].Host != "localhost" {
+	if e.spec.ExternalEtcd() {
 		endpoints := make([]string, 0, len(e.spec.EtcdServers))
 		for _, etcd := range e.spec.EtcdServers {
-			endpoints = append(endpoints, fmt.Sprintf("%s:%d", etcd.Host, etcd.ClientPort))
+			endpoints = append(endpoints, etcd.Address())
 		}
 		return k8s.EtcdConfig{
 			External:  true,
@@ -375,13 +678,20 @@ func (e *KubernetesExecutor) buildEtcdConfig() k8s.EtcdConfig {
 		replicaCount = 1
 	}
 
+	values := map[string]interface{}{
+		"replicaCount": replicaCount,
+	}
+	var size string
+	if len(e.spec.EtcdServers) > 0 {
+		size = e.spec.EtcdServers[0].Storage
+	}
+	setPersistence(values, size, e.spec.Global.StorageClass)
+
 	return k8s.EtcdConfig{
 		InCluster: &k8s.InClusterConfig{
 			DeletionPolicy: "Delete",
 			PVCDeletion:    true,
-			Values: map[string]interface{}{
-				"replicaCount": replicaCount,
-			},
+			Values:         values,
 		},
 	}
 }
@@ -389,16 +699,15 @@ func (e *KubernetesExecutor) buildEtcdConfig() k8s.EtcdConfig {
 // buildStorageConfig builds storage configuration
 func (e *KubernetesExecutor) buildStorageConfig() k8s.StorageConfig {
 	// Check if external MinIO/S3 is configured
-	if len(e.spec.MinioServers) > 0 && e.spec.MinioServers[0].Host != "127.0.0.1" && e.spec.MinioServers[0].Host != "localhost" {
+	if e.spec.ExternalMinio() {
 		minio := e.spec.MinioServers[0]
-		// For external storage, credentials should be provided via a Kubernetes secret
-		// The secret should contain accessKeyID and secretAccessKey
+		// Credentials come from an existing secret with accesskey and
+		// secretkey, e.g. the one of a helm-installed MinIO
 		return k8s.StorageConfig{
-			Type:     "MinIO",
-			External: true,
-			Endpoint: fmt.Sprintf("%s:%d", minio.Host, minio.Port),
-			// SecretRef should point to a secret with access credentials
-			// SecretRef: "milvus-minio-secret",
+			Type:      "MinIO",
+			External:  true,
+			Endpoint:  minio.Address(),
+			SecretRef: minio.SecretRef,
 		}
 	}
 
@@ -408,63 +717,104 @@ func (e *KubernetesExecutor) buildStorageConfig() k8s.StorageConfig {
 		storageMode = "distributed"
 	}
 
+	values := map[string]interface{}{
+		"mode": storageMode,
+		"resources": map[string]interface{}{
+			"requests": map[string]string{
+				"memory": "256Mi",
+			},
+		},
+	}
+	var size string
+	if len(e.spec.MinioServers) > 0 {
+		size = e.spec.MinioServers[0].Storage
+	}
+	setPersistence(values, size, e.spec.Global.StorageClass)
+
 	return k8s.StorageConfig{
 		InCluster: &k8s.InClusterConfig{
 			DeletionPolicy: "Delete",
 			PVCDeletion:    true,
-			Values: map[string]interface{}{
-				"mode": storageMode,
-				"resources": map[string]interface{}{
-					"requests": map[string]string{
-						"memory": "256Mi",
-					},
-				},
-			},
+			Values:         values,
 		},
 	}
 }
 
+// setPersistence sets the PVC size and storage class in in-cluster
+// dependency chart values; empty ones are left to the chart defaults
+func setPersistence(values map[string]interface{}, size, storageClass string) {
+	persistence := make(map[string]interface{})
+	if size != "" {
+		persistence["size"] = size
+	}
+	if storageClass != "" {
+		persistence["storageClass"] = storageClass
+	}
+	if len(persistence) > 0 {
+		values["persistence"] = persistence
+	}
+}
+
 // buildComponents builds component configuration
 func (e *KubernetesExecutor) buildComponents() k8s.MilvusComponents {
 	components := k8s.MilvusComponents{}
 
+	var milvusComponents spec.MilvusComponents
+	if len(e.spec.MilvusServers) > 0 {
+		milvusComponents = e.spec.MilvusServers[0].Components
+	}
+	components.Env = envVars(milvusComponents.Env)
+
 	if e.spec.GetMode() == spec.ModeStandalone {
 		one := int32(1)
 		components.Standalone = &k8s.ComponentSpec{
-			Replicas: &one,
+			Replicas:  &one,
+			Resources: resourceRequests(milvusComponents.Standalone.Resources),
+			Env:       envVars(milvusComponents.Standalone.Env),
+		}
+		if affinity := podAntiAffinity(e.clusterName, "standalone", milvusComponents.Standalone.AntiAffinity); affinity != nil {
+			components.Standalone.Affinity = affinity
 		}
 	} else {
 		// Cluster mode - get replicas from spec (defaults are already set)
-		milvusSpec := e.spec.MilvusServers[0]
-
-		proxyReplicas := int32(milvusSpec.Components.Proxy.Replicas)
-		components.Proxy = &k8s.ComponentSpec{Replicas: &proxyReplicas}
-
-		rootCoordReplicas := int32(milvusSpec.Components.RootCoord.Replicas)
-		components.RootCoord = &k8s.ComponentSpec{Replicas: &rootCoordReplicas}
-
-		queryCoordReplicas := int32(milvusSpec.Components.QueryCoord.Replicas)
-		components.QueryCoord = &k8s.ComponentSpec{Replicas: &queryCoordReplicas}
-
-		dataCoordReplicas := int32(milvusSpec.Components.DataCoord.Replicas)
-		components.DataCoord = &k8s.ComponentSpec{Replicas: &dataCoordReplicas}
-
-		indexCoordReplicas := int32(milvusSpec.Components.IndexCoord.Replicas)
-		components.IndexCoord = &k8s.ComponentSpec{Replicas: &indexCoordReplicas}
-
-		queryNodeReplicas := int32(milvusSpec.Components.QueryNode.Replicas)
-		components.QueryNode = &k8s.ComponentSpec{Replicas: &queryNodeReplicas}
-
-		dataNodeReplicas := int32(milvusSpec.Components.DataNode.Replicas)
-		components.DataNode = &k8s.ComponentSpec{Replicas: &dataNodeReplicas}
+		build := func(name string, c spec.ComponentSpec) *k8s.ComponentSpec {
+			replicas := int32(c.Replicas)
+			out := &k8s.ComponentSpec{Replicas: &replicas, Resources: resourceRequests(c.Resources), Env: envVars(c.Env)}
+			if affinity := podAntiAffinity(e.clusterName, name, c.AntiAffinity); affinity != nil {
+				out.Affinity = affinity
+			}
+			return out
+		}
 
-		indexNodeReplicas := int32(milvusSpec.Components.IndexNode.Replicas)
-		components.IndexNode = &k8s.ComponentSpec{Replicas: &indexNodeReplicas}
+		components.Proxy = build("proxy", milvusComponents.Proxy)
+		components.RootCoord = build("rootcoord", milvusComponents.RootCoord)
+		components.QueryCoord = build("querycoord", milvusComponents.QueryCoord)
+		components.DataCoord = build("datacoord", milvusComponents.DataCoord)
+		components.IndexCoord = build("indexcoord", milvusComponents.IndexCoord)
+		components.QueryNode = build("querynode", milvusComponents.QueryNode)
+		components.DataNode = build("datanode", milvusComponents.DataNode)
+		components.IndexNode = build("indexnode", milvusComponents.IndexNode)
 	}
 
 	return components
 }
 
+// resourceRequests returns the CPU and memory requests of a topology
+// component, or nil to leave them to the operator if it sets neither
+func resourceRequests(r spec.ResourceSpec) *k8s.ResourceRequirements {
+	requests := make(map[string]string)
+	if r.CPU != "" {
+		requests["cpu"] = r.CPU
+	}
+	if r.Memory != "" {
+		requests["memory"] = r.Memory
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+	return &k8s.ResourceRequirements{Requests: requests}
+}
+
 // GetEndpoint returns the Milvus service endpoint
 func (e *KubernetesExecutor) GetEndpoint(ctx context.Context) (string, error) {
 	return e.client.GetMilvusService(ctx, e.clusterName, e.namespace)
@@ -480,7 +830,7 @@ func (e *KubernetesExecutor) Scale(ctx context.Context, component string, opts S
 	component = strings.ToLower(component)
 
 	// Get the component spec to modify
-	compSpec, err := e.getComponentSpec(milvus, component)
+	compSpec, err := e.getComponentSpec(ctx, milvus, component)
 	if err != nil {
 		return err
 	}
@@ -520,107 +870,78 @@ func (e *KubernetesExecutor) Scale(ctx context.Context, component string, opts S
 		}
 	}
 
+	// Apply environment changes
+	if opts.HasEnvChange() {
+		compSpec.Env = mergeEnv(compSpec.Env, opts.Env)
+	}
+
 	// Update the Milvus resource
-	if err := e.client.UpdateMilvus(ctx, milvus); err != nil {
+	if err := e.updateMilvus(ctx, milvus); err != nil {
 		return fmt.Errorf("failed to update Milvus cluster: %w", err)
 	}
+	if e.dryRun() {
+		return nil
+	}
 
 	// Wait for the cluster to be healthy again
-	return e.waitForReady(ctx, 5*time.Minute)
-}
-
-// getComponentSpec returns the component spec for the given component name
-func (e *KubernetesExecutor) getComponentSpec(milvus *k8s.Milvus, component string) (*k8s.ComponentSpec, error) {
-	isStandalone := milvus.Spec.Mode == k8s.MilvusModeStandalone
-
-	switch component {
-	case "standalone":
-		if !isStandalone {
-			return nil, fmt.Errorf("cannot scale standalone component in cluster mode")
-		}
-		if milvus.Spec.Components.Standalone == nil {
-			milvus.Spec.Components.Standalone = &k8s.ComponentSpec{}
-		}
-		return milvus.Spec.Components.Standalone, nil
-
-	case "proxy":
-		if isStandalone {
-			return nil, fmt.Errorf("cannot scale proxy in standalone mode")
-		}
-		if milvus.Spec.Components.Proxy == nil {
-			milvus.Spec.Components.Proxy = &k8s.ComponentSpec{}
-		}
-		return milvus.Spec.Components.Proxy, nil
+	if err := e.waitForReady(ctx, 5*time.Minute); err != nil {
+		return err
+	}
 
-	case "querynode":
-		if isStandalone {
-			return nil, fmt.Errorf("cannot scale querynode in standalone mode")
-		}
-		if milvus.Spec.Components.QueryNode == nil {
-			milvus.Spec.Components.QueryNode = &k8s.ComponentSpec{}
-		}
-		return milvus.Spec.Components.QueryNode, nil
+	// The operator may report Healthy before the deployment has caught up
+	if opts.HasReplicaChange() {
+		return e.waitForReplicas(ctx, component, opts.Replicas, 5*time.Minute)
+	}
+	return nil
+}
 
-	case "datanode":
-		if isStandalone {
-			return nil, fmt.Errorf("cannot scale datanode in standalone mode")
-		}
-		if milvus.Spec.Components.DataNode == nil {
-			milvus.Spec.Components.DataNode = &k8s.ComponentSpec{}
-		}
-		return milvus.Spec.Components.DataNode, nil
+// waitForReplicas waits until a component has exactly want ready replicas
+func (e *KubernetesExecutor) waitForReplicas(ctx context.Context, component string, want int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	var last ReplicaCount
 
-	case "indexnode":
-		if isStandalone {
-			return nil, fmt.Errorf("cannot scale indexnode in standalone mode")
-		}
-		if milvus.Spec.Components.IndexNode == nil {
-			milvus.Spec.Components.IndexNode = &k8s.ComponentSpec{}
+	for time.Now().Before(deadline) {
+		milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+		if err == nil {
+			last = replicaCounts(milvus)[component]
+			if last.Desired == want && last.Ready == want {
+				return nil
+			}
 		}
-		return milvus.Spec.Components.IndexNode, nil
 
-	case "rootcoord":
-		if isStandalone {
-			return nil, fmt.Errorf("cannot scale rootcoord in standalone mode")
-		}
-		if milvus.Spec.Components.RootCoord == nil {
-			milvus.Spec.Components.RootCoord = &k8s.ComponentSpec{}
+		if err := sleepContext(ctx, 5*time.Second); err != nil {
+			return fmt.Errorf("%w (%s has %d/%d ready replicas): %w", ErrWaitCancelled, component, last.Ready, want, err)
 		}
-		return milvus.Spec.Components.RootCoord, nil
+	}
 
-	case "querycoord":
-		if isStandalone {
-			return nil, fmt.Errorf("cannot scale querycoord in standalone mode")
-		}
-		if milvus.Spec.Components.QueryCoord == nil {
-			milvus.Spec.Components.QueryCoord = &k8s.ComponentSpec{}
-		}
-		return milvus.Spec.Components.QueryCoord, nil
+	return fmt.Errorf("%w: %s has %d/%d ready replicas", ErrTimeout, component, last.Ready, want)
+}
 
-	case "datacoord":
-		if isStandalone {
-			return nil, fmt.Errorf("cannot scale datacoord in standalone mode")
-		}
-		if milvus.Spec.Components.DataCoord == nil {
-			milvus.Spec.Components.DataCoord = &k8s.ComponentSpec{}
-		}
-		return milvus.Spec.Components.DataCoord, nil
+// getComponentSpec returns the spec of the named component, adding an empty
+// one if the resource has none. Components of newer Milvus versions are
+// accepted if the operator's CRD has them.
+func (e *KubernetesExecutor) getComponentSpec(ctx context.Context, milvus *k8s.Milvus, component string) (*k8s.ComponentSpec, error) {
+	isStandalone := milvus.Spec.Mode == k8s.MilvusModeStandalone
+	if component == "standalone" && !isStandalone {
+		return nil, fmt.Errorf("cannot scale standalone component in cluster mode")
+	}
 
-	case "indexcoord":
-		if isStandalone {
-			return nil, fmt.Errorf("cannot scale indexcoord in standalone mode")
+	compSpec := milvus.Spec.Components.Component(component)
+	if compSpec == nil {
+		if keys, err := e.client.MilvusComponentKeys(ctx); err == nil {
+			compSpec = milvus.Spec.Components.Component(component, keys...)
 		}
-		if milvus.Spec.Components.IndexCoord == nil {
-			milvus.Spec.Components.IndexCoord = &k8s.ComponentSpec{}
-		}
-		return milvus.Spec.Components.IndexCoord, nil
-
-	default:
-		return nil, fmt.Errorf("unknown component: %s. Valid components: proxy, querynode, datanode, indexnode, rootcoord, querycoord, datacoord, indexcoord, standalone", component)
 	}
+	if compSpec == nil {
+		return nil, fmt.Errorf("%w: %s. Valid components: %s", ErrInvalidComponent, component, strings.Join(ComponentNames, ", "))
+	}
+	if isStandalone && component != "standalone" {
+		return nil, fmt.Errorf("%w: cannot scale %s in standalone mode", ErrInvalidComponent, component)
+	}
+	return compSpec, nil
 }
 
-// GetReplicas returns the current replica count for each component
+// GetReplicas returns the ready replica count for each component
 func (e *KubernetesExecutor) GetReplicas(ctx context.Context) (map[string]int, error) {
 	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
 	if err != nil {
@@ -649,22 +970,38 @@ func (e *KubernetesExecutor) Upgrade(ctx context.Context, version string) error
 		version = "v" + version
 	}
 
-	// Build the new image name
-	newImage := fmt.Sprintf("milvusdb/milvus:%s", version)
+	return e.updateImage(ctx, milvus, fmt.Sprintf("milvusdb/milvus:%s", version))
+}
 
-	// Check if already at the target version
+// SetImage rolls the cluster to an arbitrary Milvus image, e.g. a nightly
+// or patched build whose tag is not a release version
+func (e *KubernetesExecutor) SetImage(ctx context.Context, image string) error {
+	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get Milvus cluster: %w", err)
+	}
+
+	return e.updateImage(ctx, milvus, image)
+}
+
+// updateImage sets the Milvus image and waits for the rolling update
+func (e *KubernetesExecutor) updateImage(ctx context.Context, milvus *k8s.Milvus, newImage string) error {
+	// Check if already at the target image
 	currentImage := milvus.Spec.Components.Image
 	if currentImage == newImage {
-		return fmt.Errorf("cluster is already running version %s", version)
+		return fmt.Errorf("%w: %s", ErrAlreadyAtVersion, newImage)
 	}
 
 	// Update the image
 	milvus.Spec.Components.Image = newImage
 
 	// Update the Milvus resource (this triggers a rolling update by the operator)
-	if err := e.client.UpdateMilvus(ctx, milvus); err != nil {
+	if err := e.updateMilvus(ctx, milvus); err != nil {
 		return fmt.Errorf("failed to update Milvus cluster: %w", err)
 	}
+	if e.dryRun() {
+		return nil
+	}
 
 	// Wait for the upgrade to complete
 	return e.waitForReady(ctx, 15*time.Minute)
@@ -677,18 +1014,7 @@ func (e *KubernetesExecutor) GetVersion(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("failed to get Milvus cluster: %w", err)
 	}
 
-	image := milvus.Spec.Components.Image
-	if image == "" {
-		return "unknown", nil
-	}
-
-	// Extract version from image (e.g., "milvusdb/milvus:v2.5.4" -> "v2.5.4")
-	parts := strings.Split(image, ":")
-	if len(parts) < 2 {
-		return "latest", nil
-	}
-
-	return parts[len(parts)-1], nil
+	return ImageVersion(milvus.Spec.Components.Image), nil
 }
 
 // GetConfig returns the current Milvus configuration from the CRD
@@ -721,14 +1047,37 @@ func (e *KubernetesExecutor) SetConfig(ctx context.Context, config map[string]in
 	mergeConfig(milvus.Spec.Config, config)
 
 	// Update the Milvus resource
-	if err := e.client.UpdateMilvus(ctx, milvus); err != nil {
+	if err := e.updateMilvus(ctx, milvus); err != nil {
 		return fmt.Errorf("failed to update Milvus cluster: %w", err)
 	}
+	if e.dryRun() {
+		return nil
+	}
 
 	// Wait for the cluster to be healthy after config change
 	return e.waitForReady(ctx, 10*time.Minute)
 }
 
+// ReplaceConfig replaces the Milvus configuration in the CRD, removing keys
+// that config doesn't have, e.g. to restore a snapshot
+func (e *KubernetesExecutor) ReplaceConfig(ctx context.Context, config map[string]interface{}) error {
+	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get Milvus cluster: %w", err)
+	}
+
+	milvus.Spec.Config = config
+
+	if err := e.updateMilvus(ctx, milvus); err != nil {
+		return fmt.Errorf("failed to update Milvus cluster: %w", err)
+	}
+	if e.dryRun() {
+		return nil
+	}
+
+	return e.waitForReady(ctx, 10*time.Minute)
+}
+
 // mergeConfig deep merges src into dst
 func mergeConfig(dst, src map[string]interface{}) {
 	for key, srcVal := range src {
@@ -785,6 +1134,9 @@ func (e *KubernetesExecutor) Diagnose(ctx context.Context) (*DiagnoseResult, err
 	// Check conditions for issues
 	e.diagnoseConditions(milvus, result)
 
+	// Check pod resource usage
+	e.diagnoseResources(ctx, result)
+
 	// Generate summary
 	errorCount := 0
 	warningCount := 0
@@ -818,27 +1170,30 @@ func (e *KubernetesExecutor) diagnoseComponents(milvus *k8s.Milvus, result *Diag
 	// Worker nodes can be scaled
 	workerComponents := []string{"querynode", "datanode", "indexnode", "streamingnode", "standalone"}
 
-	// Check which components actually exist in the deployment
-	for name, status := range deployStatus {
+	// Check which components actually exist in the deployment, comparing
+	// ready replicas against the desired count from the spec
+	counts := replicaCounts(milvus)
+	for name := range deployStatus {
+		count := counts[name]
 		check := ComponentCheck{
 			Name:     name,
-			Replicas: int(status.Status.Replicas),
-			Ready:    int(status.Status.ReadyReplicas),
+			Replicas: count.Desired,
+			Ready:    count.Ready,
 		}
 
-		if status.Status.ReadyReplicas >= status.Status.Replicas && status.Status.Replicas > 0 {
+		if count.Ready >= count.Desired && count.Desired > 0 {
 			check.Status = CheckStatusOK
-			check.Message = fmt.Sprintf("%d/%d ready", status.Status.ReadyReplicas, status.Status.Replicas)
-		} else if status.Status.ReadyReplicas > 0 {
+			check.Message = fmt.Sprintf("%d/%d ready", count.Ready, count.Desired)
+		} else if count.Ready > 0 {
 			check.Status = CheckStatusWarning
-			check.Message = fmt.Sprintf("%d/%d ready (degraded)", status.Status.ReadyReplicas, status.Status.Replicas)
+			check.Message = fmt.Sprintf("%d/%d ready (degraded)", count.Ready, count.Desired)
 			result.Issues = append(result.Issues, Issue{
 				Severity:    CheckStatusWarning,
 				Component:   name,
 				Description: fmt.Sprintf("%s has fewer ready replicas than desired", name),
 				Suggestion:  fmt.Sprintf("Check pod status: kubectl get pods -l app.kubernetes.io/instance=%s,app.kubernetes.io/component=%s -n %s", e.clusterName, name, e.namespace),
 			})
-		} else if status.Status.Replicas == 0 {
+		} else if count.Desired == 0 {
 			// Zero replicas - check if this is expected
 			isCore := false
 			for _, c := range coreComponents {
@@ -952,6 +1307,92 @@ func (e *KubernetesExecutor) diagnoseConditions(milvus *k8s.Milvus, result *Diag
 	}
 }
 
+// diagnoseResources compares pod resource usage with limits. Without
+// metrics-server it adds a single warning instead of per-pod errors.
+func (e *KubernetesExecutor) diagnoseResources(ctx context.Context, result *DiagnoseResult) {
+	usage, err := e.client.ListPodUsage(ctx, e.namespace, fmt.Sprintf("app.kubernetes.io/instance=%s", e.clusterName))
+	if err != nil {
+		suggestion := "Check that the metrics API is healthy: kubectl top pods -n " + e.namespace
+		if errors.Is(err, k8s.ErrMetricsUnavailable) {
+			suggestion = "Install metrics-server to enable resource checks: https://github.com/kubernetes-sigs/metrics-server"
+		}
+		result.Issues = append(result.Issues, Issue{
+			Severity:    CheckStatusWarning,
+			Component:   "metrics",
+			Description: fmt.Sprintf("Resource usage checks skipped: %v", err),
+			Suggestion:  suggestion,
+		})
+		return
+	}
+
+	pods, err := e.client.ListMilvusPodObjects(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return
+	}
+	podsByName := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		podsByName[pods[i].Name] = &pods[i]
+	}
+
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Name < usage[j].Name })
+	for _, u := range usage {
+		check := resourceCheck(u, podsByName[u.Name])
+		result.Resources = append(result.Resources, check)
+		if check.Status != CheckStatusOK {
+			result.Issues = append(result.Issues, Issue{
+				Severity:    check.Status,
+				Component:   u.Name,
+				Description: check.Message,
+				Suggestion:  "Raise the limits with 'miup instance scale --cpu-limit/--memory-limit' or add replicas",
+			})
+		}
+	}
+}
+
+// resourceHighUsage is the fraction of a limit above which usage is flagged
+const resourceHighUsage = 0.9
+
+// resourceCheck compares a pod's usage with the sum of its container limits
+func resourceCheck(usage k8s.PodUsage, pod *corev1.Pod) ResourceCheck {
+	check := ResourceCheck{
+		Name:    usage.Name,
+		Status:  CheckStatusOK,
+		Usage:   fmt.Sprintf("cpu %s, memory %s", usage.CPU.String(), usage.Memory.String()),
+		Limit:   "none",
+		Message: "Usage within limits",
+	}
+	if pod == nil {
+		return check
+	}
+
+	var cpuLimit, memLimit resource.Quantity
+	for _, c := range pod.Spec.Containers {
+		if q, ok := c.Resources.Limits[corev1.ResourceCPU]; ok {
+			cpuLimit.Add(q)
+		}
+		if q, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+			memLimit.Add(q)
+		}
+	}
+	if cpuLimit.IsZero() && memLimit.IsZero() {
+		return check
+	}
+	check.Limit = fmt.Sprintf("cpu %s, memory %s", cpuLimit.String(), memLimit.String())
+
+	var high []string
+	if !cpuLimit.IsZero() && float64(usage.CPU.MilliValue()) >= resourceHighUsage*float64(cpuLimit.MilliValue()) {
+		high = append(high, "CPU")
+	}
+	if !memLimit.IsZero() && float64(usage.Memory.Value()) >= resourceHighUsage*float64(memLimit.Value()) {
+		high = append(high, "memory")
+	}
+	if len(high) > 0 {
+		check.Status = CheckStatusWarning
+		check.Message = fmt.Sprintf("%s usage above %.0f%% of limit", strings.Join(high, " and "), resourceHighUsage*100)
+	}
+	return check
+}
+
 // Reload triggers a configuration reload on the Milvus cluster
 func (e *KubernetesExecutor) Reload(ctx context.Context, opts ReloadOptions) error {
 	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
@@ -974,12 +1415,12 @@ func (e *KubernetesExecutor) Reload(ctx context.Context, opts ReloadOptions) err
 	milvus.ObjectMeta.Annotations["milvus.io/reload-at"] = time.Now().Format(time.RFC3339)
 
 	// Update the Milvus resource
-	if err := e.client.UpdateMilvus(ctx, milvus); err != nil {
+	if err := e.updateMilvus(ctx, milvus); err != nil {
 		return fmt.Errorf("failed to update Milvus cluster: %w", err)
 	}
 
 	// Wait for the cluster to be ready if requested
-	if opts.Wait {
+	if opts.Wait && !e.dryRun() {
 		timeout := opts.Timeout
 		if timeout == 0 {
 			timeout = 10 * time.Minute
@@ -1006,3 +1447,96 @@ func mergeConfigAny(dst, src map[string]any) {
 		dst[key] = srcVal
 	}
 }
+
+// ExportCRD returns the Milvus custom resource as YAML
+func (e *KubernetesExecutor) ExportCRD(ctx context.Context) ([]byte, error) {
+	obj, err := e.client.GetMilvusRaw(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// managedFields is noise for humans reading the export
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "managedFields")
+	}
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Milvus resource: %w", err)
+	}
+	return data, nil
+}
+
+// Events returns events for the cluster and its pods sorted by time
+func (e *KubernetesExecutor) Events(ctx context.Context) ([]Event, error) {
+	events, err := e.client.ListEvents(ctx, e.namespace, e.clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Event, 0, len(events))
+	for _, ev := range events {
+		result = append(result, newEvent(ev))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result, nil
+}
+
+// WatchEvents calls fn for each event of the cluster and its pods as it
+// occurs, until ctx is cancelled
+func (e *KubernetesExecutor) WatchEvents(ctx context.Context, fn func(Event)) error {
+	return e.client.WatchEvents(ctx, e.namespace, e.clusterName, func(ev corev1.Event) {
+		fn(newEvent(ev))
+	})
+}
+
+// newEvent converts a Kubernetes event, timed by when it last occurred
+func newEvent(ev corev1.Event) Event {
+	t := ev.LastTimestamp.Time
+	if t.IsZero() {
+		t = ev.EventTime.Time
+	}
+	if t.IsZero() {
+		t = ev.CreationTimestamp.Time
+	}
+
+	return Event{
+		Time:    t,
+		Type:    ev.Type,
+		Reason:  ev.Reason,
+		Object:  fmt.Sprintf("%s/%s", strings.ToLower(ev.InvolvedObject.Kind), ev.InvolvedObject.Name),
+		Message: strings.TrimSpace(ev.Message),
+		Count:   ev.Count,
+	}
+}
+
+// PodManifests returns the YAML manifest of each cluster pod keyed by pod name
+func (e *KubernetesExecutor) PodManifests(ctx context.Context) (map[string][]byte, error) {
+	pods, err := e.client.ListMilvusPodObjects(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]byte, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		pod.ManagedFields = nil
+
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert pod %s: %w", pod.Name, err)
+		}
+
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal pod %s: %w", pod.Name, err)
+		}
+		result[pod.Name] = data
+	}
+
+	return result, nil
+}
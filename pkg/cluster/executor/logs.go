@@ -0,0 +1,235 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// milvusLogTimeLayout is the timestamp layout used by Milvus log lines,
+// e.g. "[2024/01/15 10:30:45.123 +00:00] [INFO] ..."
+const milvusLogTimeLayout = "2006/01/02 15:04:05.000 -07:00"
+
+// kubeLogTimeLayout is the layout timestamps added by the kubelet are
+// rewritten in: RFC3339 with a fixed number of digits, so lines line up
+const kubeLogTimeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// dependencyNames are non-Milvus components that share the instance label
+var dependencyNames = []string{"etcd", "minio", "pulsar", "kafka"}
+
+// logFetchConcurrency is the number of pods whose logs are fetched at the
+// same time
+const logFetchConcurrency = 8
+
+// PodLogs holds the logs retrieved from a single pod
+type PodLogs struct {
+	Pod  string
+	Logs string
+	Err  error
+}
+
+// fetchPodLogs calls fetch for each pod with RunBulk and returns the
+// results in the order of pods, so one slow or broken pod doesn't hold up or
+// abort the rest; pods not yet started when ctx is cancelled fail with its
+// error
+func fetchPodLogs(ctx context.Context, pods []string, concurrency int, fetch func(ctx context.Context, pod string) PodLogs) []PodLogs {
+	index := make(map[string]int, len(pods))
+	for i, pod := range pods {
+		index[pod] = i
+	}
+
+	logs := make([]PodLogs, len(pods))
+	results := RunBulk(ctx, pods, concurrency, func(ctx context.Context, pod string) error {
+		l := fetch(ctx, pod)
+		logs[index[pod]] = l
+		return l.Err
+	})
+	for i, r := range results {
+		if logs[i] == (PodLogs{}) {
+			logs[i] = PodLogs{Pod: r.Name, Err: r.Err}
+		}
+	}
+	return logs
+}
+
+// ComponentFromPodName derives the component name from a pod name created by
+// the Milvus Operator (e.g. "my-milvus-milvus-querynode-5d8f-abcde" -> "querynode").
+// Returns "other" if no known component is found.
+func ComponentFromPodName(instance, pod string) string {
+	name := strings.TrimPrefix(pod, instance+"-")
+	name = strings.TrimPrefix(name, "milvus-")
+
+	first := name
+	if i := strings.Index(name, "-"); i >= 0 {
+		first = name[:i]
+	}
+
+	for _, c := range ComponentNames {
+		if first == c {
+			return c
+		}
+	}
+	for _, c := range dependencyNames {
+		if first == c {
+			return c
+		}
+	}
+	return "other"
+}
+
+// ParseLogTimestamp extracts the timestamp from a Milvus log line
+func ParseLogTimestamp(line string) (time.Time, bool) {
+	if !strings.HasPrefix(line, "[") {
+		return time.Time{}, false
+	}
+	end := strings.Index(line, "]")
+	if end < 0 {
+		return time.Time{}, false
+	}
+	ts, err := time.Parse(milvusLogTimeLayout, line[1:end])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return ts, true
+}
+
+// splitKubeTimestamp splits off the timestamp the kubelet prefixes a line
+// with when logs are requested with timestamps
+func splitKubeTimestamp(line string) (time.Time, string, bool) {
+	prefix, rest, _ := strings.Cut(line, " ")
+	ts, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return ts, rest, true
+}
+
+// NormalizeTimestamps rewrites the kubelet timestamp of each line in loc
+// (UTC if nil), so that logs from pods on different nodes can be compared
+// line by line. Lines without a timestamp are left unchanged.
+func NormalizeTimestamps(logs string, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	lines := strings.Split(logs, "\n")
+	for i, line := range lines {
+		if ts, rest, ok := splitKubeTimestamp(line); ok {
+			lines[i] = ts.In(loc).Format(kubeLogTimeLayout)
+			// An empty line gets no trailing space
+			if rest != "" {
+				lines[i] += " " + rest
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FormatLogsByPod renders logs with a "--- pod ---" header per pod
+func FormatLogsByPod(logs []PodLogs) string {
+	var sb strings.Builder
+	for _, l := range logs {
+		writePodLogs(&sb, l)
+	}
+	return sb.String()
+}
+
+// FormatLogsByComponent renders logs grouped under component headers
+func FormatLogsByComponent(instance string, logs []PodLogs) string {
+	groups := make(map[string][]PodLogs)
+	var order []string
+	for _, l := range logs {
+		component := ComponentFromPodName(instance, l.Pod)
+		if _, ok := groups[component]; !ok {
+			order = append(order, component)
+		}
+		groups[component] = append(groups[component], l)
+	}
+	sort.Strings(order)
+
+	var sb strings.Builder
+	for _, component := range order {
+		sb.WriteString(fmt.Sprintf("=== %s ===\n", component))
+		for _, l := range groups[component] {
+			writePodLogs(&sb, l)
+		}
+	}
+	return sb.String()
+}
+
+// MergeLogs interleaves log lines from all pods sorted by log timestamp:
+// the kubelet's if the logs were fetched with timestamps, which every
+// component has, otherwise Milvus' own. Each line is prefixed with its pod
+// name. Lines without a timestamp (e.g. stack traces) stay attached to the
+// preceding line.
+func MergeLogs(logs []PodLogs) string {
+	type entry struct {
+		ts   time.Time
+		line string
+	}
+
+	var entries []entry
+	for _, l := range logs {
+		if l.Err != nil {
+			entries = append(entries, entry{line: fmt.Sprintf("[%s] (error: %v)", l.Pod, l.Err)})
+			continue
+		}
+
+		var last time.Time
+		for _, line := range strings.Split(strings.TrimRight(l.Logs, "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			if ts, _, ok := splitKubeTimestamp(line); ok {
+				last = ts
+			} else if ts, ok := ParseLogTimestamp(line); ok {
+				last = ts
+			}
+			entries = append(entries, entry{ts: last, line: fmt.Sprintf("[%s] %s", l.Pod, line)})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ts.Before(entries[j].ts)
+	})
+
+	var sb strings.Builder
+	for _, e := range entries {
+		sb.WriteString(e.line)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// WriteLogsToDir writes each pod's logs to <dir>/<pod>.log, creating dir if
+// needed. Pods whose logs could not be retrieved are skipped.
+// Returns the paths of the written files.
+func WriteLogsToDir(dir string, logs []PodLogs) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	var files []string
+	for _, l := range logs {
+		if l.Err != nil {
+			continue
+		}
+		path := filepath.Join(dir, l.Pod+".log")
+		if err := os.WriteFile(path, []byte(l.Logs), 0644); err != nil {
+			return files, fmt.Errorf("failed to write logs for pod %s: %w", l.Pod, err)
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+func writePodLogs(sb *strings.Builder, l PodLogs) {
+	if l.Err != nil {
+		sb.WriteString(fmt.Sprintf("--- %s (error: %v) ---\n", l.Pod, l.Err))
+		return
+	}
+	sb.WriteString(fmt.Sprintf("--- %s ---\n%s\n", l.Pod, l.Logs))
+}
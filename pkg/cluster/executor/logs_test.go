@@ -0,0 +1,318 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestComponentFromPodName(t *testing.T) {
+	tests := []struct {
+		pod  string
+		want string
+	}{
+		{"demo-milvus-querynode-5d8f7c-abcde", "querynode"},
+		{"demo-milvus-standalone-7f9b-xyz12", "standalone"},
+		{"demo-milvus-proxy-6c4d-qwert", "proxy"},
+		{"demo-etcd-0", "etcd"},
+		{"demo-minio-1", "minio"},
+		{"demo-something-else", "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pod, func(t *testing.T) {
+			if got := ComponentFromPodName("demo", tt.pod); got != tt.want {
+				t.Errorf("ComponentFromPodName() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLogTimestamp(t *testing.T) {
+	ts, ok := ParseLogTimestamp("[2024/01/15 10:30:45.123 +00:00] [INFO] [proxy/impl.go:100] [\"started\"]")
+	if !ok {
+		t.Fatal("ParseLogTimestamp() ok = false, want true")
+	}
+	if ts.Hour() != 10 || ts.Minute() != 30 || ts.Second() != 45 {
+		t.Errorf("ParseLogTimestamp() = %v, want 10:30:45", ts)
+	}
+
+	if _, ok := ParseLogTimestamp("goroutine 1 [running]:"); ok {
+		t.Error("ParseLogTimestamp() should fail for line without timestamp")
+	}
+}
+
+func TestSinceSeconds(t *testing.T) {
+	tests := []struct {
+		since time.Duration
+		want  int64
+	}{
+		{0, 0},
+		{time.Millisecond, 1},
+		{500 * time.Millisecond, 1},
+		{time.Second, 1},
+		{1500 * time.Millisecond, 2},
+		{10 * time.Minute, 600},
+	}
+
+	for _, tt := range tests {
+		if got := sinceSeconds(tt.since); got != tt.want {
+			t.Errorf("sinceSeconds(%s) = %d, want %d", tt.since, got, tt.want)
+		}
+	}
+}
+
+func TestFetchPodLogs(t *testing.T) {
+	pods := []string{"a", "b", "c", "d", "e"}
+	var running, maxRunning atomic.Int32
+
+	logs := fetchPodLogs(context.Background(), pods, 2, func(ctx context.Context, pod string) PodLogs {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		// Later pods finish first
+		time.Sleep(time.Duration(len(pods)-strings.Index("abcde", pod)) * 5 * time.Millisecond)
+
+		if pod == "c" {
+			return PodLogs{Pod: pod, Err: errors.New("boom")}
+		}
+		return PodLogs{Pod: pod, Logs: pod + "1"}
+	})
+
+	if maxRunning.Load() > 2 {
+		t.Errorf("fetched %d pods at once, want at most 2", maxRunning.Load())
+	}
+	if len(logs) != len(pods) {
+		t.Fatalf("fetchPodLogs() returned %d results, want %d", len(logs), len(pods))
+	}
+	for i, l := range logs {
+		if l.Pod != pods[i] {
+			t.Errorf("result %d is pod %s, want %s", i, l.Pod, pods[i])
+		}
+		if wantErr := l.Pod == "c"; (l.Err != nil) != wantErr {
+			t.Errorf("pod %s error = %v, wantErr %v", l.Pod, l.Err, wantErr)
+		}
+	}
+}
+
+func TestFetchPodLogsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	logs := fetchPodLogs(ctx, []string{"a", "b"}, 1, func(ctx context.Context, pod string) PodLogs {
+		t.Errorf("fetch called for %s after cancel", pod)
+		return PodLogs{Pod: pod}
+	})
+	for _, l := range logs {
+		if !errors.Is(l.Err, context.Canceled) {
+			t.Errorf("pod %s error = %v, want context.Canceled", l.Pod, l.Err)
+		}
+	}
+}
+
+func TestMergeLogs(t *testing.T) {
+	logs := []PodLogs{
+		{Pod: "a", Logs: "[2024/01/15 10:00:01.000 +00:00] a1\n[2024/01/15 10:00:03.000 +00:00] a2\n\tcontinued\n"},
+		{Pod: "b", Logs: "[2024/01/15 10:00:02.000 +00:00] b1\n"},
+		{Pod: "c", Err: errors.New("boom")},
+	}
+
+	got := strings.Split(strings.TrimRight(MergeLogs(logs), "\n"), "\n")
+	want := []string{
+		"[c] (error: boom)",
+		"[a] [2024/01/15 10:00:01.000 +00:00] a1",
+		"[b] [2024/01/15 10:00:02.000 +00:00] b1",
+		"[a] [2024/01/15 10:00:03.000 +00:00] a2",
+		"[a] \tcontinued",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("MergeLogs() returned %d lines, want %d:\n%s", len(got), len(want), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNormalizeTimestamps(t *testing.T) {
+	logs := "2024-01-15T10:00:01.5Z [INFO] started\ngoroutine 1 [running]:\n2024-01-15T10:00:02.123456789Z\n"
+	shanghai := time.FixedZone("CST", 8*60*60)
+
+	tests := []struct {
+		name string
+		loc  *time.Location
+		want string
+	}{
+		{
+			name: "utc",
+			want: "2024-01-15T10:00:01.500000000Z [INFO] started\ngoroutine 1 [running]:\n2024-01-15T10:00:02.123456789Z\n",
+		},
+		{
+			name: "fixed zone",
+			loc:  shanghai,
+			want: "2024-01-15T18:00:01.500000000+08:00 [INFO] started\ngoroutine 1 [running]:\n2024-01-15T18:00:02.123456789+08:00\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTimestamps(logs, tt.loc); got != tt.want {
+				t.Errorf("NormalizeTimestamps() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeLogsKubeTimestamps(t *testing.T) {
+	// etcd's own timestamps aren't understood, but the kubelet's order it
+	logs := []PodLogs{
+		{Pod: "milvus", Logs: "2024-01-15T10:00:01.000000000Z [2024/01/15 10:00:01.000 +00:00] m1\n2024-01-15T10:00:03.000000000Z [2024/01/15 10:00:03.000 +00:00] m2\n"},
+		{Pod: "etcd", Logs: "2024-01-15T10:00:02.000000000Z {\"level\":\"warn\"}\n"},
+	}
+
+	got := strings.Split(strings.TrimRight(MergeLogs(logs), "\n"), "\n")
+	want := []string{"m1", "warn", "m2"}
+	if len(got) != len(want) {
+		t.Fatalf("MergeLogs() returned %d lines, want %d:\n%s", len(got), len(want), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) {
+			t.Errorf("line %d = %q, want it to contain %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFormatLogsByComponent(t *testing.T) {
+	logs := []PodLogs{
+		{Pod: "demo-milvus-querynode-1", Logs: "q1"},
+		{Pod: "demo-milvus-proxy-1", Logs: "p1"},
+		{Pod: "demo-milvus-querynode-2", Logs: "q2"},
+	}
+
+	out := FormatLogsByComponent("demo", logs)
+
+	proxyIdx := strings.Index(out, "=== proxy ===")
+	queryIdx := strings.Index(out, "=== querynode ===")
+	if proxyIdx < 0 || queryIdx < 0 {
+		t.Fatalf("FormatLogsByComponent() missing component headers:\n%s", out)
+	}
+	if proxyIdx > queryIdx {
+		t.Error("components should be sorted by name")
+	}
+	if strings.Count(out, "=== querynode ===") != 1 {
+		t.Error("querynode pods should be grouped under a single header")
+	}
+	if !strings.Contains(out, "--- demo-milvus-querynode-2 ---\nq2\n") {
+		t.Error("pod header and logs should be preserved within a group")
+	}
+}
+
+func TestWriteLogsToDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	logs := []PodLogs{
+		{Pod: "demo-milvus-proxy-1", Logs: "proxy logs\n"},
+		{Pod: "demo-milvus-querynode-1", Err: errors.New("unavailable")},
+	}
+
+	files, err := WriteLogsToDir(dir, logs)
+	if err != nil {
+		t.Fatalf("WriteLogsToDir() error = %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("len(files) = %d, want 1", len(files))
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "demo-milvus-proxy-1.log"))
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if string(data) != "proxy logs\n" {
+		t.Errorf("log file content = %q, want %q", string(data), "proxy logs\n")
+	}
+}
+
+func TestPodLogsOperator(t *testing.T) {
+	operator := func(namespace string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: k8s.MilvusOperatorDeployment, Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/name": "milvus-operator"},
+			}},
+		}
+	}
+	pod := func(name, namespace, app string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/name": app},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		wantPods []string
+		wantErr  error
+	}{
+		{
+			name: "operator pods, not the cluster's",
+			objects: []runtime.Object{
+				operator("kube-system"),
+				pod("milvus-operator-5d8f7c-abcde", "kube-system", "milvus-operator"),
+				pod("coredns-7f9b-xyz12", "kube-system", "coredns"),
+				pod("prod-milvus-standalone-0", "milvus", "milvus"),
+			},
+			wantPods: []string{"milvus-operator-5d8f7c-abcde"},
+		},
+		{
+			name:    "operator not installed",
+			objects: []runtime.Object{pod("prod-milvus-standalone-0", "milvus", "milvus")},
+			wantErr: ErrOperatorNotInstalled,
+		},
+		{
+			name:    "operator without pods",
+			objects: []runtime.Object{operator("milvus-operator")},
+			wantErr: ErrNoPods,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := newFakeKubernetesExecutor(t, nil, tt.objects...)
+
+			logs, err := e.PodLogs(context.Background(), LogsOptions{Operator: true, Tail: 10})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PodLogs() error = %v, want %v", err, tt.wantErr)
+			}
+			var pods []string
+			for _, l := range logs {
+				if l.Err != nil {
+					t.Errorf("logs of %s: %v", l.Pod, l.Err)
+				}
+				pods = append(pods, l.Pod)
+			}
+			if !slices.Equal(pods, tt.wantPods) {
+				t.Errorf("PodLogs() pods = %v, want %v", pods, tt.wantPods)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	"github.com/mmga-lab/miup/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// drainTimeout bounds each wait for pods while draining a node
+const drainTimeout = 5 * time.Minute
+
+// surgeComponents are the stateless components whose pods are replaced
+// before they are deleted, so their capacity never drops while draining
+var surgeComponents = []string{"proxy", "querynode", "datanode", "indexnode"}
+
+// NodePod is a pod of the cluster and the node it is scheduled on
+type NodePod struct {
+	Name      string `json:"name"`
+	Component string `json:"component"`
+	Node      string `json:"node"`
+	Ready     bool   `json:"ready"`
+
+	// Moved is set by DrainNode once the pod has been replaced on another node
+	Moved bool `json:"moved,omitempty"`
+}
+
+// PodsOnNode returns the cluster's pods scheduled on a node, sorted by name
+func (e *KubernetesExecutor) PodsOnNode(ctx context.Context, node string) ([]NodePod, error) {
+	pods, err := e.client.ListMilvusPodObjects(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, err
+	}
+	return podsOnNode(pods, node), nil
+}
+
+// podsOnNode returns the pods scheduled on node, sorted by name
+func podsOnNode(pods []corev1.Pod, node string) []NodePod {
+	var result []NodePod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName != node {
+			continue
+		}
+		result = append(result, NodePod{
+			Name:      pod.Name,
+			Component: pod.Labels["app.kubernetes.io/component"],
+			Node:      pod.Spec.NodeName,
+			Ready:     k8s.IsPodReady(pod),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// DrainNode cordons a node and moves the cluster's pods off it. A pod of a
+// stateless component (proxy, querynode, datanode, indexnode) is moved by
+// scaling the component up by one, deleting the pod once the extra replica
+// is ready and scaling back, so queries keep being served. Other pods
+// (standalone, coordinators) have a single replica and are deleted and
+// rescheduled elsewhere by their deployment, which interrupts that
+// component, so confirm is asked before deleting each; a declined pod is
+// left on the node. A nil confirm deletes them without asking. It returns the
+// pods that were on the node, and an error wrapping ErrDrainDeclined if any
+// was left.
+func (e *KubernetesExecutor) DrainNode(ctx context.Context, node string, confirm func(pod NodePod) bool) ([]NodePod, error) {
+	logger.Info("Cordoning node %s...", node)
+	if err := e.client.CordonNode(ctx, node); err != nil {
+		return nil, err
+	}
+
+	pods, err := e.PodsOnNode(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	var declined []string
+	for i := range pods {
+		pod := &pods[i]
+		if slices.Contains(surgeComponents, pod.Component) {
+			err = e.surgeReplace(ctx, pod)
+		} else {
+			if confirm != nil && !confirm(*pod) {
+				logger.Warn("Leaving %s pod %s on %s", pod.Component, pod.Name, node)
+				declined = append(declined, pod.Name)
+				continue
+			}
+			logger.Warn("Deleting %s pod %s; the component is unavailable until it is rescheduled", pod.Component, pod.Name)
+			err = e.replacePod(ctx, pod)
+		}
+		if err != nil {
+			return pods, fmt.Errorf("failed to move pod %s: %w", pod.Name, err)
+		}
+		pod.Moved = true
+		logger.Success("Moved %s off %s", pod.Name, node)
+	}
+
+	if len(declined) > 0 {
+		return pods, fmt.Errorf("%w: %s left on %s", ErrDrainDeclined, strings.Join(declined, ", "), node)
+	}
+	return pods, nil
+}
+
+// surgeReplace replaces a pod of a scalable component by scaling up by one,
+// deleting the pod and scaling back down
+func (e *KubernetesExecutor) surgeReplace(ctx context.Context, pod *NodePod) error {
+	counts, err := e.GetReplicaCounts(ctx)
+	if err != nil {
+		return err
+	}
+	desired := counts[pod.Component].Desired
+
+	// Scale waits until the extra replica is ready
+	logger.Info("Scaling %s to %d replicas to replace %s...", pod.Component, desired+1, pod.Name)
+	if err := e.Scale(ctx, pod.Component, ScaleOptions{Replicas: desired + 1}); err != nil {
+		return err
+	}
+
+	replaceErr := e.replacePod(ctx, pod)
+
+	// Scale back even if the replacement failed, so the surge doesn't linger
+	logger.Info("Scaling %s back to %d replicas...", pod.Component, desired)
+	if err := e.Scale(ctx, pod.Component, ScaleOptions{Replicas: desired}); err != nil {
+		if replaceErr == nil {
+			replaceErr = err
+		}
+	}
+	return replaceErr
+}
+
+// replacePod deletes a pod and waits until it is gone and its component has
+// all its replicas ready again
+func (e *KubernetesExecutor) replacePod(ctx context.Context, pod *NodePod) error {
+	if err := e.client.DeletePod(ctx, e.namespace, pod.Name); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(drainTimeout)
+	for time.Now().Before(deadline) {
+		pods, err := e.client.ListMilvusPodObjects(ctx, e.clusterName, e.namespace)
+		if err == nil && !slices.ContainsFunc(pods, func(p corev1.Pod) bool { return p.Name == pod.Name }) {
+			counts, err := e.GetReplicaCounts(ctx)
+			if count := counts[pod.Component]; err == nil && count.Ready >= count.Desired {
+				return nil
+			}
+		}
+		if err := sleepContext(ctx, 5*time.Second); err != nil {
+			return fmt.Errorf("%w: %w", ErrWaitCancelled, err)
+		}
+	}
+	return fmt.Errorf("%w: pod %s was not replaced within %s", ErrTimeout, pod.Name, drainTimeout)
+}
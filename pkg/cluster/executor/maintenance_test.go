@@ -0,0 +1,220 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPodsOnNode(t *testing.T) {
+	pod := func(name, component, node string, ready bool) corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"app.kubernetes.io/component": component}},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		}
+	}
+
+	pods := []corev1.Pod{
+		pod("prod-milvus-querynode-b", "querynode", "node-1", true),
+		pod("prod-milvus-proxy-a", "proxy", "node-2", true),
+		pod("prod-milvus-querynode-a", "querynode", "node-1", false),
+		pod("prod-milvus-pending", "datanode", "", false),
+	}
+
+	got := podsOnNode(pods, "node-1")
+	want := []NodePod{
+		{Name: "prod-milvus-querynode-a", Component: "querynode", Node: "node-1"},
+		{Name: "prod-milvus-querynode-b", Component: "querynode", Node: "node-1", Ready: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("podsOnNode() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("podsOnNode()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if got := podsOnNode(pods, "node-3"); len(got) != 0 {
+		t.Errorf("podsOnNode(node-3) = %+v, want none", got)
+	}
+}
+
+// operatorReconciles makes every update of a Milvus resource report it
+// healthy with the replicas of its spec ready, as the operator would once
+// it has caught up
+func operatorReconciles(t *testing.T, dynamicClient *dynamicfake.FakeDynamicClient) {
+	dynamicClient.PrependReactor("update", k8s.MilvusResource, func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.UpdateAction).GetObject().(*unstructured.Unstructured)
+		data, err := json.Marshal(obj.Object)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var milvus k8s.Milvus
+		if err := json.Unmarshal(data, &milvus); err != nil {
+			t.Fatal(err)
+		}
+
+		milvus.Status.Status = "Healthy"
+		milvus.Status.ComponentsDeployStatus = make(map[string]k8s.ComponentDeployStatus)
+		for name, count := range replicaCounts(&milvus) {
+			status := k8s.DeploymentStatus{Replicas: int32(count.Desired), ReadyReplicas: int32(count.Desired)}
+			milvus.Status.ComponentsDeployStatus[name] = k8s.ComponentDeployStatus{Status: status}
+		}
+
+		if data, err = json.Marshal(&milvus); err != nil {
+			t.Fatal(err)
+		}
+		obj.Object = nil
+		if err := json.Unmarshal(data, &obj.Object); err != nil {
+			t.Fatal(err)
+		}
+		return false, nil, nil
+	})
+}
+
+func TestDrainNode(t *testing.T) {
+	ctx := context.Background()
+	two, one := int32(2), int32(1)
+	yes, no := true, false
+	newMilvus := func() *k8s.Milvus {
+		return &k8s.Milvus{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "milvus"},
+			Spec: k8s.MilvusSpec{
+				Mode: k8s.MilvusModeCluster,
+				Components: k8s.MilvusComponents{
+					QueryNode: &k8s.ComponentSpec{Replicas: &two},
+					MixCoord:  &k8s.ComponentSpec{Replicas: &one},
+				},
+			},
+			Status: k8s.MilvusStatus{
+				Status: "Healthy",
+				ComponentsDeployStatus: map[string]k8s.ComponentDeployStatus{
+					"querynode": {Status: k8s.DeploymentStatus{Replicas: 2, ReadyReplicas: 2}},
+					"mixcoord":  {Status: k8s.DeploymentStatus{Replicas: 1, ReadyReplicas: 1}},
+				},
+			},
+		}
+	}
+	pod := func(name, component, node string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "milvus", Labels: map[string]string{
+				"app.kubernetes.io/instance":  "prod",
+				"app.kubernetes.io/component": component,
+			}},
+			Spec: corev1.PodSpec{NodeName: node},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		confirm           *bool
+		wantAsked         []string
+		wantLeft          []string
+		wantErr           error
+		wantMixcoordMoved bool
+	}{
+		{
+			name:              "deleted without asking",
+			wantLeft:          []string{"prod-milvus-querynode-b"},
+			wantMixcoordMoved: true,
+		},
+		{
+			name:              "deletion confirmed",
+			confirm:           &yes,
+			wantAsked:         []string{"prod-milvus-mixcoord-a"},
+			wantLeft:          []string{"prod-milvus-querynode-b"},
+			wantMixcoordMoved: true,
+		},
+		{
+			name:      "deletion declined",
+			confirm:   &no,
+			wantAsked: []string{"prod-milvus-mixcoord-a"},
+			wantLeft:  []string{"prod-milvus-mixcoord-a", "prod-milvus-querynode-b"},
+			wantErr:   ErrDrainDeclined,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, clientset, dynamicClient := newFakeExecutorClients(t, []*k8s.Milvus{newMilvus()},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+				pod("prod-milvus-querynode-a", "querynode", "node-1"),
+				pod("prod-milvus-mixcoord-a", "mixcoord", "node-1"),
+				pod("prod-milvus-querynode-b", "querynode", "node-2"))
+			// External dependencies leave no etcd or MinIO pods to wait for
+			e.spec = &spec.Specification{
+				EtcdServers:  []spec.EtcdSpec{{Host: "etcd.example.com"}},
+				MinioServers: []spec.MinioSpec{{Host: "minio.example.com"}},
+			}
+			operatorReconciles(t, dynamicClient)
+
+			var asked []string
+			var confirm func(NodePod) bool
+			if tt.confirm != nil {
+				confirm = func(pod NodePod) bool {
+					asked = append(asked, pod.Name)
+					return *tt.confirm
+				}
+			}
+
+			pods, err := e.DrainNode(ctx, "node-1", confirm)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DrainNode() error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(asked, tt.wantAsked) {
+				t.Errorf("asked to confirm %v, want %v", asked, tt.wantAsked)
+			}
+
+			moved := make(map[string]bool)
+			for _, p := range pods {
+				moved[p.Name] = p.Moved
+			}
+			if !moved["prod-milvus-querynode-a"] || moved["prod-milvus-mixcoord-a"] != tt.wantMixcoordMoved {
+				t.Errorf("moved = %v, want querynode-a moved and mixcoord-a moved %t", moved, tt.wantMixcoordMoved)
+			}
+
+			node, err := clientset.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{})
+			if err != nil || !node.Spec.Unschedulable {
+				t.Errorf("node-1 not cordoned: %v", err)
+			}
+			list, err := clientset.CoreV1().Pods("milvus").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var left []string
+			for _, p := range list.Items {
+				left = append(left, p.Name)
+			}
+			slices.Sort(left)
+			if !slices.Equal(left, tt.wantLeft) {
+				t.Errorf("pods left = %v, want %v", left, tt.wantLeft)
+			}
+
+			// The surge replica is scaled away again
+			counts, err := e.GetReplicaCounts(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := counts["querynode"]; got.Desired != 2 {
+				t.Errorf("querynode replicas = %d, want 2 after the surge", got.Desired)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+// InstanceLabel ties a namespace created by miup to the instance it was
+// created for
+const InstanceLabel = "miup.io/instance"
+
+// namespaceLabels returns the labels of a namespace created for an instance
+func namespaceLabels(clusterName string) map[string]string {
+	return map[string]string{
+		ManagedByLabel: "miup",
+		InstanceLabel:  clusterName,
+	}
+}
+
+// EnsureNamespace creates the cluster's namespace if it does not exist yet,
+// labelled with the instance. It reports whether the namespace was created.
+func (e *KubernetesExecutor) EnsureNamespace(ctx context.Context) (bool, error) {
+	_, err := e.client.GetNamespace(ctx, e.namespace)
+	if err == nil {
+		return false, nil
+	}
+	if !k8s.IsNotFound(err) {
+		return false, err
+	}
+
+	if err := e.client.CreateNamespace(ctx, e.namespace, namespaceLabels(e.clusterName)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CheckPermissions returns ErrPermissionDenied, listing what is missing, if
+// the current user may not deploy and manage the cluster. See
+// k8s.CheckDeployPermissions for when creating the namespace is checked.
+func (e *KubernetesExecutor) CheckPermissions(ctx context.Context, createNamespace bool) error {
+	_, missing, err := e.client.CheckDeployPermissions(ctx, e.namespace, createNamespace)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: cannot %s; ask a cluster administrator to grant them",
+		ErrPermissionDenied, strings.Join(k8s.PermissionNames(missing), ", "))
+}
+
+// DeleteNamespace deletes the cluster's namespace if it was created for this
+// cluster and holds nothing else: no other Milvus resources, and no
+// workloads, services or PVCs besides the cluster's own (which may still be
+// terminating, or kept by the deletion policy)
+func (e *KubernetesExecutor) DeleteNamespace(ctx context.Context) error {
+	ns, err := e.client.GetNamespace(ctx, e.namespace)
+	if err != nil {
+		if k8s.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if ns.Labels[InstanceLabel] != e.clusterName {
+		return fmt.Errorf("%w: namespace %s is not labelled %s=%s", ErrNamespaceNotOwned, e.namespace, InstanceLabel, e.clusterName)
+	}
+
+	milvuses, err := e.client.ListMilvus(ctx, e.namespace)
+	if err != nil {
+		return err
+	}
+	objects, err := e.client.NamespaceObjects(ctx, e.namespace)
+	if err != nil {
+		return err
+	}
+	if others := namespaceOthers(e.clusterName, milvuses.Items, objects); len(others) > 0 {
+		return fmt.Errorf("%w: namespace %s still contains %s", ErrNamespaceNotEmpty, e.namespace, strings.Join(others, ", "))
+	}
+
+	return e.client.DeleteNamespace(ctx, e.namespace)
+}
+
+// namespaceOthers lists the Milvus resources and other objects in a
+// namespace that don't belong to the cluster
+func namespaceOthers(clusterName string, milvuses []k8s.Milvus, objects []k8s.NamespaceObject) []string {
+	var others []string
+	for _, m := range milvuses {
+		if m.Name != clusterName {
+			others = append(others, "milvus/"+m.Name)
+		}
+	}
+	for _, obj := range objects {
+		if !belongsTo(clusterName, obj) {
+			others = append(others, obj.Kind+"/"+obj.GetName())
+		}
+	}
+	return others
+}
+
+// dependencyReleases are the dependencies the Milvus Operator deploys for a
+// cluster as releases named <cluster>-<dependency>
+var dependencyReleases = []string{"etcd", "minio", "pulsar", "kafka"}
+
+// belongsTo reports whether an object is part of the cluster: owned by its
+// Milvus resource, or labelled by the operator with the cluster or the
+// release of one of its dependencies. Names are not enough, as those of
+// cluster "prod" are a prefix of those of cluster "prod-2".
+func belongsTo(clusterName string, obj k8s.NamespaceObject) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == k8s.MilvusKind && ref.Name == clusterName {
+			return true
+		}
+	}
+
+	releases := []string{clusterName}
+	for _, dep := range dependencyReleases {
+		releases = append(releases, clusterName+"-"+dep)
+	}
+	labels := obj.GetLabels()
+	return slices.Contains(releases, labels["app.kubernetes.io/instance"]) || slices.Contains(releases, labels["release"])
+}
@@ -0,0 +1,136 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNamespaceOthers(t *testing.T) {
+	milvus := func(name string) k8s.Milvus {
+		return k8s.Milvus{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	object := func(kind, name string, labels map[string]string, owner string) k8s.NamespaceObject {
+		meta := &metav1.ObjectMeta{Name: name, Labels: labels}
+		if owner != "" {
+			meta.OwnerReferences = []metav1.OwnerReference{{Kind: k8s.MilvusKind, Name: owner}}
+		}
+		return k8s.NamespaceObject{Kind: kind, Object: meta}
+	}
+	instance := func(name string) map[string]string {
+		return map[string]string{"app.kubernetes.io/instance": name}
+	}
+
+	tests := []struct {
+		name     string
+		milvuses []k8s.Milvus
+		objects  []k8s.NamespaceObject
+		want     []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "only the cluster",
+			milvuses: []k8s.Milvus{milvus("prod")},
+			objects: []k8s.NamespaceObject{
+				object("pod", "prod-milvus-standalone-5c7d9-abcde", instance("prod"), ""),
+				object("deployment", "prod-milvus-standalone", nil, "prod"),
+				object("service", "prod-milvus", nil, "prod"),
+				object("statefulset", "prod-etcd", instance("prod-etcd"), ""),
+				object("pvc", "data-prod-etcd-0", instance("prod-etcd"), ""),
+				object("pvc", "export-prod-minio-0", map[string]string{"release": "prod-minio"}, ""),
+			},
+		},
+		{
+			name:     "other instance and workloads",
+			milvuses: []k8s.Milvus{milvus("prod"), milvus("prod-2")},
+			objects: []k8s.NamespaceObject{
+				object("pod", "prod-etcd-0", instance("prod-etcd"), ""),
+				object("pod", "prod-2-etcd-0", instance("prod-2-etcd"), ""),
+				object("deployment", "prod-2-milvus-standalone", nil, "prod-2"),
+				object("deployment", "production-app", instance("production-app"), ""),
+				object("service", "prod-api", nil, ""),
+				object("pvc", "prod-backup", nil, ""),
+				object("cronjob", "prod-cleanup", instance("prod-cleanup"), ""),
+			},
+			want: []string{
+				"milvus/prod-2", "pod/prod-2-etcd-0", "deployment/prod-2-milvus-standalone",
+				"deployment/production-app", "service/prod-api", "pvc/prod-backup", "cronjob/prod-cleanup",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := namespaceOthers("prod", tt.milvuses, tt.objects)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("namespaceOthers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if labels := namespaceLabels("prod"); labels[InstanceLabel] != "prod" || labels[ManagedByLabel] != "miup" {
+		t.Errorf("namespaceLabels() = %v", labels)
+	}
+}
+
+func TestDeleteNamespace(t *testing.T) {
+	ctx := context.Background()
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "milvus", Labels: namespaceLabels("prod")}}
+	etcd := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+		Name: "data-prod-etcd-0", Namespace: "milvus", Labels: map[string]string{"app.kubernetes.io/instance": "prod-etcd"},
+	}}
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		wantErr     error
+		wantDeleted bool
+	}{
+		{
+			name:        "only kept volumes of the cluster",
+			objects:     []runtime.Object{namespace.DeepCopy(), etcd.DeepCopy()},
+			wantDeleted: true,
+		},
+		{
+			name: "service of something else",
+			objects: []runtime.Object{namespace.DeepCopy(), etcd.DeepCopy(),
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "milvus"}}},
+			wantErr: ErrNamespaceNotEmpty,
+		},
+		{
+			name: "statefulset of something else",
+			objects: []runtime.Object{namespace.DeepCopy(),
+				&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "milvus"}}},
+			wantErr: ErrNamespaceNotEmpty,
+		},
+		{
+			name:    "not created for the cluster",
+			objects: []runtime.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "milvus"}}},
+			wantErr: ErrNamespaceNotOwned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, clientset := newFakeKubernetesExecutor(t, nil, tt.objects...)
+
+			if err := e.DeleteNamespace(ctx); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteNamespace() error = %v, want %v", err, tt.wantErr)
+			}
+			_, err := clientset.CoreV1().Namespaces().Get(ctx, "milvus", metav1.GetOptions{})
+			if deleted := apierrors.IsNotFound(err); deleted != tt.wantDeleted {
+				t.Errorf("namespace deleted = %t, want %t", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
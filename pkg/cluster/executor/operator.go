@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// Operator is the state of the Milvus Operator deployment
+type Operator struct {
+	Namespace string `json:"namespace"`
+	Image     string `json:"image"`
+	Version   string `json:"version"`
+
+	Replicas  int32 `json:"replicas"`
+	Ready     int32 `json:"ready"`
+	Available int32 `json:"available"`
+	Updated   int32 `json:"updated"`
+
+	// Conditions are the deployment conditions, e.g. Available and
+	// Progressing, with the reason when they are not true
+	Conditions []ConditionInfo `json:"conditions"`
+}
+
+// Healthy reports whether every desired replica of the operator is ready
+// and runs the current version of the deployment
+func (o *Operator) Healthy() bool {
+	return o.Replicas > 0 && o.Ready == o.Replicas && o.Updated == o.Replicas
+}
+
+// DescribeOperator finds the Milvus Operator deployment the same way
+// 'miup instance check' does and returns its state. It returns
+// ErrOperatorNotInstalled if the Milvus CRD is missing, and a nil Operator
+// if the CRD exists but the deployment is not in MilvusOperatorNamespaces.
+// Namespace and ClusterName in opts are ignored.
+func DescribeOperator(ctx context.Context, opts KubernetesOptions) (*Operator, error) {
+	client, err := k8s.NewClient(k8s.ClientOptions{
+		Kubeconfig: opts.Kubeconfig,
+		Context:    opts.Context,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	installed, err := client.CheckMilvusOperatorInstalled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check Milvus Operator: %w", err)
+	}
+	if !installed {
+		return nil, fmt.Errorf("%w: the %s/%s CRD was not found", ErrOperatorNotInstalled, k8s.MilvusGroup, k8s.MilvusVersion)
+	}
+
+	deploy, err := client.FindMilvusOperator(ctx)
+	if err != nil || deploy == nil {
+		return nil, err
+	}
+	return describeOperator(deploy), nil
+}
+
+// describeOperator converts the operator deployment into an Operator
+func describeOperator(deploy *appsv1.Deployment) *Operator {
+	o := &Operator{
+		Namespace:  deploy.Namespace,
+		Replicas:   1,
+		Ready:      deploy.Status.ReadyReplicas,
+		Available:  deploy.Status.AvailableReplicas,
+		Updated:    deploy.Status.UpdatedReplicas,
+		Conditions: []ConditionInfo{},
+	}
+	if deploy.Spec.Replicas != nil {
+		o.Replicas = *deploy.Spec.Replicas
+	}
+
+	// The operator runs in the "manager" container; fall back to the first
+	containers := deploy.Spec.Template.Spec.Containers
+	for i, c := range containers {
+		if i == 0 || c.Name == "manager" {
+			o.Image = c.Image
+		}
+	}
+	o.Version = deploy.Labels["app.kubernetes.io/version"]
+	if o.Version == "" {
+		o.Version = ImageVersion(o.Image)
+	}
+
+	for _, cond := range deploy.Status.Conditions {
+		o.Conditions = append(o.Conditions, ConditionInfo{
+			Type:               string(cond.Type),
+			Status:             string(cond.Status),
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastTransitionTime: cond.LastTransitionTime.Time,
+		})
+	}
+	return o
+}
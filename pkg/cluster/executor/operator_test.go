@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func operatorDeployment(replicas, ready, updated int32, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "milvus-operator", Namespace: "milvus-operator", Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "kube-rbac-proxy", Image: "gcr.io/kubebuilder/kube-rbac-proxy:v0.13.1"},
+				{Name: "manager", Image: "milvusdb/milvus-operator:v1.1.2"},
+			}}},
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas:     ready,
+			AvailableReplicas: ready,
+			UpdatedReplicas:   updated,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue, Reason: "MinimumReplicasAvailable"},
+			},
+		},
+	}
+}
+
+func TestDescribeOperator(t *testing.T) {
+	tests := []struct {
+		name        string
+		deploy      *appsv1.Deployment
+		wantVersion string
+		wantHealthy bool
+	}{
+		{
+			name:        "healthy",
+			deploy:      operatorDeployment(1, 1, 1, nil),
+			wantVersion: "v1.1.2",
+			wantHealthy: true,
+		},
+		{
+			name:        "version label",
+			deploy:      operatorDeployment(1, 1, 1, map[string]string{"app.kubernetes.io/version": "1.1.3"}),
+			wantVersion: "1.1.3",
+			wantHealthy: true,
+		},
+		{
+			name:        "not ready",
+			deploy:      operatorDeployment(2, 1, 2, nil),
+			wantVersion: "v1.1.2",
+		},
+		{
+			name:        "rolling out",
+			deploy:      operatorDeployment(1, 1, 0, nil),
+			wantVersion: "v1.1.2",
+		},
+		{
+			name:        "scaled to zero",
+			deploy:      operatorDeployment(0, 0, 0, nil),
+			wantVersion: "v1.1.2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := describeOperator(tt.deploy)
+			if o.Image != "milvusdb/milvus-operator:v1.1.2" {
+				t.Errorf("Image = %q, want the manager container's", o.Image)
+			}
+			if o.Version != tt.wantVersion {
+				t.Errorf("Version = %q, want %q", o.Version, tt.wantVersion)
+			}
+			if o.Healthy() != tt.wantHealthy {
+				t.Errorf("Healthy() = %v, want %v", o.Healthy(), tt.wantHealthy)
+			}
+			if len(o.Conditions) != 1 || o.Conditions[0].Type != "Available" {
+				t.Errorf("Conditions = %+v, want Available", o.Conditions)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	"github.com/mmga-lab/miup/pkg/timing"
+)
+
+// PlannedChange is a field of the Milvus resource that an operation changes
+type PlannedChange struct {
+	// Path is the dotted path of the field, e.g.
+	// spec.components.queryNode.replicas
+	Path string `json:"path"`
+
+	// Old is the current value, nil if the field is added
+	Old any `json:"old,omitempty"`
+
+	// New is the target value, nil if the field is removed
+	New any `json:"new,omitempty"`
+}
+
+// Plan collects the changes a dry run would make to the Milvus resource.
+// An executor created with a Plan records its updates there instead of
+// applying them, and returns without waiting for the cluster.
+type Plan struct {
+	// Resource is the namespace/name of the Milvus resource
+	Resource string          `json:"resource"`
+	Changes  []PlannedChange `json:"changes"`
+}
+
+// updateMilvus updates the Milvus resource, or on a dry run records how it
+// differs from the live resource in the plan
+func (e *KubernetesExecutor) updateMilvus(ctx context.Context, milvus *k8s.Milvus) error {
+	if e.plan == nil {
+		defer timing.Start(ctx, "update Milvus resource")()
+		return e.client.UpdateMilvus(ctx, milvus)
+	}
+
+	current, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return err
+	}
+	changes, err := diffMilvus(current, milvus)
+	if err != nil {
+		return err
+	}
+	e.plan.Resource = e.namespace + "/" + e.clusterName
+	e.plan.Changes = append(e.plan.Changes, changes...)
+	return nil
+}
+
+// dryRun reports whether updates are recorded in a plan instead of applied
+func (e *KubernetesExecutor) dryRun() bool {
+	return e.plan != nil
+}
+
+// diffMilvus returns the changes to the metadata and spec that turn from
+// into to, sorted by path. Lists are compared as a whole.
+func diffMilvus(from, to *k8s.Milvus) ([]PlannedChange, error) {
+	a, err := milvusFields(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := milvusFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []PlannedChange{}
+	diffFields("", a, b, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// milvusFields returns the metadata and spec of a Milvus resource as JSON
+// maps, leaving out fields the API server maintains
+func milvusFields(milvus *k8s.Milvus) (map[string]any, error) {
+	data, err := json.Marshal(milvus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Milvus resource: %w", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to encode Milvus resource: %w", err)
+	}
+
+	delete(fields, "status")
+	if metadata, ok := fields["metadata"].(map[string]any); ok {
+		for _, key := range []string{"resourceVersion", "generation", "managedFields", "creationTimestamp", "uid"} {
+			delete(metadata, key)
+		}
+	}
+	return fields, nil
+}
+
+func diffFields(prefix string, from, to map[string]any, changes *[]PlannedChange) {
+	for key, old := range from {
+		path := prefix + key
+		value, ok := to[key]
+		if !ok {
+			*changes = append(*changes, PlannedChange{Path: path, Old: old})
+			continue
+		}
+		oldSection, oldIsSection := old.(map[string]any)
+		section, isSection := value.(map[string]any)
+		if oldIsSection && isSection {
+			diffFields(path+".", oldSection, section, changes)
+		} else if !reflect.DeepEqual(old, value) {
+			*changes = append(*changes, PlannedChange{Path: path, Old: old, New: value})
+		}
+	}
+	for key, value := range to {
+		if _, ok := from[key]; !ok {
+			*changes = append(*changes, PlannedChange{Path: prefix + key, New: value})
+		}
+	}
+}
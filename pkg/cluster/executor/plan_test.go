@@ -0,0 +1,52 @@
+package executor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiffMilvus(t *testing.T) {
+	three, five := int32(3), int32(5)
+	from := &k8s.Milvus{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", ResourceVersion: "1"},
+		Spec: k8s.MilvusSpec{
+			Mode: k8s.MilvusModeCluster,
+			Components: k8s.MilvusComponents{
+				Image:     "milvusdb/milvus:v2.5.4",
+				QueryNode: &k8s.ComponentSpec{Replicas: &three},
+			},
+		},
+		Status: k8s.MilvusStatus{Status: "Healthy"},
+	}
+	to := &k8s.Milvus{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", ResourceVersion: "2"},
+		Spec: k8s.MilvusSpec{
+			Mode: k8s.MilvusModeCluster,
+			Components: k8s.MilvusComponents{
+				Image:     "milvusdb/milvus:v2.6.0",
+				QueryNode: &k8s.ComponentSpec{Replicas: &five},
+				DataNode:  &k8s.ComponentSpec{Replicas: &three},
+			},
+		},
+	}
+
+	changes, err := diffMilvus(from, to)
+	if err != nil {
+		t.Fatalf("diffMilvus() error = %v", err)
+	}
+	want := []PlannedChange{
+		{Path: "spec.components.dataNode", New: map[string]any{"replicas": float64(3)}},
+		{Path: "spec.components.image", Old: "milvusdb/milvus:v2.5.4", New: "milvusdb/milvus:v2.6.0"},
+		{Path: "spec.components.queryNode.replicas", Old: float64(3), New: float64(5)},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("diffMilvus() = %+v, want %+v", changes, want)
+	}
+
+	if changes, _ := diffMilvus(from, from); len(changes) != 0 {
+		t.Errorf("diffMilvus() of identical resources = %+v, want none", changes)
+	}
+}
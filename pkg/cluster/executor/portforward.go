@@ -0,0 +1,91 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+// Forward is a local port forwarded to a port of a cluster service
+type Forward struct {
+	// Name describes what the port serves, e.g. "Milvus" or "MinIO console"
+	Name string `json:"name"`
+
+	Service   string `json:"service"`
+	Port      int    `json:"port"`
+	LocalPort int    `json:"local_port"`
+}
+
+// Ports forwarded by a debugging session along with k8s.MilvusPort
+const (
+	metricsPort      = 9091
+	minioConsolePort = 9001
+)
+
+// DebugForwards returns the forwards of a debugging session: Milvus, its
+// metrics port and, for an in-cluster MinIO, the MinIO console. Each local
+// port defaults to the remote port.
+func (e *KubernetesExecutor) DebugForwards() []Forward {
+	return debugForwards(e.clusterName, e.spec.ExternalMinio())
+}
+
+func debugForwards(clusterName string, externalMinio bool) []Forward {
+	forwards := []Forward{
+		{Name: "Milvus", Service: clusterName + "-milvus", Port: k8s.MilvusPort, LocalPort: k8s.MilvusPort},
+		{Name: "Metrics", Service: clusterName + "-milvus", Port: metricsPort, LocalPort: metricsPort},
+	}
+	if !externalMinio {
+		forwards = append(forwards, Forward{Name: "MinIO console", Service: clusterName + "-minio", Port: minioConsolePort, LocalPort: minioConsolePort})
+	}
+	return forwards
+}
+
+// PortForward forwards each port on address until ctx is cancelled or a
+// forward fails, which stops the others. ready is called for each forward
+// once its local port listens.
+func (e *KubernetesExecutor) PortForward(ctx context.Context, address string, forwards []Forward, ready func(Forward)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for _, f := range forwards {
+		pod, podPort, err := e.client.ResolveServicePort(ctx, e.namespace, f.Service, f.Port)
+		if err != nil {
+			cancel()
+			wg.Wait()
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+
+		readyCh := make(chan struct{})
+		ports := []string{strconv.Itoa(f.LocalPort) + ":" + strconv.Itoa(podPort)}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := e.client.PortForward(ctx, e.namespace, pod, address, ports, readyCh, io.Discard, io.Discard)
+			if err != nil && ctx.Err() == nil {
+				once.Do(func() { firstErr = fmt.Errorf("%s: %w", f.Name, err) })
+				cancel()
+			}
+		}()
+		go func() {
+			select {
+			case <-readyCh:
+				if ready != nil {
+					ready(f)
+				}
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	wg.Wait()
+	return firstErr
+}
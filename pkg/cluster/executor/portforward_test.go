@@ -0,0 +1,25 @@
+package executor
+
+import "testing"
+
+func TestDebugForwards(t *testing.T) {
+	forwards := debugForwards("prod", false)
+	if len(forwards) != 3 {
+		t.Fatalf("debugForwards() = %v, want 3 forwards", forwards)
+	}
+	for _, f := range forwards {
+		if f.LocalPort != f.Port {
+			t.Errorf("%s: local port %d, want %d", f.Name, f.LocalPort, f.Port)
+		}
+	}
+	if forwards[0].Service != "prod-milvus" || forwards[0].Port != 19530 {
+		t.Errorf("Milvus forward = %+v", forwards[0])
+	}
+	if forwards[2].Service != "prod-minio" {
+		t.Errorf("MinIO forward = %+v", forwards[2])
+	}
+
+	if forwards := debugForwards("prod", true); len(forwards) != 2 {
+		t.Errorf("debugForwards(external MinIO) = %v, want no MinIO forward", forwards)
+	}
+}
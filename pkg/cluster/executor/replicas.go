@@ -0,0 +1,67 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+// ReplicaCount is the desired and ready replica count of a component
+type ReplicaCount struct {
+	// Desired is the replica count requested in the Milvus resource
+	Desired int `json:"desired"`
+
+	// Ready is the number of ready pods reported by the Milvus Operator
+	Ready int `json:"ready"`
+}
+
+// GetReplicaCounts returns the desired and ready replica count for each component
+func (e *KubernetesExecutor) GetReplicaCounts(ctx context.Context) (map[string]ReplicaCount, error) {
+	milvus, err := e.client.GetMilvus(ctx, e.clusterName, e.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Milvus cluster: %w", err)
+	}
+
+	return replicaCounts(milvus), nil
+}
+
+// replicaCounts combines the spec replicas with the operator's deploy
+// status. A component in the spec without replicas uses the replica count of
+// its deployment if one is reported, and the operator's default of one if not.
+func replicaCounts(milvus *k8s.Milvus) map[string]ReplicaCount {
+	specs := componentSpecs(milvus)
+
+	counts := make(map[string]ReplicaCount)
+	for name, status := range milvus.Status.ComponentsDeployStatus {
+		counts[name] = ReplicaCount{
+			Desired: int(status.Status.Replicas),
+			Ready:   int(status.Status.ReadyReplicas),
+		}
+	}
+	for name, s := range specs {
+		if s == nil {
+			continue
+		}
+		count, reported := counts[name]
+		switch {
+		case s.Replicas != nil:
+			count.Desired = int(*s.Replicas)
+		case !reported:
+			count.Desired = 1
+		}
+		counts[name] = count
+	}
+	return counts
+}
+
+// componentSpecs returns the component specs of the Milvus resource's mode
+// by component name; components missing from the spec are nil
+func componentSpecs(milvus *k8s.Milvus) map[string]*k8s.ComponentSpec {
+	specs := milvus.Spec.Components.ComponentSpecs()
+	if milvus.Spec.Mode != k8s.MilvusModeCluster {
+		return map[string]*k8s.ComponentSpec{"standalone": specs["standalone"]}
+	}
+	delete(specs, "standalone")
+	return specs
+}
@@ -0,0 +1,139 @@
+package executor
+
+import (
+	"encoding/json"
+	"maps"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+)
+
+func TestReplicaCounts(t *testing.T) {
+	two, three, zero := int32(2), int32(3), int32(0)
+	deployStatus := func(replicas, ready int32) k8s.ComponentDeployStatus {
+		return k8s.ComponentDeployStatus{Status: k8s.DeploymentStatus{Replicas: replicas, ReadyReplicas: ready}}
+	}
+
+	tests := []struct {
+		name   string
+		milvus *k8s.Milvus
+		want   map[string]ReplicaCount
+	}{
+		{
+			name: "scaling up",
+			milvus: &k8s.Milvus{
+				Spec: k8s.MilvusSpec{
+					Mode: k8s.MilvusModeCluster,
+					Components: k8s.MilvusComponents{
+						Proxy:     &k8s.ComponentSpec{},
+						QueryNode: &k8s.ComponentSpec{Replicas: &three},
+					},
+				},
+				Status: k8s.MilvusStatus{
+					ComponentsDeployStatus: map[string]k8s.ComponentDeployStatus{
+						"proxy":     deployStatus(1, 1),
+						"querynode": deployStatus(2, 2),
+					},
+				},
+			},
+			want: map[string]ReplicaCount{
+				"proxy":     {Desired: 1, Ready: 1},
+				"querynode": {Desired: 3, Ready: 2},
+			},
+		},
+		{
+			name: "streaming node",
+			milvus: &k8s.Milvus{
+				Spec: k8s.MilvusSpec{
+					Mode: k8s.MilvusModeCluster,
+					Components: k8s.MilvusComponents{
+						MixCoord:      &k8s.ComponentSpec{},
+						StreamingNode: &k8s.ComponentSpec{Replicas: &two},
+					},
+				},
+				Status: k8s.MilvusStatus{
+					ComponentsDeployStatus: map[string]k8s.ComponentDeployStatus{
+						"mixcoord":      deployStatus(1, 1),
+						"streamingnode": deployStatus(2, 1),
+					},
+				},
+			},
+			want: map[string]ReplicaCount{
+				"mixcoord":      {Desired: 1, Ready: 1},
+				"streamingnode": {Desired: 2, Ready: 1},
+			},
+		},
+		{
+			name: "component without a field",
+			milvus: &k8s.Milvus{
+				Spec: k8s.MilvusSpec{
+					Mode: k8s.MilvusModeCluster,
+					Components: k8s.MilvusComponents{
+						Extra: map[string]json.RawMessage{"futureNode": json.RawMessage(`{"replicas":2}`)},
+					},
+				},
+				Status: k8s.MilvusStatus{
+					ComponentsDeployStatus: map[string]k8s.ComponentDeployStatus{"futurenode": deployStatus(2, 2)},
+				},
+			},
+			want: map[string]ReplicaCount{"futurenode": {Desired: 2, Ready: 2}},
+		},
+		{
+			name: "not deployed yet",
+			milvus: &k8s.Milvus{
+				Spec: k8s.MilvusSpec{
+					Mode:       k8s.MilvusModeStandalone,
+					Components: k8s.MilvusComponents{Standalone: &k8s.ComponentSpec{}},
+				},
+			},
+			want: map[string]ReplicaCount{"standalone": {Desired: 1}},
+		},
+		{
+			name: "stopped",
+			milvus: &k8s.Milvus{
+				Spec: k8s.MilvusSpec{
+					Mode:       k8s.MilvusModeStandalone,
+					Components: k8s.MilvusComponents{Standalone: &k8s.ComponentSpec{Replicas: &zero}},
+				},
+				Status: k8s.MilvusStatus{
+					ComponentsDeployStatus: map[string]k8s.ComponentDeployStatus{"standalone": deployStatus(1, 1)},
+				},
+			},
+			want: map[string]ReplicaCount{"standalone": {Desired: 0, Ready: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replicaCounts(tt.milvus); !maps.Equal(got, tt.want) {
+				t.Errorf("replicaCounts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagnoseComponentsUsesDesiredReplicas(t *testing.T) {
+	three := int32(3)
+	milvus := &k8s.Milvus{
+		Spec: k8s.MilvusSpec{
+			Mode:       k8s.MilvusModeCluster,
+			Components: k8s.MilvusComponents{QueryNode: &k8s.ComponentSpec{Replicas: &three}},
+		},
+		Status: k8s.MilvusStatus{
+			// The deployment has not caught up with the spec yet
+			ComponentsDeployStatus: map[string]k8s.ComponentDeployStatus{"querynode": {}},
+		},
+	}
+
+	e := &KubernetesExecutor{clusterName: "prod", namespace: "milvus"}
+	result := &DiagnoseResult{Healthy: true}
+	e.diagnoseComponents(milvus, result)
+
+	check := result.Components[0]
+	if check.Name != "querynode" || check.Replicas != 3 || check.Ready != 0 || check.Status != CheckStatusError {
+		t.Errorf("querynode check = %+v, want 0/3 ready as an error", check)
+	}
+	if result.Healthy {
+		t.Error("a component with no ready replicas should make the cluster unhealthy")
+	}
+}
@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExportCRD(t *testing.T) {
+	milvus := &k8s.Milvus{ObjectMeta: metav1.ObjectMeta{
+		Name:          "prod",
+		Namespace:     "milvus",
+		ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "miup"}},
+	}}
+	e, _ := newFakeKubernetesExecutor(t, []*k8s.Milvus{milvus})
+
+	data, err := e.ExportCRD(context.Background())
+	if err != nil {
+		t.Fatalf("ExportCRD() error = %v", err)
+	}
+	if !strings.Contains(string(data), "name: prod") {
+		t.Errorf("ExportCRD() = %s, want the prod resource", data)
+	}
+	if strings.Contains(string(data), "managedFields") {
+		t.Errorf("ExportCRD() = %s, want managedFields dropped", data)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	base := time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC)
+	event := func(name, object string, at time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "milvus"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: object},
+			Reason:         name,
+			Message:        " " + name + " \n",
+			LastTimestamp:  metav1.NewTime(at),
+		}
+	}
+	e, _ := newFakeKubernetesExecutor(t, nil,
+		event("late", "prod-milvus-proxy-0", base.Add(time.Minute)),
+		event("early", "prod-etcd-0", base),
+		event("other", "prod2-milvus-proxy-0", base),
+	)
+
+	events, err := e.Events(context.Background())
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	if len(events) != 2 || events[0].Reason != "early" || events[1].Reason != "late" {
+		t.Fatalf("Events() = %+v, want early then late of prod only", events)
+	}
+	if events[0].Object != "pod/prod-etcd-0" || events[0].Message != "early" {
+		t.Errorf("Events()[0] = %+v, want object pod/prod-etcd-0 and a trimmed message", events[0])
+	}
+}
+
+func TestPodManifests(t *testing.T) {
+	pod := func(name, instance string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:          name,
+			Namespace:     "milvus",
+			Labels:        map[string]string{"app.kubernetes.io/instance": instance},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}},
+		}}
+	}
+	e, _ := newFakeKubernetesExecutor(t, nil, pod("prod-milvus-proxy-0", "prod"), pod("other-milvus-proxy-0", "other"))
+
+	manifests, err := e.PodManifests(context.Background())
+	if err != nil {
+		t.Fatalf("PodManifests() error = %v", err)
+	}
+	if len(manifests) != 1 {
+		t.Fatalf("PodManifests() = %v, want only the prod pod", manifests)
+	}
+	data := string(manifests["prod-milvus-proxy-0"])
+	if !strings.Contains(data, "name: prod-milvus-proxy-0") || strings.Contains(data, "managedFields") {
+		t.Errorf("manifest = %s, want the pod without managedFields", data)
+	}
+}
@@ -0,0 +1,188 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// VolumeComponents are the in-cluster dependencies whose volumes can be expanded
+var VolumeComponents = []string{"etcd", "minio"}
+
+// ResizeVolumesOptions defines options for expanding persistent volumes
+type ResizeVolumesOptions struct {
+	// Component selects the volumes to expand (etcd or minio)
+	Component string
+
+	// Size is the new requested storage (e.g., "200Gi")
+	Size string
+
+	// Timeout is the maximum time to wait for the resize to complete
+	Timeout time.Duration
+}
+
+// VolumeResize reports the result of expanding a persistent volume claim
+type VolumeResize struct {
+	Name         string `json:"name"`
+	StorageClass string `json:"storage_class"`
+	OldSize      string `json:"old_size"`
+	Capacity     string `json:"capacity"`
+
+	// Pending is true when the file system resize waits for a pod restart
+	Pending bool `json:"pending,omitempty"`
+}
+
+// CheckStorageClass returns ErrStorageClassNotFound if the topology sets a
+// storage class for the in-cluster dependencies that the cluster doesn't
+// have. If it can't be looked up, e.g. for lack of permission to read
+// storage classes, the PVCs will tell.
+func (e *KubernetesExecutor) CheckStorageClass(ctx context.Context) error {
+	name := e.spec.Global.StorageClass
+	if name == "" || (e.spec.ExternalEtcd() && e.spec.ExternalMinio()) {
+		return nil
+	}
+
+	_, err := e.client.GetStorageClass(ctx, name)
+	if k8s.IsNotFound(err) {
+		return fmt.Errorf("%w: '%s'; set global.storage_class to an existing one (see miup instance check)", ErrStorageClassNotFound, name)
+	}
+	return nil
+}
+
+// ResizeVolumes expands the PVCs of an in-cluster dependency and waits for
+// the new capacity to be reported. The storage class of every PVC must allow
+// volume expansion.
+func (e *KubernetesExecutor) ResizeVolumes(ctx context.Context, opts ResizeVolumesOptions) ([]VolumeResize, error) {
+	if !slices.Contains(VolumeComponents, opts.Component) {
+		return nil, fmt.Errorf("%w: %s (valid: %s)", ErrInvalidComponent, opts.Component, strings.Join(VolumeComponents, ", "))
+	}
+
+	size, err := resource.ParseQuantity(opts.Size)
+	if err != nil {
+		return nil, fmt.Errorf("invalid size '%s': %w", opts.Size, err)
+	}
+
+	pvcs, err := e.client.ListPVCs(ctx, e.namespace)
+	if err != nil {
+		return nil, err
+	}
+	pvcs = selectPVCs(pvcs, e.clusterName, opts.Component)
+	if len(pvcs) == 0 {
+		return nil, fmt.Errorf("no %s PVCs found for cluster '%s' in namespace '%s'", opts.Component, e.clusterName, e.namespace)
+	}
+
+	// Validate every PVC before patching any of them
+	results := make([]VolumeResize, len(pvcs))
+	classes := make(map[string]*storagev1.StorageClass)
+	for i, pvc := range pvcs {
+		current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if size.Cmp(current) <= 0 {
+			return nil, fmt.Errorf("new size %s must be larger than the current size %s of PVC %s", size.String(), current.String(), pvc.Name)
+		}
+
+		var className string
+		if pvc.Spec.StorageClassName != nil {
+			className = *pvc.Spec.StorageClassName
+		}
+		sc, ok := classes[className]
+		if !ok {
+			sc, err = e.client.GetStorageClass(ctx, className)
+			if err != nil {
+				return nil, err
+			}
+			classes[className] = sc
+		}
+		if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+			return nil, fmt.Errorf("storage class '%s' of PVC %s does not allow volume expansion (set allowVolumeExpansion: true on the storage class)", sc.Name, pvc.Name)
+		}
+
+		results[i] = VolumeResize{
+			Name:         pvc.Name,
+			StorageClass: sc.Name,
+			OldSize:      current.String(),
+		}
+	}
+
+	for _, pvc := range pvcs {
+		if err := e.client.ResizePVC(ctx, pvc.Name, e.namespace, size); err != nil {
+			return nil, err
+		}
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Minute
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		remaining := 0
+		for i := range results {
+			if results[i].Capacity != "" {
+				continue
+			}
+			pvc, err := e.client.GetPVC(ctx, results[i].Name, e.namespace)
+			if err != nil {
+				return nil, err
+			}
+			if capacity, pending, done := resizeStatus(pvc, size); done {
+				results[i].Capacity = capacity
+				results[i].Pending = pending
+			} else {
+				remaining++
+			}
+		}
+
+		if remaining == 0 {
+			return results, nil
+		}
+		if time.Now().After(deadline) {
+			return results, fmt.Errorf("timed out waiting for %d PVC(s) to resize", remaining)
+		}
+		if err := sleepContext(ctx, 5*time.Second); err != nil {
+			return results, err
+		}
+	}
+}
+
+// selectPVCs returns the PVCs of a cluster's dependency, selected by the
+// label of its release like its pods are, since names such as
+// "data-prod-etcd-0" are ambiguous between clusters
+func selectPVCs(pvcs []corev1.PersistentVolumeClaim, clusterName, component string) []corev1.PersistentVolumeClaim {
+	selector, err := labels.Parse(dependencySelector(clusterName, component))
+	if err != nil {
+		return nil
+	}
+	var selected []corev1.PersistentVolumeClaim
+	for _, pvc := range pvcs {
+		if selector.Matches(labels.Set(pvc.Labels)) {
+			selected = append(selected, pvc)
+		}
+	}
+	return selected
+}
+
+// resizeStatus reports whether a PVC resize has completed. A resize is also
+// considered done when only the file system resize remains, which happens
+// on the next pod restart.
+func resizeStatus(pvc *corev1.PersistentVolumeClaim, size resource.Quantity) (capacity string, pending, done bool) {
+	current := pvc.Status.Capacity[corev1.ResourceStorage]
+	if current.Cmp(size) >= 0 {
+		return current.String(), false, true
+	}
+
+	for _, cond := range pvc.Status.Conditions {
+		if cond.Type == corev1.PersistentVolumeClaimFileSystemResizePending && cond.Status == corev1.ConditionTrue {
+			return size.String(), true, true
+		}
+	}
+	return "", false, false
+}
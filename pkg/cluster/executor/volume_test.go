@@ -0,0 +1,117 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelectPVCs(t *testing.T) {
+	pvc := func(name string, labels map[string]string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	etcd := func(instance string) map[string]string {
+		return map[string]string{"app.kubernetes.io/instance": instance + "-etcd"}
+	}
+	minio := map[string]string{"release": "prod-minio"}
+	pvcs := []corev1.PersistentVolumeClaim{
+		pvc("data-prod-etcd-0", etcd("prod")),
+		pvc("data-prod-etcd-1", etcd("prod")),
+		pvc("export-prod-minio-0", minio),
+		pvc("prod-minio", minio),
+		pvc("data-prod2-etcd-0", etcd("prod2")),
+		// The name of another cluster's PVC can contain this one's
+		pvc("data-x-prod-etcd-0", etcd("x-prod")),
+		pvc("data-prod-etcd-9", nil),
+	}
+
+	tests := []struct {
+		component string
+		want      []string
+	}{
+		{"etcd", []string{"data-prod-etcd-0", "data-prod-etcd-1"}},
+		{"minio", []string{"export-prod-minio-0", "prod-minio"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.component, func(t *testing.T) {
+			got := selectPVCs(pvcs, "prod", tt.component)
+			if len(got) != len(tt.want) {
+				t.Fatalf("selectPVCs() returned %d PVCs, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].Name != tt.want[i] {
+					t.Errorf("selectPVCs()[%d] = %s, want %s", i, got[i].Name, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResizeStatus(t *testing.T) {
+	size := resource.MustParse("20Gi")
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}
+	if _, _, done := resizeStatus(pvc, size); done {
+		t.Error("resizeStatus() should not be done before capacity grows")
+	}
+
+	pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{{
+		Type:   corev1.PersistentVolumeClaimFileSystemResizePending,
+		Status: corev1.ConditionTrue,
+	}}
+	if _, pending, done := resizeStatus(pvc, size); !done || !pending {
+		t.Errorf("resizeStatus() = pending %v, done %v, want pending and done", pending, done)
+	}
+
+	pvc.Status.Conditions = nil
+	pvc.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("20Gi")
+	if capacity, pending, done := resizeStatus(pvc, size); !done || pending || capacity != "20Gi" {
+		t.Errorf("resizeStatus() = %s, pending %v, done %v, want 20Gi done", capacity, pending, done)
+	}
+}
+
+func TestCheckStorageClass(t *testing.T) {
+	fast := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast"}}
+	local := []spec.EtcdSpec{{Host: "127.0.0.1"}}
+
+	tests := []struct {
+		name         string
+		storageClass string
+		etcd         []spec.EtcdSpec
+		minio        []spec.MinioSpec
+		wantErr      error
+	}{
+		{name: "default class", etcd: local},
+		{name: "existing class", storageClass: "fast", etcd: local},
+		{name: "missing class", storageClass: "slow", etcd: local, wantErr: ErrStorageClassNotFound},
+		{
+			name:         "missing class without in-cluster dependencies",
+			storageClass: "slow",
+			etcd:         []spec.EtcdSpec{{Host: "etcd.example.com"}},
+			minio:        []spec.MinioSpec{{Host: "s3.example.com"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := newFakeKubernetesExecutor(t, nil, fast.DeepCopy())
+			e.spec = &spec.Specification{
+				Global:       spec.GlobalOptions{StorageClass: tt.storageClass},
+				EtcdServers:  tt.etcd,
+				MinioServers: tt.minio,
+			}
+
+			if err := e.CheckStorageClass(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckStorageClass() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
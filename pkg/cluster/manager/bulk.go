@@ -0,0 +1,52 @@
+package manager
+
+import (
+	"sort"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/logger"
+)
+
+// DefaultBulkConcurrency is the number of clusters a bulk operation works on
+// at the same time
+const DefaultBulkConcurrency = 4
+
+// Select returns the metadata of the clusters whose labels match the
+// selector, sorted by name. Unlike List it does not query the cluster status.
+func (m *Manager) Select(selector string) ([]*spec.ClusterMeta, error) {
+	sel, err := spec.ParseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return m.filter(func(meta *spec.ClusterMeta) bool { return meta.MatchesSelector(sel) })
+}
+
+// Expired returns the metadata of the clusters whose TTL ran out before now,
+// sorted by name
+func (m *Manager) Expired(now time.Time) ([]*spec.ClusterMeta, error) {
+	return m.filter(func(meta *spec.ClusterMeta) bool { return meta.Expired(now) })
+}
+
+// filter returns the metadata of the clusters that match, sorted by name
+func (m *Manager) filter(match func(meta *spec.ClusterMeta) bool) ([]*spec.ClusterMeta, error) {
+	names, err := m.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []*spec.ClusterMeta
+	for _, name := range names {
+		meta, err := m.store.Load(name)
+		if err != nil {
+			logger.Warn("Failed to load metadata for cluster '%s': %v", name, err)
+			continue
+		}
+		if match(meta) {
+			clusters = append(clusters, meta)
+		}
+	}
+
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	return clusters, nil
+}
@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+func TestSelect(t *testing.T) {
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	clusters := map[string]struct {
+		labels    map[string]string
+		expiresAt *time.Time
+	}{
+		"ci-b": {labels: map[string]string{"env": "ci"}, expiresAt: &past},
+		"ci-a": {labels: map[string]string{"env": "ci", "keep": "true"}, expiresAt: &future},
+		"prod": {labels: map[string]string{"env": "prod"}},
+		"bare": {},
+	}
+	for name, c := range clusters {
+		if err := os.MkdirAll(mgr.ClusterDir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		meta := &spec.ClusterMeta{Name: name, Labels: c.labels, ExpiresAt: c.expiresAt}
+		if err := spec.SaveMeta(meta, mgr.MetaPath(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{"env=ci", []string{"ci-a", "ci-b"}},
+		{"env=ci,!keep", []string{"ci-b"}},
+		{"env", []string{"ci-a", "ci-b", "prod"}},
+		{"env=staging", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := mgr.Select(tt.selector)
+			if err != nil {
+				t.Fatalf("Select() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Select() returned %d clusters, want %v", len(got), tt.want)
+			}
+			for i, name := range tt.want {
+				if got[i].Name != name {
+					t.Errorf("Select()[%d] = %s, want %s", i, got[i].Name, name)
+				}
+			}
+		})
+	}
+
+	if _, err := mgr.Select(""); err == nil {
+		t.Error("Select() should reject an empty selector")
+	}
+
+	expired, err := mgr.Expired(now)
+	if err != nil {
+		t.Fatalf("Expired() error = %v", err)
+	}
+	if len(expired) != 1 || expired[0].Name != "ci-b" {
+		t.Errorf("Expired() = %v, want only 'ci-b'", expired)
+	}
+}
+
+// endpointExecutor reports an endpoint for its cluster, or fails for "gone"
+type endpointExecutor struct {
+	executor.Executor
+	name string
+}
+
+func (e *endpointExecutor) Endpoint(ctx context.Context) (*executor.Endpoint, error) {
+	if e.name == "gone" {
+		return nil, errors.New("service not found")
+	}
+	return &executor.Endpoint{Service: e.name + "-milvus", Address: "10.0.0.1:19530"}, nil
+}
+
+func TestEndpoints(t *testing.T) {
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+	mgr.newExecutor = func(opts executor.KubernetesOptions) (executor.Executor, error) {
+		return &endpointExecutor{name: opts.ClusterName}, nil
+	}
+
+	topology, err := os.ReadFile(writeTopology(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{"a", "b", "gone"}
+	for _, name := range names {
+		if err := os.MkdirAll(mgr.ClusterDir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		meta := &spec.ClusterMeta{Name: name, Backend: spec.BackendKubernetes}
+		if err := spec.SaveMeta(meta, mgr.MetaPath(name)); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(mgr.TopologyPath(name), topology, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := mgr.Endpoints(context.Background(), append(names, "missing"))
+	if len(got) != 2 {
+		t.Fatalf("Endpoints() returned %d endpoints, want 2: %v", len(got), got)
+	}
+	for _, name := range []string{"a", "b"} {
+		if got[name] == nil || got[name].Service != name+"-milvus" {
+			t.Errorf("Endpoints()[%s] = %+v", name, got[name])
+		}
+	}
+}
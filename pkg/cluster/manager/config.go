@@ -0,0 +1,112 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// GetConfigValue returns the value of a dotted key (e.g.
+// "common.security.tlsMode") in the Milvus configuration of a cluster. It
+// returns an error wrapping ErrConfigKeyNotFound if the key isn't set.
+func (m *Manager) GetConfigValue(ctx context.Context, name, key string) (any, error) {
+	config, err := m.GetConfig(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupConfig(config, key)
+}
+
+// lookupConfig traverses config by the parts of a dotted key
+func lookupConfig(config map[string]interface{}, key string) (any, error) {
+	var value any = config
+	for _, part := range strings.Split(key, ".") {
+		section, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrConfigKeyNotFound, key)
+		}
+		if value, ok = section[part]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrConfigKeyNotFound, key)
+		}
+	}
+	return value, nil
+}
+
+// Kinds of ConfigChange
+const (
+	ConfigAdded   = "added"
+	ConfigRemoved = "removed"
+	ConfigChanged = "changed"
+)
+
+// ConfigChange is a difference between two configurations at a key
+type ConfigChange struct {
+	// Key is the dotted key of the value
+	Key  string `json:"key"`
+	Kind string `json:"kind"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// DiffConfig deep-compares two Milvus configurations and returns the
+// changes that turn from into to, sorted by key. Sections are compared key
+// by key and lists as a whole. Numbers compare equal whatever their Go
+// type, so a configuration read from YAML can be compared with one from the
+// CRD.
+func DiffConfig(from, to map[string]interface{}) ([]ConfigChange, error) {
+	var a, b map[string]interface{}
+	if err := normalizeConfig(from, &a); err != nil {
+		return nil, err
+	}
+	if err := normalizeConfig(to, &b); err != nil {
+		return nil, err
+	}
+
+	var changes []ConfigChange
+	diffConfig("", a, b, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes, nil
+}
+
+// normalizeConfig round-trips config through JSON, which makes every
+// number a float64 and every section a map[string]interface{}
+func normalizeConfig(config map[string]interface{}, out *map[string]interface{}) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to compare configuration: %w", err)
+	}
+	return json.Unmarshal(data, out)
+}
+
+func diffConfig(prefix string, from, to map[string]interface{}, changes *[]ConfigChange) {
+	for key, old := range from {
+		path := joinKey(prefix, key)
+		value, ok := to[key]
+		if !ok {
+			*changes = append(*changes, ConfigChange{Key: path, Kind: ConfigRemoved, Old: old})
+			continue
+		}
+		oldSection, oldIsSection := old.(map[string]interface{})
+		section, isSection := value.(map[string]interface{})
+		if oldIsSection && isSection {
+			diffConfig(path, oldSection, section, changes)
+		} else if !reflect.DeepEqual(old, value) {
+			*changes = append(*changes, ConfigChange{Key: path, Kind: ConfigChanged, Old: old, New: value})
+		}
+	}
+	for key, value := range to {
+		if _, ok := from[key]; !ok {
+			*changes = append(*changes, ConfigChange{Key: joinKey(prefix, key), Kind: ConfigAdded, New: value})
+		}
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,84 @@
+package manager
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLookupConfig(t *testing.T) {
+	config := map[string]interface{}{
+		"common": map[string]interface{}{
+			"security": map[string]interface{}{"tlsMode": float64(1)},
+		},
+		"proxy": map[string]interface{}{"maxTaskNum": float64(1024)},
+	}
+
+	tests := []struct {
+		key     string
+		want    any
+		wantErr bool
+	}{
+		{key: "common.security.tlsMode", want: float64(1)},
+		{key: "proxy", want: map[string]interface{}{"maxTaskNum": float64(1024)}},
+		{key: "common.security.missing", wantErr: true},
+		{key: "proxy.maxTaskNum.deeper", wantErr: true},
+		{key: "queryNode", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := lookupConfig(config, tt.key)
+			if tt.wantErr {
+				if !errors.Is(err, ErrConfigKeyNotFound) {
+					t.Errorf("lookupConfig() error = %v, want ErrConfigKeyNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("lookupConfig() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lookupConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffConfig(t *testing.T) {
+	// live comes from the CRD (JSON numbers), reference from YAML (ints)
+	live := map[string]interface{}{
+		"common": map[string]interface{}{
+			"security": map[string]interface{}{"tlsMode": float64(1)},
+		},
+		"proxy":     map[string]interface{}{"maxTaskNum": float64(1024), "timeTickInterval": float64(200)},
+		"queryNode": map[string]interface{}{"gracefulTime": float64(5000)},
+		"log":       map[string]interface{}{"level": "info"},
+	}
+	reference := map[string]interface{}{
+		"common": map[string]interface{}{
+			"security": map[string]interface{}{"tlsMode": 1},
+		},
+		"proxy":    map[string]interface{}{"maxTaskNum": 2048, "timeTickInterval": 200},
+		"dataNode": map[string]interface{}{"flush": map[string]interface{}{"insertBufSize": 16777216}},
+		"log":      "debug",
+	}
+
+	got, err := DiffConfig(live, reference)
+	if err != nil {
+		t.Fatalf("DiffConfig() error = %v", err)
+	}
+	want := []ConfigChange{
+		{Key: "dataNode", Kind: ConfigAdded, New: map[string]interface{}{"flush": map[string]interface{}{"insertBufSize": float64(16777216)}}},
+		{Key: "log", Kind: ConfigChanged, Old: map[string]interface{}{"level": "info"}, New: "debug"},
+		{Key: "proxy.maxTaskNum", Kind: ConfigChanged, Old: float64(1024), New: float64(2048)},
+		{Key: "queryNode", Kind: ConfigRemoved, Old: map[string]interface{}{"gracefulTime": float64(5000)}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffConfig() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got, _ := DiffConfig(live, live); len(got) != 0 {
+		t.Errorf("DiffConfig() of equal configs = %+v, want none", got)
+	}
+}
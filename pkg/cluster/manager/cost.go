@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+)
+
+// gib is the number of bytes in a GiB, the unit memory and storage are priced in
+const gib = 1 << 30
+
+// Prices are monthly unit prices used to estimate what an instance costs
+type Prices struct {
+	// CPU is the price of one requested core
+	CPU float64 `json:"cpu"`
+
+	// Memory is the price of one requested GiB of memory
+	Memory float64 `json:"mem"`
+
+	// Storage is the price of one GiB of persistent volume
+	Storage float64 `json:"storage"`
+}
+
+// ParsePrices parses a price table such as "cpu=20,mem=3,storage=0.1".
+// Omitted resources cost nothing.
+func ParsePrices(s string) (Prices, error) {
+	var p Prices
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return p, fmt.Errorf("invalid price '%s': expected KEY=VALUE", pair)
+		}
+		price, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || price < 0 {
+			return p, fmt.Errorf("invalid price '%s': value must be a non-negative number", pair)
+		}
+		switch strings.TrimSpace(key) {
+		case "cpu":
+			p.CPU = price
+		case "mem", "memory":
+			p.Memory = price
+		case "storage":
+			p.Storage = price
+		default:
+			return p, fmt.Errorf("invalid price '%s': unknown resource (valid: cpu, mem, storage)", pair)
+		}
+	}
+	return p, nil
+}
+
+// Cost is an estimated monthly cost, split by resource
+type Cost struct {
+	CPU     float64 `json:"cpu"`
+	Memory  float64 `json:"mem"`
+	Storage float64 `json:"storage"`
+	Total   float64 `json:"total"`
+}
+
+// EstimateCost prices the requests (not the limits) of a footprint
+func EstimateCost(f *executor.Footprint, p Prices) Cost {
+	c := Cost{
+		CPU:     f.Total.CPURequest * p.CPU,
+		Memory:  float64(f.Total.MemoryRequest) / gib * p.Memory,
+		Storage: float64(f.Storage) / gib * p.Storage,
+	}
+	c.Total = c.CPU + c.Memory + c.Storage
+	return c
+}
+
+// Footprint returns the CPU, memory and storage an instance requests
+func (m *Manager) Footprint(ctx context.Context, name string) (*executor.Footprint, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.Footprint(ctx)
+}
@@ -0,0 +1,47 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+)
+
+func TestParsePrices(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Prices
+		wantErr bool
+	}{
+		{in: "", want: Prices{}},
+		{in: "cpu=20,mem=3", want: Prices{CPU: 20, Memory: 3}},
+		{in: "cpu=20, memory=2.5, storage=0.1", want: Prices{CPU: 20, Memory: 2.5, Storage: 0.1}},
+		{in: "cpu", wantErr: true},
+		{in: "cpu=free", wantErr: true},
+		{in: "cpu=-1", wantErr: true},
+		{in: "gpu=100", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParsePrices(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePrices(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParsePrices(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	f := &executor.Footprint{
+		Total:   executor.Resources{CPURequest: 4, CPULimit: 8, MemoryRequest: 16 << 30, MemoryLimit: 32 << 30},
+		Storage: 100 << 30,
+	}
+	got := EstimateCost(f, Prices{CPU: 20, Memory: 3, Storage: 0.1})
+	want := Cost{CPU: 80, Memory: 48, Storage: 10, Total: 138}
+	if got != want {
+		t.Errorf("EstimateCost() = %+v, want %+v", got, want)
+	}
+}
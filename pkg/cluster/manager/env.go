@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+)
+
+// EnvShells are the shells supported by FormatEnv
+var EnvShells = []string{"bash", "zsh", "sh", "fish", "powershell"}
+
+// EnvVar is an environment variable describing how to reach an instance
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Env returns the connection environment of an instance (MILVUS_URI,
+// ETCD_ENDPOINTS, MINIO_ENDPOINT, ...) for tools run against it
+func (m *Manager) Env(ctx context.Context, name string) ([]EnvVar, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := exec.Describe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return instanceEnv(specification, desc), nil
+}
+
+// instanceEnv derives the environment from the topology and the CRD status.
+// In-cluster etcd and MinIO use the services created by the Milvus Operator.
+func instanceEnv(s *spec.Specification, desc *executor.Description) []EnvVar {
+	name, ns := desc.Name, desc.Namespace
+
+	endpoint := desc.Endpoint
+	if endpoint == "" {
+		port := 19530
+		if len(s.MilvusServers) > 0 && s.MilvusServers[0].Port > 0 {
+			port = s.MilvusServers[0].Port
+		}
+		endpoint = fmt.Sprintf("%s-milvus.%s:%d", name, ns, port)
+	}
+	scheme := "http"
+	if s.Global.TLS.Enabled {
+		scheme = "https"
+	}
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		host = endpoint
+	}
+
+	etcd := []string{fmt.Sprintf("%s-etcd.%s:2379", name, ns)}
+	if s.ExternalEtcd() {
+		etcd = etcd[:0]
+		for _, server := range s.EtcdServers {
+			etcd = append(etcd, server.Address())
+		}
+	}
+
+	minio := fmt.Sprintf("%s-minio.%s:9000", name, ns)
+	if s.ExternalMinio() {
+		minio = s.MinioServers[0].Address()
+	}
+
+	env := []EnvVar{
+		{Name: "MIUP_INSTANCE", Value: name},
+		{Name: "MIUP_NAMESPACE", Value: ns},
+		{Name: "MILVUS_URI", Value: fmt.Sprintf("%s://%s", scheme, endpoint)},
+		{Name: "MILVUS_HOST", Value: host},
+	}
+	if port != "" {
+		env = append(env, EnvVar{Name: "MILVUS_PORT", Value: port})
+	}
+	return append(env,
+		EnvVar{Name: "ETCD_ENDPOINTS", Value: strings.Join(etcd, ",")},
+		EnvVar{Name: "MINIO_ENDPOINT", Value: minio},
+	)
+}
+
+// FormatEnv renders env as statements for the given shell, suitable for
+// eval "$(miup env NAME)", miup env NAME --shell fish | source, or
+// miup env NAME --shell powershell | Invoke-Expression
+func FormatEnv(env []EnvVar, shell string) (string, error) {
+	if !slices.Contains(EnvShells, shell) {
+		return "", fmt.Errorf("unknown shell: %s (supported: %s)", shell, strings.Join(EnvShells, ", "))
+	}
+
+	var sb strings.Builder
+	for _, v := range env {
+		switch shell {
+		case "bash", "zsh", "sh":
+			fmt.Fprintf(&sb, "export %s='%s'\n", v.Name, strings.ReplaceAll(v.Value, "'", `'\''`))
+		case "fish":
+			value := strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(v.Value)
+			fmt.Fprintf(&sb, "set -gx %s '%s'\n", v.Name, value)
+		case "powershell":
+			fmt.Fprintf(&sb, "$env:%s = '%s'\n", v.Name, strings.ReplaceAll(v.Value, "'", "''"))
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,121 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+)
+
+func envMap(env []EnvVar) map[string]string {
+	m := make(map[string]string, len(env))
+	for _, v := range env {
+		m[v.Name] = v.Value
+	}
+	return m
+}
+
+func TestInstanceEnv(t *testing.T) {
+	desc := &executor.Description{Name: "prod", Namespace: "milvus", Endpoint: "prod-milvus.milvus:19530"}
+
+	tests := []struct {
+		name string
+		spec *spec.Specification
+		desc *executor.Description
+		want map[string]string
+	}{
+		{
+			name: "in-cluster dependencies",
+			spec: &spec.Specification{
+				EtcdServers:  []spec.EtcdSpec{{Host: "127.0.0.1"}},
+				MinioServers: []spec.MinioSpec{{Host: "127.0.0.1"}},
+			},
+			desc: desc,
+			want: map[string]string{
+				"MIUP_INSTANCE":  "prod",
+				"MIUP_NAMESPACE": "milvus",
+				"MILVUS_URI":     "http://prod-milvus.milvus:19530",
+				"MILVUS_HOST":    "prod-milvus.milvus",
+				"MILVUS_PORT":    "19530",
+				"ETCD_ENDPOINTS": "prod-etcd.milvus:2379",
+				"MINIO_ENDPOINT": "prod-minio.milvus:9000",
+			},
+		},
+		{
+			name: "external dependencies and TLS",
+			spec: &spec.Specification{
+				Global: spec.GlobalOptions{TLS: spec.TLSConfig{Enabled: true}},
+				EtcdServers: []spec.EtcdSpec{
+					{Host: "etcd-0.example.com", ClientPort: 2379},
+					{Host: "etcd-1.example.com", ClientPort: 2379},
+				},
+				MinioServers: []spec.MinioSpec{{Host: "s3.example.com", Port: 443}},
+			},
+			desc: desc,
+			want: map[string]string{
+				"MILVUS_URI":     "https://prod-milvus.milvus:19530",
+				"ETCD_ENDPOINTS": "etcd-0.example.com:2379,etcd-1.example.com:2379",
+				"MINIO_ENDPOINT": "s3.example.com:443",
+			},
+		},
+		{
+			name: "no endpoint reported yet",
+			spec: &spec.Specification{MilvusServers: []spec.MilvusSpec{{Port: 29530}}},
+			desc: &executor.Description{Name: "prod", Namespace: "milvus"},
+			want: map[string]string{
+				"MILVUS_URI":  "http://prod-milvus.milvus:29530",
+				"MILVUS_PORT": "29530",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := envMap(instanceEnv(tt.spec, tt.desc))
+			for k, want := range tt.want {
+				if got[k] != want {
+					t.Errorf("%s = %q, want %q", k, got[k], want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatEnv(t *testing.T) {
+	env := []EnvVar{
+		{Name: "MILVUS_URI", Value: "http://prod-milvus.milvus:19530"},
+		{Name: "QUOTED", Value: `it's a \ test`},
+	}
+
+	tests := []struct {
+		shell   string
+		want    string
+		wantErr bool
+	}{
+		{
+			shell: "bash",
+			want:  "export MILVUS_URI='http://prod-milvus.milvus:19530'\nexport QUOTED='it'\\''s a \\ test'\n",
+		},
+		{
+			shell: "fish",
+			want:  "set -gx MILVUS_URI 'http://prod-milvus.milvus:19530'\nset -gx QUOTED 'it\\'s a \\\\ test'\n",
+		},
+		{
+			shell: "powershell",
+			want:  "$env:MILVUS_URI = 'http://prod-milvus.milvus:19530'\n$env:QUOTED = 'it''s a \\ test'\n",
+		},
+		{shell: "tcsh", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			got, err := FormatEnv(env, tt.shell)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FormatEnv() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package manager
+
+import "errors"
+
+// Errors returned by Manager operations. They are wrapped with the cluster
+// name or cause, so match them with errors.Is.
+var (
+	// ErrClusterNotFound is returned when no cluster with the name is managed locally
+	ErrClusterNotFound = errors.New("cluster not found")
+
+	// ErrMetaNotFound is returned by a MetaStore for a cluster without metadata
+	ErrMetaNotFound = errors.New("cluster metadata not found")
+
+	// ErrClusterExists is returned when deploying a cluster whose name is taken
+	ErrClusterExists = errors.New("cluster already exists")
+
+	// ErrInvalidTopology is returned when a topology file fails validation
+	ErrInvalidTopology = errors.New("invalid topology")
+
+	// ErrConfigKeyNotFound is returned when a configuration key isn't set
+	ErrConfigKeyNotFound = errors.New("configuration key not found")
+
+	// ErrOperationInProgress is returned when another miup process holds the cluster lock
+	ErrOperationInProgress = errors.New("another operation is in progress")
+
+	// ErrClusterStopped is returned by operations that would start a stopped
+	// cluster as a side effect
+	ErrClusterStopped = errors.New("cluster is stopped")
+
+	// ErrNotOrphaned is returned by RemoveOrphan when the cluster's Milvus
+	// resource exists
+	ErrNotOrphaned = errors.New("Milvus resource of the cluster still exists")
+)
@@ -0,0 +1,205 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+// fakeExecutor records calls and returns err from every operation. Methods
+// it does not override panic through the nil embedded Executor.
+type fakeExecutor struct {
+	executor.Executor
+
+	opts  executor.KubernetesOptions
+	err   error
+	calls []string
+
+	// exists and existsErr are returned by Exists
+	exists    bool
+	existsErr error
+
+	// namespaceCreated is returned by EnsureNamespace
+	namespaceCreated bool
+
+	// config is returned by GetConfig
+	config map[string]interface{}
+
+	// permissionsErr is returned by CheckPermissions
+	permissionsErr error
+
+	// storageClassErr is returned by CheckStorageClass
+	storageClassErr error
+
+	// events are returned by Events and passed to the callback of
+	// WatchEvents
+	events []executor.Event
+
+	// crd, podManifests and podLogs are returned by ExportCRD,
+	// PodManifests and PodLogs
+	crd          []byte
+	podManifests map[string][]byte
+	podLogs      []executor.PodLogs
+
+	// load is returned by CPULoad
+	load *executor.CPULoad
+
+	// waitErr is returned by WaitDeleted
+	waitErr error
+
+	// during runs inside each operation, e.g. to check in-progress status
+	during func()
+}
+
+func (f *fakeExecutor) call(name string) error {
+	f.calls = append(f.calls, name)
+	if f.during != nil {
+		f.during()
+	}
+	return f.err
+}
+
+func (f *fakeExecutor) Deploy(ctx context.Context) error  { return f.call("deploy") }
+func (f *fakeExecutor) Start(ctx context.Context) error   { return f.call("start") }
+func (f *fakeExecutor) Stop(ctx context.Context) error    { return f.call("stop") }
+func (f *fakeExecutor) Destroy(ctx context.Context) error { return f.call("destroy") }
+
+func (f *fakeExecutor) WaitDeleted(ctx context.Context, timeout time.Duration) error {
+	f.calls = append(f.calls, "wait deleted")
+	return f.waitErr
+}
+
+func (f *fakeExecutor) Scale(ctx context.Context, component string, opts executor.ScaleOptions) error {
+	return f.call("scale " + component)
+}
+
+func (f *fakeExecutor) Upgrade(ctx context.Context, version string) error {
+	return f.call("upgrade " + version)
+}
+
+func (f *fakeExecutor) SetImage(ctx context.Context, image string) error {
+	return f.call("set-image " + image)
+}
+
+func (f *fakeExecutor) Apply(ctx context.Context) error { return f.call("apply") }
+
+func (f *fakeExecutor) Exists(ctx context.Context) (bool, error) {
+	return f.exists, f.existsErr
+}
+
+func (f *fakeExecutor) EnsureNamespace(ctx context.Context) (bool, error) {
+	return f.namespaceCreated, f.call("ensure namespace")
+}
+
+func (f *fakeExecutor) DeleteNamespace(ctx context.Context) error {
+	return f.call("delete namespace")
+}
+
+func (f *fakeExecutor) CheckPermissions(ctx context.Context, createNamespace bool) error {
+	return f.permissionsErr
+}
+
+func (f *fakeExecutor) CheckStorageClass(ctx context.Context) error {
+	return f.storageClassErr
+}
+
+func (f *fakeExecutor) GetConfig(ctx context.Context) (map[string]interface{}, error) {
+	return f.config, f.err
+}
+
+func (f *fakeExecutor) ReplaceConfig(ctx context.Context, config map[string]interface{}) error {
+	if err := f.call("replace config"); err != nil {
+		return err
+	}
+	f.config = config
+	return nil
+}
+
+func (f *fakeExecutor) GetVersion(ctx context.Context) (string, error) {
+	return "v2.5.4", nil
+}
+
+func (f *fakeExecutor) CPULoad(ctx context.Context, component string) (*executor.CPULoad, error) {
+	return f.load, f.call("cpu load " + component)
+}
+
+func (f *fakeExecutor) WatchEvents(ctx context.Context, fn func(executor.Event)) error {
+	for _, ev := range f.events {
+		fn(ev)
+	}
+	return f.err
+}
+
+func (f *fakeExecutor) ExportCRD(ctx context.Context) ([]byte, error) {
+	return f.crd, nil
+}
+
+func (f *fakeExecutor) Events(ctx context.Context) ([]executor.Event, error) {
+	return f.events, nil
+}
+
+func (f *fakeExecutor) PodManifests(ctx context.Context) (map[string][]byte, error) {
+	return f.podManifests, nil
+}
+
+func (f *fakeExecutor) PodLogs(ctx context.Context, opts executor.LogsOptions) ([]executor.PodLogs, error) {
+	return f.podLogs, nil
+}
+
+func (f *fakeExecutor) Diagnose(ctx context.Context) (*executor.DiagnoseResult, error) {
+	return &executor.DiagnoseResult{Healthy: true}, f.err
+}
+
+// newFakeManager returns a manager whose executors are fake
+func newFakeManager(t *testing.T, fake *fakeExecutor) *Manager {
+	t.Helper()
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+	mgr.newExecutor = func(opts executor.KubernetesOptions) (executor.Executor, error) {
+		fake.opts = opts
+		return fake, nil
+	}
+	return mgr
+}
+
+// writeTopology writes a minimal valid topology and returns its path
+func writeTopology(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "topology.yaml")
+	topology := `
+milvus_servers:
+  - host: milvus
+etcd_servers:
+  - host: etcd
+minio_servers:
+  - host: minio
+`
+	if err := os.WriteFile(path, []byte(topology), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// deployFake deploys a cluster through a succeeding fake executor
+func deployFake(t *testing.T, mgr *Manager, fake *fakeExecutor, name string) {
+	t.Helper()
+	if err := mgr.Deploy(context.Background(), name, writeTopology(t), DeployOptions{}); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	fake.calls = nil
+}
+
+// status returns the saved status of a cluster
+func status(t *testing.T, mgr *Manager, name string) spec.ClusterStatus {
+	t.Helper()
+	meta, err := spec.LoadMeta(mgr.MetaPath(name))
+	if err != nil {
+		t.Fatalf("LoadMeta() error = %v", err)
+	}
+	return meta.Status
+}
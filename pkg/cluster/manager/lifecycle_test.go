@@ -0,0 +1,640 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/logger"
+	"github.com/mmga-lab/miup/pkg/version"
+)
+
+var errFake = errors.New("fake failure")
+
+func TestDeploy(t *testing.T) {
+	t.Setenv(version.DefaultMilvusVersionEnv, "")
+	fake := &fakeExecutor{}
+	mgr := newFakeManager(t, fake)
+
+	var during spec.ClusterStatus
+	fake.during = func() { during = status(t, mgr, "prod") }
+
+	opts := DeployOptions{Namespace: "milvus", TTL: time.Hour, Labels: map[string]string{"env": "ci"}}
+	if err := mgr.Deploy(context.Background(), "prod", writeTopology(t), opts); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+
+	if during != spec.StatusDeploying {
+		t.Errorf("status during deploy = %s, want %s", during, spec.StatusDeploying)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusRunning {
+		t.Errorf("status after deploy = %s, want %s", got, spec.StatusRunning)
+	}
+	if fake.opts.ClusterName != "prod" || fake.opts.Namespace != "milvus" || fake.opts.Spec == nil {
+		t.Errorf("executor options = %+v", fake.opts)
+	}
+	if fake.opts.ExpiresAt == nil {
+		t.Error("executor should get the TTL expiry")
+	}
+
+	meta, err := spec.LoadMeta(mgr.MetaPath("prod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Labels["env"] != "ci" || meta.ExpiresAt == nil {
+		t.Errorf("meta = %+v, want labels and expiry", meta)
+	}
+	if meta.MilvusVersion != version.DefaultMilvusVersion {
+		t.Errorf("meta.MilvusVersion = %s, want the default %s", meta.MilvusVersion, version.DefaultMilvusVersion)
+	}
+	if _, err := spec.LoadSpecification(mgr.TopologyPath("prod")); err != nil {
+		t.Errorf("topology should be saved: %v", err)
+	}
+
+	if err := mgr.Deploy(context.Background(), "prod", writeTopology(t), DeployOptions{}); !errors.Is(err, ErrClusterExists) {
+		t.Errorf("second Deploy() error = %v, want ErrClusterExists", err)
+	}
+}
+
+func TestDeployEvents(t *testing.T) {
+	mgr := newFakeManager(t, &fakeExecutor{})
+
+	var buf bytes.Buffer
+	stop := logger.StartEvents(&buf)
+	deployErr := mgr.Deploy(context.Background(), "prod", writeTopology(t), DeployOptions{})
+	// Fails early, before reaching the executor
+	existsErr := mgr.Deploy(context.Background(), "prod", writeTopology(t), DeployOptions{})
+	stop()
+
+	if deployErr != nil || !errors.Is(existsErr, ErrClusterExists) {
+		t.Fatalf("Deploy() errors = %v, %v, want nil and ErrClusterExists", deployErr, existsErr)
+	}
+
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var e logger.Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid event %q: %v", line, err)
+		}
+		got = append(got, e.Type+" "+e.Phase+" "+e.Error)
+	}
+	want := []string{
+		"phase_start deploy ",
+		"phase_end deploy ",
+		"phase_start deploy ",
+		"phase_end deploy " + existsErr.Error(),
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("events = %q, want %q", got, want)
+	}
+}
+
+func TestDeployFailure(t *testing.T) {
+	fake := &fakeExecutor{err: errFake}
+	mgr := newFakeManager(t, fake)
+
+	err := mgr.Deploy(context.Background(), "prod", writeTopology(t), DeployOptions{})
+	if !errors.Is(err, errFake) {
+		t.Fatalf("Deploy() error = %v, want the executor error", err)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusUnknown {
+		t.Errorf("status after failed deploy = %s, want %s", got, spec.StatusUnknown)
+	}
+}
+
+func TestDeployMilvusExists(t *testing.T) {
+	fake := &fakeExecutor{err: executor.ErrMilvusExists}
+	mgr := newFakeManager(t, fake)
+
+	err := mgr.Deploy(context.Background(), "prod", writeTopology(t), DeployOptions{})
+	if !errors.Is(err, ErrClusterExists) {
+		t.Fatalf("Deploy() error = %v, want ErrClusterExists", err)
+	}
+	if mgr.Exists("prod") {
+		t.Error("local state should be removed when the Milvus resource already exists")
+	}
+}
+
+func TestDeployExistingMilvusResource(t *testing.T) {
+	fake := &fakeExecutor{exists: true}
+	mgr := newFakeManager(t, fake)
+
+	err := mgr.Deploy(context.Background(), "prod", writeTopology(t), DeployOptions{Namespace: "milvus"})
+	if !errors.Is(err, ErrClusterExists) {
+		t.Fatalf("Deploy() error = %v, want ErrClusterExists", err)
+	}
+	if !strings.Contains(err.Error(), "miup instance repair prod --namespace milvus") {
+		t.Errorf("error %q should suggest adopting the instance", err)
+	}
+	if _, statErr := os.Stat(mgr.ClusterDir("prod")); !os.IsNotExist(statErr) {
+		t.Error("no local state should be written")
+	}
+	if len(fake.calls) > 0 {
+		t.Errorf("executor should not deploy, got %v", fake.calls)
+	}
+
+	// --apply adopts the existing resource
+	if err := mgr.Deploy(context.Background(), "prod", writeTopology(t), DeployOptions{Apply: true}); err != nil {
+		t.Fatalf("Deploy(Apply) error = %v", err)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusRunning {
+		t.Errorf("status = %s, want %s", got, spec.StatusRunning)
+	}
+}
+
+func TestDeployPermissions(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		storageClassErr error
+		wantErr         error
+		wantDeploy      bool
+	}{
+		{"denied", fmt.Errorf("%w: cannot create secrets", executor.ErrPermissionDenied), nil, executor.ErrPermissionDenied, false},
+		{"unchecked", errFake, nil, nil, true},
+		{"missing storage class", nil, fmt.Errorf("%w: 'fast'", executor.ErrStorageClassNotFound), executor.ErrStorageClassNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeExecutor{permissionsErr: tt.err, storageClassErr: tt.storageClassErr}
+			mgr := newFakeManager(t, fake)
+
+			err := mgr.Deploy(context.Background(), "prod", writeTopology(t), DeployOptions{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Deploy() error = %v, want %v", err, tt.wantErr)
+			}
+			if deployed := slices.Contains(fake.calls, "deploy"); deployed != tt.wantDeploy {
+				t.Errorf("deployed = %v, want %v", deployed, tt.wantDeploy)
+			}
+			if !tt.wantDeploy && mgr.Exists("prod") {
+				t.Error("no local state should be written")
+			}
+		})
+	}
+}
+
+func TestDeploySetNamespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		want      string
+	}{
+		{"set wins without --namespace", "", "staging"},
+		{"--namespace wins over set", "prod-ns", "prod-ns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeExecutor{}
+			mgr := newFakeManager(t, fake)
+
+			opts := DeployOptions{Namespace: tt.namespace, Set: []string{"global.namespace=staging"}}
+			if err := mgr.Deploy(context.Background(), "prod", writeTopology(t), opts); err != nil {
+				t.Fatalf("Deploy() error = %v", err)
+			}
+			if fake.opts.Namespace != tt.want {
+				t.Errorf("executor namespace = %s, want %s", fake.opts.Namespace, tt.want)
+			}
+			meta, err := spec.LoadMeta(mgr.MetaPath("prod"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if meta.Namespace != tt.want {
+				t.Errorf("meta namespace = %s, want %s", meta.Namespace, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeployInvalidTopology(t *testing.T) {
+	fake := &fakeExecutor{}
+	mgr := newFakeManager(t, fake)
+
+	opts := DeployOptions{Set: []string{"milvus_servers[0].host="}}
+	if err := mgr.Deploy(context.Background(), "prod", writeTopology(t), opts); !errors.Is(err, ErrInvalidTopology) {
+		t.Errorf("Deploy() error = %v, want ErrInvalidTopology", err)
+	}
+	if len(fake.calls) > 0 {
+		t.Errorf("executor should not be called, got %v", fake.calls)
+	}
+}
+
+func TestStartStop(t *testing.T) {
+	fake := &fakeExecutor{}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "prod")
+	ctx := context.Background()
+
+	if err := mgr.Stop(ctx, "prod"); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusStopped {
+		t.Errorf("status after stop = %s, want %s", got, spec.StatusStopped)
+	}
+
+	fake.err = errFake
+	if err := mgr.Start(ctx, "prod"); !errors.Is(err, errFake) {
+		t.Fatalf("Start() error = %v, want the executor error", err)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusStopped {
+		t.Errorf("status after failed start = %s, want %s", got, spec.StatusStopped)
+	}
+
+	fake.err = nil
+	if err := mgr.Start(ctx, "prod"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusRunning {
+		t.Errorf("status after start = %s, want %s", got, spec.StatusRunning)
+	}
+}
+
+func TestScaleStatusTransitions(t *testing.T) {
+	fake := &fakeExecutor{}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "prod")
+	ctx := context.Background()
+
+	var during spec.ClusterStatus
+	fake.during = func() { during = status(t, mgr, "prod") }
+
+	if err := mgr.Scale(ctx, "prod", "querynode", executor.ScaleOptions{Replicas: 3}); err != nil {
+		t.Fatalf("Scale() error = %v", err)
+	}
+	if during != spec.StatusScaling {
+		t.Errorf("status during scale = %s, want %s", during, spec.StatusScaling)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusRunning {
+		t.Errorf("status after scale = %s, want %s", got, spec.StatusRunning)
+	}
+
+	if err := mgr.Stop(ctx, "prod"); err != nil {
+		t.Fatal(err)
+	}
+	fake.err = errFake
+	if err := mgr.Scale(ctx, "prod", "querynode", executor.ScaleOptions{Replicas: 3}); !errors.Is(err, errFake) {
+		t.Fatalf("Scale() error = %v, want the executor error", err)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusStopped {
+		t.Errorf("status after failed scale = %s, want the previous %s", got, spec.StatusStopped)
+	}
+}
+
+func TestRecommendScale(t *testing.T) {
+	fake := &fakeExecutor{}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "prod")
+	ctx := context.Background()
+
+	fake.load = &executor.CPULoad{
+		Component: "querynode",
+		Replicas:  2,
+		Pods: []executor.PodCPU{
+			{Name: "q-0", UsageMillicores: 900, RequestMillicores: 1000},
+			{Name: "q-1", UsageMillicores: 900, RequestMillicores: 1000},
+		},
+	}
+	rec, err := mgr.RecommendScale(ctx, "prod", "querynode", executor.AutoscaleOptions{TargetCPU: 60})
+	if err != nil {
+		t.Fatalf("RecommendScale() error = %v", err)
+	}
+	if rec.TargetReplicas != 3 {
+		t.Errorf("TargetReplicas = %d, want 3", rec.TargetReplicas)
+	}
+	// Only measured, nothing scaled
+	if want := []string{"cpu load querynode"}; !slices.Equal(fake.calls, want) {
+		t.Errorf("calls = %v, want %v", fake.calls, want)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusRunning {
+		t.Errorf("status = %s, want %s", got, spec.StatusRunning)
+	}
+
+	if _, err := mgr.RecommendScale(ctx, "missing", "querynode", executor.AutoscaleOptions{TargetCPU: 60}); !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("RecommendScale() of a missing cluster error = %v, want ErrClusterNotFound", err)
+	}
+}
+
+func TestUpgradeStatusTransitions(t *testing.T) {
+	fake := &fakeExecutor{}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "prod")
+	ctx := context.Background()
+
+	var during spec.ClusterStatus
+	fake.during = func() { during = status(t, mgr, "prod") }
+
+	fake.err = errFake
+	if err := mgr.Upgrade(ctx, "prod", "2.5.5"); !errors.Is(err, errFake) {
+		t.Fatalf("Upgrade() error = %v, want the executor error", err)
+	}
+	if during != spec.StatusUpgrading {
+		t.Errorf("status during upgrade = %s, want %s", during, spec.StatusUpgrading)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusRunning {
+		t.Errorf("status after failed upgrade = %s, want %s", got, spec.StatusRunning)
+	}
+
+	fake.err = nil
+	if err := mgr.Upgrade(ctx, "prod", "2.5.5"); err != nil {
+		t.Fatalf("Upgrade() error = %v", err)
+	}
+	meta, err := spec.LoadMeta(mgr.MetaPath("prod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Status != spec.StatusRunning || meta.MilvusVersion != "v2.5.5" {
+		t.Errorf("after upgrade status = %s, version = %s, want running v2.5.5", meta.Status, meta.MilvusVersion)
+	}
+}
+
+func TestSetImage(t *testing.T) {
+	fake := &fakeExecutor{}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "prod")
+	ctx := context.Background()
+
+	if err := mgr.SetImage(ctx, "prod", ""); err == nil {
+		t.Error("SetImage() with an empty image should fail")
+	}
+
+	fake.err = errFake
+	if err := mgr.SetImage(ctx, "prod", "myrepo/milvus:pr-1234"); !errors.Is(err, errFake) {
+		t.Fatalf("SetImage() error = %v, want the executor error", err)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusRunning {
+		t.Errorf("status after failed set-image = %s, want %s", got, spec.StatusRunning)
+	}
+
+	fake.err = nil
+	if err := mgr.SetImage(ctx, "prod", "myrepo/milvus:pr-1234"); err != nil {
+		t.Fatalf("SetImage() error = %v", err)
+	}
+	meta, err := spec.LoadMeta(mgr.MetaPath("prod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Image != "myrepo/milvus:pr-1234" || meta.MilvusVersion != "pr-1234" {
+		t.Errorf("after set-image image = %s, version = %s, want myrepo/milvus:pr-1234 and pr-1234", meta.Image, meta.MilvusVersion)
+	}
+
+	// Upgrading to a release goes back to the stock image
+	if err := mgr.Upgrade(ctx, "prod", "v2.5.5"); err != nil {
+		t.Fatalf("Upgrade() error = %v", err)
+	}
+	if meta, err = spec.LoadMeta(mgr.MetaPath("prod")); err != nil {
+		t.Fatal(err)
+	}
+	if meta.Image != "" {
+		t.Errorf("after upgrade image = %s, want it cleared", meta.Image)
+	}
+}
+
+func TestApply(t *testing.T) {
+	fake := &fakeExecutor{}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "prod")
+	ctx := context.Background()
+
+	if err := mgr.SetImage(ctx, "prod", "myrepo/milvus:pr-1234"); err != nil {
+		t.Fatal(err)
+	}
+
+	var during spec.ClusterStatus
+	fake.during = func() { during = status(t, mgr, "prod") }
+
+	if err := mgr.Apply(ctx, "prod"); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if during != spec.StatusApplying {
+		t.Errorf("status during apply = %s, want %s", during, spec.StatusApplying)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusRunning {
+		t.Errorf("status after apply = %s, want %s", got, spec.StatusRunning)
+	}
+	if fake.opts.Image != "myrepo/milvus:pr-1234" {
+		t.Errorf("executor image = %q, want the one set with set-image", fake.opts.Image)
+	}
+
+	fake.err = errFake
+	if err := mgr.Apply(ctx, "prod"); !errors.Is(err, errFake) {
+		t.Fatalf("Apply() error = %v, want the executor error", err)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusRunning {
+		t.Errorf("status after failed apply = %s, want %s", got, spec.StatusRunning)
+	}
+
+	// A topology broken by hand is rejected before touching the cluster
+	fake.err = nil
+	fake.calls = nil
+	if err := os.WriteFile(mgr.TopologyPath("prod"), []byte("milvus_servers: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.Apply(ctx, "prod"); !errors.Is(err, ErrInvalidTopology) {
+		t.Errorf("Apply() error = %v, want ErrInvalidTopology", err)
+	}
+	if _, err := mgr.PlanApply(ctx, "prod"); !errors.Is(err, ErrInvalidTopology) {
+		t.Errorf("PlanApply() error = %v, want ErrInvalidTopology", err)
+	}
+	if len(fake.calls) != 0 {
+		t.Errorf("calls = %v, want none", fake.calls)
+	}
+
+	// Applying the replicas of the topology would start a stopped cluster
+	if err := mgr.Stop(ctx, "prod"); err != nil {
+		t.Fatal(err)
+	}
+	fake.calls = nil
+	if err := mgr.Apply(ctx, "prod"); !errors.Is(err, ErrClusterStopped) {
+		t.Errorf("Apply() error = %v, want ErrClusterStopped", err)
+	}
+	if _, err := mgr.PlanApply(ctx, "prod"); !errors.Is(err, ErrClusterStopped) {
+		t.Errorf("PlanApply() error = %v, want ErrClusterStopped", err)
+	}
+	if len(fake.calls) != 0 {
+		t.Errorf("calls = %v, want none", fake.calls)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusStopped {
+		t.Errorf("status after rejected apply = %s, want %s", got, spec.StatusStopped)
+	}
+
+	if err := mgr.Apply(ctx, "missing"); !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("Apply() error = %v, want ErrClusterNotFound", err)
+	}
+}
+
+func TestDestroy(t *testing.T) {
+	fake := &fakeExecutor{}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "prod")
+	ctx := context.Background()
+
+	fake.err = errFake
+	if err := mgr.Destroy(ctx, "prod", DestroyOptions{}); !errors.Is(err, errFake) {
+		t.Fatalf("Destroy() error = %v, want the executor error", err)
+	}
+	if !mgr.Exists("prod") {
+		t.Fatal("a failed destroy should keep the local state")
+	}
+
+	if err := mgr.Destroy(ctx, "prod", DestroyOptions{Force: true}); err != nil {
+		t.Fatalf("Destroy(force) error = %v", err)
+	}
+	if mgr.Exists("prod") {
+		t.Error("a forced destroy should remove the local state")
+	}
+}
+
+func TestDestroyWait(t *testing.T) {
+	ctx := context.Background()
+	timeout := fmt.Errorf("%w after 10m0s", executor.ErrDeleteTimeout)
+
+	tests := []struct {
+		name       string
+		opts       DestroyOptions
+		err        error
+		waitErr    error
+		wantCalls  []string
+		wantErr    error
+		wantExists bool
+	}{
+		{
+			name:      "no wait",
+			wantCalls: []string{"destroy"},
+		},
+		{
+			name:      "deleted",
+			opts:      DestroyOptions{Wait: true},
+			wantCalls: []string{"destroy", "wait deleted"},
+		},
+		{
+			name:       "timed out",
+			opts:       DestroyOptions{Wait: true},
+			waitErr:    timeout,
+			wantCalls:  []string{"destroy", "wait deleted"},
+			wantErr:    executor.ErrDeleteTimeout,
+			wantExists: true,
+		},
+		{
+			name:      "forced past a timeout",
+			opts:      DestroyOptions{Wait: true, Force: true},
+			waitErr:   timeout,
+			wantCalls: []string{"destroy", "wait deleted"},
+		},
+		{
+			name:      "forced past a failed delete",
+			opts:      DestroyOptions{Wait: true, Force: true},
+			err:       errFake,
+			wantCalls: []string{"destroy"},
+		},
+		{
+			name:       "failed delete",
+			opts:       DestroyOptions{Wait: true},
+			err:        errFake,
+			wantCalls:  []string{"destroy"},
+			wantErr:    errFake,
+			wantExists: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeExecutor{}
+			mgr := newFakeManager(t, fake)
+			deployFake(t, mgr, fake, "prod")
+
+			fake.err = tt.err
+			fake.waitErr = tt.waitErr
+			err := mgr.Destroy(ctx, "prod", tt.opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Destroy() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Destroy() error = %v", err)
+			}
+			if !slices.Equal(fake.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", fake.calls, tt.wantCalls)
+			}
+			if mgr.Exists("prod") != tt.wantExists {
+				t.Errorf("Exists() = %v, want %v", mgr.Exists("prod"), tt.wantExists)
+			}
+		})
+	}
+}
+
+func TestDeployCreateNamespace(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name             string
+		namespaceCreated bool
+		wantCalls        []string
+	}{
+		{
+			name:             "created by miup",
+			namespaceCreated: true,
+			wantCalls:        []string{"destroy", "delete namespace"},
+		},
+		{
+			name:      "already existed",
+			wantCalls: []string{"destroy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeExecutor{namespaceCreated: tt.namespaceCreated}
+			mgr := newFakeManager(t, fake)
+
+			opts := DeployOptions{Namespace: "prod", CreateNamespace: true}
+			if err := mgr.Deploy(ctx, "prod", writeTopology(t), opts); err != nil {
+				t.Fatalf("Deploy() error = %v", err)
+			}
+			if !slices.Equal(fake.calls, []string{"ensure namespace", "deploy"}) {
+				t.Errorf("deploy calls = %v, want the namespace ensured first", fake.calls)
+			}
+			meta, err := spec.LoadMeta(mgr.MetaPath("prod"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if meta.NamespaceCreated != tt.namespaceCreated {
+				t.Errorf("NamespaceCreated = %v, want %v", meta.NamespaceCreated, tt.namespaceCreated)
+			}
+
+			// Only a namespace miup created is deleted
+			fake.calls = nil
+			if err := mgr.Destroy(ctx, "prod", DestroyOptions{DeleteNamespace: true}); err != nil {
+				t.Fatalf("Destroy() error = %v", err)
+			}
+			if !slices.Equal(fake.calls, tt.wantCalls) {
+				t.Errorf("destroy calls = %v, want %v", fake.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDeployCreateNamespaceFailure(t *testing.T) {
+	fake := &fakeExecutor{err: errFake}
+	mgr := newFakeManager(t, fake)
+
+	opts := DeployOptions{CreateNamespace: true}
+	if err := mgr.Deploy(context.Background(), "prod", writeTopology(t), opts); !errors.Is(err, errFake) {
+		t.Fatalf("Deploy() error = %v, want the namespace error", err)
+	}
+	if mgr.Exists("prod") {
+		t.Error("a failed namespace creation should not leave local state")
+	}
+	if slices.Contains(fake.calls, "deploy") {
+		t.Error("Deploy should not run after the namespace could not be created")
+	}
+}
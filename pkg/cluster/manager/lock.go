@@ -0,0 +1,32 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/mmga-lab/miup/pkg/flock"
+	"github.com/mmga-lab/miup/pkg/logger"
+)
+
+// LockFileName is the per-instance lock file in the cluster directory
+const LockFileName = ".lock"
+
+// lock acquires the per-instance operation lock. Mutating operations hold
+// it until they return so that concurrent commands cannot clobber each
+// other's CRD updates or metadata; read-only operations do not take it.
+func (m *Manager) lock(name string) (func(), error) {
+	l, err := flock.TryLock(filepath.Join(m.ClusterDir(name), LockFileName))
+	if err != nil {
+		if errors.Is(err, flock.ErrLocked) {
+			return nil, fmt.Errorf("%w on cluster '%s'", ErrOperationInProgress, name)
+		}
+		return nil, err
+	}
+
+	return func() {
+		if err := l.Unlock(); err != nil {
+			logger.Debug("Failed to release lock for cluster '%s': %v", name, err)
+		}
+	}, nil
+}
@@ -0,0 +1,66 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/logger"
+)
+
+// PodsOnNode returns a cluster's pods scheduled on a Kubernetes node
+func (m *Manager) PodsOnNode(ctx context.Context, name, node string) ([]executor.NodePod, error) {
+	exec, err := m.maintenanceExecutor(name)
+	if err != nil {
+		return nil, err
+	}
+	return exec.PodsOnNode(ctx, node)
+}
+
+// DrainNode cordons a Kubernetes node and moves a cluster's pods off it,
+// asking confirm before deleting a single-replica pod (see
+// executor.KubernetesExecutor.DrainNode)
+func (m *Manager) DrainNode(ctx context.Context, name, node string, confirm func(pod executor.NodePod) bool) ([]executor.NodePod, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	exec, err := m.maintenanceExecutor(name)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Draining cluster '%s' from node %s...", name, node)
+	pods, err := exec.DrainNode(ctx, node, confirm)
+	if err != nil {
+		return pods, err
+	}
+	logger.Success("Cluster '%s' has no pods left on node %s", name, node)
+	return pods, nil
+}
+
+// maintenanceExecutor returns the executor of an existing cluster
+func (m *Manager) maintenanceExecutor(name string) (executor.Executor, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	return m.createExecutor(name, specification, m.buildDeployOptions(meta))
+}
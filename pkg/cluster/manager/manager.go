@@ -2,16 +2,21 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mmga-lab/miup/pkg/cluster/executor"
 	"github.com/mmga-lab/miup/pkg/cluster/spec"
 	"github.com/mmga-lab/miup/pkg/localdata"
 	"github.com/mmga-lab/miup/pkg/logger"
+	"github.com/mmga-lab/miup/pkg/timing"
+	"github.com/mmga-lab/miup/pkg/version"
 )
 
 const (
@@ -26,11 +31,36 @@ const (
 // Manager manages cluster lifecycle
 type Manager struct {
 	profile *localdata.Profile
+
+	// store persists cluster metadata
+	store MetaStore
+
+	// newExecutor creates the executor for a cluster; tests replace it to
+	// run the orchestration logic against a fake
+	newExecutor ExecutorFactory
 }
 
-// NewManager creates a new cluster manager
+// ExecutorFactory creates the executor for a cluster from its options
+type ExecutorFactory func(opts executor.KubernetesOptions) (executor.Executor, error)
+
+// NewManager creates a new cluster manager keeping metadata in the profile
 func NewManager(profile *localdata.Profile) *Manager {
-	return &Manager{profile: profile}
+	return NewManagerWithStore(profile, NewFileMetaStore(profile.Path(ClusterDir)))
+}
+
+// NewManagerWithStore creates a new cluster manager keeping metadata in
+// store. Topologies and locks stay in the profile directory.
+func NewManagerWithStore(profile *localdata.Profile, store MetaStore) *Manager {
+	return &Manager{profile: profile, store: store, newExecutor: newKubernetesExecutor}
+}
+
+// newKubernetesExecutor is the default ExecutorFactory
+func newKubernetesExecutor(opts executor.KubernetesOptions) (executor.Executor, error) {
+	exec, err := executor.NewKubernetesExecutor(opts)
+	if err != nil {
+		return nil, err
+	}
+	return exec, nil
 }
 
 // ClusterDir returns the path to a cluster directory
@@ -58,28 +88,144 @@ type DeployOptions struct {
 	KubeContext string
 	Namespace   string
 	WithMonitor bool
+
+	// Set are PATH=VALUE overrides applied to the topology before
+	// validation, like helm's --set (see spec.Specification.ApplySets)
+	Set []string
+
+	// Env is merged into components.env of the topology, overriding the
+	// variables set there; the env of a single component still takes
+	// precedence over it
+	Env map[string]string
+
+	// SpreadZones sets anti_affinity: zone on components that have none
+	SpreadZones bool
+
+	// Labels are user labels for selecting the cluster in bulk operations
+	Labels map[string]string
+
+	// TTL makes the cluster expire this long after deploy, so that
+	// 'miup instance reap' destroys it. Zero means no expiry.
+	TTL time.Duration
+
+	// expiresAt is the expiry recorded on the Milvus resource
+	expiresAt *time.Time
+
+	// image is the image set with SetImage, overriding MilvusVersion
+	image string
+
+	// plan makes the executor record updates instead of applying them
+	plan *executor.Plan
+
+	// Apply adopts and updates a Milvus resource that already exists in
+	// Kubernetes, e.g. one left behind by a deploy that failed before
+	// saving its metadata
+	Apply bool
+
+	// CreateNamespace creates the namespace before deploying if it does not
+	// exist, labelled with the instance, instead of relying on it existing
+	CreateNamespace bool
+
+	// StrictEnv fails the deploy if the topology references an undefined
+	// environment variable without a default
+	StrictEnv bool
 }
 
 // Deploy deploys a new cluster
-func (m *Manager) Deploy(ctx context.Context, name string, topoPath string, opts DeployOptions) error {
+func (m *Manager) Deploy(ctx context.Context, name string, topoPath string, opts DeployOptions) (err error) {
+	// Set default Milvus version
+	if opts.MilvusVersion == "" {
+		opts.MilvusVersion = version.MilvusDefault()
+	}
+
+	fields := map[string]string{"cluster": name, "version": opts.MilvusVersion}
+	logger.PhaseStart("deploy", fields)
+	defer func() { logger.PhaseEnd("deploy", err, fields) }()
+
 	// Check if cluster already exists
 	if m.Exists(name) {
-		return fmt.Errorf("cluster '%s' already exists", name)
+		return fmt.Errorf("%w: %s", ErrClusterExists, name)
 	}
 
 	// Load and validate specification
-	specification, err := spec.LoadSpecification(topoPath)
+	specification, err := spec.LoadSpecificationWithOptions(topoPath, spec.LoadOptions{ExpandEnv: true, StrictEnv: opts.StrictEnv})
+	if errors.Is(err, spec.ErrUndefinedVariable) {
+		return fmt.Errorf("%w: %w", ErrInvalidTopology, err)
+	}
 	if err != nil {
 		return err
 	}
 
+	if err := specification.ApplySets(opts.Set); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTopology, err)
+	}
+
 	if err := specification.Validate(); err != nil {
-		return fmt.Errorf("invalid topology: %w", err)
+		return fmt.Errorf("%w: %w", ErrInvalidTopology, err)
 	}
 
-	// Set default Milvus version
-	if opts.MilvusVersion == "" {
-		opts.MilvusVersion = "v2.5.4"
+	if len(opts.Env) > 0 {
+		if err := spec.ValidateEnv(opts.Env); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTopology, err)
+		}
+		components := &specification.MilvusServers[0].Components
+		if components.Env == nil {
+			components.Env = make(map[string]string, len(opts.Env))
+		}
+		for k, v := range opts.Env {
+			components.Env[k] = v
+		}
+	}
+
+	if opts.SpreadZones {
+		if err := specification.SpreadZones(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTopology, err)
+		}
+	}
+
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = specification.Global.Namespace
+	}
+	if opts.TTL > 0 {
+		expiresAt := time.Now().Add(opts.TTL)
+		opts.expiresAt = &expiresAt
+	}
+
+	// Create executor
+	exec, err := m.createExecutor(name, specification, opts)
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	// Fail with the missing permissions up front rather than with a 403
+	// halfway through. If they can't be checked, let the deploy find out.
+	endPermissions := timing.Start(ctx, "check permissions")
+	err = exec.CheckPermissions(ctx, opts.CreateNamespace)
+	endPermissions()
+	if err != nil {
+		if errors.Is(err, executor.ErrPermissionDenied) {
+			return err
+		}
+		logger.Warn("Could not check Kubernetes permissions: %v", err)
+	}
+
+	// A missing storage class would leave the PVCs pending; fail before any
+	// local state is written so the deploy can simply be rerun
+	if err := exec.CheckStorageClass(ctx); err != nil {
+		return err
+	}
+
+	// Refuse to take over a Milvus resource created outside miup, before
+	// any local state is written
+	if !opts.Apply {
+		exists, err := exec.Exists(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check for an existing Milvus resource: %w", err)
+		}
+		if exists {
+			return milvusExistsError(name, namespace)
+		}
 	}
 
 	// Create cluster directory
@@ -88,37 +234,64 @@ func (m *Manager) Deploy(ctx context.Context, name string, topoPath string, opts
 		return fmt.Errorf("failed to create cluster directory: %w", err)
 	}
 
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Another deploy may have created the cluster since the check above
+	if _, err := m.store.Load(name); !errors.Is(err, ErrMetaNotFound) {
+		return fmt.Errorf("%w: %s", ErrClusterExists, name)
+	}
+
 	// Save topology
 	if err := spec.SaveSpecification(specification, m.TopologyPath(name)); err != nil {
 		return fmt.Errorf("failed to save topology: %w", err)
 	}
 
+	namespaceCreated := false
+	if opts.CreateNamespace {
+		namespaceCreated, err = exec.EnsureNamespace(ctx)
+		if err != nil {
+			if rmErr := os.RemoveAll(clusterDir); rmErr != nil {
+				logger.Warn("Failed to clean up cluster directory: %v", rmErr)
+			}
+			return fmt.Errorf("failed to create namespace: %w", err)
+		}
+		if namespaceCreated {
+			logger.Info("Created namespace '%s'", namespace)
+		}
+	}
+
 	// Create and save metadata
 	meta := spec.NewClusterMeta(name, specification, opts.MilvusVersion)
 
 	// Save Kubernetes options
 	meta.Kubeconfig = opts.Kubeconfig
 	meta.KubeContext = opts.KubeContext
-	meta.Namespace = opts.Namespace
-	if meta.Namespace == "" {
-		meta.Namespace = specification.Global.Namespace
-	}
+	meta.Namespace = namespace
+	meta.NamespaceCreated = namespaceCreated
+	meta.Labels = opts.Labels
+	meta.ExpiresAt = opts.expiresAt
 
-	if err := spec.SaveMeta(meta, m.MetaPath(name)); err != nil {
+	if err := m.store.Save(name, meta); err != nil {
 		return fmt.Errorf("failed to save metadata: %w", err)
 	}
 
-	// Create executor
-	exec, err := m.createExecutor(name, specification, opts)
-	if err != nil {
-		return fmt.Errorf("failed to create executor: %w", err)
-	}
-
 	// Deploy
 	logger.Info("Deploying cluster '%s'...", name)
 	if err := exec.Deploy(ctx); err != nil {
+		if errors.Is(err, executor.ErrMilvusExists) {
+			// Created concurrently since the check above; nothing was
+			// created by us, so drop the local state again
+			if rmErr := os.RemoveAll(clusterDir); rmErr != nil {
+				logger.Warn("Failed to clean up cluster directory: %v", rmErr)
+			}
+			return milvusExistsError(name, namespace)
+		}
 		meta.Status = spec.StatusUnknown
-		if saveErr := spec.SaveMeta(meta, m.MetaPath(name)); saveErr != nil {
+		if saveErr := m.store.Save(name, meta); saveErr != nil {
 			logger.Warn("Failed to update metadata: %v", saveErr)
 		}
 		return fmt.Errorf("deployment failed: %w", err)
@@ -126,7 +299,7 @@ func (m *Manager) Deploy(ctx context.Context, name string, topoPath string, opts
 
 	// Update status
 	meta.Status = spec.StatusRunning
-	if err := spec.SaveMeta(meta, m.MetaPath(name)); err != nil {
+	if err := m.store.Save(name, meta); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
 	}
 
@@ -134,13 +307,31 @@ func (m *Manager) Deploy(ctx context.Context, name string, topoPath string, opts
 	return nil
 }
 
+// milvusExistsError reports a Milvus resource that exists in the cluster
+// but is not tracked locally
+func milvusExistsError(name, namespace string) error {
+	where, repair := "the current namespace", "miup instance repair "+name
+	if namespace != "" {
+		where = fmt.Sprintf("namespace '%s'", namespace)
+		repair += " --namespace " + namespace
+	}
+	return fmt.Errorf("%w: Milvus instance '%s' already exists in %s; adopt it with '%s' or deploy with --apply, or choose another name",
+		ErrClusterExists, name, where, repair)
+}
+
 // Start starts a cluster
 func (m *Manager) Start(ctx context.Context, name string) error {
 	if !m.Exists(name) {
-		return fmt.Errorf("cluster '%s' does not exist", name)
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return err
 	}
@@ -161,7 +352,7 @@ func (m *Manager) Start(ctx context.Context, name string) error {
 	}
 
 	meta.Status = spec.StatusRunning
-	if err := spec.SaveMeta(meta, m.MetaPath(name)); err != nil {
+	if err := m.store.Save(name, meta); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
 	}
 
@@ -172,10 +363,16 @@ func (m *Manager) Start(ctx context.Context, name string) error {
 // Stop stops a cluster
 func (m *Manager) Stop(ctx context.Context, name string) error {
 	if !m.Exists(name) {
-		return fmt.Errorf("cluster '%s' does not exist", name)
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return err
 	}
@@ -196,7 +393,7 @@ func (m *Manager) Stop(ctx context.Context, name string) error {
 	}
 
 	meta.Status = spec.StatusStopped
-	if err := spec.SaveMeta(meta, m.MetaPath(name)); err != nil {
+	if err := m.store.Save(name, meta); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
 	}
 
@@ -204,13 +401,37 @@ func (m *Manager) Stop(ctx context.Context, name string) error {
 	return nil
 }
 
+// DestroyOptions contains options for destroying a cluster
+type DestroyOptions struct {
+	// Force removes the local state even if deleting the Kubernetes
+	// resources fails
+	Force bool
+
+	// DeleteNamespace also deletes the namespace if miup created it on
+	// deploy and nothing else uses it
+	DeleteNamespace bool
+
+	// Wait indicates whether to wait for the Milvus resource, its pods and
+	// the dependencies the operator deletes with it to be gone, so that the
+	// name can be deployed again at once
+	Wait bool
+	// Timeout is the maximum time to wait for the resources to be deleted
+	Timeout time.Duration
+}
+
 // Destroy destroys a cluster
-func (m *Manager) Destroy(ctx context.Context, name string, force bool) error {
+func (m *Manager) Destroy(ctx context.Context, name string, opts DestroyOptions) error {
 	if !m.Exists(name) {
-		return fmt.Errorf("cluster '%s' does not exist", name)
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return err
 	}
@@ -226,11 +447,36 @@ func (m *Manager) Destroy(ctx context.Context, name string, force bool) error {
 	}
 
 	logger.Warn("Destroying cluster '%s'...", name)
-	if err := exec.Destroy(ctx); err != nil {
-		if !force {
-			return fmt.Errorf("failed to destroy cluster: %w", err)
+	destroyErr := exec.Destroy(ctx)
+	if destroyErr != nil {
+		if !opts.Force {
+			return fmt.Errorf("failed to destroy cluster: %w", destroyErr)
+		}
+		logger.Warn("Force destroying despite error: %v", destroyErr)
+	}
+
+	if opts.Wait && destroyErr == nil {
+		logger.Info("Waiting for the resources of cluster '%s' to be deleted...", name)
+		if err := exec.WaitDeleted(ctx, opts.Timeout); err != nil {
+			if !opts.Force {
+				return fmt.Errorf("failed to wait for cluster deletion: %w", err)
+			}
+			logger.Warn("Removing local state before deletion finished: %v", err)
+		}
+	}
+
+	if opts.DeleteNamespace && destroyErr == nil {
+		if !meta.NamespaceCreated {
+			logger.Warn("Keeping namespace '%s': it was not created by miup", meta.Namespace)
+		} else if err := exec.DeleteNamespace(ctx); err != nil {
+			logger.Warn("Keeping namespace '%s': %v", meta.Namespace, err)
+		} else {
+			logger.Info("Deleted namespace '%s'", meta.Namespace)
 		}
-		logger.Warn("Force destroying despite error: %v", err)
+	}
+
+	if err := m.store.Delete(name); err != nil {
+		return err
 	}
 
 	// Remove cluster directory
@@ -245,10 +491,10 @@ func (m *Manager) Destroy(ctx context.Context, name string, force bool) error {
 // Display returns cluster information
 func (m *Manager) Display(ctx context.Context, name string) (*ClusterInfo, error) {
 	if !m.Exists(name) {
-		return nil, fmt.Errorf("cluster '%s' does not exist", name)
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
 	}
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return nil, err
 	}
@@ -282,36 +528,34 @@ type ClusterInfo struct {
 
 // List lists all clusters
 func (m *Manager) List(ctx context.Context) ([]*spec.ClusterMeta, error) {
-	clustersDir := m.profile.Path(ClusterDir)
-
-	entries, err := os.ReadDir(clustersDir)
+	names, err := m.store.List()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
 		return nil, err
 	}
 
 	var clusters []*spec.ClusterMeta
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-
-		meta, err := spec.LoadMeta(m.MetaPath(entry.Name()))
+	for _, name := range names {
+		meta, err := m.store.Load(name)
 		if err != nil {
-			logger.Warn("Failed to load metadata for cluster '%s': %v", entry.Name(), err)
+			logger.Warn("Failed to load metadata for cluster '%s': %v", name, err)
 			continue
 		}
 
-		// Check actual status
-		specification, err := spec.LoadSpecification(m.TopologyPath(entry.Name()))
+		// Check actual status. Transient API failures are retried by the
+		// client; if they persist, keep the instance with an unknown status
+		// rather than reporting it as stopped.
+		specification, err := spec.LoadSpecification(m.TopologyPath(name))
 		if err == nil {
-			exec, err := m.createExecutor(entry.Name(), specification, m.buildDeployOptions(meta))
+			exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
 			if err == nil {
-				if running, _ := exec.IsRunning(ctx); running {
+				running, err := exec.IsRunning(ctx)
+				switch {
+				case err != nil:
+					logger.Warn("Failed to get status for cluster '%s': %v", name, err)
+					meta.Status = spec.StatusUnknown
+				case running:
 					meta.Status = spec.StatusRunning
-				} else {
+				default:
 					meta.Status = spec.StatusStopped
 				}
 			}
@@ -323,13 +567,101 @@ func (m *Manager) List(ctx context.Context) ([]*spec.ClusterMeta, error) {
 	return clusters, nil
 }
 
+// DiscoverOptions contains options for listing Milvus resources cluster-wide
+type DiscoverOptions struct {
+	Kubeconfig  string
+	KubeContext string
+}
+
+// DiscoveredCluster is a Milvus resource found in the Kubernetes cluster
+type DiscoveredCluster struct {
+	executor.MilvusResource
+
+	// Managed is true if the resource is tracked in local metadata
+	Managed bool `json:"managed"`
+}
+
+// Discover lists Milvus resources in all namespaces, marking which ones are
+// tracked locally. Resources labelled as miup-managed but not tracked can be
+// adopted with Repair.
+func (m *Manager) Discover(ctx context.Context, opts DiscoverOptions) ([]DiscoveredCluster, error) {
+	resources, err := executor.ListAllMilvus(ctx, executor.KubernetesOptions{
+		Kubeconfig: opts.Kubeconfig,
+		Context:    opts.KubeContext,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := m.trackedResources()
+	clusters := make([]DiscoveredCluster, 0, len(resources))
+	for _, r := range resources {
+		clusters = append(clusters, DiscoveredCluster{
+			MilvusResource: r,
+			Managed:        tracked[r.Namespace+"/"+r.Name],
+		})
+	}
+	return clusters, nil
+}
+
+// OperatorOverview is the state of the Milvus Operator and of every Milvus
+// resource in the Kubernetes cluster, which it manages
+type OperatorOverview struct {
+	// Operator is nil if the operator deployment was not found
+	Operator  *executor.Operator  `json:"operator"`
+	Instances []DiscoveredCluster `json:"instances"`
+}
+
+// DescribeOperator returns the state of the Milvus Operator along with the
+// Milvus resources in all namespaces, marked like Discover does
+func (m *Manager) DescribeOperator(ctx context.Context, opts DiscoverOptions) (*OperatorOverview, error) {
+	operator, err := executor.DescribeOperator(ctx, executor.KubernetesOptions{
+		Kubeconfig: opts.Kubeconfig,
+		Context:    opts.KubeContext,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := m.Discover(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &OperatorOverview{Operator: operator, Instances: instances}, nil
+}
+
+// trackedResources returns the namespace/name keys of locally tracked clusters
+func (m *Manager) trackedResources() map[string]bool {
+	tracked := make(map[string]bool)
+
+	names, err := m.store.List()
+	if err != nil {
+		return tracked
+	}
+
+	for _, name := range names {
+		meta, err := m.store.Load(name)
+		if err != nil {
+			continue
+		}
+		namespace := meta.Namespace
+		if namespace == "" {
+			if specification, err := spec.LoadSpecification(m.TopologyPath(name)); err == nil {
+				namespace = specification.Global.Namespace
+			}
+		}
+		tracked[namespace+"/"+meta.Name] = true
+	}
+	return tracked
+}
+
 // Logs retrieves logs from a cluster
-func (m *Manager) Logs(ctx context.Context, name string, service string, tail int) (string, error) {
+func (m *Manager) Logs(ctx context.Context, name string, opts executor.LogsOptions) (string, error) {
 	if !m.Exists(name) {
-		return "", fmt.Errorf("cluster '%s' does not exist", name)
+		return "", fmt.Errorf("%w: %s", ErrClusterNotFound, name)
 	}
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return "", err
 	}
@@ -344,16 +676,99 @@ func (m *Manager) Logs(ctx context.Context, name string, service string, tail in
 		return "", err
 	}
 
-	return exec.Logs(ctx, service, tail)
+	return exec.Logs(ctx, opts)
+}
+
+// PodLogs retrieves logs for each pod of a cluster
+func (m *Manager) PodLogs(ctx context.Context, name string, opts executor.LogsOptions) ([]executor.PodLogs, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.PodLogs(ctx, opts)
+}
+
+// Events retrieves the events of a cluster and its pods sorted by time
+func (m *Manager) Events(ctx context.Context, name string) ([]executor.Event, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.Events(ctx)
+}
+
+// WatchEvents calls fn for each event of a cluster and its pods as it
+// occurs, until ctx is cancelled
+func (m *Manager) WatchEvents(ctx context.Context, name string, fn func(executor.Event)) error {
+	if !m.Exists(name) {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return err
+	}
+
+	return exec.WatchEvents(ctx, fn)
 }
 
 // Scale scales a component in the cluster with the specified options
 func (m *Manager) Scale(ctx context.Context, name string, component string, opts executor.ScaleOptions) error {
 	if !m.Exists(name) {
-		return fmt.Errorf("cluster '%s' does not exist", name)
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	if err := spec.ValidateEnv(opts.Env); err != nil {
+		return err
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return err
 	}
@@ -371,7 +786,7 @@ func (m *Manager) Scale(ctx context.Context, name string, component string, opts
 	// Update status to scaling
 	oldStatus := meta.Status
 	meta.Status = spec.StatusScaling
-	if err := spec.SaveMeta(meta, m.MetaPath(name)); err != nil {
+	if err := m.store.Save(name, meta); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
 	}
 
@@ -381,7 +796,7 @@ func (m *Manager) Scale(ctx context.Context, name string, component string, opts
 	if err := exec.Scale(ctx, component, opts); err != nil {
 		// Restore old status on failure
 		meta.Status = oldStatus
-		if saveErr := spec.SaveMeta(meta, m.MetaPath(name)); saveErr != nil {
+		if saveErr := m.store.Save(name, meta); saveErr != nil {
 			logger.Warn("Failed to restore metadata status: %v", saveErr)
 		}
 		return fmt.Errorf("failed to scale: %w", err)
@@ -389,7 +804,7 @@ func (m *Manager) Scale(ctx context.Context, name string, component string, opts
 
 	// Update status back to running
 	meta.Status = spec.StatusRunning
-	if err := spec.SaveMeta(meta, m.MetaPath(name)); err != nil {
+	if err := m.store.Save(name, meta); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
 	}
 
@@ -418,15 +833,54 @@ func (m *Manager) logScaleOperation(component, clusterName string, opts executor
 		}
 		logger.Info("Updating %s resources in cluster '%s': %s", component, clusterName, strings.Join(resources, ", "))
 	}
+	if opts.HasEnvChange() {
+		names := make([]string, 0, len(opts.Env))
+		for name := range opts.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		logger.Info("Setting %s environment in cluster '%s': %s", component, clusterName, strings.Join(names, ", "))
+	}
 }
 
-// GetReplicas returns the current replica count for each component
+// RecommendScale sizes a component for a target CPU utilization from the
+// current CPU usage of its pods (see executor.RecommendReplicas). Nothing is
+// changed; pass the target replicas to Scale to apply it.
+func (m *Manager) RecommendScale(ctx context.Context, name string, component string, opts executor.AutoscaleOptions) (*executor.ScaleRecommendation, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	load, err := exec.CPULoad(ctx, component)
+	if err != nil {
+		return nil, err
+	}
+	return executor.RecommendReplicas(load, opts)
+}
+
+// GetReplicas returns the ready replica count for each component; see
+// GetReplicaCounts for the desired count as well
 func (m *Manager) GetReplicas(ctx context.Context, name string) (map[string]int, error) {
 	if !m.Exists(name) {
-		return nil, fmt.Errorf("cluster '%s' does not exist", name)
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
 	}
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return nil, err
 	}
@@ -444,13 +898,43 @@ func (m *Manager) GetReplicas(ctx context.Context, name string) (map[string]int,
 	return exec.GetReplicas(ctx)
 }
 
+// GetReplicaCounts returns the desired and ready replica count for each component
+func (m *Manager) GetReplicaCounts(ctx context.Context, name string) (map[string]executor.ReplicaCount, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.GetReplicaCounts(ctx)
+}
+
 // Upgrade upgrades the cluster to the specified Milvus version
-func (m *Manager) Upgrade(ctx context.Context, name string, version string) error {
+func (m *Manager) Upgrade(ctx context.Context, name string, version string) (err error) {
 	if !m.Exists(name) {
-		return fmt.Errorf("cluster '%s' does not exist", name)
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return err
 	}
@@ -468,10 +952,14 @@ func (m *Manager) Upgrade(ctx context.Context, name string, version string) erro
 	// Get current version for logging
 	currentVersion, _ := exec.GetVersion(ctx)
 
+	fields := map[string]string{"cluster": name, "from": currentVersion, "to": version}
+	logger.PhaseStart("upgrade", fields)
+	defer func() { logger.PhaseEnd("upgrade", err, fields) }()
+
 	// Update status to upgrading
 	oldStatus := meta.Status
 	meta.Status = spec.StatusUpgrading
-	if err := spec.SaveMeta(meta, m.MetaPath(name)); err != nil {
+	if err := m.store.Save(name, meta); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
 	}
 
@@ -480,7 +968,7 @@ func (m *Manager) Upgrade(ctx context.Context, name string, version string) erro
 	if err := exec.Upgrade(ctx, version); err != nil {
 		// Restore old status on failure
 		meta.Status = oldStatus
-		if saveErr := spec.SaveMeta(meta, m.MetaPath(name)); saveErr != nil {
+		if saveErr := m.store.Save(name, meta); saveErr != nil {
 			logger.Warn("Failed to restore metadata status: %v", saveErr)
 		}
 		return fmt.Errorf("failed to upgrade: %w", err)
@@ -492,8 +980,9 @@ func (m *Manager) Upgrade(ctx context.Context, name string, version string) erro
 		version = "v" + version
 	}
 	meta.MilvusVersion = version
+	meta.Image = ""
 	meta.Status = spec.StatusRunning
-	if err := spec.SaveMeta(meta, m.MetaPath(name)); err != nil {
+	if err := m.store.Save(name, meta); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
 	}
 
@@ -501,13 +990,81 @@ func (m *Manager) Upgrade(ctx context.Context, name string, version string) erro
 	return nil
 }
 
+// SetImage rolls a cluster to a full Milvus image reference, such as a
+// nightly or patched build, independent of the release version. The image's
+// tag is recorded as the cluster's version.
+func (m *Manager) SetImage(ctx context.Context, name string, image string) error {
+	if err := validateImage(image); err != nil {
+		return err
+	}
+
+	if !m.Exists(name) {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return err
+	}
+
+	oldStatus := meta.Status
+	meta.Status = spec.StatusUpgrading
+	if err := m.store.Save(name, meta); err != nil {
+		return fmt.Errorf("failed to update metadata: %w", err)
+	}
+
+	logger.Info("Setting the Milvus image of cluster '%s' to %s...", name, image)
+
+	if err := exec.SetImage(ctx, image); err != nil {
+		meta.Status = oldStatus
+		if saveErr := m.store.Save(name, meta); saveErr != nil {
+			logger.Warn("Failed to restore metadata status: %v", saveErr)
+		}
+		return fmt.Errorf("failed to set image: %w", err)
+	}
+
+	meta.Image = image
+	meta.MilvusVersion = executor.ImageVersion(image)
+	meta.Status = spec.StatusRunning
+	if err := m.store.Save(name, meta); err != nil {
+		return fmt.Errorf("failed to update metadata: %w", err)
+	}
+
+	logger.Success("Cluster '%s' now runs %s", name, image)
+	return nil
+}
+
+// validateImage rejects values that can't be an image reference
+func validateImage(image string) error {
+	if image == "" || strings.ContainsAny(image, " \t\n") {
+		return fmt.Errorf("invalid image reference: '%s'", image)
+	}
+	return nil
+}
+
 // GetVersion returns the current Milvus version for the cluster
 func (m *Manager) GetVersion(ctx context.Context, name string) (string, error) {
 	if !m.Exists(name) {
-		return "", fmt.Errorf("cluster '%s' does not exist", name)
+		return "", fmt.Errorf("%w: %s", ErrClusterNotFound, name)
 	}
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return "", err
 	}
@@ -528,10 +1085,10 @@ func (m *Manager) GetVersion(ctx context.Context, name string) (string, error) {
 // GetConfig returns the current Milvus configuration for the cluster
 func (m *Manager) GetConfig(ctx context.Context, name string) (map[string]interface{}, error) {
 	if !m.Exists(name) {
-		return nil, fmt.Errorf("cluster '%s' does not exist", name)
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
 	}
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return nil, err
 	}
@@ -552,10 +1109,16 @@ func (m *Manager) GetConfig(ctx context.Context, name string) (map[string]interf
 // SetConfig updates the Milvus configuration for the cluster
 func (m *Manager) SetConfig(ctx context.Context, name string, config map[string]interface{}) error {
 	if !m.Exists(name) {
-		return fmt.Errorf("cluster '%s' does not exist", name)
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
 	}
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return err
 	}
@@ -595,10 +1158,16 @@ type ReloadOptions struct {
 // Reload triggers a configuration reload on the cluster
 func (m *Manager) Reload(ctx context.Context, name string, opts ReloadOptions) error {
 	if !m.Exists(name) {
-		return fmt.Errorf("cluster '%s' does not exist", name)
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
 	}
+	defer unlock()
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return err
 	}
@@ -616,7 +1185,7 @@ func (m *Manager) Reload(ctx context.Context, name string, opts ReloadOptions) e
 	// Update status to reloading
 	oldStatus := meta.Status
 	meta.Status = spec.StatusReloading
-	if err := spec.SaveMeta(meta, m.MetaPath(name)); err != nil {
+	if err := m.store.Save(name, meta); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
 	}
 
@@ -631,7 +1200,7 @@ func (m *Manager) Reload(ctx context.Context, name string, opts ReloadOptions) e
 	if err := exec.Reload(ctx, execOpts); err != nil {
 		// Restore old status on failure
 		meta.Status = oldStatus
-		if saveErr := spec.SaveMeta(meta, m.MetaPath(name)); saveErr != nil {
+		if saveErr := m.store.Save(name, meta); saveErr != nil {
 			logger.Warn("Failed to restore metadata status: %v", saveErr)
 		}
 		return fmt.Errorf("failed to reload: %w", err)
@@ -639,7 +1208,7 @@ func (m *Manager) Reload(ctx context.Context, name string, opts ReloadOptions) e
 
 	// Update status back to running
 	meta.Status = spec.StatusRunning
-	if err := spec.SaveMeta(meta, m.MetaPath(name)); err != nil {
+	if err := m.store.Save(name, meta); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
 	}
 
@@ -647,13 +1216,92 @@ func (m *Manager) Reload(ctx context.Context, name string, opts ReloadOptions) e
 	return nil
 }
 
+// Apply updates a cluster to its stored topology, e.g. after the
+// topology.yaml was edited by hand, and waits for it to be ready. The
+// topology is validated first; an invalid one leaves the cluster as is. A
+// stopped cluster is rejected with ErrClusterStopped, since the replicas of
+// the topology would start it.
+func (m *Manager) Apply(ctx context.Context, name string) (err error) {
+	fields := map[string]string{"cluster": name}
+	logger.PhaseStart("apply", fields)
+	defer func() { logger.PhaseEnd("apply", err, fields) }()
+
+	if !m.Exists(name) {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return err
+	}
+	if meta.Status == spec.StatusStopped {
+		return fmt.Errorf("%w: %s; start it before applying its topology", ErrClusterStopped, name)
+	}
+
+	specification, err := m.loadStoredTopology(name)
+	if err != nil {
+		return err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return err
+	}
+
+	// Update status to applying
+	oldStatus := meta.Status
+	meta.Status = spec.StatusApplying
+	if err := m.store.Save(name, meta); err != nil {
+		return fmt.Errorf("failed to update metadata: %w", err)
+	}
+
+	logger.Info("Applying topology to cluster '%s'...", name)
+
+	if err := exec.Apply(ctx); err != nil {
+		// Restore old status on failure
+		meta.Status = oldStatus
+		if saveErr := m.store.Save(name, meta); saveErr != nil {
+			logger.Warn("Failed to restore metadata status: %v", saveErr)
+		}
+		return fmt.Errorf("failed to apply topology: %w", err)
+	}
+
+	meta.Status = spec.StatusRunning
+	if err := m.store.Save(name, meta); err != nil {
+		return fmt.Errorf("failed to update metadata: %w", err)
+	}
+
+	logger.Success("Cluster '%s' matches its topology!", name)
+	return nil
+}
+
+// loadStoredTopology loads and validates the stored topology of a cluster.
+// It may have been edited by hand to be applied, so environment variables
+// in it are expanded like in a topology to deploy.
+func (m *Manager) loadStoredTopology(name string) (*spec.Specification, error) {
+	specification, err := spec.LoadUserSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTopology, err)
+	}
+	if err := specification.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTopology, err)
+	}
+	return specification, nil
+}
+
 // Diagnose performs health diagnostics on the cluster
 func (m *Manager) Diagnose(ctx context.Context, name string) (*executor.DiagnoseResult, error) {
 	if !m.Exists(name) {
-		return nil, fmt.Errorf("cluster '%s' does not exist", name)
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
 	}
 
-	meta, err := spec.LoadMeta(m.MetaPath(name))
+	meta, err := m.store.Load(name)
 	if err != nil {
 		return nil, err
 	}
@@ -671,10 +1319,227 @@ func (m *Manager) Diagnose(ctx context.Context, name string) (*executor.Diagnose
 	return exec.Diagnose(ctx)
 }
 
-// Exists checks if a cluster exists
+// SetTTL makes a cluster expire ttl from now, or never for a zero ttl. The
+// expiry is saved in the metadata and annotated on the Milvus resource.
+func (m *Manager) SetTTL(ctx context.Context, name string, ttl time.Duration) error {
+	if !m.Exists(name) {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return err
+	}
+
+	meta.ExpiresAt = nil
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		meta.ExpiresAt = &expiresAt
+	}
+	if err := m.store.Save(name, meta); err != nil {
+		return fmt.Errorf("failed to update metadata: %w", err)
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return err
+	}
+
+	return exec.SetExpiry(ctx, meta.ExpiresAt)
+}
+
+// Describe returns the full operator-reported status of a cluster
+func (m *Manager) Describe(ctx context.Context, name string) (*executor.Description, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.Describe(ctx)
+}
+
+// Endpoint returns the connection details of a cluster's Milvus service
+func (m *Manager) Endpoint(ctx context.Context, name string) (*executor.Endpoint, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.Endpoint(ctx)
+}
+
+// Endpoints returns the Milvus endpoints of the named clusters, fetching
+// DefaultBulkConcurrency at a time. Clusters whose endpoint can't be fetched,
+// e.g. because their service is gone, are left out.
+func (m *Manager) Endpoints(ctx context.Context, names []string) map[string]*executor.Endpoint {
+	var mu sync.Mutex
+	endpoints := make(map[string]*executor.Endpoint, len(names))
+
+	executor.RunBulk(ctx, names, DefaultBulkConcurrency, func(ctx context.Context, name string) error {
+		endpoint, err := m.Endpoint(ctx, name)
+		if err != nil {
+			logger.Debug("Failed to get endpoint of cluster '%s': %v", name, err)
+			return err
+		}
+		mu.Lock()
+		endpoints[name] = endpoint
+		mu.Unlock()
+		return nil
+	})
+	return endpoints
+}
+
+// ResizeVolumes expands the persistent volumes of an in-cluster dependency
+func (m *Manager) ResizeVolumes(ctx context.Context, name string, opts executor.ResizeVolumesOptions) ([]executor.VolumeResize, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Resizing %s volumes of cluster '%s' to %s...", opts.Component, name, opts.Size)
+	return exec.ResizeVolumes(ctx, opts)
+}
+
+// RepairOptions contains options for rebuilding local cluster metadata
+type RepairOptions struct {
+	Kubeconfig  string
+	KubeContext string
+	Namespace   string
+
+	// Force rebuilds the metadata even if the existing files are readable
+	Force bool
+}
+
+// Repair rebuilds meta.json and topology.yaml for a cluster from its live
+// Milvus CRD, restoring management after local metadata was lost or
+// corrupted, or when moving to another machine
+func (m *Manager) Repair(ctx context.Context, name string, opts RepairOptions) error {
+	if m.Exists(name) && !opts.Force {
+		_, metaErr := m.store.Load(name)
+		_, topoErr := spec.LoadSpecification(m.TopologyPath(name))
+		if metaErr == nil && topoErr == nil {
+			return fmt.Errorf("cluster '%s' metadata is intact, use --force to rebuild it", name)
+		}
+	}
+
+	exec, err := executor.NewKubernetesExecutor(executor.KubernetesOptions{
+		Kubeconfig:  opts.Kubeconfig,
+		Context:     opts.KubeContext,
+		Namespace:   opts.Namespace,
+		ClusterName: name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	logger.Info("Reading Milvus CRD for '%s'...", name)
+	specification, milvusVersion, err := exec.ExportSpec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.ClusterDir(name), 0755); err != nil {
+		return fmt.Errorf("failed to create cluster directory: %w", err)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := spec.SaveSpecification(specification, m.TopologyPath(name)); err != nil {
+		return fmt.Errorf("failed to save topology: %w", err)
+	}
+
+	meta := spec.NewClusterMeta(name, specification, milvusVersion)
+	meta.Kubeconfig = opts.Kubeconfig
+	meta.KubeContext = opts.KubeContext
+	meta.Namespace = specification.Global.Namespace
+
+	running, err := exec.IsRunning(ctx)
+	switch {
+	case err != nil:
+		logger.Warn("Failed to get status for cluster '%s': %v", name, err)
+		meta.Status = spec.StatusUnknown
+	case running:
+		meta.Status = spec.StatusRunning
+	default:
+		meta.Status = spec.StatusStopped
+	}
+
+	if err := m.store.Save(name, meta); err != nil {
+		return fmt.Errorf("failed to save metadata: %w", err)
+	}
+
+	logger.Success("Rebuilt metadata for cluster '%s' (namespace %s, version %s)", name, meta.Namespace, milvusVersion)
+	return nil
+}
+
+// Exists checks if a cluster exists, i.e. the store has metadata for it,
+// even if that metadata can't be read
 func (m *Manager) Exists(name string) bool {
-	_, err := os.Stat(m.ClusterDir(name))
-	return err == nil
+	_, err := m.store.Load(name)
+	return !errors.Is(err, ErrMetaNotFound)
 }
 
 // buildDeployOptions builds DeployOptions from cluster metadata
@@ -684,6 +1549,8 @@ func (m *Manager) buildDeployOptions(meta *spec.ClusterMeta) DeployOptions {
 		Kubeconfig:    meta.Kubeconfig,
 		KubeContext:   meta.KubeContext,
 		Namespace:     meta.Namespace,
+		expiresAt:     meta.ExpiresAt,
+		image:         meta.Image,
 	}
 }
 
@@ -693,13 +1560,17 @@ func (m *Manager) createExecutor(name string, specification *spec.Specification,
 	if namespace == "" {
 		namespace = specification.Global.Namespace
 	}
-	return executor.NewKubernetesExecutor(executor.KubernetesOptions{
+	return m.newExecutor(executor.KubernetesOptions{
 		Kubeconfig:    opts.Kubeconfig,
 		Context:       opts.KubeContext,
 		Namespace:     namespace,
 		ClusterName:   name,
 		Spec:          specification,
 		MilvusVersion: opts.MilvusVersion,
+		Image:         opts.image,
 		WithMonitor:   opts.WithMonitor,
+		Apply:         opts.Apply,
+		ExpiresAt:     opts.expiresAt,
+		Plan:          opts.plan,
 	})
 }
@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+func TestOperationsOnMissingCluster(t *testing.T) {
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+	ctx := context.Background()
+
+	if err := mgr.Start(ctx, "missing"); !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("Start() error = %v, want ErrClusterNotFound", err)
+	}
+	if _, err := mgr.Display(ctx, "missing"); !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("Display() error = %v, want ErrClusterNotFound", err)
+	}
+	if err := mgr.WatchEvents(ctx, "missing", func(executor.Event) {}); !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("WatchEvents() error = %v, want ErrClusterNotFound", err)
+	}
+}
+
+func TestLock(t *testing.T) {
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+	if err := os.MkdirAll(mgr.ClusterDir("demo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	unlock, err := mgr.lock("demo")
+	if err != nil {
+		t.Fatalf("lock() error = %v", err)
+	}
+
+	if _, err := mgr.lock("demo"); !errors.Is(err, ErrOperationInProgress) {
+		t.Errorf("second lock() error = %v, want ErrOperationInProgress", err)
+	}
+
+	unlock()
+	unlock, err = mgr.lock("demo")
+	if err != nil {
+		t.Fatalf("lock() after unlock error = %v", err)
+	}
+	unlock()
+}
+
+func TestTrackedResources(t *testing.T) {
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+
+	clusters := []*spec.ClusterMeta{
+		{Name: "prod", Namespace: "milvus"},
+		{Name: "dev", Namespace: "sandbox"},
+	}
+	for _, meta := range clusters {
+		if err := os.MkdirAll(mgr.ClusterDir(meta.Name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := spec.SaveMeta(meta, mgr.MetaPath(meta.Name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tracked := mgr.trackedResources()
+	for _, key := range []string{"milvus/prod", "sandbox/dev"} {
+		if !tracked[key] {
+			t.Errorf("trackedResources() missing %s", key)
+		}
+	}
+	if tracked["milvus/dev"] {
+		t.Error("trackedResources() should match on namespace and name")
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	fake := &fakeExecutor{events: []executor.Event{
+		{Reason: "Scheduled", Object: "pod/demo-milvus-standalone-0"},
+		{Reason: "Pulling", Object: "pod/demo-milvus-standalone-0"},
+	}}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "demo")
+
+	var reasons []string
+	err := mgr.WatchEvents(context.Background(), "demo", func(ev executor.Event) {
+		reasons = append(reasons, ev.Reason)
+	})
+	if err != nil {
+		t.Fatalf("WatchEvents() error = %v", err)
+	}
+	if len(reasons) != 2 || reasons[0] != "Scheduled" || reasons[1] != "Pulling" {
+		t.Errorf("events = %v, want [Scheduled Pulling]", reasons)
+	}
+	if fake.opts.ClusterName != "demo" {
+		t.Errorf("executor cluster = %s, want demo", fake.opts.ClusterName)
+	}
+}
@@ -0,0 +1,92 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+)
+
+// PlanScale returns the changes Scale would make to the Milvus resource of
+// a cluster, without applying them
+func (m *Manager) PlanScale(ctx context.Context, name string, component string, opts executor.ScaleOptions) (*executor.Plan, error) {
+	if err := spec.ValidateEnv(opts.Env); err != nil {
+		return nil, err
+	}
+	return m.plan(name, func(exec executor.Executor) error {
+		return exec.Scale(ctx, component, opts)
+	})
+}
+
+// PlanUpgrade returns the changes Upgrade would make to the Milvus resource
+// of a cluster, without applying them
+func (m *Manager) PlanUpgrade(ctx context.Context, name string, version string) (*executor.Plan, error) {
+	return m.plan(name, func(exec executor.Executor) error {
+		return exec.Upgrade(ctx, version)
+	})
+}
+
+// PlanSetImage returns the changes SetImage would make to the Milvus
+// resource of a cluster, without applying them
+func (m *Manager) PlanSetImage(ctx context.Context, name string, image string) (*executor.Plan, error) {
+	if err := validateImage(image); err != nil {
+		return nil, err
+	}
+	return m.plan(name, func(exec executor.Executor) error {
+		return exec.SetImage(ctx, image)
+	})
+}
+
+// PlanApply returns the changes Apply would make to the Milvus resource of
+// a cluster to match its stored topology, without applying them
+func (m *Manager) PlanApply(ctx context.Context, name string) (*executor.Plan, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	if meta.Status == spec.StatusStopped {
+		return nil, fmt.Errorf("%w: %s; start it before applying its topology", ErrClusterStopped, name)
+	}
+	if _, err := m.loadStoredTopology(name); err != nil {
+		return nil, err
+	}
+	return m.plan(name, func(exec executor.Executor) error {
+		return exec.Apply(ctx)
+	})
+}
+
+// plan runs op on an executor that records updates of the cluster's Milvus
+// resource in a plan instead of applying them. Local metadata is not
+// changed and the cluster is not locked, since nothing is modified.
+func (m *Manager) plan(name string, op func(exec executor.Executor) error) (*executor.Plan, error) {
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &executor.Plan{Changes: []executor.PlannedChange{}}
+	opts := m.buildDeployOptions(meta)
+	opts.plan = plan
+	exec, err := m.createExecutor(name, specification, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := op(exec); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
@@ -0,0 +1,47 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+)
+
+func TestPlan(t *testing.T) {
+	fake := &fakeExecutor{}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "prod")
+
+	plan, err := mgr.PlanScale(context.Background(), "prod", "querynode", executor.ScaleOptions{Replicas: 5})
+	if err != nil {
+		t.Fatalf("PlanScale() error = %v", err)
+	}
+	if plan == nil || fake.opts.Plan != plan {
+		t.Error("PlanScale() should run the executor with its plan")
+	}
+
+	if _, err := mgr.PlanUpgrade(context.Background(), "prod", "v2.6.0"); err != nil {
+		t.Fatalf("PlanUpgrade() error = %v", err)
+	}
+	if _, err := mgr.PlanSetImage(context.Background(), "prod", "not an image"); err == nil {
+		t.Error("PlanSetImage() should reject an invalid image")
+	}
+
+	if want := []string{"scale querynode", "upgrade v2.6.0"}; !slices.Equal(fake.calls, want) {
+		t.Errorf("calls = %v, want %v", fake.calls, want)
+	}
+	if got := status(t, mgr, "prod"); got != spec.StatusRunning {
+		t.Errorf("status = %s, want %s unchanged by a dry run", got, spec.StatusRunning)
+	}
+
+	fake.err = errors.New("unknown component")
+	if _, err := mgr.PlanScale(context.Background(), "prod", "bogus", executor.ScaleOptions{Replicas: 1}); err == nil {
+		t.Error("PlanScale() should return the executor's error")
+	}
+	if _, err := mgr.PlanUpgrade(context.Background(), "missing", "v2.6.0"); !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("PlanUpgrade() error = %v, want ErrClusterNotFound", err)
+	}
+}
@@ -0,0 +1,58 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+)
+
+// PortForwardOptions contains options for PortForwardAll
+type PortForwardOptions struct {
+	// Address is the local address to listen on
+	Address string
+
+	// LocalPorts overrides the local port of a forward, keyed by its
+	// remote port; ports not listed are forwarded to the same local port
+	LocalPorts map[int]int
+
+	// Ready is called for each forward once its local port listens
+	Ready func(executor.Forward)
+}
+
+// PortForwardAll forwards Milvus, its metrics port and the MinIO console of
+// a cluster to local ports, blocking until ctx is cancelled
+func (m *Manager) PortForwardAll(ctx context.Context, name string, opts PortForwardOptions) error {
+	if !m.Exists(name) {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return err
+	}
+
+	forwards := exec.DebugForwards()
+	for i, f := range forwards {
+		if port, ok := opts.LocalPorts[f.Port]; ok {
+			forwards[i].LocalPort = port
+		}
+	}
+
+	address := opts.Address
+	if address == "" {
+		address = "localhost"
+	}
+	return exec.PortForward(ctx, address, forwards, opts.Ready)
+}
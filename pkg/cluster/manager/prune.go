@@ -0,0 +1,160 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/logger"
+)
+
+// OrphanedCluster is a locally tracked cluster whose Milvus resource no
+// longer exists, e.g. after a destroy that deleted the resource but failed
+// to remove the local state, or a resource deleted with kubectl
+type OrphanedCluster struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	KubeContext string `json:"kubeContext,omitempty"`
+}
+
+// UncheckedCluster is a locally tracked cluster whose Milvus resource could
+// not be looked up, so it is neither pruned nor kept for certain
+type UncheckedCluster struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// FindOrphans checks every locally tracked cluster against the Kubernetes
+// cluster it was deployed to and returns those whose Milvus resource is
+// gone. Clusters that can't be checked, because their metadata is unreadable,
+// the API is unreachable or another operation holds their lock, are returned
+// as unchecked rather than guessed about.
+func (m *Manager) FindOrphans(ctx context.Context) ([]OrphanedCluster, []UncheckedCluster, error) {
+	names, err := m.store.List()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var orphaned []OrphanedCluster
+	var unchecked []UncheckedCluster
+	for _, name := range names {
+		orphan, err := m.checkOrphan(ctx, name)
+		switch {
+		case err != nil:
+			unchecked = append(unchecked, UncheckedCluster{Name: name, Reason: err.Error()})
+		case orphan != nil:
+			orphaned = append(orphaned, *orphan)
+		}
+	}
+	return orphaned, unchecked, nil
+}
+
+// checkOrphan returns the cluster if its Milvus resource is gone, or nil if
+// it exists. The lock keeps a deploy that has saved its metadata but not yet
+// created the resource from being reported.
+func (m *Manager) checkOrphan(ctx context.Context, name string) (*OrphanedCluster, error) {
+	unlock, err := m.lock(name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w; rebuild it with 'miup instance repair'", err)
+	}
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("%w; rebuild it with 'miup instance repair'", err)
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return nil, err
+	}
+	exists, err := exec.Exists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the Milvus resource: %w", err)
+	}
+	if exists {
+		return nil, nil
+	}
+
+	namespace := meta.Namespace
+	if namespace == "" {
+		namespace = specification.Global.Namespace
+	}
+	return &OrphanedCluster{Name: name, Namespace: namespace, KubeContext: meta.KubeContext}, nil
+}
+
+// RemoveOrphan removes the local state of a cluster whose Milvus resource no
+// longer exists. It checks again under the lock and fails with
+// ErrNotOrphaned if the resource exists, e.g. because it was redeployed.
+func (m *Manager) RemoveOrphan(ctx context.Context, name string) error {
+	if !m.Exists(name) {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return err
+	}
+
+	exists, err := exec.Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up the Milvus resource: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrNotOrphaned, name)
+	}
+
+	if err := m.store.Delete(name); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(m.ClusterDir(name)); err != nil {
+		return fmt.Errorf("failed to remove cluster directory: %w", err)
+	}
+
+	logger.Success("Removed the local state of cluster '%s'", name)
+	return nil
+}
+
+// Untracked lists the Milvus resources labelled as created by miup that no
+// local cluster tracks. They can be adopted with Repair.
+func (m *Manager) Untracked(ctx context.Context, opts DiscoverOptions) ([]executor.MilvusResource, error) {
+	clusters, err := m.Discover(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return untracked(clusters), nil
+}
+
+// untracked returns the miup-created resources among clusters that are not
+// tracked locally
+func untracked(clusters []DiscoveredCluster) []executor.MilvusResource {
+	var resources []executor.MilvusResource
+	for _, c := range clusters {
+		if !c.Managed && c.ManagedBy == "miup" {
+			resources = append(resources, c.MilvusResource)
+		}
+	}
+	return resources
+}
@@ -0,0 +1,111 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+)
+
+// newPruneManager deploys a cluster for each fake and makes the manager use
+// the fake of each cluster by name
+func newPruneManager(t *testing.T, fakes map[string]*fakeExecutor) *Manager {
+	t.Helper()
+	mgr := newFakeManager(t, &fakeExecutor{})
+	for name := range fakes {
+		deployFake(t, mgr, &fakeExecutor{}, name)
+	}
+	mgr.newExecutor = func(opts executor.KubernetesOptions) (executor.Executor, error) {
+		return fakes[opts.ClusterName], nil
+	}
+	return mgr
+}
+
+func TestFindOrphans(t *testing.T) {
+	ctx := context.Background()
+	mgr := newPruneManager(t, map[string]*fakeExecutor{
+		"live":        {exists: true},
+		"gone":        {},
+		"unreachable": {existsErr: errFake},
+		"broken":      {},
+		"busy":        {},
+	})
+
+	if err := os.Remove(mgr.TopologyPath("broken")); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err := mgr.lock("busy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	orphaned, unchecked, err := mgr.FindOrphans(ctx)
+	if err != nil {
+		t.Fatalf("FindOrphans() error = %v", err)
+	}
+	if len(orphaned) != 1 || orphaned[0].Name != "gone" || orphaned[0].Namespace != "milvus" {
+		t.Errorf("orphaned = %+v, want only gone in namespace milvus", orphaned)
+	}
+
+	wantReasons := map[string]string{
+		"broken":      "miup instance repair",
+		"busy":        ErrOperationInProgress.Error(),
+		"unreachable": errFake.Error(),
+	}
+	if len(unchecked) != len(wantReasons) {
+		t.Fatalf("unchecked = %+v, want %d clusters", unchecked, len(wantReasons))
+	}
+	for _, u := range unchecked {
+		if want, ok := wantReasons[u.Name]; !ok || !strings.Contains(u.Reason, want) {
+			t.Errorf("unchecked %s: reason = %q, want one containing %q", u.Name, u.Reason, want)
+		}
+	}
+}
+
+func TestRemoveOrphan(t *testing.T) {
+	ctx := context.Background()
+	fakes := map[string]*fakeExecutor{
+		"live": {exists: true},
+		"gone": {},
+	}
+	mgr := newPruneManager(t, fakes)
+
+	if err := mgr.RemoveOrphan(ctx, "live"); !errors.Is(err, ErrNotOrphaned) {
+		t.Errorf("RemoveOrphan(live) error = %v, want ErrNotOrphaned", err)
+	}
+	if !mgr.Exists("live") {
+		t.Error("the local state of a live cluster was removed")
+	}
+
+	if err := mgr.RemoveOrphan(ctx, "gone"); err != nil {
+		t.Fatalf("RemoveOrphan(gone) error = %v", err)
+	}
+	if mgr.Exists("gone") {
+		t.Error("the local state of an orphaned cluster was kept")
+	}
+
+	if err := mgr.RemoveOrphan(ctx, "gone"); !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("second RemoveOrphan() error = %v, want ErrClusterNotFound", err)
+	}
+}
+
+func TestUntracked(t *testing.T) {
+	resource := func(name, managedBy string) executor.MilvusResource {
+		return executor.MilvusResource{Name: name, Namespace: "milvus", ManagedBy: managedBy}
+	}
+	clusters := []DiscoveredCluster{
+		{MilvusResource: resource("tracked", "miup"), Managed: true},
+		{MilvusResource: resource("lost", "miup")},
+		{MilvusResource: resource("helm", "Helm")},
+		{MilvusResource: resource("manual", "")},
+	}
+
+	got := untracked(clusters)
+	if len(got) != 1 || got[0].Name != "lost" {
+		t.Errorf("untracked() = %+v, want only lost", got)
+	}
+}
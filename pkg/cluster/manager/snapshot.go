@@ -0,0 +1,177 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/archive"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/localdata"
+	"github.com/mmga-lab/miup/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// Config snapshot layout
+const (
+	// SnapshotDir is the directory in a cluster directory holding snapshots
+	SnapshotDir = "snapshots"
+
+	// SnapshotConfigFile is the Milvus configuration in a snapshot
+	SnapshotConfigFile = "config.yaml"
+)
+
+// SnapshotConfig writes the live Milvus configuration and the topology of a
+// cluster to a tar.gz snapshot at outputPath, or to a timestamped file in
+// the cluster's snapshots directory if outputPath is empty. The snapshot can
+// be restored with RestoreConfigSnapshot. It returns the path written.
+func (m *Manager) SnapshotConfig(ctx context.Context, name, outputPath string) (string, error) {
+	config, err := m.GetConfig(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to format config: %w", err)
+	}
+	topology, err := os.ReadFile(m.TopologyPath(name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read topology: %w", err)
+	}
+
+	if outputPath == "" {
+		outputPath = m.DefaultSnapshotPath(name, time.Now())
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "miup-snapshot-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := os.WriteFile(filepath.Join(tmpDir, SnapshotConfigFile), data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, TopologyFileName), topology, 0644); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := archive.CreateTarGz(outputPath, tmpDir); err != nil {
+		return "", err
+	}
+
+	logger.Success("Configuration of '%s' saved to %s", name, outputPath)
+	return outputPath, nil
+}
+
+// DefaultSnapshotPath returns a timestamped snapshot path in the cluster's
+// snapshots directory
+func (m *Manager) DefaultSnapshotPath(name string, now time.Time) string {
+	file := fmt.Sprintf("%s-config-%s.tar.gz", name, now.Format("20060102-150405"))
+	return filepath.Join(m.ClusterDir(name), SnapshotDir, file)
+}
+
+// IsConfigSnapshot reports whether path names a config snapshot rather than
+// a plain YAML configuration
+func IsConfigSnapshot(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz")
+}
+
+// RestoreConfigSnapshot restores a cluster to a snapshot: the Milvus
+// configuration is replaced with the saved one, so keys set after the
+// snapshot was taken are removed, and the stored topology is replaced with
+// the saved topology. The topology is validated before anything changes.
+func (m *Manager) RestoreConfigSnapshot(ctx context.Context, name, path string) error {
+	if !m.Exists(name) {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	config, err := LoadConfigSnapshot(path)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	topology, err := archive.ReadTarGzFile(path, TopologyFileName)
+	if err != nil {
+		return err
+	}
+	specification, err := parseSnapshotTopology(topology)
+	if err != nil {
+		return fmt.Errorf("%w in %s: %w", ErrInvalidTopology, path, err)
+	}
+
+	unlock, err := m.lock(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Restoring configuration of cluster '%s' from %s...", name, path)
+
+	if err := exec.ReplaceConfig(ctx, config); err != nil {
+		return fmt.Errorf("failed to restore config: %w", err)
+	}
+	if err := localdata.WriteFileAtomic(m.TopologyPath(name), topology, 0644); err != nil {
+		return fmt.Errorf("failed to restore topology: %w", err)
+	}
+
+	logger.Success("Configuration and topology of '%s' restored from %s", name, path)
+	return nil
+}
+
+// parseSnapshotTopology loads and validates the topology of a snapshot
+func parseSnapshotTopology(data []byte) (*spec.Specification, error) {
+	tmpDir, err := os.MkdirTemp("", "miup-snapshot-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, TopologyFileName)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write topology: %w", err)
+	}
+	specification, err := spec.LoadSpecification(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := specification.Validate(); err != nil {
+		return nil, err
+	}
+	return specification, nil
+}
+
+// LoadConfigSnapshot returns the Milvus configuration saved in a snapshot
+func LoadConfigSnapshot(path string) (map[string]interface{}, error) {
+	data, err := archive.ReadTarGzFile(path, SnapshotConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s in %s: %w", SnapshotConfigFile, path, err)
+	}
+	return config, nil
+}
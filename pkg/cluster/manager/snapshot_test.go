@@ -0,0 +1,141 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/archive"
+)
+
+func TestSnapshotConfig(t *testing.T) {
+	fake := &fakeExecutor{config: map[string]interface{}{
+		"common": map[string]interface{}{"security": map[string]interface{}{"tlsMode": 1}},
+	}}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "prod")
+
+	path, err := mgr.SnapshotConfig(context.Background(), "prod", "")
+	if err != nil {
+		t.Fatalf("SnapshotConfig() error = %v", err)
+	}
+	if dir := filepath.Join(mgr.ClusterDir("prod"), SnapshotDir); filepath.Dir(path) != dir {
+		t.Errorf("snapshot path = %s, want in %s", path, dir)
+	}
+	if !IsConfigSnapshot(path) {
+		t.Errorf("IsConfigSnapshot(%s) = false", path)
+	}
+
+	config, err := LoadConfigSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadConfigSnapshot() error = %v", err)
+	}
+	changes, err := DiffConfig(fake.config, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("restored config differs: %+v", changes)
+	}
+
+	topology, err := archive.ReadTarGzFile(path, TopologyFileName)
+	if err != nil {
+		t.Fatalf("snapshot has no topology: %v", err)
+	}
+	saved, err := os.ReadFile(mgr.TopologyPath("prod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(topology) != string(saved) {
+		t.Errorf("snapshot topology = %q, want %q", topology, saved)
+	}
+
+	output := filepath.Join(t.TempDir(), "backup", "prod.tar.gz")
+	if path, err := mgr.SnapshotConfig(context.Background(), "prod", output); err != nil || path != output {
+		t.Errorf("SnapshotConfig(-o) = %s, %v, want %s", path, err, output)
+	}
+
+	if _, err := mgr.SnapshotConfig(context.Background(), "missing", ""); err == nil {
+		t.Error("SnapshotConfig() should fail for an unknown cluster")
+	}
+}
+
+func TestDefaultSnapshotPath(t *testing.T) {
+	mgr := newFakeManager(t, &fakeExecutor{})
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	path := mgr.DefaultSnapshotPath("prod", now)
+	if !strings.HasSuffix(path, filepath.Join("prod", SnapshotDir, "prod-config-20260304-050607.tar.gz")) {
+		t.Errorf("DefaultSnapshotPath() = %s", path)
+	}
+	if IsConfigSnapshot("config.yaml") {
+		t.Error("IsConfigSnapshot(config.yaml) = true")
+	}
+}
+
+func TestRestoreConfigSnapshot(t *testing.T) {
+	saved := map[string]interface{}{"log": map[string]interface{}{"level": "info"}}
+	fake := &fakeExecutor{config: saved}
+	mgr := newFakeManager(t, fake)
+	deployFake(t, mgr, fake, "prod")
+	ctx := context.Background()
+
+	path, err := mgr.SnapshotConfig(ctx, "prod", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	topology, err := os.ReadFile(mgr.TopologyPath("prod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Changed after the snapshot: a new key and an edited topology
+	fake.config = map[string]interface{}{
+		"log":   map[string]interface{}{"level": "debug"},
+		"proxy": map[string]interface{}{"maxNameLength": 512},
+	}
+	if err := os.WriteFile(mgr.TopologyPath("prod"), []byte("milvus_servers:\n  - host: changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mgr.RestoreConfigSnapshot(ctx, "prod", path); err != nil {
+		t.Fatalf("RestoreConfigSnapshot() error = %v", err)
+	}
+	changes, err := DiffConfig(saved, fake.config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("config after restore differs from the snapshot: %+v", changes)
+	}
+	restored, err := os.ReadFile(mgr.TopologyPath("prod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(restored) != string(topology) {
+		t.Errorf("topology after restore = %q, want %q", restored, topology)
+	}
+
+	// A snapshot with an invalid topology changes nothing
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, SnapshotConfigFile), []byte("log:\n  level: warn\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, TopologyFileName), []byte("milvus_servers: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(t.TempDir(), "invalid.tar.gz")
+	if err := archive.CreateTarGz(invalid, dir); err != nil {
+		t.Fatal(err)
+	}
+	fake.calls = nil
+	if err := mgr.RestoreConfigSnapshot(ctx, "prod", invalid); !errors.Is(err, ErrInvalidTopology) {
+		t.Errorf("RestoreConfigSnapshot() error = %v, want ErrInvalidTopology", err)
+	}
+	if len(fake.calls) != 0 {
+		t.Errorf("calls = %v, want none", fake.calls)
+	}
+}
@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+// MetaStore persists cluster metadata. The Manager reads and writes metadata
+// only through it, so state can be kept somewhere other than the profile
+// directory (e.g. a ConfigMap or object store shared by a team) by providing
+// another implementation to NewManagerWithStore. Playgrounds are out of its
+// scope: their containers and data only exist on the machine that runs them,
+// so their metadata stays in the local profile.
+type MetaStore interface {
+	// Load returns the metadata of a cluster. It returns an error wrapping
+	// ErrMetaNotFound if the cluster has none.
+	Load(name string) (*spec.ClusterMeta, error)
+
+	// Save creates or replaces the metadata of a cluster
+	Save(name string, meta *spec.ClusterMeta) error
+
+	// List returns the names of all clusters in the store, sorted
+	List() ([]string, error)
+
+	// Delete removes the metadata of a cluster; it is not an error if the
+	// cluster has none
+	Delete(name string) error
+}
+
+// FileMetaStore is the default MetaStore, which keeps the metadata of each
+// cluster in <dir>/<name>/meta.json with a backup of the previous version
+type FileMetaStore struct {
+	dir string
+}
+
+// NewFileMetaStore creates a MetaStore keeping metadata under dir
+func NewFileMetaStore(dir string) *FileMetaStore {
+	return &FileMetaStore{dir: dir}
+}
+
+func (s *FileMetaStore) path(name string) string {
+	return filepath.Join(s.dir, name, MetaFileName)
+}
+
+// Load reads the metadata file of a cluster, falling back to its backup if
+// the file is corrupt
+func (s *FileMetaStore) Load(name string) (*spec.ClusterMeta, error) {
+	meta, err := spec.LoadMeta(s.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrMetaNotFound, name)
+	}
+	return meta, err
+}
+
+// Save writes the metadata file of a cluster
+func (s *FileMetaStore) Save(name string, meta *spec.ClusterMeta) error {
+	if err := os.MkdirAll(filepath.Dir(s.path(name)), 0755); err != nil {
+		return fmt.Errorf("failed to create cluster directory: %w", err)
+	}
+	return spec.SaveMeta(meta, s.path(name))
+}
+
+// List returns the clusters that have a directory. A directory whose
+// metadata is missing or corrupt is listed too, so that callers report it
+// when Load fails rather than silently dropping the cluster.
+func (s *FileMetaStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Delete removes the metadata file of a cluster and its backup
+func (s *FileMetaStore) Delete(name string) error {
+	for _, path := range []string{s.path(name), s.path(name) + localdata.BackupSuffix} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete metadata: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+func TestFileMetaStore(t *testing.T) {
+	store := NewFileMetaStore(t.TempDir())
+
+	if _, err := store.Load("prod"); !errors.Is(err, ErrMetaNotFound) {
+		t.Errorf("Load() of missing cluster error = %v, want ErrMetaNotFound", err)
+	}
+	if names, err := store.List(); err != nil || len(names) != 0 {
+		t.Errorf("List() of empty store = %v, %v", names, err)
+	}
+
+	for _, name := range []string{"staging", "prod"} {
+		if err := store.Save(name, &spec.ClusterMeta{Name: name, Status: spec.StatusRunning}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	meta, err := store.Load("prod")
+	if err != nil || meta.Name != "prod" || meta.Status != spec.StatusRunning {
+		t.Errorf("Load() = %+v, %v", meta, err)
+	}
+	if names, err := store.List(); err != nil || !slices.Equal(names, []string{"prod", "staging"}) {
+		t.Errorf("List() = %v, %v, want [prod staging]", names, err)
+	}
+
+	if err := store.Delete("prod"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Load("prod"); !errors.Is(err, ErrMetaNotFound) {
+		t.Errorf("Load() after Delete() error = %v, want ErrMetaNotFound", err)
+	}
+	if err := store.Delete("prod"); err != nil {
+		t.Errorf("second Delete() error = %v", err)
+	}
+}
+
+// memMetaStore is a MetaStore that keeps metadata in memory
+type memMetaStore map[string]spec.ClusterMeta
+
+func (s memMetaStore) Load(name string) (*spec.ClusterMeta, error) {
+	meta, ok := s[name]
+	if !ok {
+		return nil, ErrMetaNotFound
+	}
+	return &meta, nil
+}
+
+func (s memMetaStore) Save(name string, meta *spec.ClusterMeta) error {
+	s[name] = *meta
+	return nil
+}
+
+func (s memMetaStore) List() ([]string, error) {
+	var names []string
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s memMetaStore) Delete(name string) error {
+	delete(s, name)
+	return nil
+}
+
+func TestManagerUsesMetaStore(t *testing.T) {
+	store := memMetaStore{}
+	fake := &fakeExecutor{}
+	mgr := NewManagerWithStore(localdata.NewProfile(t.TempDir()), store)
+	mgr.newExecutor = func(opts executor.KubernetesOptions) (executor.Executor, error) {
+		return fake, nil
+	}
+
+	if err := mgr.Deploy(context.Background(), "prod", writeTopology(t), DeployOptions{}); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	if store["prod"].Status != spec.StatusRunning {
+		t.Errorf("stored meta = %+v, want running", store["prod"])
+	}
+
+	// Existence is the store's to tell, not the profile directory's
+	store["shared"] = spec.ClusterMeta{Name: "shared"}
+	if !mgr.Exists("shared") {
+		t.Error("Exists() = false for a cluster only in the store")
+	}
+	delete(store, "shared")
+
+	clusters, err := mgr.filter(func(*spec.ClusterMeta) bool { return true })
+	if err != nil || len(clusters) != 1 || clusters[0].Name != "prod" {
+		t.Errorf("filter() = %v, %v", clusters, err)
+	}
+
+	if err := mgr.Destroy(context.Background(), "prod", DestroyOptions{Force: true}); err != nil {
+		t.Fatalf("Destroy() error = %v", err)
+	}
+	if _, ok := store["prod"]; ok {
+		t.Error("Destroy() should delete the stored meta")
+	}
+	if mgr.Exists("prod") {
+		t.Error("Exists() = true after Destroy()")
+	}
+}
@@ -0,0 +1,225 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/archive"
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/logger"
+	"github.com/mmga-lab/miup/pkg/version"
+	"gopkg.in/yaml.v3"
+)
+
+// SupportBundleOptions defines options for collecting a support bundle
+type SupportBundleOptions struct {
+	// OutputPath is the path of the tar.gz archive to create
+	OutputPath string
+
+	// Logs controls which logs are collected for each pod
+	Logs executor.LogsOptions
+}
+
+// SupportBundle collects diagnostics for a cluster into a tar.gz archive:
+// miup version, local meta and topology, the Milvus CRD, events, pod
+// manifests, pod logs and diagnose output. Credentials in the topology and
+// the custom resource are redacted. Failures collecting individual pieces
+// are recorded in errors.txt rather than aborting the bundle.
+func (m *Manager) SupportBundle(ctx context.Context, name string, opts SupportBundleOptions) error {
+	if !m.Exists(name) {
+		return fmt.Errorf("%w: %s", ErrClusterNotFound, name)
+	}
+
+	meta, err := m.store.Load(name)
+	if err != nil {
+		return err
+	}
+
+	specification, err := spec.LoadSpecification(m.TopologyPath(name))
+	if err != nil {
+		return err
+	}
+
+	exec, err := m.createExecutor(name, specification, m.buildDeployOptions(meta))
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "miup-support-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bundleName := strings.TrimSuffix(filepath.Base(opts.OutputPath), ".tar.gz")
+	bundleDir := filepath.Join(tmpDir, bundleName)
+
+	b := &bundleWriter{dir: bundleDir}
+
+	logger.Info("Collecting support bundle for '%s'...", name)
+
+	b.writeJSON("version.json", version.GetVersionInfo())
+	b.writeJSON("meta.json", meta)
+	topology, err := os.ReadFile(m.TopologyPath(name))
+	b.writeRedactedYAML("topology.yaml", topology, err)
+
+	crd, err := exec.ExportCRD(ctx)
+	b.writeRedactedYAML("milvus.yaml", crd, err)
+
+	events, err := exec.Events(ctx)
+	if err != nil {
+		b.recordError("events", err)
+	} else {
+		b.writeJSON("events.json", events)
+	}
+
+	manifests, err := exec.PodManifests(ctx)
+	if err != nil {
+		b.recordError("pods", err)
+	}
+	for pod, data := range manifests {
+		b.write(filepath.Join("pods", pod+".yaml"), data, nil)
+	}
+
+	podLogs, err := exec.PodLogs(ctx, opts.Logs)
+	if err != nil {
+		b.recordError("logs", err)
+	}
+	for _, l := range podLogs {
+		if l.Err != nil {
+			b.recordError("logs/"+l.Pod, l.Err)
+		}
+	}
+	if len(podLogs) > 0 {
+		if _, err := executor.WriteLogsToDir(filepath.Join(bundleDir, "logs"), podLogs); err != nil {
+			b.recordError("logs", err)
+		}
+	}
+
+	diagnose, err := exec.Diagnose(ctx)
+	if err != nil {
+		b.recordError("diagnose", err)
+	} else {
+		b.writeJSON("diagnose.json", diagnose)
+	}
+
+	if len(b.errors) > 0 {
+		b.write("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n"), nil)
+		logger.Warn("Some diagnostics could not be collected, see errors.txt in the bundle")
+	}
+
+	if err := archive.CreateTarGz(opts.OutputPath, tmpDir); err != nil {
+		return err
+	}
+
+	logger.Success("Support bundle written to %s", opts.OutputPath)
+	return nil
+}
+
+// DefaultSupportBundlePath returns a timestamped bundle file name for a cluster
+func DefaultSupportBundlePath(name string, now time.Time) string {
+	return fmt.Sprintf("miup-support-%s-%s.tar.gz", name, now.Format("20060102-150405"))
+}
+
+// bundleWriter writes files into a support bundle directory and records
+// collection errors instead of failing
+type bundleWriter struct {
+	dir    string
+	errors []string
+}
+
+func (b *bundleWriter) recordError(item string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %v", item, err))
+}
+
+func (b *bundleWriter) write(rel string, data []byte, err error) {
+	if err != nil {
+		b.recordError(rel, err)
+		return
+	}
+
+	path := filepath.Join(b.dir, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		b.recordError(rel, err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		b.recordError(rel, err)
+	}
+}
+
+func (b *bundleWriter) writeJSON(rel string, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	b.write(rel, data, err)
+}
+
+func (b *bundleWriter) writeRedactedYAML(rel string, data []byte, err error) {
+	if err == nil {
+		data, err = redactYAML(data)
+	}
+	b.write(rel, data, err)
+}
+
+// redactedKeys are the YAML keys, lowercased and without underscores,
+// whose values are credentials: those of the topology (access_key,
+// secret_key, admin_password) and of the Milvus config (accessKeyID,
+// secretAccessKey)
+var redactedKeys = map[string]bool{
+	"accesskey":       true,
+	"secretkey":       true,
+	"adminpassword":   true,
+	"accesskeyid":     true,
+	"secretaccesskey": true,
+	"password":        true,
+}
+
+// redactedValue replaces credentials in a support bundle
+const redactedValue = "REDACTED"
+
+// redactYAML replaces the values of credential keys anywhere in a YAML
+// document, keeping its layout and comments
+func redactYAML(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML for redaction: %w", err)
+	}
+	redactNode(&doc)
+
+	var buf strings.Builder
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to write redacted YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write redacted YAML: %w", err)
+	}
+	return []byte(buf.String()), nil
+}
+
+func redactNode(n *yaml.Node) {
+	if n.Kind != yaml.MappingNode {
+		for _, child := range n.Content {
+			redactNode(child)
+		}
+		return
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		name := strings.ToLower(strings.ReplaceAll(key.Value, "_", ""))
+		if redactedKeys[name] && value.Kind == yaml.ScalarNode && value.Value != "" {
+			value.Value = redactedValue
+			value.Tag = "!!str"
+			value.Style = 0
+			continue
+		}
+		redactNode(value)
+	}
+}
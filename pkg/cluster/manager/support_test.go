@@ -0,0 +1,143 @@
+package manager
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+)
+
+func TestSupportBundle(t *testing.T) {
+	fake := &fakeExecutor{
+		crd: []byte(`apiVersion: milvus.io/v1beta1
+kind: Milvus
+metadata:
+  name: prod
+spec:
+  config:
+    minio:
+      accessKeyID: crd-access
+      secretAccessKey: crd-secret
+`),
+		events:       []executor.Event{{Type: "Warning", Reason: "BackOff", Object: "pod/prod-milvus-proxy-0"}},
+		podManifests: map[string][]byte{"prod-milvus-proxy-0": []byte("kind: Pod\n")},
+		podLogs: []executor.PodLogs{
+			{Pod: "prod-milvus-proxy-0", Logs: "started\n"},
+			{Pod: "prod-milvus-datanode-0", Err: errors.New("container not ready")},
+		},
+	}
+	mgr := newFakeManager(t, fake)
+
+	topology := filepath.Join(t.TempDir(), "topology.yaml")
+	data := `
+milvus_servers:
+  - host: milvus
+etcd_servers:
+  - host: etcd
+minio_servers:
+  - host: minio
+    access_key: topo-access
+    secret_key: topo-secret
+grafana_servers:
+  - host: grafana
+    admin_password: topo-password
+`
+	if err := os.WriteFile(topology, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.Deploy(context.Background(), "prod", topology, DeployOptions{}); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+
+	bundle := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := mgr.SupportBundle(context.Background(), "prod", SupportBundleOptions{OutputPath: bundle}); err != nil {
+		t.Fatalf("SupportBundle() error = %v", err)
+	}
+
+	files := readBundle(t, bundle)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	want := []string{
+		"bundle/diagnose.json",
+		"bundle/errors.txt",
+		"bundle/events.json",
+		"bundle/logs/prod-milvus-proxy-0.log",
+		"bundle/meta.json",
+		"bundle/milvus.yaml",
+		"bundle/pods/prod-milvus-proxy-0.yaml",
+		"bundle/topology.yaml",
+		"bundle/version.json",
+	}
+	if !slices.Equal(names, want) {
+		t.Errorf("bundle files = %v, want %v", names, want)
+	}
+
+	for _, name := range []string{"bundle/topology.yaml", "bundle/milvus.yaml"} {
+		content := files[name]
+		for _, secret := range []string{"topo-access", "topo-secret", "topo-password", "crd-access", "crd-secret"} {
+			if strings.Contains(content, secret) {
+				t.Errorf("%s contains credential %q:\n%s", name, secret, content)
+			}
+		}
+		if !strings.Contains(content, redactedValue) {
+			t.Errorf("%s has no redacted values:\n%s", name, content)
+		}
+	}
+	if !strings.Contains(files["bundle/topology.yaml"], "host: minio") {
+		t.Errorf("topology.yaml lost non-credential fields:\n%s", files["bundle/topology.yaml"])
+	}
+	if got := files["bundle/errors.txt"]; !strings.Contains(got, "logs/prod-milvus-datanode-0: container not ready") {
+		t.Errorf("errors.txt = %q, want the failed pod logs", got)
+	}
+}
+
+func TestSupportBundleNotFound(t *testing.T) {
+	mgr := newFakeManager(t, &fakeExecutor{})
+	err := mgr.SupportBundle(context.Background(), "missing", SupportBundleOptions{OutputPath: filepath.Join(t.TempDir(), "b.tar.gz")})
+	if !errors.Is(err, ErrClusterNotFound) {
+		t.Errorf("SupportBundle() error = %v, want ErrClusterNotFound", err)
+	}
+}
+
+// readBundle returns the contents of each file in a tar.gz archive
+func readBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gzr)
+
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(data)
+	}
+}
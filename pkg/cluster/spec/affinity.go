@@ -0,0 +1,105 @@
+package spec
+
+import "fmt"
+
+// Anti-affinity shorthands for spreading a component's pods
+const (
+	// AntiAffinityZone spreads pods across availability zones
+	AntiAffinityZone = "zone"
+	// AntiAffinityHost spreads pods across nodes
+	AntiAffinityHost = "host"
+)
+
+// Well-known node labels used as anti-affinity topology keys
+const (
+	ZoneLabel     = "topology.kubernetes.io/zone"
+	HostnameLabel = "kubernetes.io/hostname"
+)
+
+// AntiAffinityTopologyKey returns the node label an anti-affinity shorthand
+// spreads pods by, or "" if the shorthand is unknown
+func AntiAffinityTopologyKey(antiAffinity string) string {
+	switch antiAffinity {
+	case AntiAffinityZone:
+		return ZoneLabel
+	case AntiAffinityHost:
+		return HostnameLabel
+	}
+	return ""
+}
+
+// AntiAffinityFromTopologyKey is the inverse of AntiAffinityTopologyKey
+func AntiAffinityFromTopologyKey(key string) string {
+	switch key {
+	case ZoneLabel:
+		return AntiAffinityZone
+	case HostnameLabel:
+		return AntiAffinityHost
+	}
+	return ""
+}
+
+// namedComponent pairs a component spec with its topology field name
+type namedComponent struct {
+	name string
+	spec *ComponentSpec
+}
+
+// components returns the named component specs, in CRD order
+func (c *MilvusComponents) components() []namedComponent {
+	return []namedComponent{
+		{"standalone", &c.Standalone},
+		{"rootCoord", &c.RootCoord},
+		{"queryCoord", &c.QueryCoord},
+		{"dataCoord", &c.DataCoord},
+		{"indexCoord", &c.IndexCoord},
+		{"proxy", &c.Proxy},
+		{"queryNode", &c.QueryNode},
+		{"dataNode", &c.DataNode},
+		{"indexNode", &c.IndexNode},
+	}
+}
+
+// validateAntiAffinity checks the anti_affinity shorthand of each component
+func (c *MilvusComponents) validateAntiAffinity() error {
+	for _, comp := range c.components() {
+		if comp.spec.AntiAffinity == "" {
+			continue
+		}
+		if AntiAffinityTopologyKey(comp.spec.AntiAffinity) == "" {
+			return fmt.Errorf("components.%s.anti_affinity: invalid value '%s' (valid: %s, %s)",
+				comp.name, comp.spec.AntiAffinity, AntiAffinityZone, AntiAffinityHost)
+		}
+	}
+	return nil
+}
+
+// SpreadZones sets zone anti-affinity on every distributed component that
+// has no anti_affinity of its own
+func (s *Specification) SpreadZones() error {
+	if !s.IsDistributed() {
+		return fmt.Errorf("spreading across zones requires distributed mode")
+	}
+
+	for i := range s.MilvusServers {
+		for _, comp := range s.MilvusServers[i].Components.components() {
+			if comp.name == "standalone" || comp.spec.AntiAffinity != "" {
+				continue
+			}
+			comp.spec.AntiAffinity = AntiAffinityZone
+		}
+	}
+	return nil
+}
+
+// UsesZoneAntiAffinity returns true if any component is spread across zones
+func (s *Specification) UsesZoneAntiAffinity() bool {
+	for i := range s.MilvusServers {
+		for _, comp := range s.MilvusServers[i].Components.components() {
+			if comp.spec.AntiAffinity == AntiAffinityZone {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package spec
+
+import (
+	"testing"
+)
+
+func TestValidateAntiAffinity(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"unset", "", false},
+		{"zone", "zone", false},
+		{"host", "host", false},
+		{"invalid", "region", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Specification{
+				MilvusServers: []MilvusSpec{{Host: "127.0.0.1"}},
+				EtcdServers:   []EtcdSpec{{Host: "127.0.0.1"}},
+				MinioServers:  []MinioSpec{{Host: "127.0.0.1"}},
+			}
+			s.MilvusServers[0].Components.QueryNode.AntiAffinity = tt.value
+			if err := s.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpreadZones(t *testing.T) {
+	s := &Specification{
+		MilvusServers: []MilvusSpec{{Host: "127.0.0.1", Mode: ModeStandalone}},
+	}
+	if err := s.SpreadZones(); err == nil {
+		t.Error("SpreadZones() should fail in standalone mode")
+	}
+
+	s.MilvusServers[0].Mode = ModeDistributed
+	s.MilvusServers[0].Components.Proxy.AntiAffinity = AntiAffinityHost
+	if s.UsesZoneAntiAffinity() {
+		t.Error("UsesZoneAntiAffinity() = true before SpreadZones()")
+	}
+	if err := s.SpreadZones(); err != nil {
+		t.Fatalf("SpreadZones() error = %v", err)
+	}
+
+	components := s.MilvusServers[0].Components
+	if components.QueryNode.AntiAffinity != AntiAffinityZone {
+		t.Errorf("QueryNode.AntiAffinity = %q, want %q", components.QueryNode.AntiAffinity, AntiAffinityZone)
+	}
+	if components.Proxy.AntiAffinity != AntiAffinityHost {
+		t.Errorf("Proxy.AntiAffinity = %q, explicit value should be kept", components.Proxy.AntiAffinity)
+	}
+	if components.Standalone.AntiAffinity != "" {
+		t.Errorf("Standalone.AntiAffinity = %q, want empty", components.Standalone.AntiAffinity)
+	}
+	if !s.UsesZoneAntiAffinity() {
+		t.Error("UsesZoneAntiAffinity() = false after SpreadZones()")
+	}
+}
+
+func TestAntiAffinityTopologyKey(t *testing.T) {
+	for _, v := range []string{AntiAffinityZone, AntiAffinityHost} {
+		key := AntiAffinityTopologyKey(v)
+		if key == "" {
+			t.Fatalf("AntiAffinityTopologyKey(%q) is empty", v)
+		}
+		if got := AntiAffinityFromTopologyKey(key); got != v {
+			t.Errorf("AntiAffinityFromTopologyKey(%q) = %q, want %q", key, got, v)
+		}
+	}
+	if key := AntiAffinityTopologyKey("region"); key != "" {
+		t.Errorf("AntiAffinityTopologyKey(region) = %q, want empty", key)
+	}
+}
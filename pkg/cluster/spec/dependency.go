@@ -0,0 +1,39 @@
+package spec
+
+import (
+	"errors"
+	"net"
+	"strconv"
+)
+
+// Address returns the client address of an etcd server: its service if
+// set, otherwise its host
+func (e EtcdSpec) Address() string {
+	return net.JoinHostPort(dependencyHost(e.Host, e.Service), strconv.Itoa(e.ClientPort))
+}
+
+// Address returns the S3 API address of a MinIO server: its service if
+// set, otherwise its host
+func (m MinioSpec) Address() string {
+	return net.JoinHostPort(dependencyHost(m.Host, m.Service), strconv.Itoa(m.Port))
+}
+
+// dependencyHost returns the host a dependency is reached at
+func dependencyHost(host, service string) string {
+	if service != "" {
+		return service
+	}
+	return host
+}
+
+// validateEndpoint checks that a dependency sets exactly one of host and
+// service
+func validateEndpoint(host, service string) error {
+	switch {
+	case host == "" && service == "":
+		return errors.New("host is required (or service, for an in-cluster dependency the operator doesn't manage)")
+	case host != "" && service != "":
+		return errors.New("host and service cannot both be set")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDependencyService(t *testing.T) {
+	tests := []struct {
+		name         string
+		etcd         EtcdSpec
+		wantExternal bool
+		wantAddress  string
+		wantErr      string
+	}{
+		{name: "in-cluster", etcd: EtcdSpec{Host: "127.0.0.1", ClientPort: 2379}, wantAddress: "127.0.0.1:2379"},
+		{name: "external host", etcd: EtcdSpec{Host: "10.0.0.5", ClientPort: 2379}, wantExternal: true, wantAddress: "10.0.0.5:2379"},
+		{name: "helm service", etcd: EtcdSpec{Service: "etcd.infra.svc", ClientPort: 2379}, wantExternal: true, wantAddress: "etcd.infra.svc:2379"},
+		{name: "neither", etcd: EtcdSpec{}, wantErr: "etcd_servers[0].host is required"},
+		{name: "both", etcd: EtcdSpec{Host: "10.0.0.5", Service: "etcd.infra.svc"}, wantErr: "cannot both be set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Specification{
+				MilvusServers: []MilvusSpec{{Host: "127.0.0.1"}},
+				EtcdServers:   []EtcdSpec{tt.etcd},
+				MinioServers:  []MinioSpec{{Host: "127.0.0.1"}},
+			}
+			s.setDefaults()
+
+			err := s.Validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := s.ExternalEtcd(); got != tt.wantExternal {
+				t.Errorf("ExternalEtcd() = %v, want %v", got, tt.wantExternal)
+			}
+			if got := s.EtcdServers[0].Address(); got != tt.wantAddress {
+				t.Errorf("Address() = %s, want %s", got, tt.wantAddress)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package spec
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ReservedEnvNames are environment variables set by miup or the Milvus
+// Operator to wire up dependencies. Overriding them breaks the deployment.
+var ReservedEnvNames = []string{
+	"ETCD_ENDPOINTS",
+	"MINIO_ADDRESS",
+	"PULSAR_ADDRESS",
+	"KAFKA_BROKER_LIST",
+	"ROCKSMQ_PATH",
+	"METRICS_PORT",
+	"CACHE_SIZE",
+	"POD_NAME",
+	"POD_NAMESPACE",
+	"POD_IP",
+}
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateEnv checks that env names are valid and not reserved
+func ValidateEnv(env map[string]string) error {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !envNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid environment variable name '%s'", name)
+		}
+		for _, reserved := range ReservedEnvNames {
+			if strings.EqualFold(name, reserved) {
+				return fmt.Errorf("environment variable '%s' is reserved", name)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseEnv parses KEY=VALUE pairs, e.g. from repeated --env flags
+func ParseEnv(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	env := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid environment variable '%s', expected KEY=VALUE", pair)
+		}
+		env[name] = value
+	}
+
+	if err := ValidateEnv(env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// validateEnv validates the shared and per-component env maps
+func (c *MilvusComponents) validateEnv() error {
+	if err := ValidateEnv(c.Env); err != nil {
+		return fmt.Errorf("components.env: %w", err)
+	}
+
+	for _, comp := range c.components() {
+		if err := ValidateEnv(comp.spec.Env); err != nil {
+			return fmt.Errorf("components.%s.env: %w", comp.name, err)
+		}
+	}
+	return nil
+}
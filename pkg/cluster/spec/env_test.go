@@ -0,0 +1,57 @@
+package spec
+
+import (
+	"testing"
+)
+
+func TestParseEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"empty", nil, nil, false},
+		{"pairs", []string{"GOGC=200", "MALLOC_CONF=background_thread:true"}, map[string]string{"GOGC": "200", "MALLOC_CONF": "background_thread:true"}, false},
+		{"value with equals", []string{"OPTS=a=b"}, map[string]string{"OPTS": "a=b"}, false},
+		{"empty value", []string{"GODEBUG="}, map[string]string{"GODEBUG": ""}, false},
+		{"missing equals", []string{"GOGC"}, nil, true},
+		{"invalid name", []string{"1GOGC=200"}, nil, true},
+		{"reserved", []string{"ETCD_ENDPOINTS=etcd:2379"}, nil, true},
+		{"reserved lowercase", []string{"minio_address=minio:9000"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEnv(tt.pairs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseEnv() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("ParseEnv()[%s] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateComponentEnv(t *testing.T) {
+	s := &Specification{
+		MilvusServers: []MilvusSpec{{Host: "127.0.0.1"}},
+		EtcdServers:   []EtcdSpec{{Host: "127.0.0.1"}},
+		MinioServers:  []MinioSpec{{Host: "127.0.0.1"}},
+	}
+	s.MilvusServers[0].Components.QueryNode.Env = map[string]string{"GOGC": "200"}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	s.MilvusServers[0].Components.QueryNode.Env["ETCD_ENDPOINTS"] = "etcd:2379"
+	if err := s.Validate(); err == nil {
+		t.Error("Validate() should reject reserved env names")
+	}
+}
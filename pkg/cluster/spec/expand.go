@@ -0,0 +1,82 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrUndefinedVariable is returned, with LoadOptions.StrictEnv, for a
+// topology that references an environment variable that isn't set
+var ErrUndefinedVariable = errors.New("undefined environment variable")
+
+// LoadOptions contains options for loading a topology
+type LoadOptions struct {
+	// ExpandEnv expands the environment variables in the topology (see
+	// ExpandEnv). Only topologies given by the user are expanded; a stored
+	// one was saved expanded, and expanding it again would turn "$$" in a
+	// value such as a password into "$".
+	ExpandEnv bool
+
+	// StrictEnv fails on a ${VAR} reference to an unset variable without a
+	// default, instead of expanding it to an empty string
+	StrictEnv bool
+}
+
+// envRefRe matches "$$" and ${VAR} or ${VAR:-default}
+var envRefRe = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// ExpandEnv replaces ${VAR} in a topology with the value of the environment
+// variable VAR, and ${VAR:-default} with default if VAR is unset or empty,
+// so that one topology can serve several environments. "$$" is a literal
+// "$"; any other "$" is left as is. Unset variables without a default
+// expand to an empty string; if strict, the expansion is returned along
+// with an error wrapping ErrUndefinedVariable that names them.
+func ExpandEnv(data []byte, lookup func(string) (string, bool), strict bool) ([]byte, error) {
+	undefined := make(map[string]bool)
+	expanded := envRefRe.ReplaceAllStringFunc(string(data), func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		m := envRefRe.FindStringSubmatch(ref)
+		name, hasDefault := m[1], strings.Contains(ref, ":-")
+		value, ok := lookup(name)
+		switch {
+		case hasDefault && value == "":
+			return m[2]
+		case !ok:
+			undefined[name] = true
+		}
+		return value
+	})
+
+	if strict && len(undefined) > 0 {
+		names := make([]string, 0, len(undefined))
+		for name := range undefined {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return []byte(expanded), fmt.Errorf("%w: %s", ErrUndefinedVariable, strings.Join(names, ", "))
+	}
+	return []byte(expanded), nil
+}
+
+// loadTopology reads a topology, expanding the environment variables in it
+// if opts.ExpandEnv
+func loadTopology(path string, opts LoadOptions) ([]byte, error) {
+	data, err := readTopology(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topology file: %w", err)
+	}
+	if !opts.ExpandEnv {
+		return data, nil
+	}
+	data, err = ExpandEnv(data, os.LookupEnv, opts.StrictEnv)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
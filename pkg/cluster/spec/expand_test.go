@@ -0,0 +1,92 @@
+package spec
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	env := map[string]string{"NS": "staging", "EMPTY": ""}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	tests := []struct {
+		name    string
+		in      string
+		strict  bool
+		want    string
+		wantErr bool
+	}{
+		{name: "set", in: "namespace: ${NS}", want: "namespace: staging"},
+		{name: "default unused", in: "namespace: ${NS:-milvus}", want: "namespace: staging"},
+		{name: "default for unset", in: "storage_class: ${SC:-standard}", want: "storage_class: standard"},
+		{name: "default for empty", in: "storage_class: ${EMPTY:-standard}", want: "storage_class: standard"},
+		{name: "empty default", in: "registry: '${REG:-}'", strict: true, want: "registry: ''"},
+		{name: "unset", in: "registry: '${REG}'", want: "registry: ''"},
+		{name: "unset strict", in: "registry: ${REG} ${OTHER} ${REG}", strict: true, wantErr: true},
+		{name: "set empty strict", in: "x: '${EMPTY}'", strict: true, want: "x: ''"},
+		{name: "escape", in: "password: p$$w0rd${NS}", want: "password: p$w0rdstaging"},
+		{name: "bare dollar", in: "password: p$w0rd $NS", want: "password: p$w0rd $NS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandEnv([]byte(tt.in), lookup, tt.strict)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUndefinedVariable) {
+					t.Fatalf("ExpandEnv() error = %v, want ErrUndefinedVariable", err)
+				}
+				if err.Error() != "undefined environment variable: OTHER, REG" {
+					t.Errorf("ExpandEnv() error = %q", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExpandEnv() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ExpandEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadUserSpecificationExpandsEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "topology.yaml")
+	topology := `global:
+  namespace: ${MIUP_TEST_NS:-milvus}
+  storage_class: ${MIUP_TEST_SC}
+milvus_servers:
+  - host: 127.0.0.1
+`
+	if err := os.WriteFile(path, []byte(topology), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MIUP_TEST_NS", "staging")
+
+	s, err := LoadUserSpecification(path)
+	if err != nil {
+		t.Fatalf("LoadUserSpecification() error = %v", err)
+	}
+	if s.Global.Namespace != "staging" || s.Global.StorageClass != "" {
+		t.Errorf("global = %+v, want namespace staging and no storage class", s.Global)
+	}
+
+	strict := LoadOptions{ExpandEnv: true, StrictEnv: true}
+	if _, err := LoadSpecificationWithOptions(path, strict); !errors.Is(err, ErrUndefinedVariable) {
+		t.Errorf("strict LoadSpecificationWithOptions() error = %v, want ErrUndefinedVariable", err)
+	}
+
+	// A stored topology was saved expanded and is loaded as is
+	s, err = LoadSpecification(path)
+	if err != nil {
+		t.Fatalf("LoadSpecification() error = %v", err)
+	}
+	if s.Global.Namespace != "${MIUP_TEST_NS:-milvus}" {
+		t.Errorf("namespace = %s, want the reference left unexpanded", s.Global.Namespace)
+	}
+}
@@ -0,0 +1,147 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// image is a container image on its public registry and its path under a
+// private registry that mirrors it (e.g. harbor.milvus.io)
+type image struct {
+	public  string
+	private string
+}
+
+// ref returns the image reference, under registry if one is given
+func (i image) ref(registry string) string {
+	if registry == "" {
+		return i.public
+	}
+	return registry + "/" + i.private
+}
+
+// Images of Milvus' dependencies deployed in-cluster by the Milvus Operator
+var (
+	etcdImage       = image{"quay.io/coreos/etcd:v3.5.18", "milvus-ci/etcd:3.5.18-r0"}
+	minioImage      = image{"minio/minio:RELEASE.2023-03-20T20-16-18Z", "milvus-ci/minio:RELEASE.2023-03-20T20-16-18Z"}
+	pulsarImage     = image{"apachepulsar/pulsar:3.0.7", "milvus-ci/pulsar:3.0.7"}
+	kafkaImage      = image{"bitnami/kafka:3.1.0-debian-10-r52", "milvus-ci/kafka:3.1.0-debian-10-r52"}
+	prometheusImage = image{"prom/prometheus:latest", "milvus-ci/prometheus:latest"}
+	grafanaImage    = image{"grafana/grafana:latest", "milvus-ci/grafana:latest"}
+)
+
+// MilvusImage returns the Milvus image of a version
+func MilvusImage(milvusVersion, registry string) string {
+	if registry == "" {
+		return fmt.Sprintf("milvusdb/milvus:%s", milvusVersion)
+	}
+	return fmt.Sprintf("%s/milvus/milvus:%s", registry, milvusVersion)
+}
+
+// DefaultImages returns the images of a standalone deployment with
+// in-cluster etcd and MinIO, plus Prometheus and Grafana if requested
+func DefaultImages(milvusVersion string, includeMonitoring bool, registry string) []string {
+	images := []string{
+		MilvusImage(milvusVersion, registry),
+		etcdImage.ref(registry),
+		minioImage.ref(registry),
+	}
+	if includeMonitoring {
+		images = append(images, prometheusImage.ref(registry), grafanaImage.ref(registry))
+	}
+	return images
+}
+
+// Images returns exactly the images deploying this topology pulls: etcd and
+// MinIO only when they run in-cluster, the message queue the Milvus Operator
+// deploys for it, and monitoring only when the topology has it
+func (s *Specification) Images(milvusVersion, registry string) []string {
+	images := []string{MilvusImage(milvusVersion, registry)}
+	if !s.ExternalEtcd() {
+		images = append(images, etcdImage.ref(registry))
+	}
+	if !s.ExternalMinio() {
+		images = append(images, minioImage.ref(registry))
+	}
+
+	switch s.MessageQueue() {
+	case "pulsar":
+		if len(s.PulsarServers) == 0 || isLocalHost(s.PulsarServers[0].Host) {
+			images = append(images, pulsarImage.ref(registry))
+		}
+	case "kafka":
+		if s.milvusConfig("kafka", "brokerList") == "" {
+			images = append(images, kafkaImage.ref(registry))
+		}
+	}
+
+	if len(s.MonitorServers) > 0 {
+		images = append(images, prometheusImage.ref(registry))
+	}
+	if len(s.GrafanaServers) > 0 {
+		images = append(images, grafanaImage.ref(registry))
+	}
+	return images
+}
+
+// ExternalEtcd reports whether the topology points at an existing etcd,
+// by host or in-cluster service, instead of having the Milvus Operator
+// deploy one
+func (s *Specification) ExternalEtcd() bool {
+	return len(s.EtcdServers) > 0 && (s.EtcdServers[0].Service != "" || !isLocalHost(s.EtcdServers[0].Host))
+}
+
+// ExternalMinio reports whether the topology points at an existing MinIO or
+// S3 endpoint, by host or in-cluster service, instead of having the Milvus
+// Operator deploy MinIO
+func (s *Specification) ExternalMinio() bool {
+	return len(s.MinioServers) > 0 && (s.MinioServers[0].Service != "" || !isLocalHost(s.MinioServers[0].Host))
+}
+
+// MessageQueue returns the message queue Milvus uses: the mq.type set in
+// the Milvus config, or the default of the deployment mode (Pulsar for a
+// distributed cluster, the embedded RocksMQ for standalone)
+func (s *Specification) MessageQueue() string {
+	if mq := s.milvusConfig("mq", "type"); mq != "" && mq != "default" {
+		return strings.ToLower(mq)
+	}
+	if s.IsDistributed() {
+		return "pulsar"
+	}
+	return "rocksmq"
+}
+
+// milvusConfig returns a string value from the Milvus config the way the
+// executor merges it: the config of the Milvus servers, later ones winning,
+// over server_configs.milvus
+func (s *Specification) milvusConfig(path ...string) string {
+	configs := []map[string]any{s.ServerConfigs.Milvus}
+	for _, server := range s.MilvusServers {
+		configs = append(configs, server.Config)
+	}
+	for i := len(configs) - 1; i >= 0; i-- {
+		if str, ok := configValue(configs[i], path); ok {
+			return str
+		}
+	}
+	return ""
+}
+
+// configValue returns the string at path in config, if there is one
+func configValue(config map[string]any, path []string) (string, bool) {
+	var value any = config
+	for _, key := range path {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		value = m[key]
+	}
+	str, ok := value.(string)
+	return str, ok
+}
+
+// isLocalHost reports whether a topology host means "deploy in-cluster"
+func isLocalHost(host string) bool {
+	return host == "127.0.0.1" || host == "localhost"
+}
@@ -0,0 +1,127 @@
+package spec
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestImages(t *testing.T) {
+	local := func() *Specification {
+		return &Specification{
+			MilvusServers: []MilvusSpec{{Host: "localhost", Mode: ModeStandalone}},
+			EtcdServers:   []EtcdSpec{{Host: "localhost"}},
+			MinioServers:  []MinioSpec{{Host: "localhost"}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(s *Specification)
+		registry string
+		want     []string
+	}{
+		{
+			name: "standalone",
+			want: []string{"milvusdb/milvus:v2.5.4", "quay.io/coreos/etcd:v3.5.18", "minio/minio:RELEASE.2023-03-20T20-16-18Z"},
+		},
+		{
+			name: "external etcd and minio",
+			modify: func(s *Specification) {
+				s.EtcdServers[0].Host = "etcd.example.com"
+				s.MinioServers[0].Host = "s3.amazonaws.com"
+			},
+			want: []string{"milvusdb/milvus:v2.5.4"},
+		},
+		{
+			name: "distributed defaults to pulsar",
+			modify: func(s *Specification) {
+				s.MilvusServers[0].Mode = ModeDistributed
+			},
+			want: []string{"milvusdb/milvus:v2.5.4", "quay.io/coreos/etcd:v3.5.18", "minio/minio:RELEASE.2023-03-20T20-16-18Z", "apachepulsar/pulsar:3.0.7"},
+		},
+		{
+			name: "external pulsar",
+			modify: func(s *Specification) {
+				s.MilvusServers[0].Mode = ModeDistributed
+				s.PulsarServers = []PulsarSpec{{Host: "pulsar.example.com"}}
+			},
+			want: []string{"milvusdb/milvus:v2.5.4", "quay.io/coreos/etcd:v3.5.18", "minio/minio:RELEASE.2023-03-20T20-16-18Z"},
+		},
+		{
+			name: "kafka",
+			modify: func(s *Specification) {
+				s.MilvusServers[0].Config = map[string]any{"mq": map[string]any{"type": "kafka"}}
+			},
+			want: []string{"milvusdb/milvus:v2.5.4", "quay.io/coreos/etcd:v3.5.18", "minio/minio:RELEASE.2023-03-20T20-16-18Z", "bitnami/kafka:3.1.0-debian-10-r52"},
+		},
+		{
+			name: "external kafka",
+			modify: func(s *Specification) {
+				s.MilvusServers[0].Config = map[string]any{
+					"mq":    map[string]any{"type": "kafka"},
+					"kafka": map[string]any{"brokerList": "kafka:9092"},
+				}
+			},
+			want: []string{"milvusdb/milvus:v2.5.4", "quay.io/coreos/etcd:v3.5.18", "minio/minio:RELEASE.2023-03-20T20-16-18Z"},
+		},
+		{
+			name: "kafka in server_configs",
+			modify: func(s *Specification) {
+				s.ServerConfigs.Milvus = map[string]any{"mq": map[string]any{"type": "kafka"}}
+			},
+			want: []string{"milvusdb/milvus:v2.5.4", "quay.io/coreos/etcd:v3.5.18", "minio/minio:RELEASE.2023-03-20T20-16-18Z", "bitnami/kafka:3.1.0-debian-10-r52"},
+		},
+		{
+			name: "server config over server_configs",
+			modify: func(s *Specification) {
+				s.ServerConfigs.Milvus = map[string]any{
+					"mq":    map[string]any{"type": "pulsar"},
+					"kafka": map[string]any{"brokerList": "kafka:9092"},
+				}
+				s.MilvusServers[0].Config = map[string]any{"mq": map[string]any{"type": "kafka"}}
+			},
+			want: []string{"milvusdb/milvus:v2.5.4", "quay.io/coreos/etcd:v3.5.18", "minio/minio:RELEASE.2023-03-20T20-16-18Z"},
+		},
+		{
+			name: "prometheus only",
+			modify: func(s *Specification) {
+				s.MonitorServers = []MonitorSpec{{Host: "localhost"}}
+			},
+			want: []string{"milvusdb/milvus:v2.5.4", "quay.io/coreos/etcd:v3.5.18", "minio/minio:RELEASE.2023-03-20T20-16-18Z", "prom/prometheus:latest"},
+		},
+		{
+			name: "private registry",
+			modify: func(s *Specification) {
+				s.GrafanaServers = []GrafanaSpec{{Host: "localhost"}}
+			},
+			registry: "harbor.milvus.io",
+			want: []string{
+				"harbor.milvus.io/milvus/milvus:v2.5.4",
+				"harbor.milvus.io/milvus-ci/etcd:3.5.18-r0",
+				"harbor.milvus.io/milvus-ci/minio:RELEASE.2023-03-20T20-16-18Z",
+				"harbor.milvus.io/milvus-ci/grafana:latest",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := local()
+			if tt.modify != nil {
+				tt.modify(s)
+			}
+			if got := s.Images("v2.5.4", tt.registry); !slices.Equal(got, tt.want) {
+				t.Errorf("Images() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultImages(t *testing.T) {
+	if got := DefaultImages("v2.5.4", false, ""); len(got) != 3 || got[0] != "milvusdb/milvus:v2.5.4" {
+		t.Errorf("DefaultImages() = %v", got)
+	}
+	if got := DefaultImages("v2.5.4", true, "harbor.milvus.io"); len(got) != 5 || got[4] != "harbor.milvus.io/milvus-ci/grafana:latest" {
+		t.Errorf("DefaultImages(monitoring) = %v", got)
+	}
+}
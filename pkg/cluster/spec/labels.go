@@ -0,0 +1,52 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ParseLabels parses KEY=VALUE instance labels, e.g. from repeated --label
+// flags. Keys and values follow the Kubernetes label syntax so they can be
+// matched with the usual selectors (env=ci, team in (a,b), !keep).
+func ParseLabels(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label '%s', expected KEY=VALUE", pair)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key '%s': %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label value '%s': %s", value, strings.Join(errs, "; "))
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// ParseSelector parses a label selector. An empty selector is rejected so a
+// missing value never selects every instance.
+func ParseSelector(selector string) (labels.Selector, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, fmt.Errorf("label selector must not be empty")
+	}
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector '%s': %w", selector, err)
+	}
+	return sel, nil
+}
+
+// MatchesSelector reports whether the cluster's labels match the selector
+func (m *ClusterMeta) MatchesSelector(sel labels.Selector) bool {
+	return sel.Matches(labels.Set(m.Labels))
+}
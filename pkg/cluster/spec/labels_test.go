@@ -0,0 +1,77 @@
+package spec
+
+import (
+	"testing"
+)
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"empty", nil, nil, false},
+		{"pairs", []string{"env=ci", "team=search"}, map[string]string{"env": "ci", "team": "search"}, false},
+		{"prefixed key", []string{"example.com/owner=alice"}, map[string]string{"example.com/owner": "alice"}, false},
+		{"empty value", []string{"ephemeral="}, map[string]string{"ephemeral": ""}, false},
+		{"missing equals", []string{"env"}, nil, true},
+		{"invalid key", []string{"-env=ci"}, nil, true},
+		{"invalid value", []string{"env=a b"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLabels(tt.pairs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseLabels() = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("ParseLabels()[%s] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchesSelector(t *testing.T) {
+	meta := &ClusterMeta{Name: "ci-1", Labels: map[string]string{"env": "ci", "team": "search"}}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"env=ci", true},
+		{"env=ci,team=search", true},
+		{"env=prod", false},
+		{"team in (search,index)", true},
+		{"!keep", true},
+		{"keep", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			sel, err := ParseSelector(tt.selector)
+			if err != nil {
+				t.Fatalf("ParseSelector() error = %v", err)
+			}
+			if got := meta.MatchesSelector(sel); got != tt.want {
+				t.Errorf("MatchesSelector(%q) = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseSelector(" "); err == nil {
+		t.Error("ParseSelector() should reject an empty selector")
+	}
+	if _, err := ParseSelector("env in (ci"); err == nil {
+		t.Error("ParseSelector() should reject an invalid selector")
+	}
+	if sel, _ := ParseSelector("env=ci"); (&ClusterMeta{}).MatchesSelector(sel) {
+		t.Error("an unlabeled cluster should not match")
+	}
+}
@@ -2,9 +2,13 @@ package spec
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"time"
+
+	"github.com/mmga-lab/miup/pkg/localdata"
+	"github.com/mmga-lab/miup/pkg/logger"
 )
 
 // ClusterStatus represents the cluster status
@@ -17,6 +21,7 @@ const (
 	StatusUpgrading ClusterStatus = "upgrading"
 	StatusScaling   ClusterStatus = "scaling"
 	StatusReloading ClusterStatus = "reloading"
+	StatusApplying  ClusterStatus = "applying"
 	StatusUnknown   ClusterStatus = "unknown"
 )
 
@@ -31,6 +36,10 @@ type ClusterMeta struct {
 	CreatedAt     time.Time     `json:"created_at"`
 	UpdatedAt     time.Time     `json:"updated_at"`
 
+	// Image is the full Milvus image reference when it was set directly with
+	// 'miup instance set-image' rather than derived from MilvusVersion
+	Image string `json:"image,omitempty"`
+
 	// Ports
 	MilvusPort   int `json:"milvus_port"`
 	EtcdPort     int `json:"etcd_port"`
@@ -45,6 +54,22 @@ type ClusterMeta struct {
 	Kubeconfig  string `json:"kubeconfig,omitempty"`
 	KubeContext string `json:"kube_context,omitempty"`
 	Namespace   string `json:"namespace,omitempty"`
+
+	// NamespaceCreated is set when miup created the namespace on deploy, so
+	// that destroy --delete-namespace may remove it again
+	NamespaceCreated bool `json:"namespace_created,omitempty"`
+
+	// Labels are user labels for selecting instances in bulk operations
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ExpiresAt is when the cluster's TTL runs out; 'miup instance reap'
+	// destroys expired clusters. Nil means the cluster never expires.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired reports whether the cluster has a TTL that ran out before now
+func (m *ClusterMeta) Expired(now time.Time) bool {
+	return m.ExpiresAt != nil && !now.Before(*m.ExpiresAt)
 }
 
 // SaveMeta saves cluster metadata to a file
@@ -54,28 +79,49 @@ func SaveMeta(meta *ClusterMeta, path string) error {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := localdata.WriteFileWithBackup(path, data, 0644, validMeta); err != nil {
 		return fmt.Errorf("failed to write metadata: %w", err)
 	}
 
 	return nil
 }
 
-// LoadMeta loads cluster metadata from a file
+// LoadMeta loads cluster metadata from a file. If the file is missing or
+// corrupt, the backup kept by SaveMeta is used instead.
 func LoadMeta(path string) (*ClusterMeta, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
+	var meta *ClusterMeta
+	usedBackup, err := localdata.ReadFileWithBackup(path, func(data []byte) error {
+		var err error
+		meta, err = parseMeta(data)
+		return err
+	})
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
 		return nil, fmt.Errorf("failed to read metadata: %w", err)
 	}
+	if err != nil {
+		return nil, err
+	}
+	if usedBackup {
+		logger.Warn("Metadata %s is missing or corrupt, using backup", path)
+	}
+
+	return meta, nil
+}
 
+func parseMeta(data []byte) (*ClusterMeta, error) {
 	var meta ClusterMeta
 	if err := json.Unmarshal(data, &meta); err != nil {
 		return nil, fmt.Errorf("failed to parse metadata: %w", err)
 	}
-
 	return &meta, nil
 }
 
+func validMeta(data []byte) error {
+	_, err := parseMeta(data)
+	return err
+}
+
 // NewClusterMeta creates a new cluster metadata from specification
 func NewClusterMeta(name string, spec *Specification, milvusVersion string) *ClusterMeta {
 	meta := &ClusterMeta{
@@ -0,0 +1,137 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveMeta_KeepsBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta.json")
+
+	first := &ClusterMeta{Name: "demo", Status: StatusRunning}
+	if err := SaveMeta(first, path); err != nil {
+		t.Fatalf("SaveMeta() error = %v", err)
+	}
+	second := &ClusterMeta{Name: "demo", Status: StatusStopped}
+	if err := SaveMeta(second, path); err != nil {
+		t.Fatalf("SaveMeta() error = %v", err)
+	}
+
+	backup, err := LoadMeta(path + ".bak")
+	if err != nil {
+		t.Fatalf("failed to load backup: %v", err)
+	}
+	if backup.Status != StatusRunning {
+		t.Errorf("backup status = %s, want %s", backup.Status, StatusRunning)
+	}
+}
+
+func TestLoadMeta_FallsBackOnPartialWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta.json")
+
+	if err := SaveMeta(&ClusterMeta{Name: "demo", Status: StatusRunning}, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveMeta(&ClusterMeta{Name: "demo", Status: StatusStopped}, path); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash mid-write by truncating the primary file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data[:len(data)/2], 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := LoadMeta(path)
+	if err != nil {
+		t.Fatalf("LoadMeta() error = %v", err)
+	}
+	if meta.Name != "demo" || meta.Status != StatusRunning {
+		t.Errorf("LoadMeta() = %+v, want backup with status %s", meta, StatusRunning)
+	}
+}
+
+func TestLoadMeta_FallsBackWhenMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta.json")
+	if err := SaveMeta(&ClusterMeta{Name: "demo", Status: StatusRunning}, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveMeta(&ClusterMeta{Name: "demo", Status: StatusStopped}, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := LoadMeta(path)
+	if err != nil {
+		t.Fatalf("LoadMeta() error = %v", err)
+	}
+	if meta.Status != StatusRunning {
+		t.Errorf("LoadMeta() = %+v, want backup with status %s", meta, StatusRunning)
+	}
+}
+
+func TestSaveMeta_KeepsGoodBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta.json")
+	if err := SaveMeta(&ClusterMeta{Name: "demo", Status: StatusRunning}, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveMeta(&ClusterMeta{Name: "demo", Status: StatusStopped}, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"name": "de`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveMeta(&ClusterMeta{Name: "demo", Status: StatusScaling}, path); err != nil {
+		t.Fatal(err)
+	}
+	backup, err := LoadMeta(path + ".bak")
+	if err != nil {
+		t.Fatalf("failed to load backup: %v", err)
+	}
+	if backup.Status != StatusRunning {
+		t.Errorf("backup status = %s, want %s kept over the corrupt file", backup.Status, StatusRunning)
+	}
+}
+
+func TestLoadMeta_CorruptWithoutBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta.json")
+	if err := os.WriteFile(path, []byte(`{"name": "de`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadMeta(path); err == nil {
+		t.Error("LoadMeta() should fail for corrupt metadata without a backup")
+	}
+}
+
+func TestClusterMetaExpired(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		want      bool
+	}{
+		{"no ttl", nil, false},
+		{"expired", &past, true},
+		{"expires now", &now, true},
+		{"not yet", &future, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := &ClusterMeta{ExpiresAt: tt.expiresAt}
+			if got := meta.Expired(now); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
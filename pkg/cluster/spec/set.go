@@ -0,0 +1,207 @@
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pathElem is one step of a --set path: a yaml key or a list index
+type pathElem struct {
+	key   string
+	index int
+}
+
+func (e pathElem) isIndex() bool {
+	return e.key == ""
+}
+
+// ApplySets applies KEY=VALUE overrides, e.g. from repeated --set flags,
+// like helm's --set. Keys are paths of yaml keys and list indexes such as
+// milvus_servers[0].components.queryNode.replicas, and values are parsed as
+// YAML scalars. Unknown keys are an error so typos don't go unnoticed.
+//
+// Defaults are applied again afterwards, so list entries appended by a set
+// get them like entries of the topology file. An appended server without a
+// host or service is in-cluster (localhost), as in a generated topology.
+func (s *Specification) ApplySets(pairs []string) error {
+	milvus, etcd, minio := len(s.MilvusServers), len(s.EtcdServers), len(s.MinioServers)
+
+	for _, pair := range pairs {
+		path, value, ok := strings.Cut(pair, "=")
+		if !ok || path == "" {
+			return fmt.Errorf("invalid --set '%s', expected PATH=VALUE", pair)
+		}
+		if err := s.Set(path, value); err != nil {
+			return err
+		}
+	}
+
+	for i := milvus; i < len(s.MilvusServers); i++ {
+		if s.MilvusServers[i].Host == "" {
+			s.MilvusServers[i].Host = "localhost"
+		}
+	}
+	for i := etcd; i < len(s.EtcdServers); i++ {
+		if s.EtcdServers[i].Host == "" && s.EtcdServers[i].Service == "" {
+			s.EtcdServers[i].Host = "localhost"
+		}
+	}
+	for i := minio; i < len(s.MinioServers); i++ {
+		if s.MinioServers[i].Host == "" && s.MinioServers[i].Service == "" {
+			s.MinioServers[i].Host = "localhost"
+		}
+	}
+	s.setDefaults()
+	return nil
+}
+
+// Set overrides the topology value at path (see ApplySets)
+func (s *Specification) Set(path, value string) error {
+	elems, err := parsePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid --set path '%s': %w", path, err)
+	}
+	if err := setPath(reflect.ValueOf(s).Elem(), elems, value); err != nil {
+		return fmt.Errorf("failed to set '%s': %w", path, err)
+	}
+	return nil
+}
+
+// parsePath splits a path like a.b[0].c into its keys and indexes
+func parsePath(path string) ([]pathElem, error) {
+	var elems []pathElem
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" && len(elems) == 0 {
+			return nil, fmt.Errorf("path must start with a key")
+		}
+		if key != "" {
+			elems = append(elems, pathElem{key: key})
+		} else if rest == "" {
+			return nil, fmt.Errorf("empty key")
+		}
+
+		for rest != "" {
+			idx, after, ok := strings.Cut(rest, "]")
+			if !ok {
+				return nil, fmt.Errorf("missing ']'")
+			}
+			n, err := strconv.Atoi(idx)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid index '%s'", idx)
+			}
+			elems = append(elems, pathElem{index: n})
+
+			if after == "" {
+				break
+			}
+			if !strings.HasPrefix(after, "[") {
+				return nil, fmt.Errorf("unexpected '%s' after index", after)
+			}
+			rest = after[1:]
+		}
+	}
+	return elems, nil
+}
+
+// setPath walks v along elems, creating maps, list entries and pointers as
+// needed, and sets the value at the end
+func setPath(v reflect.Value, elems []pathElem, value string) error {
+	if len(elems) == 0 {
+		ptr := reflect.New(v.Type())
+		if err := yaml.Unmarshal([]byte(value), ptr.Interface()); err != nil {
+			return fmt.Errorf("invalid value '%s': %w", value, err)
+		}
+		v.Set(ptr.Elem())
+		return nil
+	}
+	elem := elems[0]
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setPath(v.Elem(), elems, value)
+
+	case reflect.Struct:
+		if elem.isIndex() {
+			return fmt.Errorf("cannot index into '%s'", v.Type().Name())
+		}
+		field, ok := fieldByYAMLName(v, elem.key)
+		if !ok {
+			return fmt.Errorf("unknown key '%s'", elem.key)
+		}
+		return setPath(field, elems[1:], value)
+
+	case reflect.Slice:
+		if !elem.isIndex() {
+			return fmt.Errorf("'%s' is a list and needs an index", elem.key)
+		}
+		switch {
+		case elem.index == v.Len():
+			v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+		case elem.index > v.Len():
+			return fmt.Errorf("index %d out of range (list has %d entries)", elem.index, v.Len())
+		}
+		return setPath(v.Index(elem.index), elems[1:], value)
+
+	case reflect.Map:
+		if elem.isIndex() {
+			return fmt.Errorf("cannot index into a map")
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		key := reflect.ValueOf(elem.key).Convert(v.Type().Key())
+		// Map entries are not addressable, so update a copy and store it back
+		entry := reflect.New(v.Type().Elem()).Elem()
+		if existing := v.MapIndex(key); existing.IsValid() {
+			entry.Set(existing)
+		}
+		if err := setPath(entry, elems[1:], value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, entry)
+		return nil
+
+	case reflect.Interface:
+		// Free-form config (map[string]any); create nested maps as needed
+		var inner reflect.Value
+		if !v.IsNil() {
+			inner = v.Elem()
+		}
+		if !inner.IsValid() || (!elem.isIndex() && inner.Kind() != reflect.Map) {
+			if elem.isIndex() {
+				return fmt.Errorf("index %d out of range", elem.index)
+			}
+			inner = reflect.ValueOf(map[string]any{})
+		}
+		entry := reflect.New(inner.Type()).Elem()
+		entry.Set(inner)
+		if err := setPath(entry, elems, value); err != nil {
+			return err
+		}
+		v.Set(entry)
+		return nil
+
+	default:
+		return fmt.Errorf("cannot set '%s' inside a %s value", elem.key, v.Kind())
+	}
+}
+
+// fieldByYAMLName returns the struct field with the given yaml key
+func fieldByYAMLName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if tag == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,91 @@
+package spec
+
+import (
+	"testing"
+)
+
+func TestApplySets(t *testing.T) {
+	s := &Specification{
+		MilvusServers: []MilvusSpec{{Host: "localhost", Mode: ModeDistributed}},
+	}
+
+	err := s.ApplySets([]string{
+		"global.namespace=staging",
+		"milvus_servers[0].components.queryNode.replicas=5",
+		"milvus_servers[0].components.queryNode.resources.memory=8Gi",
+		"milvus_servers[0].components.env.GOGC=200",
+		"milvus_servers[0].config.queryNode.gracefulTime=1000",
+		"milvus_servers[0].config.log.level=debug",
+		"etcd_servers[0].storage=50Gi",
+		"minio_servers[0].service=my-minio",
+	})
+	if err != nil {
+		t.Fatalf("ApplySets() error = %v", err)
+	}
+
+	m := s.MilvusServers[0]
+	if s.Global.Namespace != "staging" {
+		t.Errorf("Namespace = %s, want staging", s.Global.Namespace)
+	}
+	if m.Components.QueryNode.Replicas != 5 {
+		t.Errorf("QueryNode.Replicas = %d, want 5", m.Components.QueryNode.Replicas)
+	}
+	if m.Components.QueryNode.Resources.Memory != "8Gi" {
+		t.Errorf("QueryNode.Resources.Memory = %s, want 8Gi", m.Components.QueryNode.Resources.Memory)
+	}
+	if m.Components.Env["GOGC"] != "200" {
+		t.Errorf("Env[GOGC] = %q, want 200", m.Components.Env["GOGC"])
+	}
+	queryNode, _ := m.Config["queryNode"].(map[string]any)
+	if queryNode["gracefulTime"] != 1000 {
+		t.Errorf("config.queryNode.gracefulTime = %#v, want int 1000", queryNode["gracefulTime"])
+	}
+	if log, _ := m.Config["log"].(map[string]any); log["level"] != "debug" {
+		t.Errorf("config.log.level = %#v, want debug", m.Config["log"])
+	}
+	if m.Host != "localhost" || m.Mode != ModeDistributed {
+		t.Error("unrelated values should be kept")
+	}
+	// Appended entries get the defaults of a topology file entry
+	if len(s.EtcdServers) != 1 || s.EtcdServers[0].Storage != "50Gi" {
+		t.Fatalf("EtcdServers = %+v, want one entry with storage 50Gi", s.EtcdServers)
+	}
+	if etcd := s.EtcdServers[0]; etcd.Host != "localhost" || etcd.ClientPort != 2379 {
+		t.Errorf("EtcdServers[0] = %+v, want in-cluster on the default port", etcd)
+	}
+	if s.ExternalEtcd() {
+		t.Error("an appended etcd entry should be deployed in-cluster")
+	}
+	if minio := s.MinioServers[0]; minio.Host != "" || minio.Bucket != "milvus-bucket" {
+		t.Errorf("MinioServers[0] = %+v, want the set service and the default bucket", minio)
+	}
+	if err := s.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestApplySetsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		set  string
+	}{
+		{"missing equals", "global.namespace"},
+		{"unknown key", "milvus_servers[0].components.queryNod.replicas=5"},
+		{"list without index", "milvus_servers.host=x"},
+		{"index out of range", "milvus_servers[3].host=x"},
+		{"index on struct", "global[0]=x"},
+		{"bad index", "milvus_servers[a].host=x"},
+		{"unclosed index", "milvus_servers[0.host=x"},
+		{"wrong type", "milvus_servers[0].components.queryNode.replicas=many"},
+		{"leading index", "[0].host=x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Specification{MilvusServers: []MilvusSpec{{Host: "localhost"}}}
+			if err := s.ApplySets([]string{tt.set}); err == nil {
+				t.Errorf("ApplySets(%q) should fail", tt.set)
+			}
+		})
+	}
+}
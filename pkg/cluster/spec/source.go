@@ -0,0 +1,61 @@
+package spec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mmga-lab/miup/pkg/component"
+)
+
+// StdinSource is the topology source that reads from standard input
+const StdinSource = "-"
+
+// maxTopologySize bounds the topology read from stdin or a URL
+const maxTopologySize = 10 << 20
+
+// stdin is where StdinSource reads from; tests replace it
+var stdin io.Reader = os.Stdin
+
+// IsRemoteSource reports whether a topology source is an http(s) URL
+func IsRemoteSource(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// readTopology reads a topology from a file path, StdinSource or an http(s) URL
+func readTopology(source string) ([]byte, error) {
+	switch {
+	case source == StdinSource:
+		return readLimited(stdin, "standard input")
+	case IsRemoteSource(source):
+		return fetchTopology(source)
+	default:
+		return os.ReadFile(source)
+	}
+}
+
+// fetchTopology downloads a topology from an http(s) URL with the
+// component downloader, so it shares its user agent and timeout
+func fetchTopology(url string) ([]byte, error) {
+	body, err := component.NewDownloader().Get(context.Background(), url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return readLimited(body, url)
+}
+
+// readLimited reads r fully, failing if it is larger than maxTopologySize
+func readLimited(r io.Reader, name string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxTopologySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxTopologySize {
+		return nil, fmt.Errorf("topology from %s exceeds %d MiB", name, maxTopologySize>>20)
+	}
+	return data, nil
+}
@@ -0,0 +1,71 @@
+package spec
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const sourceTopology = `
+global:
+  namespace: remote
+milvus_servers:
+  - host: localhost
+    mode: standalone
+`
+
+func TestLoadSpecification_Stdin(t *testing.T) {
+	orig := stdin
+	defer func() { stdin = orig }()
+	stdin = strings.NewReader(sourceTopology)
+
+	spec, err := LoadSpecification(StdinSource)
+	if err != nil {
+		t.Fatalf("LoadSpecification(-) error = %v", err)
+	}
+	if spec.Global.Namespace != "remote" {
+		t.Errorf("Namespace = %s, want remote", spec.Global.Namespace)
+	}
+	if spec.Global.DeployDir == "" {
+		t.Error("defaults should be applied to stdin topologies")
+	}
+}
+
+func TestLoadSpecification_URL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/topology.yaml":
+			w.Write([]byte(sourceTopology))
+		case "/invalid.yaml":
+			w.Write([]byte("invalid: yaml: content: ["))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	spec, err := LoadSpecification(server.URL + "/topology.yaml")
+	if err != nil {
+		t.Fatalf("LoadSpecification(url) error = %v", err)
+	}
+	if spec.Global.Namespace != "remote" || len(spec.MilvusServers) != 1 {
+		t.Errorf("LoadSpecification(url) = %+v", spec)
+	}
+
+	if _, err := LoadSpecification(server.URL + "/missing.yaml"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("LoadSpecification() for a missing URL error = %v, want 404", err)
+	}
+	if _, err := LoadSpecification(server.URL + "/invalid.yaml"); err == nil {
+		t.Error("LoadSpecification() should fail on invalid YAML from a URL")
+	}
+}
+
+func TestReadLimited(t *testing.T) {
+	if _, err := readLimited(strings.NewReader(strings.Repeat("a", maxTopologySize+1)), "test"); err == nil {
+		t.Error("readLimited() should reject input over the limit")
+	}
+	if data, err := readLimited(strings.NewReader("abc"), "test"); err != nil || string(data) != "abc" {
+		t.Errorf("readLimited() = %q, %v", data, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // DeployMode represents the deployment mode
@@ -93,6 +94,10 @@ type MilvusSpec struct {
 
 // MilvusComponents represents Milvus component configuration
 type MilvusComponents struct {
+	// Env is set on every component; per-component env takes precedence
+	Env map[string]string `yaml:"env,omitempty"`
+
+	Standalone ComponentSpec `yaml:"standalone,omitempty"`
 	RootCoord  ComponentSpec `yaml:"rootCoord,omitempty"`
 	QueryCoord ComponentSpec `yaml:"queryCoord,omitempty"`
 	DataCoord  ComponentSpec `yaml:"dataCoord,omitempty"`
@@ -105,8 +110,12 @@ type MilvusComponents struct {
 
 // ComponentSpec represents a component specification
 type ComponentSpec struct {
-	Replicas  int          `yaml:"replicas,omitempty"`
-	Resources ResourceSpec `yaml:"resources,omitempty"`
+	Replicas  int               `yaml:"replicas,omitempty"`
+	Resources ResourceSpec      `yaml:"resources,omitempty"`
+	Env       map[string]string `yaml:"env,omitempty"`
+
+	// AntiAffinity spreads the component's pods by "zone" or "host"
+	AntiAffinity string `yaml:"anti_affinity,omitempty"`
 }
 
 // ResourceSpec represents resource requirements
@@ -122,6 +131,14 @@ type EtcdSpec struct {
 	ClientPort int    `yaml:"client_port,omitempty"`
 	PeerPort   int    `yaml:"peer_port,omitempty"`
 	DataDir    string `yaml:"data_dir,omitempty"`
+
+	// Storage is the PVC size for in-cluster etcd (e.g. "10Gi")
+	Storage string `yaml:"storage,omitempty"`
+
+	// Service is the in-cluster DNS name of an etcd the Milvus Operator
+	// doesn't manage, e.g. a helm release ("my-etcd.infra.svc"). It is used
+	// instead of host.
+	Service string `yaml:"service,omitempty"`
 }
 
 // MinioSpec represents MinIO server specification
@@ -133,6 +150,19 @@ type MinioSpec struct {
 	SecretKey   string `yaml:"secret_key,omitempty"`
 	Bucket      string `yaml:"bucket,omitempty"`
 	DataDir     string `yaml:"data_dir,omitempty"`
+
+	// Storage is the PVC size for in-cluster MinIO (e.g. "100Gi")
+	Storage string `yaml:"storage,omitempty"`
+
+	// Service is the in-cluster DNS name of a MinIO the Milvus Operator
+	// doesn't manage, e.g. a helm release ("my-minio.infra.svc"). It is used
+	// instead of host.
+	Service string `yaml:"service,omitempty"`
+
+	// SecretRef is an existing secret in the instance namespace holding the
+	// credentials of an external MinIO (keys "accesskey" and "secretkey"),
+	// used instead of access_key and secret_key
+	SecretRef string `yaml:"secret_ref,omitempty"`
 }
 
 // PulsarSpec represents Pulsar server specification
@@ -158,11 +188,24 @@ type GrafanaSpec struct {
 	AdminPassword string `yaml:"admin_password,omitempty"`
 }
 
-// LoadSpecification loads a specification from a YAML file
+// LoadSpecification loads a specification from a YAML file. The path may
+// also be StdinSource ("-") or an http(s) URL. Environment variables are
+// not expanded, as befits a stored topology; see LoadUserSpecification.
 func LoadSpecification(path string) (*Specification, error) {
-	data, err := os.ReadFile(path)
+	return LoadSpecificationWithOptions(path, LoadOptions{})
+}
+
+// LoadUserSpecification loads a topology given by the user, expanding the
+// environment variables in it (see ExpandEnv)
+func LoadUserSpecification(path string) (*Specification, error) {
+	return LoadSpecificationWithOptions(path, LoadOptions{ExpandEnv: true})
+}
+
+// LoadSpecificationWithOptions loads a specification like LoadSpecification
+func LoadSpecificationWithOptions(path string, opts LoadOptions) (*Specification, error) {
+	data, err := loadTopology(path, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read topology file: %w", err)
+		return nil, err
 	}
 
 	var spec Specification
@@ -282,30 +325,56 @@ func setComponentDefaults(c *MilvusComponents) {
 
 // Validate validates the specification
 func (s *Specification) Validate() error {
+	if errs := s.validate(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// validate returns every problem of the specification, in the order
+// Validate reports them
+func (s *Specification) validate() []error {
+	var errs []error
+
 	if len(s.MilvusServers) == 0 {
-		return fmt.Errorf("at least one milvus server is required")
+		errs = append(errs, fmt.Errorf("at least one milvus server is required"))
 	}
 	if len(s.EtcdServers) == 0 {
-		return fmt.Errorf("at least one etcd server is required")
+		errs = append(errs, fmt.Errorf("at least one etcd server is required"))
 	}
 	if len(s.MinioServers) == 0 {
-		return fmt.Errorf("at least one minio server is required")
+		errs = append(errs, fmt.Errorf("at least one minio server is required"))
 	}
 
 	// Validate hosts
 	for i, server := range s.MilvusServers {
 		if server.Host == "" {
-			return fmt.Errorf("milvus_servers[%d].host is required", i)
+			errs = append(errs, fmt.Errorf("milvus_servers[%d].host is required", i))
+		}
+		if err := server.Components.validateEnv(); err != nil {
+			errs = append(errs, fmt.Errorf("milvus_servers[%d].%w", i, err))
+		}
+		if err := server.Components.validateAntiAffinity(); err != nil {
+			errs = append(errs, fmt.Errorf("milvus_servers[%d].%w", i, err))
+		}
+		if err := server.Components.validateResources(); err != nil {
+			errs = append(errs, fmt.Errorf("milvus_servers[%d].%w", i, err))
 		}
 	}
 	for i, server := range s.EtcdServers {
-		if server.Host == "" {
-			return fmt.Errorf("etcd_servers[%d].host is required", i)
+		if err := validateEndpoint(server.Host, server.Service); err != nil {
+			errs = append(errs, fmt.Errorf("etcd_servers[%d].%w", i, err))
+		}
+		if err := validateQuantity(server.Storage); err != nil {
+			errs = append(errs, fmt.Errorf("etcd_servers[%d].storage: %w", i, err))
 		}
 	}
 	for i, server := range s.MinioServers {
-		if server.Host == "" {
-			return fmt.Errorf("minio_servers[%d].host is required", i)
+		if err := validateEndpoint(server.Host, server.Service); err != nil {
+			errs = append(errs, fmt.Errorf("minio_servers[%d].%w", i, err))
+		}
+		if err := validateQuantity(server.Storage); err != nil {
+			errs = append(errs, fmt.Errorf("minio_servers[%d].storage: %w", i, err))
 		}
 	}
 
@@ -315,18 +384,33 @@ func (s *Specification) Validate() error {
 		// For K8s deployment, either cert files or secret name is required
 		if s.Global.TLS.SecretName == "" {
 			if s.Global.TLS.CertFile == "" {
-				return fmt.Errorf("tls.cert_file is required when TLS is enabled")
+				errs = append(errs, fmt.Errorf("tls.cert_file is required when TLS is enabled"))
 			}
 			if s.Global.TLS.KeyFile == "" {
-				return fmt.Errorf("tls.key_file is required when TLS is enabled")
+				errs = append(errs, fmt.Errorf("tls.key_file is required when TLS is enabled"))
 			}
 		}
 		// Validate TLS mode
 		if s.Global.TLS.Mode != 0 && s.Global.TLS.Mode != 1 && s.Global.TLS.Mode != 2 {
-			return fmt.Errorf("tls.mode must be 1 (one-way) or 2 (two-way)")
+			errs = append(errs, fmt.Errorf("tls.mode must be 1 (one-way) or 2 (two-way)"))
 		}
 	}
 
+	return errs
+}
+
+// validateQuantity checks that a non-empty value is a positive Kubernetes quantity
+func validateQuantity(value string) error {
+	if value == "" {
+		return nil
+	}
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return fmt.Errorf("invalid quantity '%s'", value)
+	}
+	if q.Sign() <= 0 {
+		return fmt.Errorf("quantity '%s' must be positive", value)
+	}
 	return nil
 }
 
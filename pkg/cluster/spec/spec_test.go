@@ -182,6 +182,37 @@ func TestValidate_ValidSpec(t *testing.T) {
 	}
 }
 
+func TestValidate_Storage(t *testing.T) {
+	tests := []struct {
+		name      string
+		etcd      string
+		minio     string
+		wantError bool
+	}{
+		{"unset", "", "", false},
+		{"valid", "10Gi", "100Gi", false},
+		{"decimal", "1.5Ti", "500G", false},
+		{"invalid etcd", "10GB", "", true},
+		{"invalid minio", "", "lots", true},
+		{"zero", "0", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &Specification{
+				MilvusServers: []MilvusSpec{{Host: "localhost"}},
+				EtcdServers:   []EtcdSpec{{Host: "localhost", Storage: tt.etcd}},
+				MinioServers:  []MinioSpec{{Host: "localhost", Storage: tt.minio}},
+			}
+
+			err := spec.Validate()
+			if (err != nil) != tt.wantError {
+				t.Errorf("Validate() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
 func TestSetDefaults(t *testing.T) {
 	spec := &Specification{
 		MilvusServers: []MilvusSpec{{Host: "localhost"}},
@@ -0,0 +1,100 @@
+package spec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// validateResources checks the resource quantities of each component
+func (c *MilvusComponents) validateResources() error {
+	for _, comp := range c.components() {
+		for _, r := range []struct{ key, value string }{
+			{"cpu", comp.spec.Resources.CPU},
+			{"memory", comp.spec.Resources.Memory},
+			{"storage", comp.spec.Resources.Storage},
+		} {
+			if err := validateQuantity(r.value); err != nil {
+				return fmt.Errorf("components.%s.resources.%s: %w", comp.name, r.key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Problem is something wrong with a topology file
+type Problem struct {
+	// Line is the line of the topology file, if known
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+	}
+	return p.Message
+}
+
+// Patterns of the errors yaml.v3 reports for mistyped and unknown keys
+var (
+	yamlLineRe     = regexp.MustCompile(`^line (\d+): (.*)$`)
+	unknownFieldRe = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+)
+
+// ValidateTopology loads a topology strictly, sets defaults and validates
+// it, returning every problem found rather than only the first: unknown
+// keys (usually typos, which a normal load silently ignores), invalid
+// values and missing fields, and with opts.StrictEnv undefined environment
+// variables. It fails only if the topology can't be read.
+func ValidateTopology(path string, opts LoadOptions) ([]Problem, error) {
+	data, err := readTopology(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topology file: %w", err)
+	}
+
+	var problems []Problem
+	data, err = ExpandEnv(data, os.LookupEnv, opts.StrictEnv)
+	if err != nil {
+		problems = append(problems, Problem{Message: err.Error()})
+	}
+
+	var spec Specification
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			// A syntax error leaves nothing to validate
+			return []Problem{{Message: err.Error()}}, nil
+		}
+		for _, msg := range typeErr.Errors {
+			problems = append(problems, yamlProblem(msg))
+		}
+	}
+
+	spec.setDefaults()
+	for _, err := range spec.validate() {
+		problems = append(problems, Problem{Message: err.Error()})
+	}
+	return problems, nil
+}
+
+// yamlProblem converts a yaml.v3 type error message into a Problem
+func yamlProblem(msg string) Problem {
+	m := yamlLineRe.FindStringSubmatch(msg)
+	if m == nil {
+		return Problem{Message: msg}
+	}
+	line, _ := strconv.Atoi(m[1])
+	if f := unknownFieldRe.FindStringSubmatch(m[2]); f != nil {
+		return Problem{Line: line, Message: fmt.Sprintf("unknown key '%s'", f[1])}
+	}
+	return Problem{Line: line, Message: m[2]}
+}
@@ -0,0 +1,90 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestValidateTopology(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology string
+		want     []Problem
+	}{
+		{
+			name: "valid",
+			topology: `
+milvus_servers:
+  - host: localhost
+    components:
+      standalone:
+        resources:
+          cpu: "2"
+          memory: 8Gi
+etcd_servers:
+  - host: localhost
+minio_servers:
+  - host: localhost
+`,
+		},
+		{
+			name: "unknown keys and invalid values",
+			topology: `
+milvus_servers:
+  - host: localhost
+    components:
+      queryNod:
+        replicas: 3
+      standalone:
+        resources:
+          memory: lots
+etcd_servers:
+  - host: localhost
+    storge: 10Gi
+`,
+			want: []Problem{
+				{Line: 5, Message: "unknown key 'queryNod'"},
+				{Line: 12, Message: "unknown key 'storge'"},
+				{Message: "at least one minio server is required"},
+				{Message: "milvus_servers[0].components.standalone.resources.memory: invalid quantity 'lots'"},
+			},
+		},
+		{
+			name:     "syntax error",
+			topology: "milvus_servers: [",
+			want:     []Problem{{Message: "yaml: line 1: did not find expected node content"}},
+		},
+		{
+			name:     "empty",
+			topology: "",
+			want: []Problem{
+				{Message: "at least one milvus server is required"},
+				{Message: "at least one etcd server is required"},
+				{Message: "at least one minio server is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "topology.yaml")
+			if err := os.WriteFile(path, []byte(tt.topology), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ValidateTopology(path, LoadOptions{})
+			if err != nil {
+				t.Fatalf("ValidateTopology() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateTopology() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ValidateTopology(filepath.Join(t.TempDir(), "missing.yaml"), LoadOptions{}); err == nil {
+		t.Error("ValidateTopology() of a missing file should fail")
+	}
+}
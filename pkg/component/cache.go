@@ -0,0 +1,86 @@
+package component
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mmga-lab/miup/pkg/archive"
+)
+
+// checksumSuffix is appended to a cached asset path to store its SHA-256
+const checksumSuffix = ".sha256"
+
+// Cache stores downloaded release assets keyed by repo, tag and asset name.
+// Each entry is verified against its recorded SHA-256 (and the GitHub
+// asset digest when available) before it is reused.
+type Cache struct {
+	root string
+}
+
+// NewCache creates a cache rooted at the given directory
+func NewCache(root string) *Cache {
+	return &Cache{root: root}
+}
+
+// Root returns the cache root directory
+func (c *Cache) Root() string {
+	return c.root
+}
+
+// AssetPath returns the cache path for a release asset
+func (c *Cache) AssetPath(repo, tag string, asset *Asset) string {
+	return filepath.Join(c.root, strings.ReplaceAll(repo, "/", "_"), tag, asset.Name)
+}
+
+// Lookup returns the cached asset path if it exists and its checksum is valid
+func (c *Cache) Lookup(repo, tag string, asset *Asset) (string, bool) {
+	path := c.AssetPath(repo, tag, asset)
+
+	recorded, err := os.ReadFile(path + checksumSuffix)
+	if err != nil {
+		return "", false
+	}
+
+	sum, err := archive.FileSHA256(path)
+	if err != nil || sum != strings.TrimSpace(string(recorded)) {
+		return "", false
+	}
+
+	// GitHub reports digests as "sha256:<hex>"; an upstream re-upload under
+	// the same tag changes the digest and invalidates the entry
+	if digest, ok := strings.CutPrefix(asset.Digest, "sha256:"); ok && digest != sum {
+		return "", false
+	}
+
+	return path, true
+}
+
+// Store records the checksum of a file already written to the asset path
+func (c *Cache) Store(repo, tag string, asset *Asset) error {
+	path := c.AssetPath(repo, tag, asset)
+
+	sum, err := archive.FileSHA256(path)
+	if err != nil {
+		return fmt.Errorf("failed to checksum cached asset: %w", err)
+	}
+
+	if digest, ok := strings.CutPrefix(asset.Digest, "sha256:"); ok && digest != sum {
+		os.Remove(path)
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", asset.Name, digest, sum)
+	}
+
+	if err := os.WriteFile(path+checksumSuffix, []byte(sum+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write checksum: %w", err)
+	}
+	return nil
+}
+
+// Clean removes all cached assets
+func (c *Cache) Clean() error {
+	if err := os.RemoveAll(c.root); err != nil {
+		return fmt.Errorf("failed to clean cache: %w", err)
+	}
+	return nil
+}
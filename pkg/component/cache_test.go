@@ -0,0 +1,99 @@
+package component
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCachedAsset(t *testing.T, c *Cache, repo, tag string, asset *Asset, content string) string {
+	t.Helper()
+	path := c.AssetPath(repo, tag, asset)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCache_AssetPath(t *testing.T) {
+	c := NewCache("/cache")
+	got := c.AssetPath("milvus-io/birdwatcher", "v1.0.0", &Asset{Name: "bw.tar.gz"})
+	want := "/cache/milvus-io_birdwatcher/v1.0.0/bw.tar.gz"
+	if got != want {
+		t.Errorf("AssetPath() = %s, want %s", got, want)
+	}
+}
+
+func TestCache_StoreAndLookup(t *testing.T) {
+	c := NewCache(t.TempDir())
+	asset := &Asset{Name: "bw.tar.gz"}
+
+	if _, ok := c.Lookup("repo/x", "v1", asset); ok {
+		t.Fatal("Lookup() should miss on empty cache")
+	}
+
+	path := writeCachedAsset(t, c, "repo/x", "v1", asset, "content")
+
+	// Not stored yet: no checksum recorded
+	if _, ok := c.Lookup("repo/x", "v1", asset); ok {
+		t.Fatal("Lookup() should miss without a recorded checksum")
+	}
+
+	if err := c.Store("repo/x", "v1", asset); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	got, ok := c.Lookup("repo/x", "v1", asset)
+	if !ok || got != path {
+		t.Fatalf("Lookup() = %s, %v, want %s, true", got, ok, path)
+	}
+
+	// Corrupt the cached file
+	if err := os.WriteFile(path, []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Lookup("repo/x", "v1", asset); ok {
+		t.Error("Lookup() should miss when the checksum does not match")
+	}
+}
+
+func TestCache_Digest(t *testing.T) {
+	c := NewCache(t.TempDir())
+	sum := sha256.Sum256([]byte("content"))
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	asset := &Asset{Name: "bw.tar.gz", Digest: digest}
+	writeCachedAsset(t, c, "repo/x", "v1", asset, "content")
+	if err := c.Store("repo/x", "v1", asset); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if _, ok := c.Lookup("repo/x", "v1", asset); !ok {
+		t.Error("Lookup() should hit when digest matches")
+	}
+
+	// Upstream asset replaced under the same tag
+	changed := &Asset{Name: "bw.tar.gz", Digest: "sha256:" + hex.EncodeToString(make([]byte, 32))}
+	if _, ok := c.Lookup("repo/x", "v1", changed); ok {
+		t.Error("Lookup() should miss when digest differs")
+	}
+	if err := c.Store("repo/x", "v1", changed); err == nil {
+		t.Error("Store() should fail on digest mismatch")
+	}
+}
+
+func TestCache_Clean(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "cache")
+	c := NewCache(root)
+	writeCachedAsset(t, c, "repo/x", "v1", &Asset{Name: "a"}, "content")
+
+	if err := c.Clean(); err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		t.Error("cache root should be removed")
+	}
+}
@@ -1,6 +1,7 @@
 package component
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"strings"
@@ -12,6 +13,18 @@ type Component struct {
 	Description string // Brief description
 	Repo        string // GitHub repo, e.g., "milvus-io/birdwatcher"
 	Binary      string // Binary name after extraction
+
+	// VerifyArgs are passed to the binary after install to check that it
+	// runs on this machine; defaults to --version
+	VerifyArgs []string
+
+	// HelpArgs make the binary print its usage, for
+	// 'miup run --help-component'; defaults to --help
+	HelpArgs []string
+
+	// Readiness, if set, tells 'miup run --wait-ready' when a server
+	// started by the component is serving
+	Readiness *Readiness
 }
 
 // ComponentDef defines a component with its asset naming function
@@ -19,6 +32,11 @@ type ComponentDef struct {
 	Component
 	// AssetName returns the asset filename for a given version and platform
 	AssetName func(version, os, arch string) string
+
+	// PostInstall optionally sets up an installed version, e.g. writes a
+	// default config next to the binary in installDir. It runs before the
+	// user's post-install hook.
+	PostInstall func(ctx context.Context, installDir string) error
 }
 
 // SupportsPlatform checks if the component supports the given OS/Arch
@@ -34,6 +52,14 @@ func (c *ComponentDef) SupportsPlatform(os, arch string) bool {
 	return false
 }
 
+// verifyArgs returns the arguments for the post-install smoke test
+func (c *Component) verifyArgs() []string {
+	if len(c.VerifyArgs) > 0 {
+		return c.VerifyArgs
+	}
+	return []string{"--version"}
+}
+
 // Registry holds all supported components
 var Registry = map[string]*ComponentDef{
 	"birdwatcher": {
@@ -56,6 +82,12 @@ var Registry = map[string]*ComponentDef{
 			Description: "Milvus backup and restore utility",
 			Repo:        "zilliztech/milvus-backup",
 			Binary:      "milvus-backup",
+			// 'milvus-backup server' serves its REST API on :8080
+			Readiness: &Readiness{
+				Command:   "server",
+				Port:      8080,
+				PortFlags: []string{"--port", "-p"},
+			},
 		},
 		// Asset pattern: milvus-backup_0.5.9_Darwin_arm64.tar.gz
 		AssetName: func(version, os, arch string) string {
@@ -2,9 +2,11 @@ package component
 
 import (
 	"archive/tar"
+	"archive/zip"
 	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +14,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/schollz/progressbar/v3"
 	"golang.org/x/term"
@@ -19,8 +23,13 @@ import (
 
 // GitHubRelease represents a GitHub release
 type GitHubRelease struct {
-	TagName string  `json:"tag_name"`
-	Assets  []Asset `json:"assets"`
+	TagName    string  `json:"tag_name"`
+	Draft      bool    `json:"draft,omitempty"`
+	Prerelease bool    `json:"prerelease,omitempty"`
+	Assets     []Asset `json:"assets"`
+
+	// TargetCommitish is the branch or commit the release was built from
+	TargetCommitish string `json:"target_commitish,omitempty"`
 }
 
 // Asset represents a GitHub release asset
@@ -28,12 +37,25 @@ type Asset struct {
 	Name               string `json:"name"`
 	BrowserDownloadURL string `json:"browser_download_url"`
 	Size               int64  `json:"size"`
+	Digest             string `json:"digest,omitempty"`
 }
 
+// DefaultDownloadTimeout is how long a download may go without receiving
+// any data, or a request without a response, before it fails
+const DefaultDownloadTimeout = 30 * time.Second
+
+// ErrDownloadStalled indicates the server stopped sending data
+var ErrDownloadStalled = errors.New("download stalled")
+
+// githubAPI is the base URL of the GitHub REST API
+const githubAPI = "https://api.github.com"
+
 // Downloader handles downloading components from GitHub
 type Downloader struct {
 	client    *http.Client
 	userAgent string
+	timeout   time.Duration
+	apiURL    string
 }
 
 // NewDownloader creates a new downloader
@@ -41,47 +63,140 @@ func NewDownloader() *Downloader {
 	return &Downloader{
 		client:    &http.Client{},
 		userAgent: "miup/1.0",
+		timeout:   DefaultDownloadTimeout,
+		apiURL:    githubAPI,
+	}
+}
+
+// WithTimeout sets how long a request may wait for a response, and a
+// download for more data, before failing with ErrDownloadStalled. Zero
+// means no limit. A slow download that keeps receiving data never times out.
+func (d *Downloader) WithTimeout(timeout time.Duration) *Downloader {
+	d.timeout = timeout
+	return d
+}
+
+// do sends req, failing with ErrDownloadStalled if no response arrives or
+// the response body stops receiving data for the downloader's timeout.
+// Cancelling the request context aborts it at any point.
+func (d *Downloader) do(req *http.Request) (*http.Response, error) {
+	if d.timeout <= 0 {
+		return d.client.Do(req)
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	w := &stallWatch{cancel: cancel, timeout: d.timeout}
+	w.timer = time.AfterFunc(d.timeout, w.fire)
+
+	resp, err := d.client.Do(req.WithContext(ctx))
+	if err != nil {
+		w.stop()
+		if w.fired.Load() {
+			return nil, fmt.Errorf("%w: no response within %s", ErrDownloadStalled, d.timeout)
+		}
+		return nil, err
+	}
+	w.timer.Reset(d.timeout)
+	resp.Body = &stallReader{rc: resp.Body, w: w}
+	return resp, nil
+}
+
+// stallWatch cancels a request once its timer fires
+type stallWatch struct {
+	timer   *time.Timer
+	cancel  context.CancelFunc
+	timeout time.Duration
+	fired   atomic.Bool
+}
+
+func (w *stallWatch) fire() {
+	w.fired.Store(true)
+	w.cancel()
+}
+
+func (w *stallWatch) stop() {
+	w.timer.Stop()
+	w.cancel()
+}
+
+// stallReader restarts its watch's timer whenever data arrives
+type stallReader struct {
+	rc io.ReadCloser
+	w  *stallWatch
+}
+
+func (r *stallReader) Read(p []byte) (int, error) {
+	n, err := r.rc.Read(p)
+	if n > 0 {
+		r.w.timer.Reset(r.w.timeout)
+	}
+	if err != nil && err != io.EOF && r.w.fired.Load() {
+		err = fmt.Errorf("%w: no data received for %s", ErrDownloadStalled, r.w.timeout)
 	}
+	return n, err
+}
+
+func (r *stallReader) Close() error {
+	r.w.stop()
+	return r.rc.Close()
 }
 
 // GetLatestRelease fetches the latest release info from GitHub
 func (d *Downloader) GetLatestRelease(ctx context.Context, repo string) (*GitHubRelease, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repo)
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", d.apiURL, repo)
 	return d.getRelease(ctx, url)
 }
 
 // GetRelease fetches a specific release by tag
 func (d *Downloader) GetRelease(ctx context.Context, repo, tag string) (*GitHubRelease, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/tags/%s", repo, tag)
+	url := fmt.Sprintf("%s/repos/%s/releases/tags/%s", d.apiURL, repo, tag)
 	return d.getRelease(ctx, url)
 }
 
+// ListReleases fetches the most recent releases (up to 100), newest first
+func (d *Downloader) ListReleases(ctx context.Context, repo string) ([]GitHubRelease, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=100", d.apiURL, repo)
+	var releases []GitHubRelease
+	if err := d.getJSON(ctx, url, &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
 func (d *Downloader) getRelease(ctx context.Context, url string) (*GitHubRelease, error) {
+	var release GitHubRelease
+	if err := d.getJSON(ctx, url, &release); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// getJSON fetches a GitHub API URL and decodes the response into v
+func (d *Downloader) getJSON(ctx context.Context, url string, v any) error {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("User-Agent", d.userAgent)
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 
-	resp, err := d.client.Do(req)
+	resp, err := d.do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch release: %w", err)
+		return fmt.Errorf("failed to fetch release: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("release not found")
+		return fmt.Errorf("release not found")
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+		return fmt.Errorf("unexpected status: %s", resp.Status)
 	}
 
-	var release GitHubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, fmt.Errorf("failed to decode release: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode release: %w", err)
 	}
-	return &release, nil
+	return nil
 }
 
 // DownloadAsset downloads and extracts a release asset
@@ -90,25 +205,131 @@ func (d *Downloader) DownloadAsset(ctx context.Context, asset *Asset, destDir st
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
+	resp, reader, counter, err := d.fetch(ctx, asset, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	writeErr := extractAsset(asset.Name, reader, destDir)
+
+	// The tar reader may stop before the end of the stream (padding), so
+	// drain the rest before comparing sizes
+	if writeErr == nil {
+		_, _ = io.Copy(io.Discard, reader)
+	}
+
+	// A stalled or cancelled download is short too; report why it stopped
+	if errors.Is(writeErr, ErrDownloadStalled) || ctx.Err() != nil {
+		return writeErr
+	}
+
+	// A short body surfaces as a confusing extraction error; report the
+	// truncation instead since that is the root cause
+	if err := verifyDownloadSize(counter.n, asset.Size, resp.ContentLength); err != nil {
+		return err
+	}
+	return writeErr
+}
+
+// FetchAsset downloads a release asset to destPath without extracting it.
+// The file is written to a temporary path and renamed once complete.
+func (d *Downloader) FetchAsset(ctx context.Context, asset *Asset, destPath string) error {
+	return d.fetchToFile(ctx, asset, "", destPath)
+}
+
+// fetchToFile downloads asset to destPath, authenticated with token if set
+func (d *Downloader) fetchToFile(ctx context.Context, asset *Asset, token, destPath string) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	resp, reader, counter, err := d.fetch(ctx, asset, token)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	partPath := destPath + ".part"
+	if err := downloadToFile(reader, partPath); err != nil {
+		os.Remove(partPath)
+		return err
+	}
+	if err := verifyDownloadSize(counter.n, asset.Size, resp.ContentLength); err != nil {
+		os.Remove(partPath)
+		return err
+	}
+	if err := os.Rename(partPath, destPath); err != nil {
+		os.Remove(partPath)
+		return fmt.Errorf("failed to save download: %w", err)
+	}
+	return nil
+}
+
+// ExtractFile extracts a previously downloaded asset file into destDir
+func ExtractFile(assetName, srcPath, destDir string) error {
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", srcPath, err)
+	}
+	defer f.Close()
+
+	return extractAsset(assetName, f, destDir)
+}
+
+// Get fetches a URL with the downloader's user agent and timeout and
+// returns the response body, which the caller must close. Any status other
+// than 200 OK is an error.
+func (d *Downloader) Get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", d.userAgent)
+
+	resp, err := d.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status fetching %s: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// fetch starts downloading an asset and returns the response together with
+// a reader that reports progress and counts the bytes received. The request
+// is authenticated with token if set.
+func (d *Downloader) fetch(ctx context.Context, asset *Asset, token string) (*http.Response, io.Reader, *countingReader, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", asset.BrowserDownloadURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("User-Agent", d.userAgent)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
-	resp, err := d.client.Do(req)
+	resp, err := d.do(req)
 	if err != nil {
-		return fmt.Errorf("failed to download: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to download: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed: %s", resp.Status)
+		resp.Body.Close()
+		return nil, nil, nil, fmt.Errorf("download failed: %s", resp.Status)
 	}
 
 	// Create progress bar only if stderr is a terminal (TTY)
 	// In non-TTY environments (e.g., CI, piped output), progressbar produces
 	// excessive output that can cause issues
+	counter := &countingReader{r: resp.Body}
+
 	var reader io.Reader
 	if term.IsTerminal(int(os.Stderr.Fd())) {
 		bar := progressbar.NewOptions64(
@@ -127,21 +348,57 @@ func (d *Downloader) DownloadAsset(ctx context.Context, asset *Asset, destDir st
 				BarEnd:        "]",
 			}),
 		)
-		reader = io.TeeReader(resp.Body, bar)
+		reader = io.TeeReader(counter, bar)
 	} else {
-		// Non-TTY: just print a simple message
+		// Non-TTY: print a line when the download starts and then periodic
+		// progress lines
 		fmt.Fprintf(os.Stderr, "Downloading %s (%d MB)...\n", asset.Name, asset.Size/1024/1024)
-		reader = resp.Body
+		reader = io.TeeReader(counter, newProgressLogger(os.Stderr, asset.Name, asset.Size))
+	}
+
+	return resp, reader, counter, nil
+}
+
+// extractAsset writes an asset stream into destDir based on its file type
+func extractAsset(assetName string, r io.Reader, destDir string) error {
+	switch {
+	case strings.HasSuffix(assetName, ".tar.gz") || strings.HasSuffix(assetName, ".tgz"):
+		return extractTarGz(r, destDir)
+	case strings.HasSuffix(assetName, ".zip"):
+		return extractZip(r, destDir)
+	case strings.HasSuffix(assetName, ".gz"):
+		// Single compressed file: strip the .gz suffix
+		return extractGzip(r, filepath.Join(destDir, strings.TrimSuffix(assetName, ".gz")))
+	default:
+		// Direct binary download
+		return downloadToFile(r, filepath.Join(destDir, assetName))
 	}
+}
+
+// ErrDownloadTruncated indicates fewer bytes were received than expected
+var ErrDownloadTruncated = errors.New("download truncated")
 
-	// Handle different archive types
-	if strings.HasSuffix(asset.Name, ".tar.gz") || strings.HasSuffix(asset.Name, ".tgz") {
-		return extractTarGz(reader, destDir)
+// verifyDownloadSize checks the number of received bytes against the asset
+// size and the server's Content-Length. Unknown sizes (<= 0) are skipped.
+func verifyDownloadSize(received, assetSize, contentLength int64) error {
+	for _, expected := range []int64{contentLength, assetSize} {
+		if expected > 0 && received < expected {
+			return fmt.Errorf("%w: received %d of %d bytes", ErrDownloadTruncated, received, expected)
+		}
 	}
+	return nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
 
-	// Direct binary download
-	destPath := filepath.Join(destDir, asset.Name)
-	return downloadToFile(reader, destPath)
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
 }
 
 // extractTarGz extracts a tar.gz archive to the destination directory
@@ -152,6 +409,7 @@ func extractTarGz(r io.Reader, destDir string) error {
 	}
 	defer gzr.Close()
 
+	var symlinks []string
 	tr := tar.NewReader(gzr)
 	for {
 		header, err := tr.Next()
@@ -162,11 +420,18 @@ func extractTarGz(r io.Reader, destDir string) error {
 			return fmt.Errorf("tar read error: %w", err)
 		}
 
-		target := filepath.Join(destDir, header.Name)
-
-		// Security: prevent path traversal
-		if !strings.HasPrefix(filepath.Clean(target), filepath.Clean(destDir)+string(os.PathSeparator)) {
-			return fmt.Errorf("invalid file path: %s", header.Name)
+		target, err := safeJoin(destDir, header.Name)
+		if err != nil {
+			return err
+		}
+		// Nothing is written through a symlink extracted earlier, which
+		// could point anywhere inside destDir and, chained, outside it
+		checked := filepath.Dir(target)
+		if header.Typeflag == tar.TypeDir {
+			checked = target
+		}
+		if err := checkNoSymlinks(destDir, checked); err != nil {
+			return err
 		}
 
 		switch header.Typeflag {
@@ -178,7 +443,12 @@ func extractTarGz(r io.Reader, destDir string) error {
 			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
 				return fmt.Errorf("failed to create parent directory: %w", err)
 			}
-			f, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR, os.FileMode(header.Mode))
+			// Replace rather than write through an existing symlink
+			if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				_ = os.Remove(target)
+			}
+			mode := header.FileInfo().Mode().Perm()
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR|os.O_TRUNC, mode)
 			if err != nil {
 				return fmt.Errorf("failed to create file: %w", err)
 			}
@@ -187,11 +457,232 @@ func extractTarGz(r io.Reader, destDir string) error {
 				return fmt.Errorf("failed to extract file: %w", err)
 			}
 			f.Close()
+			// OpenFile's mode is filtered by umask and ignored for existing
+			// files, so set it explicitly to keep exec bits
+			if err := os.Chmod(target, mode); err != nil {
+				return fmt.Errorf("failed to set file mode: %w", err)
+			}
+		case tar.TypeSymlink:
+			if err := extractSymlink(destDir, target, header.Linkname); err != nil {
+				return err
+			}
+			symlinks = append(symlinks, target)
+		case tar.TypeLink:
+			// Hard link names are relative to the archive root
+			source, err := safeJoin(destDir, header.Linkname)
+			if err != nil {
+				return err
+			}
+			if err := checkNoSymlinks(destDir, filepath.Dir(source)); err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return fmt.Errorf("failed to create parent directory: %w", err)
+			}
+			_ = os.Remove(target)
+			if err := os.Link(source, target); err != nil {
+				return fmt.Errorf("failed to create hard link: %w", err)
+			}
+		}
+	}
+
+	// A link checked on creation can escape through links extracted after
+	// it, e.g. a -> b/x/../.. followed by b -> ., so check them all again
+	// against the final tree
+	for _, link := range symlinks {
+		if err := resolveInside(destDir, link); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// extractSymlink creates a symlink at target pointing to linkname, rejecting
+// absolute links and links that resolve outside destDir, following the
+// symlinks already extracted
+func extractSymlink(destDir, target, linkname string) error {
+	if filepath.IsAbs(linkname) {
+		return fmt.Errorf("invalid symlink target: %s", linkname)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create parent directory: %w", err)
+	}
+	_ = os.Remove(target)
+	if err := os.Symlink(linkname, target); err != nil {
+		return fmt.Errorf("failed to create symlink: %w", err)
+	}
+	if err := resolveInside(destDir, target); err != nil {
+		_ = os.Remove(target)
+		return err
+	}
+	return nil
+}
+
+// checkNoSymlinks fails if path or a directory between destDir and path is
+// a symlink. Components that don't exist yet are fine.
+func checkNoSymlinks(destDir, path string) error {
+	rel, err := filepath.Rel(destDir, path)
+	if err != nil || rel == "." {
+		return err
+	}
+	current := destDir
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", current, err)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("invalid file path: %s is written through a symlink", path)
+		}
+	}
+	return nil
+}
+
+// maxSymlinkHops bounds how many symlinks resolveInside follows, like the
+// kernel's ELOOP limit
+const maxSymlinkHops = 40
+
+// resolveInside follows path one component at a time the way the kernel
+// does, including the symlinks in it, and fails if any step leaves destDir.
+// Unlike filepath.EvalSymlinks it accepts dangling links, and unlike
+// filepath.Clean it applies ".." after a symlink is followed.
+func resolveInside(destDir, path string) error {
+	rel, err := filepath.Rel(destDir, path)
+	if err != nil {
+		return err
+	}
+	parts := strings.Split(rel, string(os.PathSeparator))
+	current := destDir
+	for hops := 0; len(parts) > 0; {
+		part := parts[0]
+		parts = parts[1:]
+
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			if current == destDir {
+				return fmt.Errorf("invalid symlink: %s resolves outside the destination", path)
+			}
+			current = filepath.Dir(current)
+			continue
+		}
+
+		next := filepath.Join(current, part)
+		info, err := os.Lstat(next)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			// A missing component ends the resolution, so the rest is
+			// only walked lexically
+			current = next
+			continue
+		}
+
+		if hops++; hops > maxSymlinkHops {
+			return fmt.Errorf("invalid symlink: too many levels of symlinks in %s", path)
+		}
+		linkname, err := os.Readlink(next)
+		if err != nil {
+			return fmt.Errorf("failed to read symlink: %w", err)
+		}
+		if filepath.IsAbs(linkname) {
+			return fmt.Errorf("invalid symlink target: %s", linkname)
+		}
+		parts = append(strings.Split(filepath.ToSlash(linkname), "/"), parts...)
+	}
+	return nil
+}
+
+// extractZip extracts a zip archive to the destination directory.
+// zip requires random access, so the stream is buffered to a temp file first.
+func extractZip(r io.Reader, destDir string) error {
+	tmp, err := os.CreateTemp("", "miup-*.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return fmt.Errorf("failed to download archive: %w", err)
+	}
+
+	zr, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return fmt.Errorf("failed to open zip archive: %w", err)
+	}
+
+	for _, f := range zr.File {
+		target, err := safeJoin(destDir, f.Name)
+		if err != nil {
+			return err
+		}
+		if err := checkNoSymlinks(destDir, target); err != nil {
+			return err
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create parent directory: %w", err)
+		}
+		if err := extractZipFile(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(f *zip.File, target string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s in archive: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR|os.O_TRUNC, f.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, rc); err != nil {
+		return fmt.Errorf("failed to extract file: %w", err)
+	}
+	return nil
+}
+
+// extractGzip decompresses a single-file gzip stream to destPath
+func extractGzip(r io.Reader, destPath string) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer gzr.Close()
+
+	return downloadToFile(gzr, destPath)
+}
+
+// safeJoin joins an archive entry name onto destDir, rejecting entries that
+// would escape destDir (path traversal)
+func safeJoin(destDir, name string) (string, error) {
+	target := filepath.Join(destDir, name)
+	if !strings.HasPrefix(filepath.Clean(target), filepath.Clean(destDir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid file path: %s", name)
+	}
+	return target, nil
+}
+
 // downloadToFile downloads content directly to a file
 func downloadToFile(r io.Reader, destPath string) error {
 	f, err := os.Create(destPath)
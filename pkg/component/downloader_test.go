@@ -0,0 +1,376 @@
+package component
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyDownloadSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		received      int64
+		assetSize     int64
+		contentLength int64
+		wantErr       bool
+	}{
+		{"complete", 100, 100, 100, false},
+		{"unknown sizes", 100, 0, -1, false},
+		{"short of content length", 50, 0, 100, true},
+		{"short of asset size", 50, 100, -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyDownloadSize(tt.received, tt.assetSize, tt.contentLength)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyDownloadSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrDownloadTruncated) {
+				t.Errorf("verifyDownloadSize() error = %v, want ErrDownloadTruncated", err)
+			}
+		})
+	}
+}
+
+func TestDownloadAsset_Truncated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+	}))
+	defer server.Close()
+
+	destDir := t.TempDir()
+	d := NewDownloader()
+
+	asset := &Asset{Name: "tool", Size: 1024, BrowserDownloadURL: server.URL}
+	err := d.DownloadAsset(context.Background(), asset, destDir)
+	if !errors.Is(err, ErrDownloadTruncated) {
+		t.Fatalf("DownloadAsset() error = %v, want ErrDownloadTruncated", err)
+	}
+
+	asset.Size = int64(len("partial"))
+	if err := d.DownloadAsset(context.Background(), asset, destDir); err != nil {
+		t.Fatalf("DownloadAsset() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "tool")); err != nil {
+		t.Errorf("downloaded file missing: %v", err)
+	}
+}
+
+func TestDownloaderGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(r.UserAgent()))
+	}))
+	defer server.Close()
+
+	d := NewDownloader()
+	body, err := d.Get(context.Background(), server.URL+"/ok")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != d.userAgent {
+		t.Errorf("User-Agent = %q, want %q", data, d.userAgent)
+	}
+
+	if _, err := d.Get(context.Background(), server.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Get() for a missing URL error = %v, want 404", err)
+	}
+}
+
+func TestExtractZip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("bin/tool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte("binary"))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := t.TempDir()
+	if err := extractZip(&buf, destDir); err != nil {
+		t.Fatalf("extractZip() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(destDir, "bin", "tool"))
+	if err != nil {
+		t.Fatalf("extracted file missing: %v", err)
+	}
+	if string(data) != "binary" {
+		t.Errorf("extracted content = %q, want binary", string(data))
+	}
+}
+
+func TestExtractZip_PathTraversal(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("../evil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte("x"))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := extractZip(&buf, t.TempDir()); err == nil {
+		t.Error("extractZip() should reject entries outside the destination")
+	}
+}
+
+func TestExtractGzip(t *testing.T) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	_, _ = gzw.Write([]byte("binary"))
+	if err := gzw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	destPath := filepath.Join(t.TempDir(), "tool")
+	if err := extractGzip(&buf, destPath); err != nil {
+		t.Fatalf("extractGzip() error = %v", err)
+	}
+
+	data, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("extracted file missing: %v", err)
+	}
+	if string(data) != "binary" {
+		t.Errorf("extracted content = %q, want binary", string(data))
+	}
+}
+
+// buildTarGz builds a tar.gz archive from the given headers and contents
+func buildTarGz(t *testing.T, entries []tarEntry) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for _, e := range entries {
+		e.header.Size = int64(len(e.content))
+		if err := tw.WriteHeader(e.header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+type tarEntry struct {
+	header  *tar.Header
+	content string
+}
+
+func TestExtractTarGz_SymlinkAndMode(t *testing.T) {
+	buf := buildTarGz(t, []tarEntry{
+		{header: &tar.Header{Name: "bin/tool-v1", Typeflag: tar.TypeReg, Mode: 0755}, content: "binary"},
+		{header: &tar.Header{Name: "bin/tool", Typeflag: tar.TypeSymlink, Linkname: "tool-v1"}},
+	})
+
+	destDir := t.TempDir()
+	if err := extractTarGz(buf, destDir); err != nil {
+		t.Fatalf("extractTarGz() error = %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(destDir, "bin", "tool-v1"))
+	if err != nil {
+		t.Fatalf("extracted file missing: %v", err)
+	}
+	if info.Mode().Perm()&0111 == 0 {
+		t.Errorf("extracted file mode = %v, want executable", info.Mode().Perm())
+	}
+
+	link, err := os.Readlink(filepath.Join(destDir, "bin", "tool"))
+	if err != nil {
+		t.Fatalf("symlink missing: %v", err)
+	}
+	if link != "tool-v1" {
+		t.Errorf("symlink target = %s, want tool-v1", link)
+	}
+
+	data, err := os.ReadFile(filepath.Join(destDir, "bin", "tool"))
+	if err != nil {
+		t.Fatalf("failed to read through symlink: %v", err)
+	}
+	if string(data) != "binary" {
+		t.Errorf("content via symlink = %q, want binary", string(data))
+	}
+}
+
+func TestExtractTarGz_SymlinkTraversal(t *testing.T) {
+	tests := []struct {
+		name     string
+		linkname string
+	}{
+		{"relative escape", "../../etc/passwd"},
+		{"absolute", "/etc/passwd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := buildTarGz(t, []tarEntry{
+				{header: &tar.Header{Name: "bin/evil", Typeflag: tar.TypeSymlink, Linkname: tt.linkname}},
+			})
+			if err := extractTarGz(buf, t.TempDir()); err == nil {
+				t.Error("extractTarGz() should reject symlinks escaping the destination")
+			}
+		})
+	}
+}
+
+func TestExtractTarGz_SymlinkChain(t *testing.T) {
+	symlink := func(name, linkname string) tarEntry {
+		return tarEntry{header: &tar.Header{Name: name, Typeflag: tar.TypeSymlink, Linkname: linkname}}
+	}
+
+	tests := []struct {
+		name    string
+		entries []tarEntry
+	}{
+		{
+			name:    "through an earlier link",
+			entries: []tarEntry{symlink("b", "."), symlink("a", "b/b/b/../..")},
+		},
+		{
+			name:    "through a later link",
+			entries: []tarEntry{symlink("a", "b/x/../.."), symlink("b", ".")},
+		},
+		{
+			name:    "loop",
+			entries: []tarEntry{symlink("a", "b"), symlink("b", "a")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := extractTarGz(buildTarGz(t, tt.entries), t.TempDir()); err == nil {
+				t.Error("extractTarGz() should reject symlink chains escaping the destination")
+			}
+		})
+	}
+}
+
+func TestExtractTarGz_ThroughSymlinkedDir(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry tarEntry
+	}{
+		{"file", tarEntry{header: &tar.Header{Name: "dir/file", Typeflag: tar.TypeReg, Mode: 0644}, content: "evil"}},
+		{"directory", tarEntry{header: &tar.Header{Name: "dir/new", Typeflag: tar.TypeDir, Mode: 0755}}},
+		{"hard link", tarEntry{header: &tar.Header{Name: "dir/hard", Typeflag: tar.TypeLink, Linkname: "sub/file"}}},
+		{"hard link source", tarEntry{header: &tar.Header{Name: "hard", Typeflag: tar.TypeLink, Linkname: "dir/file"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destDir := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(destDir, "sub"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(destDir, "sub", "file"), []byte("x"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			buf := buildTarGz(t, []tarEntry{
+				{header: &tar.Header{Name: "dir", Typeflag: tar.TypeSymlink, Linkname: "sub"}},
+				tt.entry,
+			})
+			if err := extractTarGz(buf, destDir); err == nil {
+				t.Error("extractTarGz() should refuse to write through a symlinked directory")
+			}
+			if data, _ := os.ReadFile(filepath.Join(destDir, "sub", "file")); string(data) != "x" {
+				t.Errorf("sub/file = %q, overwritten through the symlink", data)
+			}
+			for _, name := range []string{"sub/new", "sub/hard", "hard"} {
+				if _, err := os.Lstat(filepath.Join(destDir, name)); err == nil {
+					t.Errorf("%s was created through the symlink", name)
+				}
+			}
+		})
+	}
+}
+
+func TestDownloadAsset_Stalled(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "no response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			},
+		},
+		{
+			name: "no data",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Length", "1024")
+				_, _ = w.Write([]byte("partial"))
+				w.(http.Flusher).Flush()
+				<-r.Context().Done()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			d := NewDownloader().WithTimeout(100 * time.Millisecond)
+			asset := &Asset{Name: "tool", Size: 1024, BrowserDownloadURL: server.URL}
+			err := d.DownloadAsset(context.Background(), asset, t.TempDir())
+			if !errors.Is(err, ErrDownloadStalled) {
+				t.Fatalf("DownloadAsset() error = %v, want ErrDownloadStalled", err)
+			}
+		})
+	}
+}
+
+func TestDownloadAsset_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	d := NewDownloader().WithTimeout(0)
+	asset := &Asset{Name: "tool", Size: 1024, BrowserDownloadURL: server.URL}
+	err := d.DownloadAsset(ctx, asset, t.TempDir())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("DownloadAsset() error = %v, want context.DeadlineExceeded", err)
+	}
+}
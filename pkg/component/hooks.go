@@ -0,0 +1,122 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/mmga-lab/miup/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// UserConfigFileName is the user components file in the miup home, which
+// configures install hooks per component:
+//
+//	components:
+//	  milvus-backup:
+//	    hooks:
+//	      post_install: cp ~/backup.yaml "$MIUP_INSTALL_DIR/configs/backup.yaml"
+const UserConfigFileName = "components.yaml"
+
+// Hook stages
+const (
+	HookPreInstall  = "pre-install"
+	HookPostInstall = "post-install"
+)
+
+// Hooks are shell commands run before and after installing a component
+type Hooks struct {
+	PreInstall  string `yaml:"pre_install,omitempty"`
+	PostInstall string `yaml:"post_install,omitempty"`
+}
+
+// command returns the hook command of a stage
+func (h Hooks) command(stage string) string {
+	if stage == HookPreInstall {
+		return h.PreInstall
+	}
+	return h.PostInstall
+}
+
+// UserComponent is the user configuration of a component
+type UserComponent struct {
+	Hooks Hooks `yaml:"hooks,omitempty"`
+}
+
+// UserConfig is the content of the user components file
+type UserConfig struct {
+	Components map[string]UserComponent `yaml:"components,omitempty"`
+}
+
+// LoadUserConfig loads the user components file; a missing file is an
+// empty configuration
+func LoadUserConfig(path string) (*UserConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &UserConfig{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var config UserConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// Hook is a user hook about to run, passed to InstallOptions.ConfirmHook
+type Hook struct {
+	Component string
+	Version   string
+	Stage     string
+	Command   string
+}
+
+// runUserHook runs the user hook of a stage, if one is configured. Hooks
+// are arbitrary shell commands, so each one runs only if confirm approves
+// it; a hook that is not approved is skipped with a warning.
+func (m *Manager) runUserHook(ctx context.Context, stage, name, version string, confirm func(Hook) bool) error {
+	config, err := LoadUserConfig(m.profile.Path(UserConfigFileName))
+	if err != nil {
+		return err
+	}
+	command := config.Components[name].Hooks.command(stage)
+	if command == "" {
+		return nil
+	}
+
+	hook := Hook{Component: name, Version: version, Stage: stage, Command: command}
+	if confirm == nil || !confirm(hook) {
+		logger.Warn("Skipping %s hook of %s (use --allow-hooks to run it): %s", stage, name, command)
+		return nil
+	}
+
+	logger.Info("Running %s hook of %s...", stage, name)
+	if err := runHook(ctx, command, m.hookEnv(name, version)); err != nil {
+		return fmt.Errorf("%s hook failed: %w", stage, err)
+	}
+	return nil
+}
+
+// hookEnv returns the environment of a hook: miup's own plus the component,
+// its version, install directory and binary
+func (m *Manager) hookEnv(name, version string) []string {
+	return append(os.Environ(),
+		"MIUP_COMPONENT="+name,
+		"MIUP_COMPONENT_VERSION="+version,
+		"MIUP_INSTALL_DIR="+m.VersionDir(name, version),
+		"MIUP_BINARY="+m.BinaryPath(name, version),
+	)
+}
+
+// runHook runs a hook command with sh, streaming its output
+func runHook(ctx context.Context, command string, env []string) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = env
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,85 @@
+package component
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+func TestLoadUserConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	config, err := LoadUserConfig(filepath.Join(dir, UserConfigFileName))
+	if err != nil || len(config.Components) != 0 {
+		t.Errorf("LoadUserConfig() of missing file = %+v, %v", config, err)
+	}
+
+	path := filepath.Join(dir, UserConfigFileName)
+	data := "components:\n  birdwatcher:\n    hooks:\n      post_install: echo done\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err = LoadUserConfig(path)
+	if err != nil {
+		t.Fatalf("LoadUserConfig() error = %v", err)
+	}
+	if got := config.Components["birdwatcher"].Hooks.PostInstall; got != "echo done" {
+		t.Errorf("post_install = %q, want echo done", got)
+	}
+
+	if err := os.WriteFile(path, []byte("components: ["), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadUserConfig(path); err == nil {
+		t.Error("LoadUserConfig() of invalid YAML should fail")
+	}
+}
+
+func TestRunUserHook(t *testing.T) {
+	profile := localdata.NewProfile(t.TempDir())
+	mgr := NewManager(profile)
+	out := filepath.Join(t.TempDir(), "hook.out")
+
+	config := "components:\n  birdwatcher:\n    hooks:\n" +
+		"      pre_install: exit 3\n" +
+		"      post_install: echo \"$MIUP_COMPONENT $MIUP_COMPONENT_VERSION $MIUP_INSTALL_DIR\" > " + out + "\n"
+	if err := os.WriteFile(profile.Path(UserConfigFileName), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	allow := func(Hook) bool { return true }
+
+	// Not approved: skipped
+	if err := mgr.runUserHook(ctx, HookPostInstall, "birdwatcher", "v1.0.0", nil); err != nil {
+		t.Fatalf("runUserHook() without approval error = %v", err)
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Fatal("a hook that was not approved should not run")
+	}
+
+	var asked Hook
+	if err := mgr.runUserHook(ctx, HookPostInstall, "birdwatcher", "v1.0.0", func(h Hook) bool { asked = h; return true }); err != nil {
+		t.Fatalf("runUserHook() error = %v", err)
+	}
+	if asked.Stage != HookPostInstall || asked.Component != "birdwatcher" {
+		t.Errorf("confirmed hook = %+v", asked)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "birdwatcher v1.0.0 " + mgr.VersionDir("birdwatcher", "v1.0.0") + "\n"; string(got) != want {
+		t.Errorf("hook output = %q, want %q", got, want)
+	}
+
+	if err := mgr.runUserHook(ctx, HookPreInstall, "birdwatcher", "v1.0.0", allow); err == nil {
+		t.Error("a failing hook should return an error")
+	}
+	if err := mgr.runUserHook(ctx, HookPreInstall, "milvus-backup", "v1.0.0", allow); err != nil {
+		t.Errorf("runUserHook() without a configured hook error = %v", err)
+	}
+}
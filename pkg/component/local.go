@@ -0,0 +1,89 @@
+package component
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// archiveSuffixes are the file extensions of release archives, longest first
+var archiveSuffixes = []string{".tar.gz", ".tgz", ".zip", ".gz"}
+
+// filenameVersion matches a version embedded in a release file name, e.g.
+// v1.2.0 in birdwatcher_v1.2.0_linux_amd64.tar.gz
+var filenameVersion = regexp.MustCompile(`v?\d+\.\d+\.\d+(-[0-9A-Za-z.]+)?`)
+
+// localVersion returns the version to register a local install under: the
+// requested one, or else the version in the file name of src
+func localVersion(src, requested string) (string, error) {
+	if requested == "" || requested == "latest" {
+		name := filepath.Base(src)
+		for _, suffix := range archiveSuffixes {
+			if strings.HasSuffix(name, suffix) {
+				name = strings.TrimSuffix(name, suffix)
+				break
+			}
+		}
+		requested = filenameVersion.FindString(name)
+		if requested == "" {
+			return "", fmt.Errorf("cannot infer the version from %s; specify it with --version", filepath.Base(src))
+		}
+	} else if isVersionRange(requested) {
+		return "", fmt.Errorf("installing from a local file needs an exact version, not %s", requested)
+	}
+
+	if !strings.HasPrefix(requested, "v") {
+		requested = "v" + requested
+	}
+	return requested, nil
+}
+
+// installLocal installs a component from src into destDir. src is a release
+// archive, a gzipped or plain binary, or a directory holding an extracted
+// release, and must provide the component's binary.
+func installLocal(compDef *ComponentDef, src, destDir string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+
+	name := filepath.Base(src)
+	binaryPath := filepath.Join(destDir, compDef.Binary)
+	switch {
+	case info.IsDir():
+		if err := os.CopyFS(destDir, os.DirFS(src)); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", src, err)
+		}
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"), strings.HasSuffix(name, ".zip"):
+		if err := ExtractFile(name, src, destDir); err != nil {
+			return err
+		}
+	default:
+		// A single binary, possibly gzipped, is installed under the
+		// component's binary name whatever the file is called
+		if err := os.MkdirAll(destDir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		f, err := os.Open(src)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", src, err)
+		}
+		defer f.Close()
+
+		if strings.HasSuffix(name, ".gz") {
+			err = extractGzip(f, binaryPath)
+		} else {
+			err = downloadToFile(f, binaryPath)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := os.Stat(binaryPath); err != nil {
+		return fmt.Errorf("%s does not contain the %s binary", name, compDef.Binary)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package component
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+func TestLocalVersion(t *testing.T) {
+	tests := []struct {
+		src       string
+		requested string
+		want      string
+		wantErr   bool
+	}{
+		{"./birdwatcher_v1.2.0_linux_amd64.tar.gz", "", "v1.2.0", false},
+		{"milvus-backup_0.5.9_Linux_x86_64.tar.gz", "latest", "v0.5.9", false},
+		{"/tmp/birdwatcher-v1.3.0-rc.1.tgz", "", "v1.3.0-rc.1", false},
+		{"birdwatcher_v1.2.0.tar.gz", "1.1.0", "v1.1.0", false},
+		{"birdwatcher", "", "", true},
+		{"birdwatcher.tar.gz", "^1.2", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			got, err := localVersion(tt.src, tt.requested)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("localVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("localVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstallFrom(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("components are only supported on linux and darwin")
+	}
+
+	script := "#!/bin/sh\necho birdwatcher v1.2.0\n"
+	srcDir := t.TempDir()
+
+	archive := filepath.Join(srcDir, "birdwatcher_v1.2.0_linux_amd64.tar.gz")
+	buf := buildTarGz(t, []tarEntry{
+		{header: &tar.Header{Name: "birdwatcher", Typeflag: tar.TypeReg, Mode: 0755, Size: int64(len(script))}, content: script},
+	})
+	if err := os.WriteFile(archive, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	binary := filepath.Join(srcDir, "birdwatcher-linux")
+	if err := os.WriteFile(binary, []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	release := filepath.Join(srcDir, "release")
+	if err := os.MkdirAll(release, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(release, "birdwatcher"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	empty := filepath.Join(srcDir, "empty")
+	if err := os.MkdirAll(empty, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		from    string
+		version string
+		want    string
+		wantErr bool
+	}{
+		{"archive", archive, "", "v1.2.0", false},
+		{"binary", binary, "v1.1.0", "v1.1.0", false},
+		{"directory", release, "1.0.0", "v1.0.0", false},
+		{"no version", binary, "", "", true},
+		{"no binary", empty, "v1.0.0", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewManager(localdata.NewProfile(t.TempDir()))
+			err := mgr.Install(context.Background(), "birdwatcher", tt.version, InstallOptions{From: tt.from})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Install() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			meta, err := LoadMeta(filepath.Join(mgr.ComponentDir("birdwatcher"), MetaFileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			installed := meta.Versions[tt.want]
+			if installed == nil {
+				t.Fatalf("versions = %v, want %s", meta.Versions, tt.want)
+			}
+			if installed.AssetName != filepath.Base(tt.from) {
+				t.Errorf("AssetName = %s, want %s", installed.AssetName, filepath.Base(tt.from))
+			}
+			if _, err := os.Stat(mgr.BinaryPath("birdwatcher", tt.want)); err != nil {
+				t.Errorf("binary missing: %v", err)
+			}
+		})
+	}
+}
+
+func TestReinstallUnrunnableKeepsVersion(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("components are only supported on linux and darwin")
+	}
+
+	srcDir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(srcDir, name)
+		if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	working := write("working", "#!/bin/sh\necho birdwatcher v1.2.0\n")
+	// Not a valid executable, like a binary for another architecture
+	broken := write("broken", "\x7fELF garbage")
+
+	ctx := context.Background()
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+	if err := mgr.Install(ctx, "birdwatcher", "v1.2.0", InstallOptions{From: working}); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if err := mgr.Install(ctx, "birdwatcher", "v1.2.0", InstallOptions{From: broken}); !errors.Is(err, ErrBinaryNotRunnable) {
+		t.Fatalf("reinstall error = %v, want ErrBinaryNotRunnable", err)
+	}
+
+	data, err := os.ReadFile(mgr.BinaryPath("birdwatcher", "v1.2.0"))
+	if err != nil {
+		t.Fatalf("installed binary missing: %v", err)
+	}
+	if string(data) != "#!/bin/sh\necho birdwatcher v1.2.0\n" {
+		t.Errorf("installed binary = %q, want the working one kept", data)
+	}
+	entries, err := os.ReadDir(mgr.ComponentDir("birdwatcher"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if strings.Contains(e.Name(), ".tmp-") || strings.HasSuffix(e.Name(), ".bak") {
+			t.Errorf("left %s behind", e.Name())
+		}
+	}
+}
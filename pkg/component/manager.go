@@ -2,22 +2,27 @@ package component
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/mmga-lab/miup/pkg/localdata"
 	"github.com/mmga-lab/miup/pkg/logger"
+	"github.com/mmga-lab/miup/pkg/timing"
+	"github.com/mmga-lab/miup/pkg/version"
 )
 
 // Manager manages component installation and execution
 type Manager struct {
 	profile    *localdata.Profile
 	downloader *Downloader
+	cache      *Cache
 }
 
 // NewManager creates a new component manager
@@ -25,11 +30,40 @@ func NewManager(profile *localdata.Profile) *Manager {
 	return &Manager{
 		profile:    profile,
 		downloader: NewDownloader(),
+		cache:      NewCache(profile.CacheDir()),
 	}
 }
 
+// WithDownloadTimeout sets how long downloads may go without receiving
+// data before failing (see Downloader.WithTimeout)
+func (m *Manager) WithDownloadTimeout(timeout time.Duration) *Manager {
+	m.downloader.WithTimeout(timeout)
+	return m
+}
+
+// InstallOptions defines options for installing a component
+type InstallOptions struct {
+	// NoCache bypasses the download cache and always fetches from GitHub
+	NoCache bool
+
+	// From installs from a local release archive, binary or extracted
+	// release directory instead of GitHub, for air-gapped machines. The
+	// version is inferred from its file name unless one is requested.
+	From string
+
+	// Ref installs the latest build of a branch or commit, from a nightly
+	// pre-release or a CI artifact, instead of a release. The version must
+	// be empty; the build is installed as the pre-release tag, or as the
+	// ref and commit of the CI run (e.g. main-1a2b3c4).
+	Ref string
+
+	// ConfirmHook approves running a pre/post-install hook from the user
+	// components file. Hooks are skipped if it is nil or returns false.
+	ConfirmHook func(hook Hook) bool
+}
+
 // Install installs a component at the specified version
-func (m *Manager) Install(ctx context.Context, name, version string) error {
+func (m *Manager) Install(ctx context.Context, name, version string, opts InstallOptions) error {
 	// Look up component in registry
 	compDef, ok := Registry[name]
 	if !ok {
@@ -41,12 +75,38 @@ func (m *Manager) Install(ctx context.Context, name, version string) error {
 		return fmt.Errorf("component %s does not support %s/%s", name, runtime.GOOS, runtime.GOARCH)
 	}
 
+	if opts.Ref != "" && (opts.From != "" || (version != "" && version != "latest")) {
+		return fmt.Errorf("%s@%s names a build; it can't be combined with a version or --from", name, opts.Ref)
+	}
+
 	// Get release info
 	var release *GitHubRelease
 	var err error
-	if version == "" || version == "latest" {
+	from := opts.From
+	noCache := opts.NoCache
+	var build *refBuild
+	endResolve := timing.Start(ctx, "resolve release")
+	if opts.Ref != "" {
+		if build, err = m.resolveRef(ctx, compDef, opts.Ref); err == nil {
+			release = build.release
+			if build.artifact != nil {
+				release = &GitHubRelease{TagName: build.version}
+			}
+			// A nightly tag is moved to each new build, so its cached
+			// asset can't be trusted
+			noCache = true
+		}
+	} else if opts.From != "" {
+		version, err = localVersion(opts.From, version)
+		if err != nil {
+			return err
+		}
+		release = &GitHubRelease{TagName: version}
+	} else if version == "" || version == "latest" {
 		logger.Info("Fetching latest release for %s...", name)
 		release, err = m.downloader.GetLatestRelease(ctx, compDef.Repo)
+	} else if isVersionRange(version) {
+		release, err = m.resolveConstraint(ctx, compDef, version)
 	} else {
 		// Normalize version
 		if !strings.HasPrefix(version, "v") {
@@ -55,11 +115,27 @@ func (m *Manager) Install(ctx context.Context, name, version string) error {
 		logger.Info("Fetching release %s for %s...", version, name)
 		release, err = m.downloader.GetRelease(ctx, compDef.Repo, version)
 	}
+	endResolve()
 	if err != nil {
 		return fmt.Errorf("failed to get release: %w", err)
 	}
 
+	// A CI artifact is installed like a local file once downloaded
+	if build != nil && build.artifact != nil {
+		endDownload := timing.Start(ctx, "download")
+		src, cleanup, err := m.fetchArtifact(ctx, build.artifact)
+		endDownload()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		from = src
+	}
+
 	version = release.TagName
+	if err := m.runUserHook(ctx, HookPreInstall, name, version, opts.ConfirmHook); err != nil {
+		return err
+	}
 	logger.Info("Installing %s %s...", name, version)
 
 	// Check if already installed
@@ -71,9 +147,11 @@ func (m *Manager) Install(ctx context.Context, name, version string) error {
 	}
 
 	// Find matching asset
-	asset, err := FindAsset(release, compDef.AssetName)
-	if err != nil {
-		return err
+	asset := &Asset{Name: filepath.Base(from)}
+	if from == "" {
+		if asset, err = FindAsset(release, compDef.AssetName); err != nil {
+			return err
+		}
 	}
 
 	// Download and extract
@@ -87,43 +165,64 @@ func (m *Manager) Install(ctx context.Context, name, version string) error {
 		}
 		downloadDir = tempDir
 	}
-	if err := m.downloader.DownloadAsset(ctx, asset, downloadDir); err != nil {
-		if tempDir != "" {
-			if rmErr := os.RemoveAll(tempDir); rmErr != nil {
-				logger.Warn("Failed to cleanup temp dir: %v", rmErr)
-			}
-		} else {
-			if rmErr := os.RemoveAll(versionDir); rmErr != nil {
-				logger.Warn("Failed to cleanup version dir: %v", rmErr)
-			}
+	if from != "" {
+		endExtract := timing.Start(ctx, "extract")
+		err = installLocal(compDef, from, downloadDir)
+		endExtract()
+	} else {
+		err = m.downloadAsset(ctx, compDef.Repo, version, asset, downloadDir, noCache)
+	}
+	// On failure the download is removed and a reinstalled version is left
+	// as it was
+	cleanupDownload := func() {
+		if rmErr := os.RemoveAll(downloadDir); rmErr != nil {
+			logger.Warn("Failed to cleanup %s: %v", downloadDir, rmErr)
 		}
+	}
+	if err != nil {
+		cleanupDownload()
 		return fmt.Errorf("failed to download: %w", err)
 	}
+
+	// Make binary executable
+	binaryPath := m.BinaryPath(name, version)
+	downloadedBinary := filepath.Join(downloadDir, compDef.Binary)
+	if err := os.Chmod(downloadedBinary, 0755); err != nil {
+		cleanupDownload()
+		return fmt.Errorf("failed to set executable permission: %w", err)
+	}
+
+	// Smoke test the binary so an arch mismatch or corrupt download shows up
+	// now rather than at the first run, and before it replaces a working
+	// version on reinstall
+	endVerify := timing.Start(ctx, "verify")
+	line, err := verifyBinary(ctx, downloadedBinary, compDef.verifyArgs())
+	endVerify()
+	switch {
+	case errors.Is(err, ErrBinaryNotRunnable):
+		cleanupDownload()
+		return fmt.Errorf("installed %s %s is not usable on %s/%s: %w", name, version, runtime.GOOS, runtime.GOARCH, err)
+	case err != nil:
+		logger.Warn("Could not verify %s %s: %v", name, version, err)
+	default:
+		logger.Debug("Verified %s: %s", name, line)
+	}
+
 	if existing {
 		backupDir := versionDir + ".bak"
 		_ = os.RemoveAll(backupDir) // Ignore old backup removal
 		if err := os.Rename(versionDir, backupDir); err != nil {
-			if rmErr := os.RemoveAll(tempDir); rmErr != nil {
-				logger.Warn("Failed to cleanup temp dir: %v", rmErr)
-			}
+			cleanupDownload()
 			return fmt.Errorf("failed to backup existing version: %w", err)
 		}
 		if err := os.Rename(tempDir, versionDir); err != nil {
 			_ = os.Rename(backupDir, versionDir) // Best effort restore
-			if rmErr := os.RemoveAll(tempDir); rmErr != nil {
-				logger.Warn("Failed to cleanup temp dir: %v", rmErr)
-			}
+			cleanupDownload()
 			return fmt.Errorf("failed to replace existing version: %w", err)
 		}
 		_ = os.RemoveAll(backupDir) // Cleanup is best-effort
 	}
 
-	// Make binary executable
-	binaryPath := m.BinaryPath(name, version)
-	if err := os.Chmod(binaryPath, 0755); err != nil {
-		return fmt.Errorf("failed to set executable permission: %w", err)
-	}
-
 	// Update metadata
 	if err := m.updateMeta(name, version, asset.Name); err != nil {
 		return fmt.Errorf("failed to update metadata: %w", err)
@@ -132,7 +231,104 @@ func (m *Manager) Install(ctx context.Context, name, version string) error {
 	logger.Success("Installed %s %s", name, version)
 	logger.Info("Binary: %s", binaryPath)
 
-	return nil
+	// The version stays installed if post-install setup fails, so the setup
+	// can be fixed and re-run by reinstalling
+	if compDef.PostInstall != nil {
+		endSetup := timing.Start(ctx, "post-install setup")
+		err := compDef.PostInstall(ctx, versionDir)
+		endSetup()
+		if err != nil {
+			return fmt.Errorf("post-install setup of %s %s failed: %w", name, version, err)
+		}
+	}
+	return m.runUserHook(ctx, HookPostInstall, name, version, opts.ConfirmHook)
+}
+
+// isVersionRange reports whether a requested version is a semver range
+// rather than a release tag
+func isVersionRange(v string) bool {
+	return version.IsConstraint(v)
+}
+
+// resolveConstraint returns the newest release matching a semver range
+// such as ^1.2 or >=1.2,<2
+func (m *Manager) resolveConstraint(ctx context.Context, compDef *ComponentDef, constraint string) (*GitHubRelease, error) {
+	c, err := version.ParseConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Resolving %s %s...", compDef.Name, constraint)
+	releases, err := m.downloader.ListReleases(ctx, compDef.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	release := LatestMatching(releases, c)
+	if release == nil {
+		return nil, fmt.Errorf("no release of %s matches %s", compDef.Name, constraint)
+	}
+	logger.Info("Resolved %s %s to %s", compDef.Name, constraint, release.TagName)
+	return release, nil
+}
+
+// LatestMatching returns the newest published release whose tag satisfies
+// the constraint, or nil if none does. Drafts and pre-releases are skipped.
+func LatestMatching(releases []GitHubRelease, c *version.Constraint) *GitHubRelease {
+	var best *GitHubRelease
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft || r.Prerelease || !c.Check(r.TagName) {
+			continue
+		}
+		if best == nil {
+			best = r
+			continue
+		}
+		if cmp, _ := version.CompareSemver(r.TagName, best.TagName); cmp > 0 {
+			best = r
+		}
+	}
+	return best
+}
+
+// downloadAsset extracts an asset into destDir, reusing the download cache
+// unless noCache is set
+func (m *Manager) downloadAsset(ctx context.Context, repo, tag string, asset *Asset, destDir string, noCache bool) error {
+	if noCache {
+		// Extracted while downloading, so the two can't be told apart
+		defer timing.Start(ctx, "download and extract")()
+		return m.downloader.DownloadAsset(ctx, asset, destDir)
+	}
+
+	path, ok := m.cache.Lookup(repo, tag, asset)
+	if ok {
+		logger.Info("Using cached %s", asset.Name)
+	} else {
+		endDownload := timing.Start(ctx, "download")
+		path = m.cache.AssetPath(repo, tag, asset)
+		err := m.downloader.FetchAsset(ctx, asset, path)
+		if err == nil {
+			err = m.cache.Store(repo, tag, asset)
+		}
+		endDownload()
+		if err != nil {
+			return err
+		}
+	}
+
+	defer timing.Start(ctx, "extract")()
+	return ExtractFile(asset.Name, path, destDir)
+}
+
+// CleanCache removes all cached downloads
+func (m *Manager) CleanCache() error {
+	return m.cache.Clean()
+}
+
+// CacheDir returns the download cache directory
+func (m *Manager) CacheDir() string {
+	return m.cache.Root()
 }
 
 // Uninstall removes a component version
@@ -151,42 +347,191 @@ func (m *Manager) Uninstall(ctx context.Context, name, version string) error {
 		}
 		logger.Success("Uninstalled all versions of %s", name)
 	} else {
-		// Normalize version
-		if !strings.HasPrefix(version, "v") {
-			version = "v" + version
-		}
+		version = m.installedVersion(name, version)
 		versionDir := m.VersionDir(name, version)
 		if _, err := os.Stat(versionDir); os.IsNotExist(err) {
 			return fmt.Errorf("version %s of %s is not installed", version, name)
 		}
-		if err := os.RemoveAll(versionDir); err != nil {
-			return fmt.Errorf("failed to remove version: %w", err)
+		if err := m.removeVersions(name, []string{version}); err != nil {
+			return err
 		}
+		logger.Success("Uninstalled %s %s", name, version)
+	}
+	return nil
+}
 
-		// Update metadata
-		metaPath := filepath.Join(compDir, MetaFileName)
-		meta, _ := LoadMeta(metaPath)
-		if meta != nil {
-			delete(meta.Versions, version)
-			if meta.Active == version {
-				// Set new active version
-				meta.Active = ""
-				for v := range meta.Versions {
-					meta.Active = v
-					break
-				}
-			}
-			meta.UpdatedAt = time.Now()
-			if err := SaveMeta(meta, metaPath); err != nil {
-				logger.Warn("Failed to update metadata: %v", err)
+// UninstallOptions selects which installed versions of a component to remove
+type UninstallOptions struct {
+	// KeepActive removes every version except the active one
+	KeepActive bool
+
+	// OlderThan removes versions older than the given version
+	OlderThan string
+}
+
+// UninstallVersions removes the versions of a component selected by opts
+func (m *Manager) UninstallVersions(ctx context.Context, name string, opts UninstallOptions) error {
+	meta, err := LoadMeta(filepath.Join(m.ComponentDir(name), MetaFileName))
+	if err != nil {
+		return err
+	}
+	if meta == nil {
+		return fmt.Errorf("component %s is not installed", name)
+	}
+
+	versions, err := SelectVersions(meta, opts)
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		logger.Info("No versions of %s to remove", name)
+		return nil
+	}
+
+	if err := m.removeVersions(name, versions); err != nil {
+		return err
+	}
+	logger.Success("Uninstalled %s %s", name, strings.Join(versions, ", "))
+	return nil
+}
+
+// UninstallAll removes every installed component
+func (m *Manager) UninstallAll(ctx context.Context) error {
+	components, err := m.List(ctx)
+	if err != nil {
+		return err
+	}
+	if len(components) == 0 {
+		logger.Info("No components installed")
+		return nil
+	}
+
+	for _, meta := range components {
+		if err := os.RemoveAll(m.ComponentDir(meta.Name)); err != nil {
+			return fmt.Errorf("failed to remove component %s: %w", meta.Name, err)
+		}
+		logger.Success("Uninstalled all versions of %s", meta.Name)
+	}
+	return nil
+}
+
+// SelectVersions returns the installed versions matched by opts, sorted.
+// Versions that cannot be compared with OlderThan are kept.
+func SelectVersions(meta *ComponentMeta, opts UninstallOptions) ([]string, error) {
+	olderThan := opts.OlderThan
+	if olderThan != "" {
+		if !strings.HasPrefix(olderThan, "v") {
+			olderThan = "v" + olderThan
+		}
+		if _, _, _, ok := version.ParseSemver(olderThan); !ok {
+			return nil, fmt.Errorf("invalid version: %s", opts.OlderThan)
+		}
+	}
+
+	var selected []string
+	for v := range meta.Versions {
+		if opts.KeepActive && v == meta.Active {
+			continue
+		}
+		if olderThan != "" {
+			if cmp, ok := version.CompareSemver(v, olderThan); !ok || cmp >= 0 {
+				continue
 			}
 		}
+		selected = append(selected, v)
+	}
+	sort.Strings(selected)
+	return selected, nil
+}
 
-		logger.Success("Uninstalled %s %s", name, version)
+// removeVersions deletes version directories and updates the metadata,
+// choosing a new active version if the active one was removed
+func (m *Manager) removeVersions(name string, versions []string) error {
+	for _, v := range versions {
+		if err := os.RemoveAll(m.VersionDir(name, v)); err != nil {
+			return fmt.Errorf("failed to remove version: %w", err)
+		}
+	}
+
+	metaPath := filepath.Join(m.ComponentDir(name), MetaFileName)
+	meta, _ := LoadMeta(metaPath)
+	if meta == nil {
+		return nil
+	}
+
+	for _, v := range versions {
+		delete(meta.Versions, v)
+	}
+	if _, ok := meta.Versions[meta.Active]; !ok {
+		// Set new active version
+		meta.Active = ""
+		for v := range meta.Versions {
+			meta.Active = v
+			break
+		}
+	}
+	meta.UpdatedAt = time.Now()
+	if err := SaveMeta(meta, metaPath); err != nil {
+		logger.Warn("Failed to update metadata: %v", err)
+	}
+	return nil
+}
+
+// Activate sets the version used by Run when no version is specified
+func (m *Manager) Activate(ctx context.Context, name, version string) error {
+	version = m.installedVersion(name, version)
+
+	metaPath := filepath.Join(m.ComponentDir(name), MetaFileName)
+	meta, err := LoadMeta(metaPath)
+	if err != nil {
+		return err
+	}
+	if meta == nil {
+		return fmt.Errorf("component %s is not installed", name)
+	}
+	if _, ok := meta.Versions[version]; !ok {
+		return fmt.Errorf("version %s of %s is not installed", version, name)
+	}
+	if _, err := os.Stat(m.BinaryPath(name, version)); err != nil {
+		return fmt.Errorf("binary for %s %s not found, try reinstalling: %w", name, version, err)
+	}
+
+	if meta.Active == version {
+		logger.Info("%s %s is already active", name, version)
+		return nil
 	}
+
+	meta.Active = version
+	meta.UpdatedAt = time.Now()
+	if err := SaveMeta(meta, metaPath); err != nil {
+		return err
+	}
+
+	logger.Success("Activated %s %s", name, version)
 	return nil
 }
 
+// SortedVersions returns the installed versions of a component in ascending
+// semantic version order; non-semver versions sort last by name
+func SortedVersions(meta *ComponentMeta) []string {
+	versions := make([]string, 0, len(meta.Versions))
+	for v := range meta.Versions {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if cmp, ok := version.CompareSemver(versions[i], versions[j]); ok && cmp != 0 {
+			return cmp < 0
+		}
+		_, _, _, iok := version.ParseSemver(versions[i])
+		_, _, _, jok := version.ParseSemver(versions[j])
+		if iok != jok {
+			return iok
+		}
+		return versions[i] < versions[j]
+	})
+	return versions
+}
+
 // List returns all installed components
 func (m *Manager) List(ctx context.Context) ([]*ComponentMeta, error) {
 	componentsDir := m.profile.ComponentsDir()
@@ -213,13 +558,94 @@ func (m *Manager) List(ctx context.Context) ([]*ComponentMeta, error) {
 	return components, nil
 }
 
-// Run executes an installed component
-func (m *Manager) Run(ctx context.Context, name, version string, args []string) error {
+// RefreshPaths rewrites the binary paths recorded in component metadata to
+// point into the current profile, e.g. after the profile has been relocated
+func (m *Manager) RefreshPaths(ctx context.Context) error {
+	components, err := m.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, meta := range components {
+		for v, info := range meta.Versions {
+			info.BinaryPath = m.BinaryPath(meta.Name, v)
+		}
+		if err := SaveMeta(meta, filepath.Join(m.ComponentDir(meta.Name), MetaFileName)); err != nil {
+			return fmt.Errorf("failed to update metadata for %s: %w", meta.Name, err)
+		}
+	}
+	return nil
+}
+
+// DirSize returns the total size in bytes of the regular files under path
+func DirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// LatestVersions queries the latest release tag for each named component.
+// Components whose release lookup fails are omitted with a warning.
+func (m *Manager) LatestVersions(ctx context.Context, names []string) map[string]string {
+	latest := make(map[string]string, len(names))
+	for _, name := range names {
+		compDef, ok := Registry[name]
+		if !ok {
+			continue
+		}
+		release, err := m.downloader.GetLatestRelease(ctx, compDef.Repo)
+		if err != nil {
+			logger.Warn("Failed to check updates for %s: %v", name, err)
+			continue
+		}
+		latest[name] = release.TagName
+	}
+	return latest
+}
+
+// HasUpdate reports whether latest is a newer version than current
+func HasUpdate(current, latest string) bool {
+	if latest == "" {
+		return false
+	}
+	cmp, ok := version.CompareSemver(current, latest)
+	if !ok {
+		return current != latest
+	}
+	return cmp < 0
+}
+
+// Run executes an installed component. SIGINT and SIGTERM are passed on to
+// the component, which is killed only if it doesn't exit within
+// RunGracePeriod; cancelling ctx sends it SIGTERM.
+func (m *Manager) Run(ctx context.Context, name, version string, args []string, opts RunOptions) error {
 	// Look up component
 	if _, ok := Registry[name]; !ok {
 		return fmt.Errorf("unknown component: %s", name)
 	}
 
+	var ready *Readiness
+	var address string
+	if opts.WaitReady {
+		var err error
+		if ready, address, err = readiness(name, args); err != nil {
+			return err
+		}
+	}
+
 	if version == "" {
 		// Find active/latest version
 		meta, err := LoadMeta(filepath.Join(m.ComponentDir(name), MetaFileName))
@@ -234,10 +660,7 @@ func (m *Manager) Run(ctx context.Context, name, version string, args []string)
 			return fmt.Errorf("no active version for %s", name)
 		}
 	} else {
-		// Normalize version
-		if !strings.HasPrefix(version, "v") {
-			version = "v" + version
-		}
+		version = m.installedVersion(name, version)
 	}
 
 	binaryPath := m.BinaryPath(name, version)
@@ -245,11 +668,75 @@ func (m *Manager) Run(ctx context.Context, name, version string, args []string)
 		return fmt.Errorf("binary not found: %s (is %s %s installed?)", binaryPath, name, version)
 	}
 
-	cmd := exec.CommandContext(ctx, binaryPath, args...)
+	cmd := exec.Command(binaryPath, args...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	if ready == nil {
+		return runForwardingSignals(ctx, cmd, RunGracePeriod)
+	}
+
+	// Probe while the component runs; stop it if it never serves
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	timeout := opts.ReadyTimeout
+	if timeout == 0 {
+		timeout = DefaultReadyTimeout
+	}
+	probeErr := make(chan error, 1)
+	go func() {
+		waitCtx, cancel := context.WithTimeout(runCtx, timeout)
+		defer cancel()
+		if err := ready.waitReady(waitCtx, address); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				probeErr <- fmt.Errorf("%w: %s did not serve on %s within %s", ErrNotReady, name, address, timeout)
+				stop()
+			}
+			return
+		}
+		logger.Success("%s is ready on %s", name, address)
+		logger.Emit(logger.Event{
+			Type:    logger.EventComponentReady,
+			Message: fmt.Sprintf("%s is ready", name),
+			Fields:  map[string]string{"component": name, "address": address},
+		})
+	}()
+
+	err := runForwardingSignals(runCtx, cmd, RunGracePeriod)
+	stop()
+	select {
+	case notReady := <-probeErr:
+		return notReady
+	default:
+		return err
+	}
+}
+
+// Verify checks that an installed version exists and runs its smoke test
+// (see Install). It returns the first line of the binary's output.
+func (m *Manager) Verify(ctx context.Context, name, version string) (string, error) {
+	compDef, ok := Registry[name]
+	if !ok {
+		return "", fmt.Errorf("unknown component: %s", name)
+	}
+	binaryPath := m.BinaryPath(name, version)
+	if _, err := os.Stat(binaryPath); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrBinaryNotRunnable, err)
+	}
+	return verifyBinary(ctx, binaryPath, compDef.verifyArgs())
+}
+
+// installedVersion normalizes a version given by the user: release versions
+// may omit their "v" prefix, while builds of a branch or commit (see
+// InstallOptions.Ref) have none and are taken as installed
+func (m *Manager) installedVersion(name, version string) string {
+	if strings.HasPrefix(version, "v") {
+		return version
+	}
+	if _, err := os.Stat(m.VersionDir(name, version)); err == nil {
+		return version
+	}
+	return "v" + version
 }
 
 // ComponentDir returns the directory for a component
@@ -0,0 +1,280 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/localdata"
+	"github.com/mmga-lab/miup/pkg/version"
+)
+
+func TestHasUpdate(t *testing.T) {
+	tests := []struct {
+		current string
+		latest  string
+		want    bool
+	}{
+		{"v1.0.0", "v1.1.0", true},
+		{"v1.1.0", "v1.1.0", false},
+		{"v1.2.0", "v1.1.0", false},
+		{"v1.0.0", "", false},
+		{"nightly", "v1.1.0", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+"->"+tt.latest, func(t *testing.T) {
+			if got := HasUpdate(tt.current, tt.latest); got != tt.want {
+				t.Errorf("HasUpdate(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectVersions(t *testing.T) {
+	meta := &ComponentMeta{
+		Name: "birdwatcher",
+		Versions: map[string]*InstalledVersion{
+			"v1.0.0": {}, "v1.0.5": {}, "v1.1.0": {}, "nightly": {},
+		},
+		Active: "v1.0.5",
+	}
+
+	tests := []struct {
+		name string
+		opts UninstallOptions
+		want []string
+	}{
+		{"keep active", UninstallOptions{KeepActive: true}, []string{"nightly", "v1.0.0", "v1.1.0"}},
+		{"older than", UninstallOptions{OlderThan: "v1.1.0"}, []string{"v1.0.0", "v1.0.5"}},
+		{"older than without prefix", UninstallOptions{OlderThan: "1.0.5"}, []string{"v1.0.0"}},
+		{"older than keep active", UninstallOptions{OlderThan: "v1.1.0", KeepActive: true}, []string{"v1.0.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectVersions(meta, tt.opts)
+			if err != nil {
+				t.Fatalf("SelectVersions() error = %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("SelectVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := SelectVersions(meta, UninstallOptions{OlderThan: "latest"}); err == nil {
+		t.Error("SelectVersions() should reject an invalid version")
+	}
+}
+
+func TestUninstallVersions(t *testing.T) {
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+	for _, v := range []string{"v1.0.0", "v1.1.0"} {
+		if err := os.MkdirAll(mgr.VersionDir("birdwatcher", v), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := mgr.updateMeta("birdwatcher", v, "asset"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := mgr.UninstallVersions(context.Background(), "birdwatcher", UninstallOptions{KeepActive: true}); err != nil {
+		t.Fatalf("UninstallVersions() error = %v", err)
+	}
+
+	if _, err := os.Stat(mgr.VersionDir("birdwatcher", "v1.0.0")); !os.IsNotExist(err) {
+		t.Error("v1.0.0 should have been removed")
+	}
+	meta, err := LoadMeta(filepath.Join(mgr.ComponentDir("birdwatcher"), MetaFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.Versions) != 1 || meta.Active != "v1.1.0" {
+		t.Errorf("meta = %+v, want only active v1.1.0", meta)
+	}
+}
+
+func TestActivate(t *testing.T) {
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+	for _, v := range []string{"v1.0.0", "v1.1.0"} {
+		binary := mgr.BinaryPath("birdwatcher", v)
+		if err := os.MkdirAll(filepath.Dir(binary), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(binary, nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := mgr.updateMeta("birdwatcher", v, "asset"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := mgr.Activate(context.Background(), "birdwatcher", "1.0.0"); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	meta, err := LoadMeta(filepath.Join(mgr.ComponentDir("birdwatcher"), MetaFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Active != "v1.0.0" {
+		t.Errorf("Active = %s, want v1.0.0", meta.Active)
+	}
+
+	if err := mgr.Activate(context.Background(), "birdwatcher", "v2.0.0"); err == nil {
+		t.Error("Activate() should fail for a version that is not installed")
+	}
+	if err := mgr.Activate(context.Background(), "milvus-backup", "v1.0.0"); err == nil {
+		t.Error("Activate() should fail for a component that is not installed")
+	}
+}
+
+func TestSortedVersions(t *testing.T) {
+	meta := &ComponentMeta{
+		Versions: map[string]*InstalledVersion{
+			"v1.10.0": {}, "v1.2.0": {}, "nightly": {}, "v1.9.1": {}, "v1.2.0-rc1": {},
+		},
+	}
+
+	got := strings.Join(SortedVersions(meta), ",")
+	want := "v1.2.0-rc1,v1.2.0,v1.9.1,v1.10.0,nightly"
+	if got != want {
+		t.Errorf("SortedVersions() = %s, want %s", got, want)
+	}
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 50), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("a", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := DirSize(dir)
+	if err != nil {
+		t.Fatalf("DirSize() error = %v", err)
+	}
+	if size != 150 {
+		t.Errorf("DirSize() = %d, want 150", size)
+	}
+}
+
+func TestRefreshPaths(t *testing.T) {
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+	if err := os.MkdirAll(mgr.VersionDir("birdwatcher", "v1.0.0"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	metaPath := filepath.Join(mgr.ComponentDir("birdwatcher"), MetaFileName)
+	meta := &ComponentMeta{
+		Name: "birdwatcher",
+		Versions: map[string]*InstalledVersion{
+			"v1.0.0": {Version: "v1.0.0", BinaryPath: "/old/home/components/birdwatcher/v1.0.0/birdwatcher"},
+		},
+		Active: "v1.0.0",
+	}
+	if err := SaveMeta(meta, metaPath); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mgr.RefreshPaths(context.Background()); err != nil {
+		t.Fatalf("RefreshPaths() error = %v", err)
+	}
+
+	meta, err := LoadMeta(metaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := meta.Versions["v1.0.0"].BinaryPath, mgr.BinaryPath("birdwatcher", "v1.0.0"); got != want {
+		t.Errorf("BinaryPath = %s, want %s", got, want)
+	}
+}
+
+func TestVerifyBinary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not executable on windows")
+	}
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	ok := write("ok", "#!/bin/sh\necho \"tool v1.2.3\"\necho more\n")
+	line, err := verifyBinary(context.Background(), ok, []string{"--version"})
+	if err != nil {
+		t.Fatalf("verifyBinary() error = %v", err)
+	}
+	if line != "tool v1.2.3" {
+		t.Errorf("line = %q, want %q", line, "tool v1.2.3")
+	}
+
+	// Starts but fails: an error, but not ErrBinaryNotRunnable
+	failing := write("failing", "#!/bin/sh\nexit 2\n")
+	_, err = verifyBinary(context.Background(), failing, []string{"--version"})
+	if err == nil || errors.Is(err, ErrBinaryNotRunnable) {
+		t.Errorf("verifyBinary() error = %v, want an exit error", err)
+	}
+
+	// Not a valid executable, like a binary for another architecture
+	garbage := write("garbage", "\x7fELF garbage")
+	_, err = verifyBinary(context.Background(), garbage, []string{"--version"})
+	if !errors.Is(err, ErrBinaryNotRunnable) {
+		t.Errorf("verifyBinary() error = %v, want ErrBinaryNotRunnable", err)
+	}
+}
+
+func TestLatestMatching(t *testing.T) {
+	releases := []GitHubRelease{
+		{TagName: "v2.0.0"},
+		{TagName: "v1.3.0", Prerelease: true},
+		{TagName: "v1.2.10"},
+		{TagName: "v1.2.9"},
+		{TagName: "v1.4.0", Draft: true},
+		{TagName: "v1.1.0"},
+		{TagName: "nightly"},
+	}
+
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"^1.2", "v1.2.10"},
+		{"~1.1", "v1.1.0"},
+		{">=1.2,<2", "v1.2.10"},
+		{"*", "v2.0.0"},
+		{"^3", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := version.ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := LatestMatching(releases, c)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("LatestMatching() = %s, want none", got.TagName)
+				}
+				return
+			}
+			if got == nil || got.TagName != tt.want {
+				t.Errorf("LatestMatching() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@ package component
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"time"
+
+	"github.com/mmga-lab/miup/pkg/localdata"
 )
 
 // InstalledVersion represents an installed version of a component
@@ -32,24 +35,34 @@ func SaveMeta(meta *ComponentMeta, path string) error {
 	if err != nil {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := localdata.WriteFileWithBackup(path, data, 0644, validMeta); err != nil {
 		return fmt.Errorf("failed to write metadata: %w", err)
 	}
 	return nil
 }
 
-// LoadMeta loads component metadata from the specified path
+// LoadMeta loads component metadata from the specified path, falling back
+// to the copy kept by the previous save. It returns nil if neither exists.
 func LoadMeta(path string) (*ComponentMeta, error) {
-	data, err := os.ReadFile(path)
+	var meta ComponentMeta
+	_, err := localdata.ReadFileWithBackup(path, func(data []byte) error {
+		meta = ComponentMeta{}
+		return json.Unmarshal(data, &meta)
+	})
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("failed to read metadata: %w", err)
-	}
-	var meta ComponentMeta
-	if err := json.Unmarshal(data, &meta); err != nil {
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) {
+			return nil, fmt.Errorf("failed to read metadata: %w", err)
+		}
 		return nil, fmt.Errorf("failed to parse metadata: %w", err)
 	}
 	return &meta, nil
 }
+
+func validMeta(data []byte) error {
+	var meta ComponentMeta
+	return json.Unmarshal(data, &meta)
+}
@@ -0,0 +1,67 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// RunGracePeriod is how long Run waits for a component to exit after
+// passing on a signal before killing it
+const RunGracePeriod = 10 * time.Second
+
+// runForwardingSignals runs cmd in its own process group and passes SIGINT
+// and SIGTERM received by miup on to the whole group, so that a component
+// (and anything it started) can clean up instead of being killed mid
+// operation. Cancelling ctx sends SIGTERM. The group is killed only if it
+// hasn't exited grace after the first signal.
+//
+// When stdin is a terminal the group also becomes the terminal's foreground
+// group, so interactive components keep reading input and receive Ctrl-C
+// from the terminal directly.
+func runForwardingSignals(ctx context.Context, cmd *exec.Cmd, grace time.Duration) error {
+	restore := setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	defer restore()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var deadline <-chan time.Time
+	startGrace := func() {
+		if deadline == nil {
+			deadline = time.After(grace)
+		}
+	}
+
+	ctxDone := ctx.Done()
+	for {
+		select {
+		case err := <-done:
+			return err
+		case sig := <-sigCh:
+			signalGroup(cmd.Process, sig)
+			startGrace()
+		case <-ctxDone:
+			ctxDone = nil
+			signalGroup(cmd.Process, syscall.SIGTERM)
+			startGrace()
+		case <-deadline:
+			killGroup(cmd.Process)
+			<-done
+			return fmt.Errorf("%s did not exit within %s and was killed", cmd.Path, grace)
+		}
+	}
+}
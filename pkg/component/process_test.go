@@ -0,0 +1,67 @@
+//go:build !windows
+
+package component
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunForwardingSignals(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+		wantOut string
+	}{
+		{
+			name:    "exits on SIGTERM",
+			script:  `trap 'echo cleaned > "$OUT"; exit 0' TERM; echo ready > "$OUT"; while :; do sleep 0.05; done`,
+			wantOut: "cleaned",
+		},
+		{
+			name:    "killed after grace period",
+			script:  `trap '' TERM; echo ready > "$OUT"; while :; do sleep 0.05; done`,
+			wantErr: "was killed",
+			wantOut: "ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "out")
+			cmd := exec.Command("sh", "-c", tt.script)
+			cmd.Env = append(os.Environ(), "OUT="+out)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				// Cancel once the trap is installed
+				for {
+					if _, err := os.Stat(out); err == nil {
+						cancel()
+						return
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+			}()
+
+			err := runForwardingSignals(ctx, cmd, 200*time.Millisecond)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("runForwardingSignals() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("runForwardingSignals() error = %v, want %q", err, tt.wantErr)
+			}
+
+			data, _ := os.ReadFile(out)
+			if got := strings.TrimSpace(string(data)); got != tt.wantOut {
+				t.Errorf("output = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
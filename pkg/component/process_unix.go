@@ -0,0 +1,45 @@
+//go:build !windows
+
+package component
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+	"golang.org/x/term"
+)
+
+// setProcessGroup starts cmd in a new process group, in the foreground of
+// the terminal if stdin is one. The returned function takes the terminal
+// back once the command has exited.
+func setProcessGroup(cmd *exec.Cmd) func() {
+	fd := int(os.Stdin.Fd())
+	if cmd.Stdin != os.Stdin || !term.IsTerminal(fd) {
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		return func() {}
+	}
+
+	cmd.SysProcAttr = &syscall.SysProcAttr{Foreground: true, Ctty: fd}
+	return func() {
+		// Taking the terminal back from a background group raises SIGTTOU,
+		// which would stop miup
+		signal.Ignore(syscall.SIGTTOU)
+		defer signal.Reset(syscall.SIGTTOU)
+		_ = unix.IoctlSetPointerInt(fd, unix.TIOCSPGRP, unix.Getpgrp())
+	}
+}
+
+// signalGroup sends sig to the process group led by p
+func signalGroup(p *os.Process, sig os.Signal) {
+	if s, ok := sig.(syscall.Signal); ok {
+		_ = syscall.Kill(-p.Pid, s)
+	}
+}
+
+// killGroup kills the process group led by p
+func killGroup(p *os.Process) {
+	_ = syscall.Kill(-p.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+package component
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on Windows, where the console delivers Ctrl-C
+// to the component directly
+func setProcessGroup(cmd *exec.Cmd) func() {
+	return func() {}
+}
+
+// signalGroup does nothing on Windows, which can't send signals to another
+// process; the component is killed once the grace period ends
+func signalGroup(p *os.Process, sig os.Signal) {}
+
+// killGroup kills the process p
+func killGroup(p *os.Process) {
+	_ = p.Kill()
+}
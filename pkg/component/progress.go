@@ -0,0 +1,106 @@
+package component
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// progressInterval is how often non-TTY downloads report progress
+	progressInterval = 5 * time.Second
+
+	// progressStep reports progress every time another step (in percent) of
+	// the download is done, even before the interval has passed
+	progressStep = 25
+)
+
+// progressLogger is an io.Writer that counts downloaded bytes and prints a
+// progress line every progressInterval or progressStep percent. It is used
+// instead of the progress bar when stderr is not a terminal, so CI logs show
+// that the download is making progress.
+type progressLogger struct {
+	w        io.Writer
+	name     string
+	total    int64
+	interval time.Duration
+	now      func() time.Time
+
+	n        int64
+	start    time.Time
+	last     time.Time
+	lastStep int64
+	done     bool
+}
+
+func newProgressLogger(w io.Writer, name string, total int64) *progressLogger {
+	p := &progressLogger{
+		w:        w,
+		name:     name,
+		total:    total,
+		interval: progressInterval,
+		now:      time.Now,
+	}
+	p.start = p.now()
+	p.last = p.start
+	return p
+}
+
+func (p *progressLogger) Write(b []byte) (int, error) {
+	p.n += int64(len(b))
+	if p.done {
+		return len(b), nil
+	}
+
+	now := p.now()
+	report := now.Sub(p.last) >= p.interval
+	if p.total > 0 {
+		if p.n >= p.total {
+			p.done = true
+			report = true
+		}
+		if step := p.n * 100 / p.total / progressStep; step > p.lastStep {
+			p.lastStep = step
+			report = true
+		}
+	}
+	if report {
+		p.last = now
+		fmt.Fprintln(p.w, p.line(now))
+	}
+	return len(b), nil
+}
+
+// line formats the current progress, e.g.
+// "Downloading milvus.tar.gz: 30.0 MB / 120.0 MB (25%), 6.0 MB/s, ETA 15s"
+func (p *progressLogger) line(now time.Time) string {
+	var rate float64
+	if elapsed := now.Sub(p.start).Seconds(); elapsed > 0 {
+		rate = float64(p.n) / elapsed
+	}
+
+	s := fmt.Sprintf("Downloading %s: %s", p.name, formatBytes(p.n))
+	if p.total > 0 {
+		s += fmt.Sprintf(" / %s (%d%%)", formatBytes(p.total), p.n*100/p.total)
+	}
+	s += fmt.Sprintf(", %s/s", formatBytes(int64(rate)))
+	if p.total > 0 && p.n < p.total && rate > 0 {
+		eta := time.Duration(float64(p.total-p.n) / rate * float64(time.Second))
+		s += fmt.Sprintf(", ETA %s", eta.Round(time.Second))
+	}
+	return s
+}
+
+// formatBytes formats a byte count in binary units, e.g. "1.5 MB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
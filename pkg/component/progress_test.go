@@ -0,0 +1,89 @@
+package component
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressLogger(t *testing.T) {
+	var out bytes.Buffer
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := newProgressLogger(&out, "milvus.tar.gz", 100<<20)
+	p.now = func() time.Time { return now }
+	p.start = now
+	p.last = now
+
+	write := func(n int, after time.Duration) {
+		now = now.Add(after)
+		if _, err := p.Write(make([]byte, n)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	// Neither the interval nor a step passed: no output
+	write(10<<20, time.Second)
+	if out.Len() != 0 {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+
+	// The interval passed
+	write(10<<20, 4*time.Second)
+	// Crossed 25%
+	write(10<<20, time.Second)
+	// Done
+	write(70<<20, 4*time.Second)
+	// Writes after completion are silent
+	write(1, 10*time.Second)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		"Downloading milvus.tar.gz: 20.0 MB / 100.0 MB (20%), 4.0 MB/s, ETA 20s",
+		"Downloading milvus.tar.gz: 30.0 MB / 100.0 MB (30%), 5.0 MB/s, ETA 14s",
+		"Downloading milvus.tar.gz: 100.0 MB / 100.0 MB (100%), 10.0 MB/s",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), out.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestProgressLogger_UnknownSize(t *testing.T) {
+	var out bytes.Buffer
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := newProgressLogger(&out, "milvus", 0)
+	p.now = func() time.Time { return now }
+	p.start = now
+	p.last = now
+
+	now = now.Add(5 * time.Second)
+	p.Write(make([]byte, 5<<10))
+
+	want := "Downloading milvus: 5.0 KB, 1.0 KB/s\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KB"},
+		{1536 << 10, "1.5 MB"},
+		{3 << 30, "3.0 GB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotReady is returned by Run when a component started with WaitReady
+// does not serve within the timeout
+var ErrNotReady = errors.New("component not ready")
+
+// DefaultReadyTimeout is how long Run waits for a component to serve
+const DefaultReadyTimeout = time.Minute
+
+// readyInterval is the time between readiness probes
+const readyInterval = 500 * time.Millisecond
+
+// Readiness tells how to check that a server-like component is serving
+type Readiness struct {
+	// Command is the argument that starts the server, e.g. "server"; other
+	// invocations of the component are not checked
+	Command string
+
+	// Port is the port the server listens on by default
+	Port int
+
+	// PortFlags are the flags that override the port, e.g. "--port" and
+	// "-p". Their value may be a port, ":port" or "host:port".
+	PortFlags []string
+
+	// Path, if set, is probed with HTTP GET and must not return a server
+	// error; otherwise accepting a TCP connection is enough
+	Path string
+}
+
+// RunOptions contains options for running a component
+type RunOptions struct {
+	// WaitReady probes a server started by the component (see Readiness)
+	// and reports when it serves, with a message and a component_ready
+	// event. The component is stopped and Run fails with ErrNotReady if it
+	// doesn't serve within ReadyTimeout.
+	WaitReady bool
+
+	// ReadyTimeout defaults to DefaultReadyTimeout
+	ReadyTimeout time.Duration
+}
+
+// Address returns the local address the server started by args listens
+// on, or false if args don't start the server
+func (r *Readiness) Address(args []string) (string, bool) {
+	if !slices.Contains(args, r.Command) {
+		return "", false
+	}
+
+	host, port := "127.0.0.1", strconv.Itoa(r.Port)
+	for i, arg := range args {
+		var value string
+		for _, flag := range r.PortFlags {
+			if arg == flag && i+1 < len(args) {
+				value = args[i+1]
+			} else if v, ok := strings.CutPrefix(arg, flag+"="); ok {
+				value = v
+			}
+		}
+		if value == "" {
+			continue
+		}
+		if h, p, err := net.SplitHostPort(value); err == nil {
+			port = p
+			if h != "" && h != "0.0.0.0" && h != "::" {
+				host = h
+			}
+		} else {
+			port = value
+		}
+	}
+	return net.JoinHostPort(host, port), true
+}
+
+// probe checks once whether the server at address serves
+func (r *Readiness) probe(ctx context.Context, address string) bool {
+	if r.Path == "" {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+address+r.Path, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+// waitReady probes address until the server serves or ctx is done
+func (r *Readiness) waitReady(ctx context.Context, address string) error {
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, time.Second)
+		ready := r.probe(probeCtx, address)
+		cancel()
+		if ready {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(readyInterval):
+		}
+	}
+}
+
+// readiness returns the readiness check for running name with args, or an
+// error if the component has none for them
+func readiness(name string, args []string) (*Readiness, string, error) {
+	compDef, ok := Registry[name]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown component: %s", name)
+	}
+	r := compDef.Readiness
+	if r == nil {
+		return nil, "", fmt.Errorf("%s has no readiness check; --wait-ready applies to server-like components", name)
+	}
+	address, ok := r.Address(args)
+	if !ok {
+		return nil, "", fmt.Errorf("--wait-ready applies to '%s %s', which starts a server", name, r.Command)
+	}
+	return r, address, nil
+}
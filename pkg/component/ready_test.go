@@ -0,0 +1,109 @@
+package component
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadinessAddress(t *testing.T) {
+	r := &Readiness{Command: "server", Port: 8080, PortFlags: []string{"--port", "-p"}}
+
+	tests := []struct {
+		name   string
+		args   []string
+		want   string
+		wantOK bool
+	}{
+		{name: "default port", args: []string{"server"}, want: "127.0.0.1:8080", wantOK: true},
+		{name: "port flag", args: []string{"server", "--port", "9090"}, want: "127.0.0.1:9090", wantOK: true},
+		{name: "port flag with equals", args: []string{"server", "--port=9090"}, want: "127.0.0.1:9090", wantOK: true},
+		{name: "short flag with colon", args: []string{"server", "-p", ":9090"}, want: "127.0.0.1:9090", wantOK: true},
+		{name: "all interfaces", args: []string{"server", "-p", "0.0.0.0:9090"}, want: "127.0.0.1:9090", wantOK: true},
+		{name: "host and port", args: []string{"--port", "10.0.0.1:9090", "server"}, want: "10.0.0.1:9090", wantOK: true},
+		{name: "not a server", args: []string{"create", "-n", "backup1"}},
+		{name: "no args"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := r.Address(tt.args)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Address(%v) = %q, %v, want %q, %v", tt.args, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestReadinessWaitReady(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("tcp", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ln.Close()
+
+		r := &Readiness{}
+		waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		if err := r.waitReady(waitCtx, ln.Addr().String()); err != nil {
+			t.Errorf("waitReady() error = %v", err)
+		}
+	})
+
+	t.Run("http", func(t *testing.T) {
+		failing := true
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path != "/health" || failing {
+				failing = false
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer srv.Close()
+
+		r := &Readiness{Path: "/health"}
+		address := strings.TrimPrefix(srv.URL, "http://")
+		waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		if err := r.waitReady(waitCtx, address); err != nil {
+			t.Errorf("waitReady() error = %v", err)
+		}
+		if !r.probe(ctx, address) {
+			t.Error("probe() = false once the server is healthy")
+		}
+	})
+
+	t.Run("not serving", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		address := ln.Addr().String()
+		ln.Close()
+
+		r := &Readiness{}
+		waitCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+		if err := r.waitReady(waitCtx, address); err == nil {
+			t.Error("waitReady() should fail when nothing listens")
+		}
+	})
+}
+
+func TestReadinessRequired(t *testing.T) {
+	if _, _, err := readiness("birdwatcher", nil); err == nil {
+		t.Error("readiness() should fail for a component without a readiness check")
+	}
+	if _, _, err := readiness("milvus-backup", []string{"create"}); err == nil {
+		t.Error("readiness() should fail for arguments that don't start the server")
+	}
+	if _, address, err := readiness("milvus-backup", []string{"server"}); err != nil || address != "127.0.0.1:8080" {
+		t.Errorf("readiness() = %q, %v, want 127.0.0.1:8080", address, err)
+	}
+}
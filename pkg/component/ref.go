@@ -0,0 +1,259 @@
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/mmga-lab/miup/pkg/logger"
+)
+
+// TokenEnv is the environment variable holding the GitHub token used to
+// download CI artifacts, which GitHub serves to authenticated users only
+const TokenEnv = "GITHUB_TOKEN"
+
+// ErrNoBuild is returned when a branch or commit has neither a nightly
+// release nor a CI artifact to install
+var ErrNoBuild = errors.New("no build found")
+
+// commitSHA matches a full or abbreviated commit hash
+var commitSHA = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// WorkflowRun is a GitHub Actions run
+type WorkflowRun struct {
+	ID           int64  `json:"id"`
+	HeadBranch   string `json:"head_branch"`
+	HeadSHA      string `json:"head_sha"`
+	ArtifactsURL string `json:"artifacts_url"`
+}
+
+// Artifact is a file uploaded by a GitHub Actions run, downloaded as a zip
+type Artifact struct {
+	Name        string `json:"name"`
+	Size        int64  `json:"size_in_bytes"`
+	DownloadURL string `json:"archive_download_url"`
+	Expired     bool   `json:"expired"`
+}
+
+// GetCommitSHA returns the full hash of a commit, e.g. of an abbreviated one
+func (d *Downloader) GetCommitSHA(ctx context.Context, repo, ref string) (string, error) {
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := d.getJSON(ctx, fmt.Sprintf("%s/repos/%s/commits/%s", d.apiURL, repo, ref), &commit); err != nil {
+		return "", err
+	}
+	return commit.SHA, nil
+}
+
+// ListWorkflowRuns fetches the most recent successful workflow runs of a
+// branch, or of a commit if sha is set, newest first
+func (d *Downloader) ListWorkflowRuns(ctx context.Context, repo, branch, sha string) ([]WorkflowRun, error) {
+	query := url.Values{"status": {"success"}, "per_page": {"20"}}
+	if sha != "" {
+		query.Set("head_sha", sha)
+	} else {
+		query.Set("branch", branch)
+	}
+	var runs struct {
+		WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+	}
+	if err := d.getJSON(ctx, fmt.Sprintf("%s/repos/%s/actions/runs?%s", d.apiURL, repo, query.Encode()), &runs); err != nil {
+		return nil, err
+	}
+	return runs.WorkflowRuns, nil
+}
+
+// ListArtifacts fetches the artifacts uploaded by a workflow run
+func (d *Downloader) ListArtifacts(ctx context.Context, run *WorkflowRun) ([]Artifact, error) {
+	var artifacts struct {
+		Artifacts []Artifact `json:"artifacts"`
+	}
+	if err := d.getJSON(ctx, run.ArtifactsURL, &artifacts); err != nil {
+		return nil, err
+	}
+	return artifacts.Artifacts, nil
+}
+
+// FetchArtifact downloads the zip of a CI artifact to destPath,
+// authenticated with token
+func (d *Downloader) FetchArtifact(ctx context.Context, artifact *Artifact, token, destPath string) error {
+	// The size reported is that of the files, not of the zip
+	asset := &Asset{Name: artifact.Name + ".zip", BrowserDownloadURL: artifact.DownloadURL}
+	return d.fetchToFile(ctx, asset, token, destPath)
+}
+
+// refBuild is a build of a branch or commit: a nightly pre-release, or an
+// artifact of a successful CI run
+type refBuild struct {
+	release  *GitHubRelease
+	artifact *Artifact
+
+	// version is what the build is installed as: the release tag, or the
+	// ref and commit of the run, e.g. main-1a2b3c4
+	version string
+}
+
+// resolveRef finds the latest build of a branch or commit. A nightly
+// pre-release built from ref with an asset for this platform is preferred,
+// since it needs no token; otherwise the newest successful CI run of ref
+// with an artifact for this platform is used. Returns ErrNoBuild if there is
+// neither.
+func (m *Manager) resolveRef(ctx context.Context, compDef *ComponentDef, ref string) (*refBuild, error) {
+	logger.Info("Looking for a build of %s@%s...", compDef.Name, ref)
+
+	releases, err := m.downloader.ListReleases(ctx, compDef.Repo)
+	if err != nil {
+		logger.Debug("Could not list releases of %s: %v", compDef.Repo, err)
+	} else if release := nightlyRelease(releases, compDef, ref); release != nil {
+		logger.Info("Found nightly release %s", release.TagName)
+		return &refBuild{release: release, version: release.TagName}, nil
+	}
+
+	// An abbreviated hash must be expanded to filter runs by it. A ref that
+	// only looks like a hash is a branch if no such commit exists.
+	var sha string
+	if commitSHA.MatchString(ref) {
+		if sha, err = m.downloader.GetCommitSHA(ctx, compDef.Repo, ref); err != nil {
+			logger.Debug("%s is not a commit of %s, trying it as a branch: %v", ref, compDef.Repo, err)
+		}
+	}
+
+	runs, err := m.downloader.ListWorkflowRuns(ctx, compDef.Repo, ref, sha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CI runs of %s@%s: %w", compDef.Name, ref, err)
+	}
+	for i := range runs {
+		artifacts, err := m.downloader.ListArtifacts(ctx, &runs[i])
+		if err != nil {
+			logger.Debug("Could not list artifacts of run %d: %v", runs[i].ID, err)
+			continue
+		}
+		if artifact := pickArtifact(artifacts, runtime.GOOS, runtime.GOARCH); artifact != nil {
+			logger.Info("Found CI artifact %s of run %d (commit %s)", artifact.Name, runs[i].ID, shortSHA(runs[i].HeadSHA))
+			return &refBuild{artifact: artifact, version: refVersion(ref, runs[i].HeadSHA)}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w for %s@%s: no nightly pre-release is built from it and no successful CI run has an artifact for %s/%s; build it and install it with --from",
+		ErrNoBuild, compDef.Name, ref, runtime.GOOS, runtime.GOARCH)
+}
+
+// nightlyRelease returns the newest pre-release built from ref (its target
+// branch or commit) that has an asset for this platform, or nil
+func nightlyRelease(releases []GitHubRelease, compDef *ComponentDef, ref string) *GitHubRelease {
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft || !r.Prerelease {
+			continue
+		}
+		if r.TargetCommitish != ref && !(commitSHA.MatchString(ref) && strings.HasPrefix(r.TargetCommitish, ref)) {
+			continue
+		}
+		if _, err := FindAsset(r, compDef.AssetName); err == nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// pickArtifact returns the unexpired artifact for goos/goarch, named after
+// them like release assets (e.g. birdwatcher_Linux_x86_64), or the only
+// unexpired artifact if none is
+func pickArtifact(artifacts []Artifact, goos, goarch string) *Artifact {
+	var live []*Artifact
+	for i := range artifacts {
+		if !artifacts[i].Expired {
+			live = append(live, &artifacts[i])
+		}
+	}
+
+	osNames := []string{goos, capitalizeOS(goos)}
+	archNames := []string{goarch, normalizeArch(goarch)}
+	for _, a := range live {
+		name := strings.ToLower(a.Name)
+		if containsAny(name, osNames) && containsAny(name, archNames) {
+			return a
+		}
+	}
+	if len(live) == 1 {
+		return live[0]
+	}
+	return nil
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, strings.ToLower(sub)) {
+			return true
+		}
+	}
+	return false
+}
+
+// refVersion returns the version a CI build of ref at commit sha is
+// installed as: the short hash for a commit, else the branch and the short
+// hash, e.g. feature-x-1a2b3c4 for feature/x
+func refVersion(ref, sha string) string {
+	short := shortSHA(sha)
+	if strings.HasPrefix(sha, ref) {
+		return short
+	}
+	return strings.ReplaceAll(ref, "/", "-") + "-" + short
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// fetchArtifact downloads a CI artifact into a temporary directory and
+// returns what to install from: the release archive in it, or else the
+// extracted files. The directory is removed by cleanup.
+func (m *Manager) fetchArtifact(ctx context.Context, artifact *Artifact) (src string, cleanup func(), err error) {
+	token := os.Getenv(TokenEnv)
+	if token == "" {
+		return "", nil, fmt.Errorf("downloading CI artifact %s needs a GitHub token; set %s", artifact.Name, TokenEnv)
+	}
+
+	dir, err := os.MkdirTemp("", "miup-artifact-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	cleanup = func() { _ = os.RemoveAll(dir) }
+
+	zipPath := filepath.Join(dir, artifact.Name+".zip")
+	if err := m.downloader.FetchArtifact(ctx, artifact, token, zipPath); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	filesDir := filepath.Join(dir, "files")
+	if err := ExtractFile(zipPath, zipPath, filesDir); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	// Release workflows usually upload the archive they would publish
+	entries, err := os.ReadDir(filesDir)
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+	if len(entries) == 1 && !entries[0].IsDir() && isArchive(entries[0].Name()) {
+		return filepath.Join(filesDir, entries[0].Name()), cleanup, nil
+	}
+	return filesDir, cleanup, nil
+}
+
+// isArchive reports whether name is a release archive installLocal extracts
+func isArchive(name string) bool {
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") || strings.HasSuffix(name, ".zip")
+}
@@ -0,0 +1,217 @@
+package component
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+const testSHA = "1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d"
+
+// fakeGitHub serves the parts of the GitHub API resolveRef uses for the
+// milvus-io/birdwatcher repo
+type fakeGitHub struct {
+	releases  []GitHubRelease
+	runs      []WorkflowRun
+	artifacts []Artifact
+	zip       []byte
+}
+
+func (f *fakeGitHub) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var v any
+		switch r.URL.Path {
+		case "/repos/milvus-io/birdwatcher/releases":
+			v = f.releases
+		case "/repos/milvus-io/birdwatcher/commits/1a2b3c4":
+			v = map[string]string{"sha": testSHA}
+		case "/repos/milvus-io/birdwatcher/actions/runs":
+			var runs []WorkflowRun
+			for _, run := range f.runs {
+				if q := r.URL.Query(); q.Get("head_sha") == run.HeadSHA || q.Get("branch") == run.HeadBranch {
+					runs = append(runs, run)
+				}
+			}
+			v = map[string]any{"workflow_runs": runs}
+		case "/runs/1/artifacts":
+			v = map[string]any{"artifacts": f.artifacts}
+		case "/artifacts/1/zip":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write(f.zip)
+			return
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(v); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// newRefManager returns a manager talking to a fake GitHub serving f
+func newRefManager(t *testing.T, f *fakeGitHub) *Manager {
+	server := httptest.NewServer(f.handler(t))
+	t.Cleanup(server.Close)
+
+	for i := range f.runs {
+		f.runs[i].ArtifactsURL = server.URL + "/runs/1/artifacts"
+	}
+	for i := range f.artifacts {
+		f.artifacts[i].DownloadURL = server.URL + "/artifacts/1/zip"
+	}
+	for i := range f.releases {
+		for j := range f.releases[i].Assets {
+			f.releases[i].Assets[j].BrowserDownloadURL = server.URL + "/assets/" + f.releases[i].Assets[j].Name
+		}
+	}
+
+	mgr := NewManager(localdata.NewProfile(t.TempDir()))
+	mgr.downloader.apiURL = server.URL
+	return mgr
+}
+
+func TestResolveRef(t *testing.T) {
+	asset := Registry["birdwatcher"].CurrentPlatformAssetName("")
+	platform := "birdwatcher_" + capitalizeOS(runtime.GOOS) + "_" + normalizeArch(runtime.GOARCH)
+	mainRun := WorkflowRun{ID: 1, HeadBranch: "main", HeadSHA: testSHA}
+
+	tests := []struct {
+		name         string
+		github       fakeGitHub
+		ref          string
+		wantVersion  string
+		wantArtifact bool
+		wantErr      error
+	}{
+		{
+			name: "nightly pre-release",
+			github: fakeGitHub{
+				releases: []GitHubRelease{
+					{TagName: "v1.1.0", TargetCommitish: "main", Assets: []Asset{{Name: asset}}},
+					{TagName: "nightly", Prerelease: true, TargetCommitish: "main", Assets: []Asset{{Name: asset}}},
+				},
+			},
+			ref:         "main",
+			wantVersion: "nightly",
+		},
+		{
+			name: "nightly without an asset for the platform falls back to CI",
+			github: fakeGitHub{
+				releases:  []GitHubRelease{{TagName: "nightly", Prerelease: true, TargetCommitish: "main"}},
+				runs:      []WorkflowRun{mainRun},
+				artifacts: []Artifact{{Name: platform}},
+			},
+			ref:          "main",
+			wantVersion:  "main-1a2b3c4",
+			wantArtifact: true,
+		},
+		{
+			name: "artifact for the platform of a commit",
+			github: fakeGitHub{
+				runs:      []WorkflowRun{mainRun},
+				artifacts: []Artifact{{Name: "birdwatcher_Windows_x86_64"}, {Name: platform}, {Name: "coverage"}},
+			},
+			ref:          "1a2b3c4",
+			wantVersion:  "1a2b3c4",
+			wantArtifact: true,
+		},
+		{
+			name: "expired artifact",
+			github: fakeGitHub{
+				runs:      []WorkflowRun{mainRun},
+				artifacts: []Artifact{{Name: platform, Expired: true}},
+			},
+			ref:     "main",
+			wantErr: ErrNoBuild,
+		},
+		{
+			name:    "unknown branch",
+			github:  fakeGitHub{runs: []WorkflowRun{mainRun}},
+			ref:     "feature/x",
+			wantErr: ErrNoBuild,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := newRefManager(t, &tt.github)
+			build, err := mgr.resolveRef(context.Background(), Registry["birdwatcher"], tt.ref)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("resolveRef() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveRef() error = %v", err)
+			}
+			if build.version != tt.wantVersion {
+				t.Errorf("version = %q, want %q", build.version, tt.wantVersion)
+			}
+			if (build.artifact != nil) != tt.wantArtifact {
+				t.Errorf("artifact = %v, want artifact: %v", build.artifact, tt.wantArtifact)
+			}
+		})
+	}
+}
+
+func TestInstallRefArtifact(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("installs a shell script as the binary")
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	f, err := zw.Create("birdwatcher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("#!/bin/sh\necho birdwatcher dev\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	github := &fakeGitHub{
+		runs:      []WorkflowRun{{ID: 1, HeadBranch: "feature/x", HeadSHA: testSHA}},
+		artifacts: []Artifact{{Name: "birdwatcher"}},
+		zip:       buf.Bytes(),
+	}
+	mgr := newRefManager(t, github)
+	opts := InstallOptions{Ref: "feature/x"}
+
+	t.Setenv(TokenEnv, "")
+	if err := mgr.Install(context.Background(), "birdwatcher", "", opts); err == nil {
+		t.Fatal("Install() without a token succeeded, want an error")
+	}
+
+	t.Setenv(TokenEnv, "secret")
+	if err := mgr.Install(context.Background(), "birdwatcher", "", opts); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if _, err := os.Stat(mgr.BinaryPath("birdwatcher", "feature-x-1a2b3c4")); err != nil {
+		t.Errorf("binary not installed: %v", err)
+	}
+	// Dev builds have no "v" prefix to add
+	if err := mgr.Activate(context.Background(), "birdwatcher", "feature-x-1a2b3c4"); err != nil {
+		t.Errorf("Activate() error = %v", err)
+	}
+
+	if err := mgr.Install(context.Background(), "birdwatcher", "v1.0.0", opts); err == nil {
+		t.Error("Install() with both a version and a ref succeeded, want an error")
+	}
+}
@@ -0,0 +1,46 @@
+package component
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SplitRunArgs splits the arguments of 'miup run' into the component
+// (<name>[:<version>]) and the arguments passed through to it. dashAt is the
+// number of arguments before "--", as reported by cobra's ArgsLenAtDash, or
+// -1 if there is none.
+//
+// "--" only stops miup from parsing the flags that follow it, so every
+// argument after the component is passed through in order:
+//
+//	miup run birdwatcher -- --help             birdwatcher [--help]
+//	miup run birdwatcher connect -- --etcd x   birdwatcher [connect --etcd x]
+//	miup run -- birdwatcher --help             birdwatcher [--help]
+func SplitRunArgs(args []string, dashAt int) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("a component is required")
+	}
+	if dashAt == 0 && strings.HasPrefix(args[0], "-") {
+		return "", nil, fmt.Errorf("expected a component before '%s', e.g. miup run birdwatcher -- %s", args[0], args[0])
+	}
+	return args[0], args[1:], nil
+}
+
+// helpArgs returns the arguments that make the binary print its usage
+func (c *Component) helpArgs() []string {
+	if len(c.HelpArgs) > 0 {
+		return c.HelpArgs
+	}
+	return []string{"--help"}
+}
+
+// RunHelp runs an installed component with its help flag, printing the
+// component's own usage
+func (m *Manager) RunHelp(ctx context.Context, name, version string) error {
+	compDef, ok := Registry[name]
+	if !ok {
+		return fmt.Errorf("unknown component: %s", name)
+	}
+	return m.Run(ctx, name, version, compDef.helpArgs(), RunOptions{})
+}
@@ -0,0 +1,51 @@
+package component
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitRunArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		dashAt   int
+		wantComp string
+		wantArgs []string
+		wantErr  bool
+	}{
+		{name: "component only", args: []string{"birdwatcher"}, dashAt: -1, wantComp: "birdwatcher", wantArgs: []string{}},
+		{name: "positional args", args: []string{"birdwatcher", "connect"}, dashAt: -1, wantComp: "birdwatcher", wantArgs: []string{"connect"}},
+		{name: "help after dash", args: []string{"birdwatcher", "--help"}, dashAt: 1, wantComp: "birdwatcher", wantArgs: []string{"--help"}},
+		{name: "versioned help after dash", args: []string{"birdwatcher:v1.1.0", "-h"}, dashAt: 1, wantComp: "birdwatcher:v1.1.0", wantArgs: []string{"-h"}},
+		{name: "empty after dash", args: []string{"birdwatcher"}, dashAt: 1, wantComp: "birdwatcher", wantArgs: []string{}},
+		{name: "args on both sides of dash", args: []string{"birdwatcher", "connect", "--etcd", "x"}, dashAt: 2, wantComp: "birdwatcher", wantArgs: []string{"connect", "--etcd", "x"}},
+		{name: "dash before component", args: []string{"birdwatcher", "--help"}, dashAt: 0, wantComp: "birdwatcher", wantArgs: []string{"--help"}},
+		{name: "dash and a flag only", args: []string{"--help"}, dashAt: 0, wantErr: true},
+		{name: "no args", args: nil, dashAt: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comp, args, err := SplitRunArgs(tt.args, tt.dashAt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitRunArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if comp != tt.wantComp || !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("SplitRunArgs() = %s %v, want %s %v", comp, args, tt.wantComp, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestHelpArgs(t *testing.T) {
+	if got := (&Component{}).helpArgs(); !slices.Equal(got, []string{"--help"}) {
+		t.Errorf("default helpArgs() = %v", got)
+	}
+	if got := (&Component{HelpArgs: []string{"help"}}).helpArgs(); !slices.Equal(got, []string{"help"}) {
+		t.Errorf("helpArgs() = %v", got)
+	}
+}
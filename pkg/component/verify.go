@@ -0,0 +1,45 @@
+package component
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ErrBinaryNotRunnable indicates an installed binary cannot be executed on
+// this machine, e.g. because it was built for another architecture
+var ErrBinaryNotRunnable = errors.New("binary cannot be executed")
+
+// verifyTimeout bounds the post-install smoke test
+const verifyTimeout = 10 * time.Second
+
+// verifyBinary runs the binary with args as a smoke test and returns the
+// first line of its output. It returns ErrBinaryNotRunnable if the binary
+// cannot be started at all; any other error means it started but exited
+// with an error or timed out, which may just be an unsupported flag.
+func verifyBinary(ctx context.Context, path string, args []string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, verifyTimeout)
+	defer cancel()
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrBinaryNotRunnable, err)
+	}
+	err := cmd.Wait()
+	line, _, _ := strings.Cut(strings.TrimSpace(out.String()), "\n")
+	if ctx.Err() != nil {
+		return line, fmt.Errorf("'%s %s' did not finish within %s", path, strings.Join(args, " "), verifyTimeout)
+	}
+	if err != nil {
+		return line, fmt.Errorf("'%s %s' failed: %w", path, strings.Join(args, " "), err)
+	}
+	return line, nil
+}
@@ -2,6 +2,8 @@ package executor
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -14,6 +16,7 @@ import (
 type DockerCompose struct {
 	workDir     string
 	projectName string
+	env         []string
 }
 
 // NewDockerCompose creates a new DockerCompose instance
@@ -24,6 +27,13 @@ func NewDockerCompose(workDir, projectName string) *DockerCompose {
 	}
 }
 
+// WithEnv adds KEY=VALUE variables to the environment of compose commands,
+// e.g. values the compose file interpolates that must not be written to it
+func (dc *DockerCompose) WithEnv(env ...string) *DockerCompose {
+	dc.env = append(dc.env, env...)
+	return dc
+}
+
 // WorkDir returns the working directory
 func (dc *DockerCompose) WorkDir() string {
 	return dc.workDir
@@ -40,8 +50,13 @@ func (dc *DockerCompose) ComposeFilePath() string {
 }
 
 // Up starts the compose services
-func (dc *DockerCompose) Up(ctx context.Context) error {
-	return dc.run(ctx, "up", "-d", "--remove-orphans", "--wait")
+// pull is passed to --pull (always, never, missing); empty uses the docker default
+func (dc *DockerCompose) Up(ctx context.Context, pull string) error {
+	args := []string{"up", "-d", "--remove-orphans", "--wait"}
+	if pull != "" {
+		args = append(args, "--pull", pull)
+	}
+	return dc.run(ctx, args...)
 }
 
 // Down stops and removes the compose services
@@ -68,9 +83,68 @@ func (dc *DockerCompose) PS(ctx context.Context) (string, error) {
 	return dc.runOutput(ctx, "ps", "--format", "table")
 }
 
+// ServiceState is the state of a compose service container
+type ServiceState struct {
+	Service string `json:"Service"`
+	Name    string `json:"Name"`
+	State   string `json:"State"`
+	// Health is empty for services without a healthcheck
+	Health string `json:"Health"`
+}
+
+// Services returns the state of each compose service container
+func (dc *DockerCompose) Services(ctx context.Context) ([]ServiceState, error) {
+	cmd := dc.buildCommand(ctx, "ps", "--all", "--format", "json")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	return parseServiceStates(output)
+}
+
+// parseServiceStates parses 'docker compose ps --format json' output, which
+// is a JSON array in older compose releases and one object per line in newer ones
+func parseServiceStates(output []byte) ([]ServiceState, error) {
+	output = []byte(strings.TrimSpace(string(output)))
+	if len(output) == 0 {
+		return nil, nil
+	}
+
+	var states []ServiceState
+	if output[0] == '[' {
+		if err := json.Unmarshal(output, &states); err != nil {
+			return nil, fmt.Errorf("failed to parse service states: %w", err)
+		}
+		return states, nil
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var state ServiceState
+		if err := json.Unmarshal([]byte(line), &state); err != nil {
+			return nil, fmt.Errorf("failed to parse service states: %w", err)
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// Exec runs a command in a running service container and returns its output
+func (dc *DockerCompose) Exec(ctx context.Context, service string, command ...string) (string, error) {
+	args := append([]string{"exec", "-T", service}, command...)
+	return dc.runOutput(ctx, args...)
+}
+
 // Logs gets compose service logs
-func (dc *DockerCompose) Logs(ctx context.Context, service string, tail int) (string, error) {
+// since limits output to logs newer than the given duration (0 means no limit)
+func (dc *DockerCompose) Logs(ctx context.Context, service string, tail int, since time.Duration) (string, error) {
 	args := []string{"logs", "--tail", fmt.Sprintf("%d", tail)}
+	if since > 0 {
+		args = append(args, "--since", since.String())
+	}
 	if service != "" {
 		args = append(args, service)
 	}
@@ -150,6 +224,9 @@ func (dc *DockerCompose) buildCommand(ctx context.Context, args ...string) *exec
 
 	cmd := exec.CommandContext(ctx, "docker", baseArgs...)
 	cmd.Dir = dc.workDir
+	if len(dc.env) > 0 {
+		cmd.Env = append(os.Environ(), dc.env...)
+	}
 	return cmd
 }
 
@@ -171,6 +248,39 @@ func CheckDockerComposeAvailable() error {
 	return nil
 }
 
+// ErrDockerUnavailable is returned by RequireDocker when docker can't be used
+var ErrDockerUnavailable = errors.New("docker is unavailable")
+
+// RequireDocker checks that the docker CLI is installed and its daemon is
+// running, and with compose that the compose plugin is installed too. The
+// error says how to fix what is missing and wraps the failure of the check.
+func RequireDocker(compose bool) error {
+	if err := CheckDockerAvailable(); err != nil {
+		return fmt.Errorf("%w: Docker is not installed (%w); install it from https://docs.docker.com/get-docker/", ErrDockerUnavailable, err)
+	}
+	if compose {
+		if err := CheckDockerComposeAvailable(); err != nil {
+			return fmt.Errorf("%w: Docker Compose is not installed (%w); install the plugin from https://docs.docker.com/compose/install/", ErrDockerUnavailable, err)
+		}
+	}
+	if err := CheckDockerRunning(); err != nil {
+		return fmt.Errorf("%w: the Docker daemon is not running (%w); start Docker Desktop or run 'sudo systemctl start docker'", ErrDockerUnavailable, err)
+	}
+	return nil
+}
+
+// MissingImages returns the images that are not present in the local docker image store
+func MissingImages(ctx context.Context, images []string) []string {
+	var missing []string
+	for _, image := range images {
+		cmd := exec.CommandContext(ctx, "docker", "image", "inspect", image)
+		if err := cmd.Run(); err != nil {
+			missing = append(missing, image)
+		}
+	}
+	return missing
+}
+
 // CheckDockerRunning checks if docker daemon is running
 func CheckDockerRunning() error {
 	cmd := exec.Command("docker", "info")
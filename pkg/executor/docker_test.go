@@ -0,0 +1,126 @@
+package executor
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseServiceStates(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []ServiceState
+	}{
+		{
+			name:   "array",
+			output: `[{"Service":"etcd","Name":"milvus-etcd-default","State":"running","Health":"healthy"}]`,
+			want:   []ServiceState{{Service: "etcd", Name: "milvus-etcd-default", State: "running", Health: "healthy"}},
+		},
+		{
+			name: "lines",
+			output: `{"Service":"etcd","Name":"milvus-etcd-default","State":"running","Health":"healthy"}
+{"Service":"grafana","Name":"milvus-grafana-default","State":"exited","Health":""}
+`,
+			want: []ServiceState{
+				{Service: "etcd", Name: "milvus-etcd-default", State: "running", Health: "healthy"},
+				{Service: "grafana", Name: "milvus-grafana-default", State: "exited"},
+			},
+		},
+		{
+			name:   "empty",
+			output: "\n",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseServiceStates([]byte(tt.output))
+			if err != nil {
+				t.Fatalf("parseServiceStates() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseServiceStates() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseServiceStates()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := parseServiceStates([]byte("not json")); err == nil {
+		t.Error("parseServiceStates() should fail on invalid output")
+	}
+}
+
+func TestRequireDocker(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake docker is a shell script")
+	}
+
+	tests := []struct {
+		name      string
+		script    string
+		compose   bool
+		wantErr   string
+		wantCause error
+	}{
+		{name: "not installed", wantErr: "not installed", wantCause: exec.ErrNotFound},
+		{
+			name:    "daemon not running",
+			script:  "#!/bin/sh\n[ \"$1\" = version ]\n",
+			wantErr: "daemon is not running",
+		},
+		{
+			name:    "compose missing",
+			script:  "#!/bin/sh\n[ \"$1\" != compose ]\n",
+			compose: true,
+			wantErr: "Compose is not installed",
+		},
+		{
+			name:    "compose not required",
+			script:  "#!/bin/sh\n[ \"$1\" != compose ]\n",
+			compose: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.script != "" {
+				if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(tt.script), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+			t.Setenv("PATH", dir)
+
+			err := RequireDocker(tt.compose)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("RequireDocker() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrDockerUnavailable) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RequireDocker() error = %v, want %q", err, tt.wantErr)
+			}
+			// The failed check is kept as the cause: a missing binary, or
+			// the exit status of the docker command
+			var exitErr *exec.ExitError
+			if tt.wantCause != nil {
+				if !errors.Is(err, tt.wantCause) {
+					t.Errorf("RequireDocker() error = %v, want it to wrap %v", err, tt.wantCause)
+				}
+			} else if !errors.As(err, &exitErr) {
+				t.Errorf("RequireDocker() error = %v, want it to wrap the exit status", err)
+			}
+		})
+	}
+}
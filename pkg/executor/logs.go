@@ -0,0 +1,25 @@
+package executor
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FilterLines returns only the lines of s that match re.
+// If re is nil, s is returned unchanged.
+func FilterLines(s string, re *regexp.Regexp) string {
+	if re == nil {
+		return s
+	}
+
+	var sb strings.Builder
+	for _, line := range strings.SplitAfter(s, "\n") {
+		if line == "" {
+			continue
+		}
+		if re.MatchString(line) {
+			sb.WriteString(line)
+		}
+	}
+	return sb.String()
+}
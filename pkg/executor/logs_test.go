@@ -0,0 +1,35 @@
+package executor
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestFilterLines(t *testing.T) {
+	input := "[INFO] started\n[ERROR] failed to connect\n[WARN] slow query\n[ERROR] timeout"
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    string
+	}{
+		{"match errors", `ERROR`, "[ERROR] failed to connect\n[ERROR] timeout"},
+		{"alternation", `WARN|started`, "[INFO] started\n[WARN] slow query\n"},
+		{"no match", `DEBUG`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterLines(input, regexp.MustCompile(tt.pattern))
+			if got != tt.want {
+				t.Errorf("FilterLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("nil regexp", func(t *testing.T) {
+		if got := FilterLines(input, nil); got != input {
+			t.Errorf("FilterLines() = %q, want input unchanged", got)
+		}
+	})
+}
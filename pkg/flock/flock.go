@@ -0,0 +1,45 @@
+// Package flock provides advisory file locks used to serialize miup
+// operations across processes.
+package flock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrLocked is returned when the lock is already held by another process
+var ErrLocked = errors.New("lock is held by another process")
+
+// Lock is an exclusive advisory lock on a file
+type Lock struct {
+	file *os.File
+}
+
+// TryLock acquires an exclusive lock on path without blocking, creating the
+// file if needed. Returns ErrLocked if another holder has the lock.
+func TryLock(path string) (*Lock, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &Lock{file: f}, nil
+}
+
+// Unlock releases the lock
+func (l *Lock) Unlock() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
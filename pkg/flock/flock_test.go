@@ -0,0 +1,37 @@
+package flock
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestTryLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".lock")
+
+	l, err := TryLock(path)
+	if err != nil {
+		t.Fatalf("TryLock() error = %v", err)
+	}
+
+	if _, err := TryLock(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("second TryLock() error = %v, want ErrLocked", err)
+	}
+
+	if err := l.Unlock(); err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+
+	l2, err := TryLock(path)
+	if err != nil {
+		t.Fatalf("TryLock() after Unlock error = %v", err)
+	}
+	l2.Unlock()
+}
+
+func TestUnlockNil(t *testing.T) {
+	var l *Lock
+	if err := l.Unlock(); err != nil {
+		t.Errorf("Unlock() on nil lock error = %v", err)
+	}
+}
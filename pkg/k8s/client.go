@@ -3,11 +3,17 @@ package k8s
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -15,14 +21,31 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/flowcontrol"
 )
 
+// MilvusOperatorDeployment is the name of the Milvus Operator deployment,
+// which is looked up in MilvusOperatorNamespaces
+const MilvusOperatorDeployment = "milvus-operator"
+
+// MilvusOperatorNamespaces are the namespaces the Milvus Operator is
+// commonly installed in, in lookup order
+var MilvusOperatorNamespaces = []string{"milvus-operator", "default", "kube-system"}
+
+// ErrMilvusExists is returned by CreateMilvus when a Milvus resource with the
+// same name already exists in the namespace
+var ErrMilvusExists = errors.New("Milvus resource already exists")
+
 // Client wraps Kubernetes client operations
 type Client struct {
-	clientset     *kubernetes.Clientset
+	clientset     kubernetes.Interface
 	dynamicClient dynamic.Interface
 	config        *rest.Config
 	namespace     string
+
+	// metricsOnce guards the one-time metrics API discovery in MetricsAvailable
+	metricsOnce sync.Once
+	metricsErr  error
 }
 
 // ClientOptions contains options for creating a client
@@ -30,6 +53,10 @@ type ClientOptions struct {
 	Kubeconfig string
 	Context    string
 	Namespace  string
+
+	// RateLimit limits the client's own API requests. If nil, the client
+	// shares the process-wide limiter of DefaultRateLimit.
+	RateLimit *RateLimit
 }
 
 // NewClient creates a new Kubernetes client
@@ -39,6 +66,16 @@ func NewClient(opts ClientOptions) (*Client, error) {
 		return nil, fmt.Errorf("failed to build config: %w", err)
 	}
 
+	if opts.RateLimit != nil {
+		// One limiter for both clientsets, which would otherwise each
+		// create their own from QPS and Burst
+		config.QPS = opts.RateLimit.QPS
+		config.Burst = opts.RateLimit.Burst
+		config.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(config.QPS, config.Burst)
+	} else {
+		UseDefaultRateLimit(config)
+	}
+
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create clientset: %w", err)
@@ -62,6 +99,20 @@ func NewClient(opts ClientOptions) (*Client, error) {
 	}, nil
 }
 
+// NewClientFromInterfaces creates a client on top of existing clientsets,
+// e.g. fakes in tests of the packages using the client
+func NewClientFromInterfaces(clientset kubernetes.Interface, dynamicClient dynamic.Interface, namespace string) *Client {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return &Client{
+		clientset:     clientset,
+		dynamicClient: dynamicClient,
+		config:        &rest.Config{},
+		namespace:     namespace,
+	}
+}
+
 // buildConfig builds a Kubernetes config from kubeconfig file
 func buildConfig(kubeconfig, kubecontext string) (*rest.Config, error) {
 	if kubeconfig == "" {
@@ -113,6 +164,9 @@ func (c *Client) CreateMilvus(ctx context.Context, milvus *Milvus) error {
 	}
 
 	_, err = c.dynamicClient.Resource(milvusGVR()).Namespace(namespace).Create(ctx, obj, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("%w: %s/%s", ErrMilvusExists, namespace, milvus.Name)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create Milvus: %w", err)
 	}
@@ -120,13 +174,36 @@ func (c *Client) CreateMilvus(ctx context.Context, milvus *Milvus) error {
 	return nil
 }
 
+// ApplyMilvus creates a Milvus resource, or replaces the spec of an existing
+// one with the same name. Unlike CreateMilvus it is safe to retry.
+func (c *Client) ApplyMilvus(ctx context.Context, milvus *Milvus) error {
+	err := c.CreateMilvus(ctx, milvus)
+	if !errors.Is(err, ErrMilvusExists) {
+		return err
+	}
+
+	current, err := c.GetMilvus(ctx, milvus.Name, milvus.Namespace)
+	if err != nil {
+		return err
+	}
+
+	updated := *milvus
+	updated.ResourceVersion = current.ResourceVersion
+	return c.UpdateMilvus(ctx, &updated)
+}
+
 // GetMilvus gets a Milvus resource
 func (c *Client) GetMilvus(ctx context.Context, name, namespace string) (*Milvus, error) {
 	if namespace == "" {
 		namespace = c.namespace
 	}
 
-	obj, err := c.dynamicClient.Resource(milvusGVR()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	var obj *unstructured.Unstructured
+	err := retryRead(ctx, func() error {
+		var err error
+		obj, err = c.dynamicClient.Resource(milvusGVR()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Milvus: %w", err)
 	}
@@ -179,7 +256,12 @@ func (c *Client) ListMilvus(ctx context.Context, namespace string) (*MilvusList,
 		namespace = c.namespace
 	}
 
-	list, err := c.dynamicClient.Resource(milvusGVR()).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	var list *unstructured.UnstructuredList
+	err := retryRead(ctx, func() error {
+		var err error
+		list, err = c.dynamicClient.Resource(milvusGVR()).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list Milvus: %w", err)
 	}
@@ -201,7 +283,12 @@ func (c *Client) ListMilvus(ctx context.Context, namespace string) (*MilvusList,
 
 // ListAllMilvus lists all Milvus resources across all namespaces
 func (c *Client) ListAllMilvus(ctx context.Context) (*MilvusList, error) {
-	list, err := c.dynamicClient.Resource(milvusGVR()).List(ctx, metav1.ListOptions{})
+	var list *unstructured.UnstructuredList
+	err := retryRead(ctx, func() error {
+		var err error
+		list, err = c.dynamicClient.Resource(milvusGVR()).List(ctx, metav1.ListOptions{})
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list all Milvus: %w", err)
 	}
@@ -221,17 +308,161 @@ func (c *Client) ListAllMilvus(ctx context.Context) (*MilvusList, error) {
 	return milvusList, nil
 }
 
+// GetMilvusRaw gets a Milvus resource as a raw object, including fields
+// that are not modeled by the Milvus type
+func (c *Client) GetMilvusRaw(ctx context.Context, name, namespace string) (map[string]interface{}, error) {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	var obj *unstructured.Unstructured
+	err := retryRead(ctx, func() error {
+		var err error
+		obj, err = c.dynamicClient.Resource(milvusGVR()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Milvus: %w", err)
+	}
+
+	return obj.Object, nil
+}
+
+// ListEvents lists events in a namespace about the objects of an instance
+// (see involvesInstance), or all events if instance is empty
+func (c *Client) ListEvents(ctx context.Context, namespace, instance string) ([]corev1.Event, error) {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	list, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	result := make([]corev1.Event, 0, len(list.Items))
+	for _, ev := range list.Items {
+		if instance != "" && !involvesInstance(ev.InvolvedObject.Name, instance) {
+			continue
+		}
+		result = append(result, ev)
+	}
+
+	return result, nil
+}
+
+// involvesInstance reports whether object is the Milvus resource of an
+// instance or one of the objects named after it, such as demo-milvus-proxy
+// or demo-etcd-0 for instance demo, but not those of an instance demo2
+func involvesInstance(object, instance string) bool {
+	return object == instance || strings.HasPrefix(object, instance+"-")
+}
+
+// ListMilvusPodObjects lists the full pod objects for a Milvus cluster
+func (c *Client) ListMilvusPodObjects(ctx context.Context, name, namespace string) ([]corev1.Pod, error) {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	labelSelector := fmt.Sprintf("app.kubernetes.io/instance=%s", name)
+	var pods *corev1.PodList
+	err := retryRead(ctx, func() error {
+		var err error
+		pods, err = c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labelSelector,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	return pods.Items, nil
+}
+
+// ListSelectedPods lists the pods in a namespace matching a label selector
+func (c *Client) ListSelectedPods(ctx context.Context, namespace, selector string) ([]corev1.Pod, error) {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	var pods *corev1.PodList
+	err := retryRead(ctx, func() error {
+		var err error
+		pods, err = c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	return pods.Items, nil
+}
+
+// IsPodReady reports whether a pod has the Ready condition
+func IsPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// ListNodes lists the nodes of the cluster
+func (c *Client) ListNodes(ctx context.Context) ([]corev1.Node, error) {
+	var nodes *corev1.NodeList
+	err := retryRead(ctx, func() error {
+		var err error
+		nodes, err = c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	return nodes.Items, nil
+}
+
+// LogOptions selects the logs returned by GetPodLogs
+type LogOptions struct {
+	// Container is the container to read; empty means the pod's only one
+	Container string
+
+	// TailLines is the number of lines to return from the end of the logs
+	TailLines int64
+
+	// SinceSeconds, if positive, only returns logs newer than that
+	SinceSeconds int64
+
+	// SinceTime, if set, only returns logs written after it; it can't be
+	// combined with SinceSeconds
+	SinceTime *time.Time
+
+	// Timestamps prefixes each line with the RFC3339 time it was logged at,
+	// as recorded by the kubelet
+	Timestamps bool
+}
+
 // GetPodLogs gets logs from a pod
-func (c *Client) GetPodLogs(ctx context.Context, namespace, podName, container string, tailLines int64) (string, error) {
+func (c *Client) GetPodLogs(ctx context.Context, namespace, podName string, logOpts LogOptions) (string, error) {
 	if namespace == "" {
 		namespace = c.namespace
 	}
 
 	opts := &corev1.PodLogOptions{
-		TailLines: &tailLines,
+		TailLines:  &logOpts.TailLines,
+		Container:  logOpts.Container,
+		Timestamps: logOpts.Timestamps,
 	}
-	if container != "" {
-		opts.Container = container
+	if logOpts.SinceSeconds > 0 {
+		opts.SinceSeconds = &logOpts.SinceSeconds
+	}
+	if logOpts.SinceTime != nil {
+		since := metav1.NewTime(*logOpts.SinceTime)
+		opts.SinceTime = &since
 	}
 
 	req := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, opts)
@@ -250,8 +481,13 @@ func (c *Client) GetMilvusPods(ctx context.Context, name, namespace string) ([]s
 	}
 
 	labelSelector := fmt.Sprintf("app.kubernetes.io/instance=%s", name)
-	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: labelSelector,
+	var pods *corev1.PodList
+	err := retryRead(ctx, func() error {
+		var err error
+		pods, err = c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labelSelector,
+		})
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods: %w", err)
@@ -265,26 +501,6 @@ func (c *Client) GetMilvusPods(ctx context.Context, name, namespace string) ([]s
 	return result, nil
 }
 
-// GetMilvusService gets the service endpoint for a Milvus cluster
-func (c *Client) GetMilvusService(ctx context.Context, name, namespace string) (string, error) {
-	if namespace == "" {
-		namespace = c.namespace
-	}
-
-	svc, err := c.clientset.CoreV1().Services(namespace).Get(ctx, name+"-milvus", metav1.GetOptions{})
-	if err != nil {
-		return "", fmt.Errorf("failed to get service: %w", err)
-	}
-
-	if len(svc.Spec.Ports) == 0 {
-		return "", fmt.Errorf("no ports found in service")
-	}
-
-	// Return cluster IP and port
-	port := svc.Spec.Ports[0].Port
-	return fmt.Sprintf("%s:%d", svc.Spec.ClusterIP, port), nil
-}
-
 // CheckMilvusOperatorInstalled checks if Milvus Operator is installed
 func (c *Client) CheckMilvusOperatorInstalled(ctx context.Context) (bool, error) {
 	// Check if Milvus CRD exists
@@ -295,6 +511,52 @@ func (c *Client) CheckMilvusOperatorInstalled(ctx context.Context) (bool, error)
 	return true, nil
 }
 
+// FindMilvusOperator returns the Milvus Operator deployment from the first of
+// MilvusOperatorNamespaces that has one, or nil if none does
+func (c *Client) FindMilvusOperator(ctx context.Context) (*appsv1.Deployment, error) {
+	for _, ns := range MilvusOperatorNamespaces {
+		var deploy *appsv1.Deployment
+		err := retryRead(ctx, func() error {
+			var err error
+			deploy, err = c.clientset.AppsV1().Deployments(ns).Get(ctx, MilvusOperatorDeployment, metav1.GetOptions{})
+			return err
+		})
+		if err == nil {
+			return deploy, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get deployment %s/%s: %w", ns, MilvusOperatorDeployment, err)
+		}
+	}
+	return nil, nil
+}
+
+// GetDeploymentPods returns the names of the pods selected by a deployment
+func (c *Client) GetDeploymentPods(ctx context.Context, deploy *appsv1.Deployment) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of deployment %s: %w", deploy.Name, err)
+	}
+
+	var pods *corev1.PodList
+	err = retryRead(ctx, func() error {
+		var err error
+		pods, err = c.clientset.CoreV1().Pods(deploy.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector.String(),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	result := make([]string, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		result = append(result, pod.Name)
+	}
+	return result, nil
+}
+
 // Namespace returns the default namespace
 func (c *Client) Namespace() string {
 	return c.namespace
@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newFakeMilvusClient returns a client for namespace milvus whose Milvus
+// resources are kept by a fake dynamic client
+func newFakeMilvusClient() *Client {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{milvusGVR(): MilvusKind + "List"})
+	return NewClientFromInterfaces(fake.NewSimpleClientset(), dynamicClient, "milvus")
+}
+
+func TestApplyMilvus(t *testing.T) {
+	ctx := context.Background()
+	milvus := func(image string) *Milvus {
+		return &Milvus{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "milvus"},
+			Spec:       MilvusSpec{Mode: MilvusModeStandalone, Components: MilvusComponents{Image: image}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing *Milvus
+	}{
+		{name: "created"},
+		{name: "replaces an existing resource", existing: milvus("milvusdb/milvus:v2.5.3")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeMilvusClient()
+			if tt.existing != nil {
+				if err := client.CreateMilvus(ctx, tt.existing); err != nil {
+					t.Fatal(err)
+				}
+				if err := client.CreateMilvus(ctx, tt.existing); !errors.Is(err, ErrMilvusExists) {
+					t.Errorf("CreateMilvus() error = %v, want ErrMilvusExists for an existing resource", err)
+				}
+			}
+
+			if err := client.ApplyMilvus(ctx, milvus("milvusdb/milvus:v2.5.4")); err != nil {
+				t.Fatalf("ApplyMilvus() error = %v", err)
+			}
+			got, err := client.GetMilvus(ctx, "prod", "milvus")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Spec.Components.Image != "milvusdb/milvus:v2.5.4" {
+				t.Errorf("image = %s, want the applied milvusdb/milvus:v2.5.4", got.Spec.Components.Image)
+			}
+		})
+	}
+}
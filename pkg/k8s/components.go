@@ -0,0 +1,247 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ExtraComponents are the keys in spec.components of Milvus components that
+// MilvusComponents has no field for, such as those of newer Milvus versions.
+// Append to it to support another component; the components the installed
+// operator supports are found with Client.MilvusComponentKeys.
+var ExtraComponents []string
+
+// componentsFieldKeys are the JSON keys of the fields of MilvusComponents
+var componentsFieldKeys = func() []string {
+	var keys []string
+	t := reflect.TypeOf(MilvusComponents{})
+	for i := 0; i < t.NumField(); i++ {
+		if key, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); key != "" && key != "-" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}()
+
+// ComponentName returns the name of a component from its key in
+// spec.components: the key in lowercase, as in the operator's deploy status
+// and pod names (e.g. "queryNode" -> "querynode")
+func ComponentName(key string) string {
+	return strings.ToLower(key)
+}
+
+// fields returns the component fields by component name
+func (c *MilvusComponents) fields() map[string]**ComponentSpec {
+	return map[string]**ComponentSpec{
+		"standalone":    &c.Standalone,
+		"proxy":         &c.Proxy,
+		"rootcoord":     &c.RootCoord,
+		"querycoord":    &c.QueryCoord,
+		"datacoord":     &c.DataCoord,
+		"indexcoord":    &c.IndexCoord,
+		"querynode":     &c.QueryNode,
+		"datanode":      &c.DataNode,
+		"indexnode":     &c.IndexNode,
+		"mixcoord":      &c.MixCoord,
+		"streamingnode": &c.StreamingNode,
+	}
+}
+
+// Component returns the spec of the component called name (e.g.
+// "querynode" or "streamingnode"), adding an empty one if the resource has
+// none. Components without a field are looked up by their key in
+// ExtraComponents and extraKeys. It returns nil if name is not a component.
+func (c *MilvusComponents) Component(name string, extraKeys ...string) *ComponentSpec {
+	if field, ok := c.fields()[name]; ok {
+		if *field == nil {
+			*field = &ComponentSpec{}
+		}
+		return *field
+	}
+
+	for _, key := range append(slices.Clone(ExtraComponents), extraKeys...) {
+		if ComponentName(key) != name {
+			continue
+		}
+		if spec := c.extraSpec(key); spec != nil {
+			return spec
+		}
+		spec := &ComponentSpec{}
+		c.setExtraSpec(key, spec)
+		return spec
+	}
+	return nil
+}
+
+// ComponentSpecs returns the component specs by name: every component with
+// a field, nil if unset, and the components in ExtraComponents and
+// extraKeys that the resource sets
+func (c *MilvusComponents) ComponentSpecs(extraKeys ...string) map[string]*ComponentSpec {
+	specs := make(map[string]*ComponentSpec)
+	for name, field := range c.fields() {
+		specs[name] = *field
+	}
+	for _, key := range append(slices.Clone(ExtraComponents), extraKeys...) {
+		if spec := c.extraSpec(key); spec != nil {
+			specs[ComponentName(key)] = spec
+		}
+	}
+	return specs
+}
+
+// extraSpec returns the decoded spec of a component in Extra, or nil if the
+// resource doesn't set it. Changes to it are written back by MarshalJSON.
+func (c *MilvusComponents) extraSpec(key string) *ComponentSpec {
+	if spec, ok := c.extraSpecs[key]; ok {
+		return spec
+	}
+	raw, ok := c.Extra[key]
+	if !ok {
+		return nil
+	}
+	spec := &ComponentSpec{}
+	if err := json.Unmarshal(raw, spec); err != nil {
+		return nil
+	}
+	c.setExtraSpec(key, spec)
+	return spec
+}
+
+func (c *MilvusComponents) setExtraSpec(key string, spec *ComponentSpec) {
+	if c.extraSpecs == nil {
+		c.extraSpecs = make(map[string]*ComponentSpec)
+	}
+	c.extraSpecs[key] = spec
+}
+
+// UnmarshalJSON decodes spec.components, keeping the fields without a
+// struct field in Extra
+func (c *MilvusComponents) UnmarshalJSON(data []byte) error {
+	type plain MilvusComponents
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+
+	var extra map[string]json.RawMessage
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return err
+	}
+	for _, key := range componentsFieldKeys {
+		delete(extra, key)
+	}
+	if len(extra) > 0 {
+		p.Extra = extra
+	}
+
+	*c = MilvusComponents(p)
+	return nil
+}
+
+// MarshalJSON encodes spec.components together with the fields in Extra,
+// updated with any changes made to extra component specs
+func (c MilvusComponents) MarshalJSON() ([]byte, error) {
+	type plain MilvusComponents
+	data, err := json.Marshal(plain(c))
+	if err != nil || (len(c.Extra) == 0 && len(c.extraSpecs) == 0) {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, raw := range c.Extra {
+		fields[key] = raw
+	}
+	for key, spec := range c.extraSpecs {
+		merged, err := mergeComponentSpec(fields[key], spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode component %s: %w", key, err)
+		}
+		fields[key] = merged
+	}
+	return json.Marshal(fields)
+}
+
+// mergeComponentSpec writes the fields of spec over raw, keeping the fields
+// of raw that ComponentSpec has no field for
+func mergeComponentSpec(raw json.RawMessage, spec *ComponentSpec) (json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var set map[string]json.RawMessage
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	for key, value := range set {
+		fields[key] = value
+	}
+	return json.Marshal(fields)
+}
+
+// MilvusComponentKeys returns the keys of the components in spec.components
+// of the Milvus CRD installed in the cluster, sorted. Reading the CRD needs
+// cluster-wide permissions, so callers should treat errors as "unknown".
+func (c *Client) MilvusComponentKeys(ctx context.Context) ([]string, error) {
+	gvr := schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+	crd, err := c.dynamicClient.Resource(gvr).Get(ctx, MilvusResource+"."+MilvusGroup, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Milvus CRD: %w", err)
+	}
+	return componentKeysFromCRD(crd.Object), nil
+}
+
+// componentKeysFromCRD returns the properties of spec.components in the
+// schema of the CRD's MilvusVersion that have replicas, i.e. components
+func componentKeysFromCRD(crd map[string]interface{}) []string {
+	versions, _ := nested(crd, "spec", "versions").([]interface{})
+	for _, v := range versions {
+		version, _ := v.(map[string]interface{})
+		if version["name"] != MilvusVersion {
+			continue
+		}
+
+		properties, _ := nested(version, "schema", "openAPIV3Schema", "properties", "spec",
+			"properties", "components", "properties").(map[string]interface{})
+		var keys []string
+		for key, p := range properties {
+			property, _ := p.(map[string]interface{})
+			if nested(property, "properties", "replicas") != nil {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	return nil
+}
+
+// nested returns the value at path in nested maps, or nil
+func nested(obj map[string]interface{}, path ...string) interface{} {
+	var value interface{} = obj
+	for _, key := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestMilvusComponents_Extra(t *testing.T) {
+	data := []byte(`{
+		"image": "milvusdb/milvus:v2.6.0",
+		"proxy": {"replicas": 1},
+		"futureNode": {"replicas": 2, "serviceAccountName": "future"},
+		"enableRollingUpdate": true
+	}`)
+
+	var c MilvusComponents
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if c.Image != "milvusdb/milvus:v2.6.0" || c.Proxy == nil {
+		t.Errorf("known fields not decoded: %+v", c)
+	}
+	if len(c.Extra) != 2 {
+		t.Errorf("Extra = %v, want futureNode and enableRollingUpdate", c.Extra)
+	}
+
+	specs := c.ComponentSpecs("futureNode", "otherNode")
+	if _, ok := specs["othernode"]; ok {
+		t.Error("ComponentSpecs() includes othernode, which the resource doesn't set")
+	}
+	if specs["querynode"] != nil || specs["streamingnode"] != nil {
+		t.Error("ComponentSpecs() querynode and streamingnode should be nil")
+	}
+	if s := specs["futurenode"]; s == nil || *s.Replicas != 2 {
+		t.Errorf("ComponentSpecs() futurenode = %+v, want 2 replicas", s)
+	}
+
+	three := int32(3)
+	if c.Component("futurenode") != nil {
+		t.Error("Component() should not find a component that isn't known")
+	}
+	c.Component("futurenode", "futureNode").Replicas = &three
+	if c.Component("enablerollingupdate") != nil {
+		t.Error("Component() should not treat other fields as components")
+	}
+	c.Component("othernode", "otherNode").Replicas = &three
+	c.Component("streamingnode").Replicas = &three
+
+	out, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	future, _ := got["futureNode"].(map[string]any)
+	if future["replicas"] != float64(3) || future["serviceAccountName"] != "future" {
+		t.Errorf("futureNode = %v, want 3 replicas and its other fields kept", future)
+	}
+	for _, key := range []string{"otherNode", "streamingNode"} {
+		if spec, _ := got[key].(map[string]any); spec["replicas"] != float64(3) {
+			t.Errorf("%s = %v, want 3 replicas", key, got[key])
+		}
+	}
+	if got["enableRollingUpdate"] != true || got["image"] != "milvusdb/milvus:v2.6.0" {
+		t.Errorf("fields lost on round trip: %v", got)
+	}
+}
+
+func TestComponentKeysFromCRD(t *testing.T) {
+	component := map[string]any{"properties": map[string]any{"replicas": map[string]any{"type": "integer"}}}
+	crd := map[string]any{
+		"spec": map[string]any{
+			"versions": []any{
+				map[string]any{"name": "v1alpha1"},
+				map[string]any{
+					"name": MilvusVersion,
+					"schema": map[string]any{"openAPIV3Schema": map[string]any{"properties": map[string]any{
+						"spec": map[string]any{"properties": map[string]any{
+							"components": map[string]any{"properties": map[string]any{
+								"queryNode":     component,
+								"streamingNode": component,
+								"image":         map[string]any{"type": "string"},
+							}},
+						}},
+					}}},
+				},
+			},
+		},
+	}
+
+	got := componentKeysFromCRD(crd)
+	if want := []string{"queryNode", "streamingNode"}; !slices.Equal(got, want) {
+		t.Errorf("componentKeysFromCRD() = %v, want %v", got, want)
+	}
+	if got := componentKeysFromCRD(map[string]any{}); got != nil {
+		t.Errorf("componentKeysFromCRD(empty) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// WatchEvents calls fn for each event in a namespace about the objects of an
+// instance (see involvesInstance), or every event if instance is empty, as
+// it is created or updated, until ctx is cancelled. Events that exist when
+// it starts are not reported. The watch is resumed when the API server
+// closes it.
+func (c *Client) WatchEvents(ctx context.Context, namespace, instance string, fn func(corev1.Event)) error {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+	events := c.clientset.CoreV1().Events(namespace)
+
+	resourceVersion := ""
+	for ctx.Err() == nil {
+		if resourceVersion == "" {
+			list, err := events.List(ctx, metav1.ListOptions{Limit: 1})
+			if err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				return fmt.Errorf("failed to list events: %w", err)
+			}
+			resourceVersion = list.ResourceVersion
+		}
+
+		w, err := events.Watch(ctx, metav1.ListOptions{ResourceVersion: resourceVersion})
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return fmt.Errorf("failed to watch events: %w", err)
+		}
+		resourceVersion = watchEvents(w.ResultChan(), instance, resourceVersion, fn)
+		w.Stop()
+	}
+	return nil
+}
+
+// watchEvents passes the events received on ch to fn until ch is closed and
+// returns the resource version to resume from, or "" to start over
+func watchEvents(ch <-chan watch.Event, instance, resourceVersion string, fn func(corev1.Event)) string {
+	for e := range ch {
+		switch e.Type {
+		case watch.Added, watch.Modified:
+			ev, ok := e.Object.(*corev1.Event)
+			if !ok {
+				continue
+			}
+			resourceVersion = ev.ResourceVersion
+			if instance == "" || involvesInstance(ev.InvolvedObject.Name, instance) {
+				fn(*ev)
+			}
+		case watch.Error:
+			// Typically the resource version expired; events since then
+			// are lost, so carry on from the current state
+			return ""
+		}
+	}
+	return resourceVersion
+}
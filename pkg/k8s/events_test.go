@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testEvent(object, resourceVersion string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{ResourceVersion: resourceVersion},
+		InvolvedObject: corev1.ObjectReference{Name: object},
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []watch.Event
+		want   []string
+		wantRV string
+	}{
+		{
+			name: "filters by instance",
+			events: []watch.Event{
+				{Type: watch.Added, Object: testEvent("demo-milvus-proxy-0", "11")},
+				{Type: watch.Added, Object: testEvent("other-milvus-0", "12")},
+				{Type: watch.Added, Object: testEvent("demo2-milvus-0", "13")},
+				{Type: watch.Added, Object: testEvent("demo", "14")},
+				{Type: watch.Modified, Object: testEvent("demo-etcd-0", "15")},
+				{Type: watch.Deleted, Object: testEvent("demo-etcd-0", "16")},
+			},
+			want:   []string{"demo-milvus-proxy-0", "demo", "demo-etcd-0"},
+			wantRV: "15",
+		},
+		{
+			name:   "closed without events",
+			wantRV: "10",
+		},
+		{
+			name: "expired",
+			events: []watch.Event{
+				{Type: watch.Added, Object: testEvent("demo-milvus-0", "11")},
+				{Type: watch.Error, Object: &metav1.Status{Code: 410}},
+				{Type: watch.Added, Object: testEvent("demo-milvus-1", "12")},
+			},
+			want:   []string{"demo-milvus-0"},
+			wantRV: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan watch.Event, len(tt.events))
+			for _, e := range tt.events {
+				ch <- e
+			}
+			close(ch)
+
+			var got []string
+			rv := watchEvents(ch, "demo", "10", func(ev corev1.Event) {
+				got = append(got, ev.InvolvedObject.Name)
+			})
+			if rv != tt.wantRV {
+				t.Errorf("resource version = %q, want %q", rv, tt.wantRV)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("events = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("events = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestListEvents(t *testing.T) {
+	event := func(name, object string) runtime.Object {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "milvus"},
+			InvolvedObject: corev1.ObjectReference{Name: object},
+		}
+	}
+	clientset := fake.NewSimpleClientset(
+		event("a", "demo"),
+		event("b", "demo-milvus-proxy-0"),
+		event("c", "demo2-milvus-proxy-0"),
+		event("d", "demonstration"),
+	)
+	client := NewClientFromInterfaces(clientset, nil, "milvus")
+
+	events, err := client.ListEvents(context.Background(), "", "demo")
+	if err != nil {
+		t.Fatalf("ListEvents() error = %v", err)
+	}
+	var got []string
+	for _, ev := range events {
+		got = append(got, ev.InvolvedObject.Name)
+	}
+	if want := []string{"demo", "demo-milvus-proxy-0"}; !slices.Equal(got, want) {
+		t.Errorf("ListEvents() objects = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ErrMetricsUnavailable is returned when the cluster does not serve the
+// metrics.k8s.io API, usually because metrics-server is not installed
+var ErrMetricsUnavailable = errors.New("metrics API (metrics.k8s.io) is not available; install metrics-server to see resource usage")
+
+// metricsGroupVersion is the resource metrics API served by metrics-server
+const metricsGroupVersion = "metrics.k8s.io/v1beta1"
+
+// PodUsage is the current resource usage of a pod, summed over its containers
+type PodUsage struct {
+	Name   string
+	CPU    resource.Quantity
+	Memory resource.Quantity
+}
+
+// MetricsAvailable reports whether the metrics API is served. Discovery runs
+// once per client and the result is reused, so callers can check freely.
+// The returned error wraps ErrMetricsUnavailable.
+func (c *Client) MetricsAvailable() error {
+	c.metricsOnce.Do(func() {
+		if _, err := c.clientset.Discovery().ServerResourcesForGroupVersion(metricsGroupVersion); err != nil {
+			c.metricsErr = fmt.Errorf("%w (%v)", ErrMetricsUnavailable, err)
+		}
+	})
+	return c.metricsErr
+}
+
+// ListPodUsage lists the resource usage of pods matching a label selector.
+// It returns an error wrapping ErrMetricsUnavailable without calling the API
+// if metrics-server is not installed.
+func (c *Client) ListPodUsage(ctx context.Context, namespace, labelSelector string) ([]PodUsage, error) {
+	if err := c.MetricsAvailable(); err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	gvr := schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	var list *unstructured.UnstructuredList
+	err := retryRead(ctx, func() error {
+		var err error
+		list, err = c.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labelSelector,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod metrics: %w", err)
+	}
+
+	usage := make([]PodUsage, 0, len(list.Items))
+	for _, item := range list.Items {
+		u, err := podUsageFromMetrics(item.Object)
+		if err != nil {
+			return nil, err
+		}
+		usage = append(usage, u)
+	}
+	return usage, nil
+}
+
+// podUsageFromMetrics sums container usage from a PodMetrics object
+func podUsageFromMetrics(obj map[string]interface{}) (PodUsage, error) {
+	name, _, _ := unstructured.NestedString(obj, "metadata", "name")
+	usage := PodUsage{Name: name}
+
+	containers, _, err := unstructured.NestedSlice(obj, "containers")
+	if err != nil {
+		return usage, fmt.Errorf("invalid metrics for pod %s: %w", name, err)
+	}
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key, total := range map[string]*resource.Quantity{"cpu": &usage.CPU, "memory": &usage.Memory} {
+			value, found, _ := unstructured.NestedString(container, "usage", key)
+			if !found {
+				continue
+			}
+			q, err := resource.ParseQuantity(value)
+			if err != nil {
+				return usage, fmt.Errorf("invalid %s usage for pod %s: %w", key, name, err)
+			}
+			total.Add(q)
+		}
+	}
+	return usage, nil
+}
@@ -0,0 +1,42 @@
+package k8s
+
+import (
+	"testing"
+)
+
+func TestPodUsageFromMetrics(t *testing.T) {
+	obj := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "prod-milvus-querynode-0"},
+		"containers": []interface{}{
+			map[string]interface{}{
+				"name":  "querynode",
+				"usage": map[string]interface{}{"cpu": "250m", "memory": "1Gi"},
+			},
+			map[string]interface{}{
+				"name":  "sidecar",
+				"usage": map[string]interface{}{"cpu": "50m", "memory": "512Mi"},
+			},
+		},
+	}
+
+	usage, err := podUsageFromMetrics(obj)
+	if err != nil {
+		t.Fatalf("podUsageFromMetrics() error = %v", err)
+	}
+	if usage.Name != "prod-milvus-querynode-0" {
+		t.Errorf("Name = %q", usage.Name)
+	}
+	if got := usage.CPU.MilliValue(); got != 300 {
+		t.Errorf("CPU = %dm, want 300m", got)
+	}
+	if got := usage.Memory.Value(); got != 1536*1024*1024 {
+		t.Errorf("Memory = %d, want 1.5Gi", got)
+	}
+
+	obj["containers"] = []interface{}{
+		map[string]interface{}{"usage": map[string]interface{}{"cpu": "lots"}},
+	}
+	if _, err := podUsageFromMetrics(obj); err == nil {
+		t.Error("podUsageFromMetrics() should reject invalid quantities")
+	}
+}
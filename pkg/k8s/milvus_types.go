@@ -1,6 +1,8 @@
 package k8s
 
 import (
+	"encoding/json"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -131,6 +133,9 @@ type MilvusComponents struct {
 	// VolumeMounts specifies additional volume mounts
 	VolumeMounts []VolumeMount `json:"volumeMounts,omitempty"`
 
+	// Env specifies environment variables for all components
+	Env []EnvVar `json:"env,omitempty"`
+
 	// Standalone specifies standalone configuration
 	Standalone *ComponentSpec `json:"standalone,omitempty"`
 
@@ -157,6 +162,20 @@ type MilvusComponents struct {
 
 	// IndexNode specifies index node configuration
 	IndexNode *ComponentSpec `json:"indexNode,omitempty"`
+
+	// MixCoord specifies the combined coordinator of Milvus 2.5+
+	MixCoord *ComponentSpec `json:"mixCoord,omitempty"`
+
+	// StreamingNode specifies streaming node configuration (Milvus 2.5+)
+	StreamingNode *ComponentSpec `json:"streamingNode,omitempty"`
+
+	// Extra holds the fields of spec.components without a field above, such
+	// as the components of newer Milvus versions, so updates preserve them.
+	// Use Component to read or change an extra component.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	// extraSpecs are the decoded specs of extra components
+	extraSpecs map[string]*ComponentSpec
 }
 
 // ComponentSpec defines a component specification
@@ -175,6 +194,15 @@ type ComponentSpec struct {
 
 	// Affinity specifies affinity rules
 	Affinity interface{} `json:"affinity,omitempty"`
+
+	// Env specifies environment variables for the component
+	Env []EnvVar `json:"env,omitempty"`
+}
+
+// EnvVar defines an environment variable
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
 }
 
 // ResourceRequirements defines resource requirements
@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NamespaceObject is an object found in a namespace by NamespaceObjects
+type NamespaceObject struct {
+	// Kind is the lowercase kind of the object, e.g. "deployment"
+	Kind string
+
+	metav1.Object
+}
+
+// GetNamespace gets a namespace
+func (c *Client) GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	var ns *corev1.Namespace
+	err := retryRead(ctx, func() error {
+		var err error
+		ns, err = c.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", name, err)
+	}
+
+	return ns, nil
+}
+
+// CreateNamespace creates a namespace with the given labels
+func (c *Client) CreateNamespace(ctx context.Context, name string, labels map[string]string) error {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+	if _, err := c.clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create namespace %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// DeleteNamespace deletes a namespace and everything left in it
+func (c *Client) DeleteNamespace(ctx context.Context, name string) error {
+	if err := c.clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete namespace %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// NamespaceObjects lists the workloads (pods, deployments, statefulsets,
+// daemonsets, jobs and cronjobs), services and PVCs in a namespace
+func (c *Client) NamespaceObjects(ctx context.Context, namespace string) ([]NamespaceObject, error) {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	opts := metav1.ListOptions{}
+	lists := []struct {
+		kind string
+		list func() (runtime.Object, error)
+	}{
+		{"pod", func() (runtime.Object, error) { return c.clientset.CoreV1().Pods(namespace).List(ctx, opts) }},
+		{"deployment", func() (runtime.Object, error) { return c.clientset.AppsV1().Deployments(namespace).List(ctx, opts) }},
+		{"statefulset", func() (runtime.Object, error) { return c.clientset.AppsV1().StatefulSets(namespace).List(ctx, opts) }},
+		{"daemonset", func() (runtime.Object, error) { return c.clientset.AppsV1().DaemonSets(namespace).List(ctx, opts) }},
+		{"job", func() (runtime.Object, error) { return c.clientset.BatchV1().Jobs(namespace).List(ctx, opts) }},
+		{"cronjob", func() (runtime.Object, error) { return c.clientset.BatchV1().CronJobs(namespace).List(ctx, opts) }},
+		{"service", func() (runtime.Object, error) { return c.clientset.CoreV1().Services(namespace).List(ctx, opts) }},
+		{"pvc", func() (runtime.Object, error) {
+			return c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+		}},
+	}
+
+	var objects []NamespaceObject
+	for _, l := range lists {
+		var list runtime.Object
+		err := retryRead(ctx, func() error {
+			var err error
+			list, err = l.list()
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %w", l.kind, err)
+		}
+
+		items, err := apimeta.ExtractList(list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s list: %w", l.kind, err)
+		}
+		for _, item := range items {
+			obj, err := apimeta.Accessor(item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", l.kind, err)
+			}
+			objects = append(objects, NamespaceObject{Kind: l.kind, Object: obj})
+		}
+	}
+	return objects, nil
+}
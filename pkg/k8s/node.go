@@ -0,0 +1,31 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CordonNode marks a node unschedulable so no new pods are placed on it
+func (c *Client) CordonNode(ctx context.Context, name string) error {
+	patch := []byte(`{"spec":{"unschedulable":true}}`)
+	_, err := c.clientset.CoreV1().Nodes().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to cordon node %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeletePod deletes a pod, giving it its termination grace period to exit
+func (c *Client) DeletePod(ctx context.Context, namespace, name string) error {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	if err := c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete pod %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// ResolveServicePort finds a running pod behind a service and the pod port a
+// service port maps to. If the service doesn't expose port, the same port
+// of the pod is used, which reaches ports only the pod listens on.
+func (c *Client) ResolveServicePort(ctx context.Context, namespace, service string, port int) (string, int, error) {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	svc, err := c.clientset.CoreV1().Services(namespace).Get(ctx, service, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get service %s: %w", service, err)
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return "", 0, fmt.Errorf("service %s has no selector", service)
+	}
+
+	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to list pods of service %s: %w", service, err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		return pod.Name, podPort(svc, &pod, port), nil
+	}
+	return "", 0, fmt.Errorf("no running pod found for service %s", service)
+}
+
+// podPort maps a service port to the port of a pod behind the service
+func podPort(svc *corev1.Service, pod *corev1.Pod, port int) int {
+	for _, p := range svc.Spec.Ports {
+		if int(p.Port) != port {
+			continue
+		}
+		switch {
+		case p.TargetPort.Type == intstr.Int && p.TargetPort.IntVal != 0:
+			return int(p.TargetPort.IntVal)
+		case p.TargetPort.Type == intstr.String:
+			for _, container := range pod.Spec.Containers {
+				for _, cp := range container.Ports {
+					if cp.Name == p.TargetPort.StrVal {
+						return int(cp.ContainerPort)
+					}
+				}
+			}
+		}
+	}
+	return port
+}
+
+// PortForward forwards local ports to ports of a pod until ctx is done, like
+// kubectl port-forward. ports are "local:remote" pairs; ready is closed once
+// all ports listen on address.
+func (c *Client) PortForward(ctx context.Context, namespace, pod, address string, ports []string, ready chan struct{}, out, errOut io.Writer) error {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to create port-forward transport: %w", err)
+	}
+	url := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(pod).SubResource("portforward").URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stop := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(stop)
+	}()
+
+	fw, err := portforward.NewOnAddresses(dialer, []string{address}, ports, stop, ready, out, errOut)
+	if err != nil {
+		return fmt.Errorf("failed to forward to pod %s: %w", pod, err)
+	}
+	if err := fw.ForwardPorts(); err != nil {
+		return fmt.Errorf("port-forward to pod %s failed: %w", pod, err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestPodPort(t *testing.T) {
+	svc := &corev1.Service{Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+		{Port: 19530, TargetPort: intstr.FromString("milvus")},
+		{Port: 9091, TargetPort: intstr.FromInt32(9092)},
+		{Port: 80},
+	}}}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Ports: []corev1.ContainerPort{{Name: "milvus", ContainerPort: 19531}},
+	}}}}
+
+	tests := []struct {
+		port int
+		want int
+	}{
+		{port: 19530, want: 19531},
+		{port: 9091, want: 9092},
+		{port: 80, want: 80},
+		{port: 9001, want: 9001},
+	}
+
+	for _, tt := range tests {
+		if got := podPort(svc, pod, tt.port); got != tt.want {
+			t.Errorf("podPort(%d) = %d, want %d", tt.port, got, tt.want)
+		}
+	}
+}
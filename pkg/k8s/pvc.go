@@ -0,0 +1,114 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultStorageClassAnnotation marks the cluster's default storage class
+const DefaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// ListPVCs lists the persistent volume claims in a namespace
+func (c *Client) ListPVCs(ctx context.Context, namespace string) ([]corev1.PersistentVolumeClaim, error) {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	var pvcs *corev1.PersistentVolumeClaimList
+	err := retryRead(ctx, func() error {
+		var err error
+		pvcs, err = c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PVCs: %w", err)
+	}
+
+	return pvcs.Items, nil
+}
+
+// GetPVC gets a persistent volume claim
+func (c *Client) GetPVC(ctx context.Context, name, namespace string) (*corev1.PersistentVolumeClaim, error) {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	var pvc *corev1.PersistentVolumeClaim
+	err := retryRead(ctx, func() error {
+		var err error
+		pvc, err = c.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PVC %s: %w", name, err)
+	}
+
+	return pvc, nil
+}
+
+// ResizePVC sets the requested storage of a persistent volume claim
+func (c *Client) ResizePVC(ctx context.Context, name, namespace string, size resource.Quantity) error {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"resources": map[string]interface{}{
+				"requests": map[string]string{
+					"storage": size.String(),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.clientset.CoreV1().PersistentVolumeClaims(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to resize PVC %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetStorageClass gets a storage class. An empty name returns the
+// cluster's default storage class.
+func (c *Client) GetStorageClass(ctx context.Context, name string) (*storagev1.StorageClass, error) {
+	if name != "" {
+		var sc *storagev1.StorageClass
+		err := retryRead(ctx, func() error {
+			var err error
+			sc, err = c.clientset.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get storage class %s: %w", name, err)
+		}
+		return sc, nil
+	}
+
+	var list *storagev1.StorageClassList
+	err := retryRead(ctx, func() error {
+		var err error
+		list, err = c.clientset.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list storage classes: %w", err)
+	}
+
+	for i := range list.Items {
+		if list.Items[i].Annotations[DefaultStorageClassAnnotation] == "true" {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no default storage class found")
+}
@@ -0,0 +1,113 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// Default client-side rate limit for Kubernetes API requests. client-go's
+// own defaults (5 QPS, burst 10) throttle miup noticeably on commands that
+// look at many resources, so these are higher while staying polite to shared
+// API servers.
+const (
+	DefaultQPS   = 50
+	DefaultBurst = 100
+)
+
+// Environment variables overriding the default rate limit
+const (
+	QPSEnv   = "MIUP_KUBE_API_QPS"
+	BurstEnv = "MIUP_KUBE_API_BURST"
+)
+
+// RateLimit limits the rate of requests a client sends to the API server
+type RateLimit struct {
+	// QPS is the sustained number of requests per second
+	QPS float32
+
+	// Burst is the number of requests that may be sent at once above QPS
+	Burst int
+}
+
+var (
+	rateLimitMu sync.Mutex
+	rateLimit   = RateLimit{QPS: DefaultQPS, Burst: DefaultBurst}
+
+	// limiter enforces rateLimit for every client of the process, so that
+	// the clients of a bulk command running with --concurrency share it
+	// rather than each getting the full limit. It is created on first use.
+	limiter flowcontrol.RateLimiter
+)
+
+// DefaultRateLimit returns the rate limit of clients created without one
+func DefaultRateLimit() RateLimit {
+	rateLimitMu.Lock()
+	defer rateLimitMu.Unlock()
+	return rateLimit
+}
+
+// SetDefaultRateLimit sets the rate limit of clients created without one,
+// e.g. from command-line flags
+func SetDefaultRateLimit(limit RateLimit) error {
+	if err := limit.Validate(); err != nil {
+		return err
+	}
+	rateLimitMu.Lock()
+	defer rateLimitMu.Unlock()
+	rateLimit = limit
+	limiter = nil
+	return nil
+}
+
+// UseDefaultRateLimit makes config share the process-wide limiter of the
+// default rate limit with every other client configured by it
+func UseDefaultRateLimit(config *rest.Config) {
+	rateLimitMu.Lock()
+	defer rateLimitMu.Unlock()
+	if limiter == nil {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(rateLimit.QPS, rateLimit.Burst)
+	}
+	config.QPS = rateLimit.QPS
+	config.Burst = rateLimit.Burst
+	config.RateLimiter = limiter
+}
+
+// Validate checks that the rate limit allows requests
+func (l RateLimit) Validate() error {
+	if l.QPS <= 0 {
+		return fmt.Errorf("invalid API QPS %g: must be positive", l.QPS)
+	}
+	if l.Burst < 1 {
+		return fmt.Errorf("invalid API burst %d: must be at least 1", l.Burst)
+	}
+	return nil
+}
+
+// RateLimitFromEnv returns the default rate limit with the values set in
+// MIUP_KUBE_API_QPS and MIUP_KUBE_API_BURST applied
+func RateLimitFromEnv() (RateLimit, error) {
+	limit := RateLimit{QPS: DefaultQPS, Burst: DefaultBurst}
+
+	if v := strings.TrimSpace(os.Getenv(QPSEnv)); v != "" {
+		qps, err := strconv.ParseFloat(v, 32)
+		if err != nil {
+			return limit, fmt.Errorf("invalid %s %q: %w", QPSEnv, v, err)
+		}
+		limit.QPS = float32(qps)
+	}
+	if v := strings.TrimSpace(os.Getenv(BurstEnv)); v != "" {
+		burst, err := strconv.Atoi(v)
+		if err != nil {
+			return limit, fmt.Errorf("invalid %s %q: %w", BurstEnv, v, err)
+		}
+		limit.Burst = burst
+	}
+
+	return limit, limit.Validate()
+}
@@ -0,0 +1,88 @@
+package k8s
+
+import (
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestRateLimitFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		qps     string
+		burst   string
+		want    RateLimit
+		wantErr bool
+	}{
+		{"defaults", "", "", RateLimit{QPS: DefaultQPS, Burst: DefaultBurst}, false},
+		{"both", "20.5", "40", RateLimit{QPS: 20.5, Burst: 40}, false},
+		{"qps only", "10", "", RateLimit{QPS: 10, Burst: DefaultBurst}, false},
+		{"invalid qps", "fast", "", RateLimit{}, true},
+		{"zero qps", "0", "", RateLimit{}, true},
+		{"zero burst", "", "0", RateLimit{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(QPSEnv, tt.qps)
+			t.Setenv(BurstEnv, tt.burst)
+
+			got, err := RateLimitFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RateLimitFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("RateLimitFromEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetDefaultRateLimit(t *testing.T) {
+	t.Cleanup(func() { _ = SetDefaultRateLimit(RateLimit{QPS: DefaultQPS, Burst: DefaultBurst}) })
+
+	if err := SetDefaultRateLimit(RateLimit{QPS: 5, Burst: 0}); err == nil {
+		t.Error("SetDefaultRateLimit() with zero burst should fail")
+	}
+	if got := DefaultRateLimit(); got.QPS != DefaultQPS || got.Burst != DefaultBurst {
+		t.Errorf("invalid limit was applied: %+v", got)
+	}
+
+	want := RateLimit{QPS: 5, Burst: 10}
+	if err := SetDefaultRateLimit(want); err != nil {
+		t.Fatalf("SetDefaultRateLimit() error = %v", err)
+	}
+	if got := DefaultRateLimit(); got != want {
+		t.Errorf("DefaultRateLimit() = %+v, want %+v", got, want)
+	}
+}
+
+func TestUseDefaultRateLimit(t *testing.T) {
+	t.Cleanup(func() { _ = SetDefaultRateLimit(RateLimit{QPS: DefaultQPS, Burst: DefaultBurst}) })
+	if err := SetDefaultRateLimit(RateLimit{QPS: 0.001, Burst: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two clients, e.g. of two instances listed concurrently
+	a, b := &rest.Config{}, &rest.Config{}
+	UseDefaultRateLimit(a)
+	UseDefaultRateLimit(b)
+	if a.RateLimiter == nil || a.RateLimiter != b.RateLimiter {
+		t.Fatal("clients should share one limiter")
+	}
+	if !a.RateLimiter.TryAccept() {
+		t.Fatal("the first request should be allowed by the burst")
+	}
+	if b.RateLimiter.TryAccept() {
+		t.Error("the second client got a request past the shared limit")
+	}
+
+	if err := SetDefaultRateLimit(RateLimit{QPS: 5, Burst: 10}); err != nil {
+		t.Fatal(err)
+	}
+	c := &rest.Config{}
+	UseDefaultRateLimit(c)
+	if c.RateLimiter == a.RateLimiter || c.QPS != 5 || c.Burst != 10 {
+		t.Errorf("config = QPS %g, burst %d, want a new limiter of the new limit", c.QPS, c.Burst)
+	}
+}
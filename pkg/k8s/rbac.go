@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Permission is a verb on a kind of resource, e.g. create milvuses.milvus.io
+// in a namespace
+type Permission struct {
+	Verb     string `json:"verb"`
+	Group    string `json:"group,omitempty"`
+	Resource string `json:"resource"`
+
+	// Namespace is empty for cluster-scoped resources
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// String formats the permission like "create milvuses.milvus.io in namespace milvus"
+func (p Permission) String() string {
+	resource := p.Resource
+	if p.Group != "" {
+		resource += "." + p.Group
+	}
+	if p.Namespace == "" {
+		return p.Verb + " " + resource
+	}
+	return fmt.Sprintf("%s %s in namespace %s", p.Verb, resource, p.Namespace)
+}
+
+// DeployPermissions returns the permissions needed to deploy and manage an
+// instance in namespace, including creating the namespace if createNamespace
+func DeployPermissions(namespace string, createNamespace bool) []Permission {
+	var perms []Permission
+	if createNamespace {
+		perms = append(perms, Permission{Verb: "create", Resource: "namespaces"})
+	}
+	for _, verb := range []string{"create", "update", "delete"} {
+		perms = append(perms, Permission{Verb: verb, Group: MilvusGroup, Resource: MilvusResource, Namespace: namespace})
+	}
+	for _, verb := range []string{"create", "update", "delete"} {
+		perms = append(perms, Permission{Verb: verb, Resource: "secrets", Namespace: namespace})
+	}
+	return perms
+}
+
+// MissingPermissions asks the API server which of perms the current user
+// lacks, with a SelfSubjectAccessReview for each
+func MissingPermissions(ctx context.Context, clientset kubernetes.Interface, perms []Permission) ([]Permission, error) {
+	reviews := clientset.AuthorizationV1().SelfSubjectAccessReviews()
+
+	var missing []Permission
+	for _, p := range perms {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: p.Namespace,
+					Verb:      p.Verb,
+					Group:     p.Group,
+					Resource:  p.Resource,
+				},
+			},
+		}
+		result, err := reviews.Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check permission to %s: %w", p, err)
+		}
+		if !result.Status.Allowed {
+			missing = append(missing, p)
+		}
+	}
+	return missing, nil
+}
+
+// CheckDeployPermissions returns the permissions needed to deploy into
+// namespace and those the current user lacks. Creating the namespace is
+// only checked if createNamespace is set and the namespace is known not to
+// exist: a user scoped to the namespace may not be allowed to get it.
+func CheckDeployPermissions(ctx context.Context, clientset kubernetes.Interface, namespace string, createNamespace bool) (perms, missing []Permission, err error) {
+	if createNamespace {
+		_, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		createNamespace = IsNotFound(err)
+	}
+
+	perms = DeployPermissions(namespace, createNamespace)
+	missing, err = MissingPermissions(ctx, clientset, perms)
+	if err != nil {
+		return nil, nil, err
+	}
+	return perms, missing, nil
+}
+
+// CheckDeployPermissions is CheckDeployPermissions for the client's user
+func (c *Client) CheckDeployPermissions(ctx context.Context, namespace string, createNamespace bool) (perms, missing []Permission, err error) {
+	return CheckDeployPermissions(ctx, c.clientset, namespace, createNamespace)
+}
+
+// PermissionNames formats each permission with String
+func PermissionNames(perms []Permission) []string {
+	names := make([]string, len(perms))
+	for i, p := range perms {
+		names[i] = p.String()
+	}
+	return names
+}
@@ -0,0 +1,120 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPermissionString(t *testing.T) {
+	tests := []struct {
+		perm Permission
+		want string
+	}{
+		{Permission{Verb: "create", Group: MilvusGroup, Resource: MilvusResource, Namespace: "milvus"}, "create milvuses.milvus.io in namespace milvus"},
+		{Permission{Verb: "delete", Resource: "secrets", Namespace: "dev"}, "delete secrets in namespace dev"},
+		{Permission{Verb: "create", Resource: "namespaces"}, "create namespaces"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.perm.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestDeployPermissions(t *testing.T) {
+	perms := DeployPermissions("milvus", false)
+	for _, p := range perms {
+		if p.Namespace != "milvus" {
+			t.Errorf("%s is not scoped to the namespace", p)
+		}
+	}
+	if len(perms) != 6 {
+		t.Errorf("len(DeployPermissions()) = %d, want 6", len(perms))
+	}
+
+	perms = DeployPermissions("milvus", true)
+	if perms[0].String() != "create namespaces" {
+		t.Errorf("DeployPermissions(createNamespace) should include creating namespaces, got %v", perms)
+	}
+}
+
+func TestMissingPermissions(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = attrs.Resource == MilvusResource || attrs.Verb == "create"
+		return true, review, nil
+	})
+
+	missing, err := MissingPermissions(context.Background(), clientset, DeployPermissions("milvus", true))
+	if err != nil {
+		t.Fatalf("MissingPermissions() error = %v", err)
+	}
+
+	want := []string{"update secrets in namespace milvus", "delete secrets in namespace milvus"}
+	if len(missing) != len(want) {
+		t.Fatalf("missing = %v, want %v", missing, want)
+	}
+	for i, p := range missing {
+		if p.String() != want[i] {
+			t.Errorf("missing[%d] = %s, want %s", i, p, want[i])
+		}
+	}
+}
+
+func TestCheckDeployPermissions(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "milvus", errors.New("no access"))
+
+	tests := []struct {
+		name            string
+		objects         []runtime.Object
+		getErr          error
+		createNamespace bool
+		wantCreate      bool
+	}{
+		{name: "exists", objects: []runtime.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "milvus"}}}, createNamespace: true},
+		{name: "missing", createNamespace: true, wantCreate: true},
+		{name: "not allowed to get", getErr: forbidden, createNamespace: true},
+		{name: "not creating", createNamespace: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(tt.objects...)
+			if tt.getErr != nil {
+				clientset.PrependReactor("get", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tt.getErr
+				})
+			}
+			clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				review.Status.Allowed = review.Spec.ResourceAttributes.Resource != "secrets"
+				return true, review, nil
+			})
+
+			perms, missing, err := CheckDeployPermissions(context.Background(), clientset, "milvus", tt.createNamespace)
+			if err != nil {
+				t.Fatalf("CheckDeployPermissions() error = %v", err)
+			}
+			if got := slices.Contains(PermissionNames(perms), "create namespaces"); got != tt.wantCreate {
+				t.Errorf("checked %v, want create namespaces checked = %v", PermissionNames(perms), tt.wantCreate)
+			}
+			if len(missing) != 3 || missing[0].Resource != "secrets" {
+				t.Errorf("missing = %v, want the three secrets permissions", PermissionNames(missing))
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ContainerStartTime returns when the current instance of a pod's container
+// started, i.e. since its last restart. An empty container means the pod's
+// first container.
+func (c *Client) ContainerStartTime(ctx context.Context, namespace, podName, container string) (time.Time, error) {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	var pod *corev1.Pod
+	err := retryRead(ctx, func() error {
+		var err error
+		pod, err = c.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		return err
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get pod: %w", err)
+	}
+	return containerStartTime(pod, container)
+}
+
+// containerStartTime returns when the current instance of a container
+// started. A container waiting to restart (e.g. in CrashLoopBackOff) has no
+// current instance, so the last one, whose logs are the current ones, is
+// used.
+func containerStartTime(pod *corev1.Pod, container string) (time.Time, error) {
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != container {
+			continue
+		}
+		switch {
+		case status.State.Running != nil:
+			return status.State.Running.StartedAt.Time, nil
+		case status.State.Terminated != nil:
+			return status.State.Terminated.StartedAt.Time, nil
+		case status.LastTerminationState.Terminated != nil:
+			return status.LastTerminationState.Terminated.StartedAt.Time, nil
+		}
+		return time.Time{}, fmt.Errorf("container %s of pod %s has not started", container, pod.Name)
+	}
+	return time.Time{}, fmt.Errorf("pod %s has no status for container %s", pod.Name, container)
+}
@@ -0,0 +1,59 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestContainerStartTime(t *testing.T) {
+	started := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	at := metav1.NewTime(started)
+
+	tests := []struct {
+		name    string
+		status  corev1.ContainerStatus
+		wantErr bool
+	}{
+		{
+			name:   "running",
+			status: corev1.ContainerStatus{State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: at}}},
+		},
+		{
+			name:   "terminated",
+			status: corev1.ContainerStatus{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{StartedAt: at}}},
+		},
+		{
+			name: "crash loop",
+			status: corev1.ContainerStatus{
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{StartedAt: at}},
+			},
+		},
+		{
+			name:    "never started",
+			status:  corev1.ContainerStatus{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.status.Name = "milvus"
+			pod := &corev1.Pod{
+				Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "milvus"}, {Name: "sidecar"}}},
+				Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "sidecar"}, tt.status}},
+			}
+
+			got, err := containerStartTime(pod, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("containerStartTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(started) {
+				t.Errorf("containerStartTime() = %v, want %v", got, started)
+			}
+		})
+	}
+}
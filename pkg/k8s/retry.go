@@ -0,0 +1,52 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// readRetryAttempts is the number of attempts for read-only API calls
+const readRetryAttempts = 4
+
+// readRetryBaseDelay is the initial backoff delay, doubled after each attempt
+var readRetryBaseDelay = 200 * time.Millisecond
+
+// IsNotFound reports whether err indicates the resource does not exist
+func IsNotFound(err error) bool {
+	return apierrors.IsNotFound(err)
+}
+
+// IsTransient reports whether err is a temporary API failure (throttling,
+// timeouts, server unavailability) that is worth retrying
+func IsTransient(err error) bool {
+	return apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsUnexpectedServerError(err)
+}
+
+// retryRead runs fn, retrying with exponential backoff while it returns a
+// transient error. Non-transient errors (e.g. not found) are returned immediately.
+func retryRead(ctx context.Context, fn func() error) error {
+	delay := readRetryBaseDelay
+
+	var err error
+	for attempt := 1; attempt <= readRetryAttempts; attempt++ {
+		err = fn()
+		if err == nil || !IsTransient(err) || attempt == readRetryAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return err
+}
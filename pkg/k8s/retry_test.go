@@ -0,0 +1,89 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsTransient(t *testing.T) {
+	gr := schema.GroupResource{Group: MilvusGroup, Resource: MilvusResource}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"too many requests", apierrors.NewTooManyRequests("throttled", 1), true},
+		{"service unavailable", apierrors.NewServiceUnavailable("down"), true},
+		{"timeout", apierrors.NewTimeoutError("slow", 1), true},
+		{"not found", apierrors.NewNotFound(gr, "demo"), false},
+		{"forbidden", apierrors.NewForbidden(gr, "demo", errors.New("denied")), false},
+		{"plain error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err); got != tt.want {
+				t.Errorf("IsTransient() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryRead(t *testing.T) {
+	orig := readRetryBaseDelay
+	readRetryBaseDelay = time.Millisecond
+	defer func() { readRetryBaseDelay = orig }()
+
+	gr := schema.GroupResource{Group: MilvusGroup, Resource: MilvusResource}
+
+	t.Run("succeeds after transient errors", func(t *testing.T) {
+		calls := 0
+		err := retryRead(context.Background(), func() error {
+			calls++
+			if calls < 3 {
+				return apierrors.NewTooManyRequests("throttled", 1)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("retryRead() error = %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("calls = %d, want 3", calls)
+		}
+	})
+
+	t.Run("not found is not retried", func(t *testing.T) {
+		calls := 0
+		err := retryRead(context.Background(), func() error {
+			calls++
+			return apierrors.NewNotFound(gr, "demo")
+		})
+		if !IsNotFound(err) {
+			t.Errorf("retryRead() error = %v, want not found", err)
+		}
+		if calls != 1 {
+			t.Errorf("calls = %d, want 1", calls)
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		calls := 0
+		err := retryRead(context.Background(), func() error {
+			calls++
+			return apierrors.NewServiceUnavailable("down")
+		})
+		if err == nil {
+			t.Error("retryRead() should return the last error")
+		}
+		if calls != readRetryAttempts {
+			t.Errorf("calls = %d, want %d", calls, readRetryAttempts)
+		}
+	})
+}
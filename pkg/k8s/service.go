@@ -0,0 +1,132 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MilvusPort is the port Milvus serves clients on
+const MilvusPort = 19530
+
+// GetMilvusService returns the address clients reach a Milvus cluster on,
+// depending on the type of its service: the LoadBalancer ingress, a node's
+// address and the node port, or the cluster IP. A headless service, or a
+// LoadBalancer without an ingress yet, falls back to the in-cluster address.
+func (c *Client) GetMilvusService(ctx context.Context, name, namespace string) (string, error) {
+	svc, err := c.GetMilvusServiceObject(ctx, name, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	port := MilvusServicePort(svc)
+	if port == nil {
+		return "", fmt.Errorf("no ports found in service")
+	}
+
+	var node string
+	if svc.Spec.Type == corev1.ServiceTypeNodePort {
+		if node, err = c.NodeAddress(ctx); err != nil {
+			return "", err
+		}
+	}
+	if external := ExternalAddress(svc, port, node); external != "" {
+		return external, nil
+	}
+	return ClusterAddress(svc, port), nil
+}
+
+// GetMilvusServiceObject returns the service exposing a Milvus cluster
+func (c *Client) GetMilvusServiceObject(ctx context.Context, name, namespace string) (*corev1.Service, error) {
+	if namespace == "" {
+		namespace = c.namespace
+	}
+
+	var svc *corev1.Service
+	err := retryRead(ctx, func() error {
+		var err error
+		svc, err = c.clientset.CoreV1().Services(namespace).Get(ctx, name+"-milvus", metav1.GetOptions{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service: %w", err)
+	}
+	return svc, nil
+}
+
+// MilvusServicePort returns the port of a Milvus service serving clients:
+// the one named "milvus" or on the Milvus port, else the first. It returns
+// nil if the service has no ports.
+func MilvusServicePort(svc *corev1.Service) *corev1.ServicePort {
+	if len(svc.Spec.Ports) == 0 {
+		return nil
+	}
+	for i, p := range svc.Spec.Ports {
+		if p.Name == "milvus" || p.Port == MilvusPort {
+			return &svc.Spec.Ports[i]
+		}
+	}
+	return &svc.Spec.Ports[0]
+}
+
+// ClusterAddress returns the in-cluster address of a service port: the
+// cluster IP, or the service's DNS name if it is headless or has no IP yet
+func ClusterAddress(svc *corev1.Service, port *corev1.ServicePort) string {
+	host := svc.Spec.ClusterIP
+	if host == "" || host == corev1.ClusterIPNone {
+		host = fmt.Sprintf("%s.%s.svc", svc.Name, svc.Namespace)
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(port.Port)))
+}
+
+// ExternalAddress returns the address a service port is reachable on from
+// outside the cluster: the first LoadBalancer ingress, or node and the node
+// port for a NodePort service. It returns "" if there is none (yet).
+func ExternalAddress(svc *corev1.Service, port *corev1.ServicePort, node string) string {
+	switch svc.Spec.Type {
+	case corev1.ServiceTypeLoadBalancer:
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			host := ingress.IP
+			if host == "" {
+				host = ingress.Hostname
+			}
+			if host != "" {
+				return net.JoinHostPort(host, strconv.Itoa(int(port.Port)))
+			}
+		}
+	case corev1.ServiceTypeNodePort:
+		if node != "" && port.NodePort != 0 {
+			return net.JoinHostPort(node, strconv.Itoa(int(port.NodePort)))
+		}
+	}
+	return ""
+}
+
+// NodeAddress returns an address of a cluster node that NodePort services
+// can be reached on, preferring external addresses
+func (c *Client) NodeAddress(ctx context.Context) (string, error) {
+	nodes, err := c.ListNodes(ctx)
+	if err != nil {
+		return "", err
+	}
+	return nodeAddress(nodes), nil
+}
+
+// nodeAddress returns the first external IP of nodes, or the first internal
+// IP if no node has one
+func nodeAddress(nodes []corev1.Node) string {
+	for _, addrType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP} {
+		for _, node := range nodes {
+			for _, addr := range node.Status.Addresses {
+				if addr.Type == addrType && addr.Address != "" {
+					return addr.Address
+				}
+			}
+		}
+	}
+	return ""
+}
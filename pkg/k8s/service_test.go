@@ -0,0 +1,41 @@
+package k8s
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNodeAddress(t *testing.T) {
+	node := func(addrs ...corev1.NodeAddress) corev1.Node {
+		return corev1.Node{Status: corev1.NodeStatus{Addresses: addrs}}
+	}
+	internal := func(ip string) corev1.NodeAddress {
+		return corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: ip}
+	}
+	external := func(ip string) corev1.NodeAddress {
+		return corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: ip}
+	}
+
+	tests := []struct {
+		name  string
+		nodes []corev1.Node
+		want  string
+	}{
+		{name: "no nodes", want: ""},
+		{name: "internal only", nodes: []corev1.Node{node(internal("10.0.0.1"))}, want: "10.0.0.1"},
+		{
+			name:  "external on a later node",
+			nodes: []corev1.Node{node(internal("10.0.0.1")), node(internal("10.0.0.2"), external("203.0.113.7"))},
+			want:  "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeAddress(tt.nodes); got != tt.want {
+				t.Errorf("nodeAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package localdata
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// BackupSuffix is appended to a file path to store its previous contents
+const BackupSuffix = ".bak"
+
+// WriteFileAtomic writes data to a temporary file in the same directory and
+// renames it over path, so readers never observe a partially written file
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// WriteFileWithBackup atomically replaces path with data, first copying the
+// current contents to path+BackupSuffix. The current contents are only
+// backed up if parse accepts them, so that a corrupt file never replaces a
+// good backup.
+func WriteFileWithBackup(path string, data []byte, perm os.FileMode, parse func([]byte) error) error {
+	if old, err := os.ReadFile(path); err == nil && parse(old) == nil {
+		if err := WriteFileAtomic(path+BackupSuffix, old, perm); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+	}
+	return WriteFileAtomic(path, data, perm)
+}
+
+// ReadFileWithBackup reads path and passes its contents to parse. If path is
+// missing or parse rejects it, the backup kept by WriteFileWithBackup is
+// parsed instead and usedBackup is true. If the backup can't be used either,
+// the error of path is returned, unwrapped so that os.IsNotExist works.
+func ReadFileWithBackup(path string, parse func([]byte) error) (usedBackup bool, err error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		err = parse(data)
+		if err == nil {
+			return false, nil
+		}
+	}
+
+	backup, bakErr := os.ReadFile(path + BackupSuffix)
+	if bakErr != nil || parse(backup) != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,140 @@
+package localdata
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "meta.json")
+
+	if err := WriteFileAtomic(path, []byte("one"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+	if err := WriteFileAtomic(path, []byte("two"), 0600); err != nil {
+		t.Fatalf("WriteFileAtomic() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "two" {
+		t.Errorf("content = %q, %v, want two", data, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
+
+// parseWord accepts contents that are a single lowercase word; a digit
+// stands for a partially written file
+func parseWord(data []byte) error {
+	for _, c := range data {
+		if c < 'a' || c > 'z' {
+			return errors.New("corrupt")
+		}
+	}
+	if len(data) == 0 {
+		return errors.New("empty")
+	}
+	return nil
+}
+
+func TestWriteFileWithBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta.json")
+
+	if err := WriteFileWithBackup(path, []byte("one"), 0644, parseWord); err != nil {
+		t.Fatalf("WriteFileWithBackup() error = %v", err)
+	}
+	if _, err := os.Stat(path + BackupSuffix); !os.IsNotExist(err) {
+		t.Error("first write should not create a backup")
+	}
+
+	if err := WriteFileWithBackup(path, []byte("two"), 0644, parseWord); err != nil {
+		t.Fatalf("WriteFileWithBackup() error = %v", err)
+	}
+	backup, err := os.ReadFile(path + BackupSuffix)
+	if err != nil || string(backup) != "one" {
+		t.Errorf("backup = %q, %v, want one", backup, err)
+	}
+
+	// A corrupt file must not replace the good backup
+	if err := os.WriteFile(path, []byte("tw0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFileWithBackup(path, []byte("three"), 0644, parseWord); err != nil {
+		t.Fatalf("WriteFileWithBackup() error = %v", err)
+	}
+	backup, err = os.ReadFile(path + BackupSuffix)
+	if err != nil || string(backup) != "one" {
+		t.Errorf("backup after corrupt file = %q, %v, want one", backup, err)
+	}
+}
+
+func TestReadFileWithBackup(t *testing.T) {
+	tests := []struct {
+		name       string
+		primary    string
+		backup     string
+		want       string
+		wantBackup bool
+		wantErr    func(error) bool
+	}{
+		{name: "valid", primary: "two", backup: "one", want: "two"},
+		{name: "corrupt", primary: "tw0", backup: "one", want: "one", wantBackup: true},
+		{name: "missing", backup: "one", want: "one", wantBackup: true},
+		{name: "corrupt without backup", primary: "tw0", wantErr: func(err error) bool { return err.Error() == "corrupt" }},
+		{name: "corrupt backup", primary: "tw0", backup: "on0", wantErr: func(err error) bool { return err.Error() == "corrupt" }},
+		{name: "neither", wantErr: os.IsNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "meta.json")
+			write := func(path, content string) {
+				if content == "" {
+					return
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			write(path, tt.primary)
+			write(path+BackupSuffix, tt.backup)
+
+			var got string
+			usedBackup, err := ReadFileWithBackup(path, func(data []byte) error {
+				if err := parseWord(data); err != nil {
+					return err
+				}
+				got = string(data)
+				return nil
+			})
+			if tt.wantErr != nil {
+				if err == nil || !tt.wantErr(err) {
+					t.Errorf("ReadFileWithBackup() error = %v, want the error of the file", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadFileWithBackup() error = %v", err)
+			}
+			if got != tt.want || usedBackup != tt.wantBackup {
+				t.Errorf("ReadFileWithBackup() = %q, usedBackup %v, want %q, %v", got, usedBackup, tt.want, tt.wantBackup)
+			}
+		})
+	}
+}
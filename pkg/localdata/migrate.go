@@ -0,0 +1,111 @@
+package localdata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Migrate moves all profile data to dest and returns a profile rooted there.
+// dest must not exist or must be an empty directory. A rename is attempted
+// first; across filesystems the tree is copied and the original removed.
+func (p *Profile) Migrate(dest string) (*Profile, error) {
+	dest, err := filepath.Abs(dest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", dest, err)
+	}
+	src, err := filepath.Abs(p.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", p.root, err)
+	}
+
+	if src == dest {
+		return nil, fmt.Errorf("profile is already at %s", dest)
+	}
+	if rel, err := filepath.Rel(src, dest); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("destination %s is inside the current profile %s", dest, src)
+	}
+	if _, err := os.Stat(src); err != nil {
+		return nil, fmt.Errorf("profile %s not found: %w", src, err)
+	}
+
+	entries, err := os.ReadDir(dest)
+	switch {
+	case err == nil && len(entries) > 0:
+		return nil, fmt.Errorf("destination %s is not empty", dest)
+	case err == nil:
+		// An empty directory cannot be the target of a rename on all platforms
+		if err := os.Remove(dest); err != nil {
+			return nil, fmt.Errorf("failed to prepare destination: %w", err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to read destination: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create destination parent: %w", err)
+	}
+
+	if err := os.Rename(src, dest); err != nil {
+		if err := copyTree(src, dest); err != nil {
+			os.RemoveAll(dest)
+			return nil, fmt.Errorf("failed to copy profile: %w", err)
+		}
+		if err := os.RemoveAll(src); err != nil {
+			return nil, fmt.Errorf("profile copied but failed to remove %s: %w", src, err)
+		}
+	}
+
+	return NewProfile(dest), nil
+}
+
+// copyTree copies a directory tree, preserving file modes and symlinks
+func copyTree(src, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		default:
+			// Skip sockets, pipes and devices
+			return nil
+		}
+	})
+}
+
+func copyFile(src, dest string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,82 @@
+package localdata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfile_Migrate(t *testing.T) {
+	base := t.TempDir()
+	p := NewProfile(filepath.Join(base, "old"))
+	if err := p.InitProfile(); err != nil {
+		t.Fatal(err)
+	}
+	binary := filepath.Join(p.ComponentDir("birdwatcher"), "v1.0.0", "birdwatcher")
+	if err := os.MkdirAll(filepath.Dir(binary), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(binary, []byte("bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	migrated, err := p.Migrate(filepath.Join(base, "new"))
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if migrated.Root() != filepath.Join(base, "new") {
+		t.Errorf("Root() = %s, want %s", migrated.Root(), filepath.Join(base, "new"))
+	}
+	if p.Exists() {
+		t.Error("old profile should be removed after migration")
+	}
+	data, err := os.ReadFile(filepath.Join(migrated.ComponentDir("birdwatcher"), "v1.0.0", "birdwatcher"))
+	if err != nil || string(data) != "bin" {
+		t.Errorf("migrated binary = %q, %v", data, err)
+	}
+}
+
+func TestProfile_MigrateRejectsInvalidDestination(t *testing.T) {
+	base := t.TempDir()
+	p := NewProfile(filepath.Join(base, "old"))
+	if err := p.InitProfile(); err != nil {
+		t.Fatal(err)
+	}
+
+	nonEmpty := filepath.Join(base, "nonempty")
+	if err := os.MkdirAll(filepath.Join(nonEmpty, "x"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dest := range []string{p.Root(), filepath.Join(p.Root(), "sub"), nonEmpty} {
+		if _, err := p.Migrate(dest); err == nil {
+			t.Errorf("Migrate(%s) should fail", dest)
+		}
+	}
+}
+
+func TestCopyTree(t *testing.T) {
+	src := t.TempDir()
+	dest := filepath.Join(t.TempDir(), "copy")
+	if err := os.WriteFile(filepath.Join(src, "run.sh"), []byte("#!/bin/sh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("run.sh", filepath.Join(src, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := copyTree(src, dest); err != nil {
+		t.Fatalf("copyTree() error = %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dest, "run.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("mode = %v, want 0755", info.Mode().Perm())
+	}
+	if link, err := os.Readlink(filepath.Join(dest, "link")); err != nil || link != "run.sh" {
+		t.Errorf("Readlink() = %q, %v, want run.sh", link, err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 )
 
 const (
+	// HomeEnv is the environment variable that overrides the profile root
+	HomeEnv = "MIUP_HOME"
 	// ProfileDirName is the name of the profile directory
 	ProfileDirName = ".miup"
 	// ComponentParentDir is the directory to store components
@@ -16,6 +18,8 @@ const (
 	StorageParentDir = "storage"
 	// TelemetryDir is the directory for telemetry data
 	TelemetryDir = "telemetry"
+	// CacheParentDir is the directory to store cached downloads
+	CacheParentDir = "cache"
 )
 
 // Profile represents a local profile for miup
@@ -30,7 +34,7 @@ func NewProfile(root string) *Profile {
 
 // DefaultProfile returns the default profile based on MIUP_HOME or HOME
 func DefaultProfile() (*Profile, error) {
-	root := os.Getenv("MIUP_HOME")
+	root := os.Getenv(HomeEnv)
 	if root == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
@@ -86,6 +90,11 @@ func (p *Profile) ClusterTopologyPath(cluster string) string {
 	return p.Path(StorageParentDir, cluster, "topology.yaml")
 }
 
+// CacheDir returns the download cache directory path
+func (p *Profile) CacheDir() string {
+	return p.Path(CacheParentDir)
+}
+
 // EnsureDir ensures the directory exists
 func (p *Profile) EnsureDir(path string) error {
 	return os.MkdirAll(path, 0755)
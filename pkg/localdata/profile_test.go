@@ -47,6 +47,7 @@ func TestProfile_DirectoryPaths(t *testing.T) {
 		{"ComponentsDir", p.ComponentsDir, "/root/components"},
 		{"DataDir", p.DataDir, "/root/data"},
 		{"StorageDir", p.StorageDir, "/root/storage"},
+		{"CacheDir", p.CacheDir, "/root/cache"},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Event types
+const (
+	// EventPhaseStart is emitted when a long operation (deploy, upgrade,
+	// mirror pull, ...) begins
+	EventPhaseStart = "phase_start"
+
+	// EventPhaseEnd is emitted when an operation finishes, with Error set if
+	// it failed
+	EventPhaseEnd = "phase_end"
+
+	// EventComponentReady is emitted when all replicas of a component are ready
+	EventComponentReady = "component_ready"
+
+	// EventImagePulled is emitted when an image has been pulled
+	EventImagePulled = "image_pulled"
+)
+
+// Event is a structured progress event for tools wrapping miup
+type Event struct {
+	Time    time.Time         `json:"time"`
+	Type    string            `json:"type"`
+	Phase   string            `json:"phase,omitempty"`
+	Message string            `json:"message,omitempty"`
+	Error   string            `json:"error,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// eventStream is the running event emitter, if any
+var eventStream struct {
+	sync.RWMutex
+	ch   chan Event
+	done chan struct{}
+}
+
+// StartEvents starts an emitter writing each event as a line of JSON to w.
+// The returned function stops it once all emitted events are written.
+func StartEvents(w io.Writer) (stop func()) {
+	ch := make(chan Event, 64)
+	done := make(chan struct{})
+
+	eventStream.Lock()
+	eventStream.ch, eventStream.done = ch, done
+	eventStream.Unlock()
+
+	go func() {
+		defer close(done)
+		enc := json.NewEncoder(w)
+		for e := range ch {
+			// A broken pipe must not fail the operation being reported on
+			_ = enc.Encode(e)
+		}
+	}()
+
+	return func() {
+		eventStream.Lock()
+		if eventStream.ch == ch {
+			close(ch)
+			eventStream.ch = nil
+		}
+		eventStream.Unlock()
+		<-done
+	}
+}
+
+// Emit sends an event to the emitter; it does nothing if none is running,
+// and drops the event if the emitter is that far behind. Time is set to now
+// if unset.
+func Emit(e Event) {
+	eventStream.RLock()
+	defer eventStream.RUnlock()
+	if eventStream.ch == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	// Drop the event rather than hold up the operation behind a slow reader
+	select {
+	case eventStream.ch <- e:
+	default:
+	}
+}
+
+// PhaseStart emits the start of an operation
+func PhaseStart(phase string, fields map[string]string) {
+	Emit(Event{Type: EventPhaseStart, Phase: phase, Fields: fields})
+}
+
+// PhaseEnd emits the end of an operation, failed if err is not nil
+func PhaseEnd(phase string, err error, fields map[string]string) {
+	e := Event{Type: EventPhaseEnd, Phase: phase, Fields: fields}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	Emit(e)
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvents(t *testing.T) {
+	// Not running: dropped
+	PhaseStart("ignored", nil)
+
+	var buf bytes.Buffer
+	stop := StartEvents(&buf)
+	PhaseStart("deploy", map[string]string{"cluster": "prod"})
+	Emit(Event{Type: EventComponentReady, Fields: map[string]string{"component": "proxy"}})
+	PhaseEnd("deploy", errors.New("timed out"), map[string]string{"cluster": "prod"})
+	stop()
+
+	// Stopped: dropped
+	PhaseEnd("ignored", nil, nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d events, want 3:\n%s", len(lines), buf.String())
+	}
+
+	var events []Event
+	for _, line := range lines {
+		var e Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid event %q: %v", line, err)
+		}
+		if e.Time.IsZero() {
+			t.Errorf("event %q has no time", line)
+		}
+		events = append(events, e)
+	}
+
+	if e := events[0]; e.Type != EventPhaseStart || e.Phase != "deploy" || e.Fields["cluster"] != "prod" {
+		t.Errorf("events[0] = %+v", e)
+	}
+	if e := events[1]; e.Type != EventComponentReady || e.Fields["component"] != "proxy" {
+		t.Errorf("events[1] = %+v", e)
+	}
+	if e := events[2]; e.Type != EventPhaseEnd || e.Error != "timed out" {
+		t.Errorf("events[2] = %+v", e)
+	}
+}
+
+// blockedWriter blocks every write until unblocked
+type blockedWriter struct {
+	unblock chan struct{}
+	n       int
+}
+
+func (w *blockedWriter) Write(p []byte) (int, error) {
+	<-w.unblock
+	w.n++
+	return len(p), nil
+}
+
+func TestEventsSlowReader(t *testing.T) {
+	w := &blockedWriter{unblock: make(chan struct{})}
+	stop := StartEvents(w)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 200 {
+			PhaseStart("deploy", nil)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Emit() blocked on a slow reader")
+	}
+
+	close(w.unblock)
+	stop()
+	if w.n == 0 || w.n >= 200 {
+		t.Errorf("wrote %d events, want the buffered ones and the rest dropped", w.n)
+	}
+}
@@ -0,0 +1,204 @@
+// Package miup is the supported Go API for managing Milvus instances.
+//
+// It wraps the same cluster manager used by the miup command line, so
+// instances created through a Client are visible to "miup instance" and vice
+// versa. Types in other pkg/ packages are internal details and may change
+// between releases; programs embedding miup should depend on this package.
+//
+// Progress messages are written through pkg/logger; use logger.SetOutput to
+// redirect or discard them.
+package miup
+
+import (
+	"context"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/manager"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+// Errors returned by Client methods. Match them with errors.Is.
+var (
+	ErrNotFound            = manager.ErrClusterNotFound
+	ErrExists              = manager.ErrClusterExists
+	ErrInvalidTopology     = manager.ErrInvalidTopology
+	ErrOperationInProgress = manager.ErrOperationInProgress
+	ErrTimeout             = executor.ErrTimeout
+	ErrWaitCancelled       = executor.ErrWaitCancelled
+
+	// ErrMetaNotFound is returned by a MetaStore for an instance without
+	// metadata
+	ErrMetaNotFound = manager.ErrMetaNotFound
+)
+
+// Options configures a Client
+type Options struct {
+	// Home is the miup data directory. Defaults to $MIUP_HOME or ~/.miup.
+	Home string
+
+	// MetaStore persists instance metadata. Defaults to JSON files under Home.
+	MetaStore MetaStore
+}
+
+// Instance describes a managed Milvus instance
+type Instance struct {
+	Name          string    `json:"name"`
+	Status        string    `json:"status"`
+	Mode          string    `json:"mode"`
+	Backend       string    `json:"backend"`
+	MilvusVersion string    `json:"milvus_version"`
+	Namespace     string    `json:"namespace,omitempty"`
+	MilvusPort    int       `json:"milvus_port"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Details       string    `json:"details,omitempty"`
+}
+
+// Client manages Milvus instances stored under a miup home directory
+type Client struct {
+	profile *localdata.Profile
+	mgr     *manager.Manager
+}
+
+// NewClient creates a new client
+func NewClient(opts Options) (*Client, error) {
+	profile := localdata.NewProfile(opts.Home)
+	if opts.Home == "" {
+		var err error
+		profile, err = localdata.DefaultProfile()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := profile.InitProfile(); err != nil {
+		return nil, err
+	}
+
+	mgr := manager.NewManager(profile)
+	if opts.MetaStore != nil {
+		mgr = manager.NewManagerWithStore(profile, metaStoreAdapter{opts.MetaStore})
+	}
+
+	return &Client{
+		profile: profile,
+		mgr:     mgr,
+	}, nil
+}
+
+// Home returns the miup data directory used by the client
+func (c *Client) Home() string {
+	return c.profile.Root()
+}
+
+// Deploy deploys a new instance from a topology file
+func (c *Client) Deploy(ctx context.Context, name, topologyPath string, opts DeployOptions) error {
+	return c.mgr.Deploy(ctx, name, topologyPath, opts.toManager())
+}
+
+// Start starts a stopped instance
+func (c *Client) Start(ctx context.Context, name string) error {
+	return c.mgr.Start(ctx, name)
+}
+
+// Stop stops a running instance
+func (c *Client) Stop(ctx context.Context, name string) error {
+	return c.mgr.Stop(ctx, name)
+}
+
+// Destroy removes an instance and its local metadata. With force, local
+// metadata is removed even if deleting the backend resources fails.
+func (c *Client) Destroy(ctx context.Context, name string, force bool) error {
+	return c.mgr.Destroy(ctx, name, manager.DestroyOptions{Force: force})
+}
+
+// Get returns a single instance. Details holds the backend status report
+// shown by "miup instance display".
+func (c *Client) Get(ctx context.Context, name string) (*Instance, error) {
+	info, err := c.mgr.Display(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	inst := newInstance(info.Meta)
+	inst.Details = info.ContainerStatus
+	return inst, nil
+}
+
+// List returns all instances with their current status
+func (c *Client) List(ctx context.Context) ([]*Instance, error) {
+	metas, err := c.mgr.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]*Instance, 0, len(metas))
+	for _, meta := range metas {
+		instances = append(instances, newInstance(meta))
+	}
+	return instances, nil
+}
+
+// Scale changes the replicas or resources of a component
+func (c *Client) Scale(ctx context.Context, name, component string, opts ScaleOptions) error {
+	return c.mgr.Scale(ctx, name, component, opts.toExecutor())
+}
+
+// Replicas returns the ready replica count of each component
+func (c *Client) Replicas(ctx context.Context, name string) (map[string]int, error) {
+	return c.mgr.GetReplicas(ctx, name)
+}
+
+// ReplicaCounts returns the desired and ready replica count of each component
+func (c *Client) ReplicaCounts(ctx context.Context, name string) (map[string]ReplicaCount, error) {
+	counts, err := c.mgr.GetReplicaCounts(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]ReplicaCount, len(counts))
+	for component, count := range counts {
+		result[component] = ReplicaCount{Desired: count.Desired, Ready: count.Ready}
+	}
+	return result, nil
+}
+
+// Upgrade upgrades an instance to the given Milvus version
+func (c *Client) Upgrade(ctx context.Context, name, version string) error {
+	return c.mgr.Upgrade(ctx, name, version)
+}
+
+// Version returns the Milvus version the instance is running
+func (c *Client) Version(ctx context.Context, name string) (string, error) {
+	return c.mgr.GetVersion(ctx, name)
+}
+
+// Reload applies configuration changes to an instance
+func (c *Client) Reload(ctx context.Context, name string, opts ReloadOptions) error {
+	return c.mgr.Reload(ctx, name, opts.toManager())
+}
+
+// Diagnose runs health checks against an instance
+func (c *Client) Diagnose(ctx context.Context, name string) (*DiagnoseResult, error) {
+	result, err := c.mgr.Diagnose(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return newDiagnoseResult(result), nil
+}
+
+func newInstance(meta *spec.ClusterMeta) *Instance {
+	return &Instance{
+		Name:          meta.Name,
+		Status:        string(meta.Status),
+		Mode:          string(meta.Mode),
+		Backend:       string(meta.Backend),
+		MilvusVersion: meta.MilvusVersion,
+		Namespace:     meta.Namespace,
+		MilvusPort:    meta.MilvusPort,
+		CreatedAt:     meta.CreatedAt,
+		UpdatedAt:     meta.UpdatedAt,
+	}
+}
@@ -0,0 +1,134 @@
+package miup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+)
+
+func TestNewClient(t *testing.T) {
+	home := t.TempDir()
+	c, err := NewClient(Options{Home: home})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if c.Home() != home {
+		t.Errorf("Home() = %s, want %s", c.Home(), home)
+	}
+
+	instances, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(instances) != 0 {
+		t.Errorf("List() = %v, want empty", instances)
+	}
+}
+
+func TestClientNotFound(t *testing.T) {
+	c, err := NewClient(Options{Home: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := c.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+	if err := c.Scale(ctx, "missing", "querynode", ScaleOptions{Replicas: 2}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Scale() error = %v, want ErrNotFound", err)
+	}
+	if _, err := c.Diagnose(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Diagnose() error = %v, want ErrNotFound", err)
+	}
+}
+
+// memoryStore is a MetaStore keeping metadata in a map
+type memoryStore map[string][]byte
+
+func (s memoryStore) Load(name string) ([]byte, error) {
+	data, ok := s[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMetaNotFound, name)
+	}
+	return data, nil
+}
+
+func (s memoryStore) Save(name string, data []byte) error {
+	s[name] = data
+	return nil
+}
+
+func (s memoryStore) List() ([]string, error) {
+	names := slices.Collect(maps.Keys(s))
+	slices.Sort(names)
+	return names, nil
+}
+
+func (s memoryStore) Delete(name string) error {
+	delete(s, name)
+	return nil
+}
+
+func TestMetaStoreAdapter(t *testing.T) {
+	store := memoryStore{}
+	adapter := metaStoreAdapter{store}
+
+	meta := &spec.ClusterMeta{Name: "prod", Status: spec.StatusRunning, MilvusPort: 19530}
+	if err := adapter.Save("prod", meta); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := adapter.Load("prod")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got.Name != meta.Name || got.Status != meta.Status || got.MilvusPort != meta.MilvusPort {
+		t.Errorf("Load() = %+v, want %+v", got, meta)
+	}
+
+	if _, err := adapter.Load("missing"); !errors.Is(err, ErrMetaNotFound) {
+		t.Errorf("Load(missing) error = %v, want ErrMetaNotFound", err)
+	}
+
+	store["broken"] = []byte("{")
+	if _, err := adapter.Load("broken"); err == nil || errors.Is(err, ErrMetaNotFound) {
+		t.Errorf("Load(broken) error = %v, want a parse error", err)
+	}
+}
+
+func TestDeployOptionsToManager(t *testing.T) {
+	opts := DeployOptions{Namespace: "milvus", Set: []string{"global.namespace=x"}, TTL: time.Hour, StrictEnv: true}
+	got := opts.toManager()
+	if !got.SkipConfirm {
+		t.Error("SkipConfirm = false, want true without a terminal to confirm on")
+	}
+	if got.Namespace != "milvus" || len(got.Set) != 1 || got.TTL != time.Hour || !got.StrictEnv {
+		t.Errorf("toManager() = %+v, want the options of %+v", got, opts)
+	}
+}
+
+func TestNewDiagnoseResult(t *testing.T) {
+	result := newDiagnoseResult(&executor.DiagnoseResult{
+		Healthy:    false,
+		Summary:    "1 issue",
+		Components: []executor.ComponentCheck{{Name: "proxy", Status: executor.CheckStatusError, Replicas: 2, Ready: 1}},
+		Issues:     []executor.Issue{{Severity: executor.CheckStatusError, Component: "proxy", Description: "not ready"}},
+	})
+
+	if result.Healthy || result.Summary != "1 issue" {
+		t.Errorf("result = %+v, want unhealthy with the summary", result)
+	}
+	if len(result.Components) != 1 || result.Components[0].Status != CheckStatusError || result.Components[0].Ready != 1 {
+		t.Errorf("Components = %+v, want the proxy check", result.Components)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Description != "not ready" {
+		t.Errorf("Issues = %+v, want the proxy issue", result.Issues)
+	}
+}
@@ -0,0 +1,62 @@
+package miup
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+)
+
+// MetaStore persists instance metadata, e.g. in a ConfigMap or object store
+// shared by a team. The metadata of an instance is an opaque JSON document
+// that the store keeps as is.
+type MetaStore interface {
+	// Load returns the metadata of an instance. It returns an error wrapping
+	// ErrMetaNotFound if the instance has none.
+	Load(name string) ([]byte, error)
+
+	// Save creates or replaces the metadata of an instance
+	Save(name string, data []byte) error
+
+	// List returns the names of all instances in the store, sorted
+	List() ([]string, error)
+
+	// Delete removes the metadata of an instance; it is not an error if the
+	// instance has none
+	Delete(name string) error
+}
+
+// metaStoreAdapter serves a MetaStore to the manager, encoding the metadata
+// as JSON
+type metaStoreAdapter struct {
+	store MetaStore
+}
+
+func (a metaStoreAdapter) Load(name string) (*spec.ClusterMeta, error) {
+	data, err := a.store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &spec.ClusterMeta{}
+	if err := json.Unmarshal(data, meta); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata of '%s': %w", name, err)
+	}
+	return meta, nil
+}
+
+func (a metaStoreAdapter) Save(name string, meta *spec.ClusterMeta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata of '%s': %w", name, err)
+	}
+	return a.store.Save(name, data)
+}
+
+func (a metaStoreAdapter) List() ([]string, error) {
+	return a.store.List()
+}
+
+func (a metaStoreAdapter) Delete(name string) error {
+	return a.store.Delete(name)
+}
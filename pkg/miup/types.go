@@ -0,0 +1,230 @@
+package miup
+
+import (
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/cluster/manager"
+)
+
+// DeployOptions contains options for Deploy
+type DeployOptions struct {
+	// MilvusVersion overrides the Milvus version of the topology
+	MilvusVersion string
+
+	// Kubernetes options
+	Kubeconfig  string
+	KubeContext string
+	Namespace   string
+	WithMonitor bool
+
+	// Set are PATH=VALUE overrides applied to the topology before
+	// validation, like "miup instance deploy --set"
+	Set []string
+
+	// Env is merged into components.env of the topology, overriding the
+	// variables set there; the env of a single component still takes
+	// precedence over it
+	Env map[string]string
+
+	// SpreadZones sets anti_affinity: zone on components that have none
+	SpreadZones bool
+
+	// Labels are user labels for selecting the instance in bulk operations
+	Labels map[string]string
+
+	// TTL makes the instance expire this long after deploy, so that
+	// "miup instance reap" destroys it. Zero means no expiry.
+	TTL time.Duration
+
+	// Apply adopts and updates a Milvus resource that already exists in
+	// Kubernetes
+	Apply bool
+
+	// CreateNamespace creates the namespace before deploying if it does not
+	// exist
+	CreateNamespace bool
+
+	// StrictEnv fails the deploy if the topology references an undefined
+	// environment variable without a default
+	StrictEnv bool
+}
+
+func (o DeployOptions) toManager() manager.DeployOptions {
+	return manager.DeployOptions{
+		MilvusVersion: o.MilvusVersion,
+		// There is no terminal to confirm on
+		SkipConfirm:     true,
+		Kubeconfig:      o.Kubeconfig,
+		KubeContext:     o.KubeContext,
+		Namespace:       o.Namespace,
+		WithMonitor:     o.WithMonitor,
+		Set:             o.Set,
+		Env:             o.Env,
+		SpreadZones:     o.SpreadZones,
+		Labels:          o.Labels,
+		TTL:             o.TTL,
+		Apply:           o.Apply,
+		CreateNamespace: o.CreateNamespace,
+		StrictEnv:       o.StrictEnv,
+	}
+}
+
+// ScaleOptions contains options for Scale. Empty fields are left unchanged.
+type ScaleOptions struct {
+	// Replicas is the target number of replicas (0 means no change)
+	Replicas int
+
+	// CPURequest is the CPU request (e.g., "2", "500m")
+	CPURequest string
+
+	// CPULimit is the CPU limit (e.g., "4", "1000m")
+	CPULimit string
+
+	// MemoryRequest is the memory request (e.g., "4Gi", "512Mi")
+	MemoryRequest string
+
+	// MemoryLimit is the memory limit (e.g., "8Gi", "1024Mi")
+	MemoryLimit string
+
+	// Env sets or overrides environment variables on the component
+	Env map[string]string
+}
+
+func (o ScaleOptions) toExecutor() executor.ScaleOptions {
+	return executor.ScaleOptions{
+		Replicas:      o.Replicas,
+		CPURequest:    o.CPURequest,
+		CPULimit:      o.CPULimit,
+		MemoryRequest: o.MemoryRequest,
+		MemoryLimit:   o.MemoryLimit,
+		Env:           o.Env,
+	}
+}
+
+// ReloadOptions contains options for Reload
+type ReloadOptions struct {
+	// ConfigFile is the path to a config file to import before reloading
+	ConfigFile string
+	// Config is the configuration to merge before reloading
+	Config map[string]any
+	// Wait indicates whether to wait for pods to become ready
+	Wait bool
+	// Timeout is the maximum time to wait for reload to complete
+	Timeout time.Duration
+}
+
+func (o ReloadOptions) toManager() manager.ReloadOptions {
+	return manager.ReloadOptions{
+		ConfigFile: o.ConfigFile,
+		Config:     o.Config,
+		Wait:       o.Wait,
+		Timeout:    o.Timeout,
+	}
+}
+
+// ReplicaCount is the desired and ready replica count of a component
+type ReplicaCount struct {
+	// Desired is the replica count requested in the Milvus resource
+	Desired int `json:"desired"`
+
+	// Ready is the number of ready pods reported by the Milvus Operator
+	Ready int `json:"ready"`
+}
+
+// CheckStatus is the outcome of a diagnosis check
+type CheckStatus string
+
+const (
+	CheckStatusOK      CheckStatus = "OK"
+	CheckStatusWarning CheckStatus = "WARNING"
+	CheckStatusError   CheckStatus = "ERROR"
+)
+
+// DiagnoseResult contains the results of a health diagnosis
+type DiagnoseResult struct {
+	Healthy      bool                `json:"healthy"`
+	Summary      string              `json:"summary"`
+	Components   []ComponentCheck    `json:"components"`
+	Connectivity []ConnectivityCheck `json:"connectivity"`
+	Resources    []ResourceCheck     `json:"resources"`
+	Issues       []Issue             `json:"issues"`
+}
+
+// ComponentCheck is the health of a component
+type ComponentCheck struct {
+	Name     string      `json:"name"`
+	Status   CheckStatus `json:"status"`
+	Message  string      `json:"message"`
+	Replicas int         `json:"replicas,omitempty"`
+	Ready    int         `json:"ready,omitempty"`
+}
+
+// ConnectivityCheck is the reachability of a service
+type ConnectivityCheck struct {
+	Name    string      `json:"name"`
+	Target  string      `json:"target"`
+	Status  CheckStatus `json:"status"`
+	Latency string      `json:"latency,omitempty"`
+	Message string      `json:"message"`
+}
+
+// ResourceCheck is the usage of a resource
+type ResourceCheck struct {
+	Name    string      `json:"name"`
+	Status  CheckStatus `json:"status"`
+	Usage   string      `json:"usage"`
+	Limit   string      `json:"limit,omitempty"`
+	Message string      `json:"message"`
+}
+
+// Issue is a problem found by a diagnosis
+type Issue struct {
+	Severity    CheckStatus `json:"severity"`
+	Component   string      `json:"component"`
+	Description string      `json:"description"`
+	Suggestion  string      `json:"suggestion"`
+}
+
+func newDiagnoseResult(r *executor.DiagnoseResult) *DiagnoseResult {
+	result := &DiagnoseResult{
+		Healthy: r.Healthy,
+		Summary: r.Summary,
+	}
+	for _, c := range r.Components {
+		result.Components = append(result.Components, ComponentCheck{
+			Name:     c.Name,
+			Status:   CheckStatus(c.Status),
+			Message:  c.Message,
+			Replicas: c.Replicas,
+			Ready:    c.Ready,
+		})
+	}
+	for _, c := range r.Connectivity {
+		result.Connectivity = append(result.Connectivity, ConnectivityCheck{
+			Name:    c.Name,
+			Target:  c.Target,
+			Status:  CheckStatus(c.Status),
+			Latency: c.Latency,
+			Message: c.Message,
+		})
+	}
+	for _, c := range r.Resources {
+		result.Resources = append(result.Resources, ResourceCheck{
+			Name:    c.Name,
+			Status:  CheckStatus(c.Status),
+			Usage:   c.Usage,
+			Limit:   c.Limit,
+			Message: c.Message,
+		})
+	}
+	for _, i := range r.Issues {
+		result.Issues = append(result.Issues, Issue{
+			Severity:    CheckStatus(i.Severity),
+			Component:   i.Component,
+			Description: i.Description,
+			Suggestion:  i.Suggestion,
+		})
+	}
+	return result
+}
@@ -6,11 +6,14 @@ type ErrorCode string
 const (
 	ErrNotFound      ErrorCode = "NOT_FOUND"
 	ErrAlreadyExists ErrorCode = "ALREADY_EXISTS"
+	ErrConflict      ErrorCode = "CONFLICT"
 	ErrTimeout       ErrorCode = "TIMEOUT"
 	ErrPermission    ErrorCode = "PERMISSION_DENIED"
 	ErrInvalidInput  ErrorCode = "INVALID_INPUT"
 	ErrK8sConnection ErrorCode = "K8S_CONNECTION_ERROR"
 	ErrInternal      ErrorCode = "INTERNAL_ERROR"
+	ErrCheckFailed   ErrorCode = "CHECK_FAILED"
+	ErrCheckWarned   ErrorCode = "CHECK_WARNED"
 )
 
 // StructuredError represents an error with a code and message for JSON output.
@@ -73,6 +73,7 @@ func TestErrorCodes(t *testing.T) {
 	codes := []ErrorCode{
 		ErrNotFound,
 		ErrAlreadyExists,
+		ErrConflict,
 		ErrTimeout,
 		ErrPermission,
 		ErrInvalidInput,
@@ -20,6 +20,11 @@ type ComponentInfo struct {
 	Active      bool      `json:"active"`
 	InstalledAt time.Time `json:"installed_at"`
 	Path        string    `json:"path"`
+	Size        int64     `json:"size"`
+
+	// Latest and UpdateAvailable are set for active versions by --check-updates
+	Latest          string `json:"latest,omitempty"`
+	UpdateAvailable bool   `json:"update_available,omitempty"`
 }
 
 // AvailableComponent represents an available (not installed) component.
@@ -32,6 +37,7 @@ type AvailableComponent struct {
 // ComponentList represents a list of components.
 type ComponentList struct {
 	Components []ComponentInfo `json:"components"`
+	TotalSize  int64           `json:"total_size"`
 }
 
 // InstanceSummary represents summary information about a Milvus instance.
@@ -43,7 +49,10 @@ type InstanceSummary struct {
 	Version   string    `json:"version"`
 	Port      int       `json:"port"`
 	Namespace string    `json:"namespace,omitempty"`
+	Endpoint  string    `json:"endpoint,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
+	// Managed is set when listing all namespaces: "miup", "untracked" or "external"
+	Managed string `json:"managed,omitempty"`
 }
 
 // InstanceList represents a list of instances.
@@ -58,6 +67,7 @@ type InstanceInfo struct {
 	Mode      string                 `json:"mode"`
 	Backend   string                 `json:"backend"`
 	Version   string                 `json:"version"`
+	Image     string                 `json:"image,omitempty"`
 	Port      int                    `json:"port"`
 	Namespace string                 `json:"namespace,omitempty"`
 	CreatedAt time.Time              `json:"created_at"`
@@ -0,0 +1,245 @@
+package playground
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// DefaultBenchMetricsPort is the host port go-vdbbench serves metrics on
+	DefaultBenchMetricsPort = 9101
+	// BenchDashboardUID is the UID of the combined benchmark and Milvus dashboard
+	BenchDashboardUID = "miup-bench"
+
+	// grafanaDatasourceName is the Prometheus datasource the dashboard uses
+	grafanaDatasourceName = "Prometheus"
+	// grafanaDatasourceUID is the UID given to the datasource if miup creates it
+	grafanaDatasourceUID = "miup-prometheus"
+	// grafanaUser and grafanaPassword are the playground Grafana admin credentials
+	grafanaUser     = "admin"
+	grafanaPassword = "admin"
+)
+
+// BenchMonitor describes where the metrics of a monitored benchmark are shown
+type BenchMonitor struct {
+	PrometheusURL string `json:"prometheus_url"`
+	DashboardURL  string `json:"dashboard_url"`
+}
+
+// MonitorBench adds a go-vdbbench exporter on the host to the playground's
+// Prometheus scrape targets, in a file of ScrapeDir so that prometheus.yml is
+// left as it is, and installs a Grafana dashboard that shows the benchmark
+// and Milvus server metrics together
+func (m *Manager) MonitorBench(ctx context.Context, tag string, metricsPort int) (*BenchMonitor, error) {
+	playgroundDir := m.PlaygroundDir(tag)
+
+	if _, err := os.Stat(playgroundDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("playground '%s' does not exist", tag)
+	}
+
+	meta, err := m.loadMeta(tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metadata: %w", err)
+	}
+	if !meta.WithMonitor {
+		return nil, fmt.Errorf("playground '%s' was started without monitoring; restart it with 'miup playground start --with-monitor'", tag)
+	}
+
+	defaults := DefaultConfig()
+	prometheusPort, grafanaPort := meta.PrometheusPort, meta.GrafanaPort
+	if prometheusPort == 0 {
+		prometheusPort = defaults.PrometheusPort
+	}
+	if grafanaPort == 0 {
+		grafanaPort = defaults.GrafanaPort
+	}
+	prometheusURL := fmt.Sprintf("http://127.0.0.1:%d", prometheusPort)
+	grafanaURL := fmt.Sprintf("http://127.0.0.1:%d", grafanaPort)
+
+	scrapeDir := filepath.Join(playgroundDir, ScrapeDir)
+	if _, err := os.Stat(scrapeDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("playground '%s' was started before benchmark monitoring was supported; clean it and start it again with --with-monitor", tag)
+	}
+	config := GenerateBenchScrapeConfig(fmt.Sprintf("host.docker.internal:%d", metricsPort))
+	if err := os.WriteFile(filepath.Join(scrapeDir, "vdbbench.yml"), []byte(config), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write prometheus scrape config: %w", err)
+	}
+	if _, err := doRequest(ctx, http.MethodPost, prometheusURL+"/-/reload", nil, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to reload prometheus: %w", err)
+	}
+
+	datasourceUID, err := ensureDatasource(ctx, grafanaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"dashboard": BenchDashboard(datasourceUID),
+		"overwrite": true,
+	}
+	if _, err := doRequest(ctx, http.MethodPost, grafanaURL+"/api/dashboards/db", body, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to create grafana dashboard: %w", err)
+	}
+
+	return &BenchMonitor{
+		PrometheusURL: fmt.Sprintf("http://localhost:%d", prometheusPort),
+		DashboardURL:  fmt.Sprintf("http://localhost:%d/d/%s", grafanaPort, BenchDashboardUID),
+	}, nil
+}
+
+// ensureDatasource returns the UID of the Grafana Prometheus datasource,
+// creating it if the playground Grafana has none yet
+func ensureDatasource(ctx context.Context, grafanaURL string) (string, error) {
+	var ds struct {
+		UID string `json:"uid"`
+	}
+
+	data, err := doRequest(ctx, http.MethodGet, grafanaURL+"/api/datasources/name/"+grafanaDatasourceName, nil, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return "", fmt.Errorf("failed to get grafana datasource: %w", err)
+	}
+	if data != nil {
+		if err := json.Unmarshal(data, &ds); err != nil {
+			return "", fmt.Errorf("failed to parse grafana datasource: %w", err)
+		}
+		return ds.UID, nil
+	}
+
+	body := map[string]interface{}{
+		"name":      grafanaDatasourceName,
+		"uid":       grafanaDatasourceUID,
+		"type":      "prometheus",
+		"url":       "http://prometheus:9090",
+		"access":    "proxy",
+		"isDefault": true,
+	}
+	if _, err := doRequest(ctx, http.MethodPost, grafanaURL+"/api/datasources", body, http.StatusOK); err != nil {
+		return "", fmt.Errorf("failed to create grafana datasource: %w", err)
+	}
+	return grafanaDatasourceUID, nil
+}
+
+// doRequest sends a JSON request with the playground Grafana credentials and
+// returns the response body. A 404 listed in ok returns a nil body.
+func doRequest(ctx context.Context, method, url string, body interface{}, ok ...int) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(grafanaUser, grafanaPassword)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range ok {
+		if resp.StatusCode == code {
+			if code == http.StatusNotFound {
+				return nil, nil
+			}
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, firstLine(string(data), fmt.Errorf("empty response")))
+}
+
+// BenchDashboard returns a Grafana dashboard model with go-vdbbench client
+// metrics next to the Milvus server metrics
+func BenchDashboard(datasourceUID string) map[string]interface{} {
+	datasource := map[string]string{"type": "prometheus", "uid": datasourceUID}
+
+	panels := []struct {
+		title string
+		unit  string
+		exprs map[string]string
+	}{
+		{"Benchmark throughput", "ops", map[string]string{
+			"{{operation}}": `sum by (operation) (rate(vdbbench_operations_total[30s]))`,
+		}},
+		{"Benchmark latency", "s", map[string]string{
+			"p50 {{operation}}": `histogram_quantile(0.50, sum by (le, operation) (rate(vdbbench_latency_seconds_bucket[30s])))`,
+			"p99 {{operation}}": `histogram_quantile(0.99, sum by (le, operation) (rate(vdbbench_latency_seconds_bucket[30s])))`,
+		}},
+		{"Benchmark errors", "ops", map[string]string{
+			"{{operation}}": `sum by (operation) (rate(vdbbench_errors_total[30s]))`,
+		}},
+		{"Milvus requests", "reqps", map[string]string{
+			"{{function_name}}": `sum by (function_name) (rate(milvus_proxy_req_count{job="milvus"}[1m]))`,
+		}},
+		{"Milvus search latency", "ms", map[string]string{
+			"p50": `histogram_quantile(0.50, sum by (le) (rate(milvus_proxy_sq_latency_bucket{job="milvus"}[1m])))`,
+			"p99": `histogram_quantile(0.99, sum by (le) (rate(milvus_proxy_sq_latency_bucket{job="milvus"}[1m])))`,
+		}},
+		{"Milvus CPU and memory", "short", map[string]string{
+			"cpu cores":   `rate(process_cpu_seconds_total{job="milvus"}[1m])`,
+			"memory (GB)": `process_resident_memory_bytes{job="milvus"} / 1e9`,
+		}},
+	}
+
+	models := make([]map[string]interface{}, 0, len(panels))
+	for i, p := range panels {
+		var targets []map[string]interface{}
+		for _, legend := range sortedKeys(p.exprs) {
+			targets = append(targets, map[string]interface{}{
+				"datasource":   datasource,
+				"expr":         p.exprs[legend],
+				"legendFormat": legend,
+				"refId":        string(rune('A' + len(targets))),
+			})
+		}
+		models = append(models, map[string]interface{}{
+			"id":          i + 1,
+			"type":        "timeseries",
+			"title":       p.title,
+			"datasource":  datasource,
+			"gridPos":     map[string]int{"x": (i % 2) * 12, "y": (i / 2) * 8, "w": 12, "h": 8},
+			"fieldConfig": map[string]interface{}{"defaults": map[string]string{"unit": p.unit}},
+			"targets":     targets,
+		})
+	}
+
+	return map[string]interface{}{
+		"uid":           BenchDashboardUID,
+		"title":         "MiUp Benchmark",
+		"tags":          []string{"miup", "vdbbench", "milvus"},
+		"refresh":       "5s",
+		"time":          map[string]string{"from": "now-15m", "to": "now"},
+		"schemaVersion": 39,
+		"panels":        models,
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,153 @@
+package playground
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+func TestGenerateBenchScrapeConfig(t *testing.T) {
+	content := GenerateBenchScrapeConfig("host.docker.internal:9101")
+
+	if !strings.HasPrefix(content, "scrape_configs:") {
+		t.Error("Should be a scrape config file")
+	}
+	if !strings.Contains(content, "job_name: 'vdbbench'") {
+		t.Error("Should contain vdbbench job")
+	}
+	if !strings.Contains(content, "host.docker.internal:9101") {
+		t.Error("Should target the bench exporter")
+	}
+}
+
+func TestBenchDashboard(t *testing.T) {
+	data, err := json.Marshal(BenchDashboard("prom"))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	content := string(data)
+
+	for _, metric := range []string{"vdbbench_operations_total", "vdbbench_latency_seconds_bucket", "milvus_proxy_req_count"} {
+		if !strings.Contains(content, metric) {
+			t.Errorf("dashboard should query %s", metric)
+		}
+	}
+	if !strings.Contains(content, `"uid":"prom"`) {
+		t.Error("dashboard should use the given datasource UID")
+	}
+}
+
+func TestMonitorBench(t *testing.T) {
+	var reloaded bool
+	prometheus := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/-/reload" {
+			reloaded = true
+		}
+	}))
+	defer prometheus.Close()
+
+	var createdDatasource bool
+	var dashboard map[string]interface{}
+	grafana := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/datasources/name/Prometheus":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/api/datasources":
+			createdDatasource = true
+		case r.URL.Path == "/api/dashboards/db":
+			var body struct {
+				Dashboard map[string]interface{} `json:"dashboard"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			dashboard = body.Dashboard
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer grafana.Close()
+
+	m := NewManager(localdata.NewProfile(t.TempDir()))
+	dir := m.PlaygroundDir("bench")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// prometheus.yml as edited by the user
+	userConfig := GeneratePrometheusConfig(nil) + `
+  - job_name: 'node'
+    static_configs:
+      - targets: ['host.docker.internal:9100']
+`
+	if err := os.WriteFile(filepath.Join(dir, "prometheus.yml"), []byte(userConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	meta := &Meta{
+		Tag:            "bench",
+		WithMonitor:    true,
+		PrometheusPort: serverPort(t, prometheus),
+		GrafanaPort:    serverPort(t, grafana),
+	}
+	if err := m.saveMeta("bench", meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.MonitorBench(context.Background(), "bench", 9101); err == nil || !strings.Contains(err.Error(), "start it again") {
+		t.Errorf("MonitorBench() error = %v, want one for a playground without %s", err, ScrapeDir)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ScrapeDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	mon, err := m.MonitorBench(context.Background(), "bench", 9101)
+	if err != nil {
+		t.Fatalf("MonitorBench() error = %v", err)
+	}
+
+	scrape, err := os.ReadFile(filepath.Join(dir, ScrapeDir, "vdbbench.yml"))
+	if err != nil || !strings.Contains(string(scrape), "host.docker.internal:9101") {
+		t.Errorf("%s/vdbbench.yml should scrape the bench exporter, got %q (%v)", ScrapeDir, scrape, err)
+	}
+	if config, err := os.ReadFile(filepath.Join(dir, "prometheus.yml")); err != nil || string(config) != userConfig {
+		t.Errorf("prometheus.yml = %q (%v), want it left as the user edited it", config, err)
+	}
+	if !reloaded {
+		t.Error("Prometheus should be reloaded")
+	}
+	if !createdDatasource {
+		t.Error("Grafana datasource should be created when missing")
+	}
+	if dashboard["uid"] != BenchDashboardUID {
+		t.Errorf("dashboard uid = %v, want %s", dashboard["uid"], BenchDashboardUID)
+	}
+	if !strings.HasSuffix(mon.DashboardURL, "/d/"+BenchDashboardUID) {
+		t.Errorf("DashboardURL = %s", mon.DashboardURL)
+	}
+
+	meta.WithMonitor = false
+	if err := m.saveMeta("bench", meta); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.MonitorBench(context.Background(), "bench", 9101); err == nil {
+		t.Error("MonitorBench() should fail without monitoring")
+	}
+}
+
+func serverPort(t *testing.T, s *httptest.Server) int {
+	_, port, err := net.SplitHostPort(strings.TrimPrefix(s.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
@@ -33,8 +33,8 @@ services:
     container_name: milvus-minio-{{.Tag}}
     image: minio/minio:{{.MinioVersion}}
     environment:
-      MINIO_ACCESS_KEY: minioadmin
-      MINIO_SECRET_KEY: minioadmin
+      MINIO_ACCESS_KEY: ${MIUP_MINIO_ACCESS_KEY:-minioadmin}
+      MINIO_SECRET_KEY: ${MIUP_MINIO_SECRET_KEY:-minioadmin}
     ports:
       - "{{.MinioPort}}:9000"
       - "{{.MinioConsole}}:9001"
@@ -58,6 +58,8 @@ services:
     environment:
       ETCD_ENDPOINTS: etcd:2379
       MINIO_ADDRESS: minio:9000
+      MINIO_ACCESS_KEY_ID: ${MIUP_MINIO_ACCESS_KEY:-minioadmin}
+      MINIO_SECRET_ACCESS_KEY: ${MIUP_MINIO_SECRET_KEY:-minioadmin}
     volumes:
       - milvus_data:/var/lib/milvus
     healthcheck:
@@ -85,10 +87,13 @@ services:
     volumes:
       - prometheus_data:/prometheus
       - ./prometheus.yml:/etc/prometheus/prometheus.yml
+      - ./scrape.d:/etc/prometheus/scrape.d
     command:
       - '--config.file=/etc/prometheus/prometheus.yml'
       - '--storage.tsdb.path=/prometheus'
       - '--web.enable-lifecycle'
+    extra_hosts:
+      - "host.docker.internal:host-gateway"
     networks:
       - milvus
 
@@ -122,10 +127,19 @@ volumes:
 {{- end}}
 `
 
+// ScrapeDir is the directory of a playground holding the scrape jobs miup
+// adds to its Prometheus, e.g. for 'miup bench --monitor'. prometheus.yml
+// includes them, so it is never rewritten and can be edited freely.
+const ScrapeDir = "scrape.d"
+
 const prometheusConfigTemplate = `global:
   scrape_interval: 15s
   evaluation_interval: 15s
 
+# Scrape jobs added by miup, e.g. for 'miup bench --monitor'
+scrape_config_files:
+  - /etc/prometheus/scrape.d/*.yml
+
 scrape_configs:
   - job_name: 'milvus'
     static_configs:
@@ -134,6 +148,16 @@ scrape_configs:
           group: 'milvus'
 `
 
+// benchScrapeTemplate scrapes a go-vdbbench exporter running on the host
+const benchScrapeTemplate = `scrape_configs:
+  - job_name: 'vdbbench'
+    scrape_interval: 5s
+    static_configs:
+      - targets: ['%s']
+        labels:
+          group: 'bench'
+`
+
 // GenerateComposeFile generates docker-compose.yaml content
 func GenerateComposeFile(cfg *Config) (string, error) {
 	tmpl, err := template.New("compose").Parse(standaloneComposeTemplate)
@@ -153,3 +177,22 @@ func GenerateComposeFile(cfg *Config) (string, error) {
 func GeneratePrometheusConfig(cfg *Config) string {
 	return prometheusConfigTemplate
 }
+
+// GenerateBenchScrapeConfig generates the scrape file in ScrapeDir for a
+// go-vdbbench exporter at target (e.g. "host.docker.internal:9101")
+func GenerateBenchScrapeConfig(target string) string {
+	return fmt.Sprintf(benchScrapeTemplate, target)
+}
+
+// RequiredImages returns the container images needed by the playground
+func RequiredImages(cfg *Config) []string {
+	images := []string{
+		fmt.Sprintf("quay.io/coreos/etcd:v%s", cfg.EtcdVersion),
+		fmt.Sprintf("minio/minio:%s", cfg.MinioVersion),
+		fmt.Sprintf("milvusdb/milvus:%s", cfg.MilvusVersion),
+	}
+	if cfg.WithMonitor {
+		images = append(images, "prom/prometheus:latest", "grafana/grafana:latest")
+	}
+	return images
+}
@@ -194,4 +194,23 @@ func TestGeneratePrometheusConfig(t *testing.T) {
 	if !strings.Contains(content, "standalone:9091") {
 		t.Error("Should target standalone on metrics port")
 	}
+	if !strings.Contains(content, "/etc/prometheus/scrape.d/*.yml") {
+		t.Error("Should include the scrape files miup adds")
+	}
+}
+
+func TestRequiredImages(t *testing.T) {
+	cfg := DefaultConfig()
+	images := RequiredImages(cfg)
+	if len(images) != 3 {
+		t.Fatalf("len(RequiredImages()) = %d, want 3", len(images))
+	}
+	if images[2] != "milvusdb/milvus:"+cfg.MilvusVersion {
+		t.Errorf("images[2] = %s, want milvusdb/milvus:%s", images[2], cfg.MilvusVersion)
+	}
+
+	cfg.WithMonitor = true
+	if got := len(RequiredImages(cfg)); got != 5 {
+		t.Errorf("len(RequiredImages()) with monitor = %d, want 5", got)
+	}
 }
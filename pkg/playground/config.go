@@ -1,5 +1,12 @@
 package playground
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/version"
+)
+
 // Mode represents the Milvus deployment mode
 type Mode string
 
@@ -7,6 +14,15 @@ const (
 	ModeStandalone Mode = "standalone"
 )
 
+// PullPolicy controls when docker compose pulls images
+type PullPolicy string
+
+const (
+	PullAlways  PullPolicy = "always"
+	PullNever   PullPolicy = "never"
+	PullMissing PullPolicy = "missing"
+)
+
 // Config holds the playground configuration
 type Config struct {
 	// Tag is the unique identifier for this playground instance
@@ -27,6 +43,20 @@ type Config struct {
 	// WithMonitor enables Prometheus and Grafana
 	WithMonitor bool
 
+	// PullPolicy controls image pulling on start (always, never, missing)
+	PullPolicy PullPolicy
+
+	// Offline assumes all images are pre-loaded and never pulls
+	Offline bool
+
+	// Minio holds the MinIO credentials. They are passed to docker compose
+	// in its environment rather than written to the compose file.
+	Minio MinioCredentials
+
+	// TTL makes the playground expire this long after start, so that
+	// 'miup playground reap' cleans it up. Zero means no expiry.
+	TTL time.Duration
+
 	// Ports configuration
 	MilvusPort     int
 	EtcdPort       int
@@ -41,10 +71,11 @@ func DefaultConfig() *Config {
 	return &Config{
 		Tag:            "default",
 		Mode:           ModeStandalone,
-		MilvusVersion:  "v2.5.4",
+		MilvusVersion:  version.MilvusDefault(),
 		EtcdVersion:    "3.5.18",
 		MinioVersion:   "RELEASE.2023-03-20T20-16-18Z",
 		WithMonitor:    false,
+		PullPolicy:     PullMissing,
 		MilvusPort:     19530,
 		EtcdPort:       2379,
 		MinioPort:      9000,
@@ -63,7 +94,22 @@ func (c *Config) Validate() error {
 		c.Mode = ModeStandalone
 	}
 	if c.MilvusVersion == "" {
-		c.MilvusVersion = "v2.5.4"
+		c.MilvusVersion = version.MilvusDefault()
+	}
+	if c.PullPolicy == "" {
+		c.PullPolicy = PullMissing
+	}
+	c.Minio.setDefaults()
+	switch c.PullPolicy {
+	case PullAlways, PullNever, PullMissing:
+	default:
+		return fmt.Errorf("invalid pull policy '%s' (must be always, never or missing)", c.PullPolicy)
+	}
+	if c.Offline {
+		if c.PullPolicy == PullAlways {
+			return fmt.Errorf("--pull always cannot be used with --offline")
+		}
+		c.PullPolicy = PullNever
 	}
 	return nil
 }
@@ -2,6 +2,8 @@ package playground
 
 import (
 	"testing"
+
+	"github.com/mmga-lab/miup/pkg/version"
 )
 
 func TestModeConstant(t *testing.T) {
@@ -141,3 +143,49 @@ func TestConfigStruct(t *testing.T) {
 		t.Errorf("GrafanaPort = %d, want 3000", cfg.GrafanaPort)
 	}
 }
+
+func TestConfig_ValidatePullPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  PullPolicy
+		offline bool
+		want    PullPolicy
+		wantErr bool
+	}{
+		{"default is missing", "", false, PullMissing, false},
+		{"always", PullAlways, false, PullAlways, false},
+		{"never", PullNever, false, PullNever, false},
+		{"invalid", "sometimes", false, "", true},
+		{"offline forces never", PullMissing, true, PullNever, false},
+		{"offline with always", PullAlways, true, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{PullPolicy: tt.policy, Offline: tt.offline}
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.PullPolicy != tt.want {
+				t.Errorf("PullPolicy = %s, want %s", cfg.PullPolicy, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultMilvusVersionOverride(t *testing.T) {
+	t.Setenv(version.DefaultMilvusVersionEnv, "v2.6.1")
+
+	if got := DefaultConfig().MilvusVersion; got != version.MilvusDefault() {
+		t.Errorf("DefaultConfig().MilvusVersion = %s, want %s", got, version.MilvusDefault())
+	}
+
+	cfg := &Config{}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if cfg.MilvusVersion != "v2.6.1" {
+		t.Errorf("MilvusVersion = %s, want v2.6.1", cfg.MilvusVersion)
+	}
+}
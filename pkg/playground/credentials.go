@@ -0,0 +1,95 @@
+package playground
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variables the MinIO credentials are read from
+const (
+	MinioAccessKeyEnv = "MINIO_ACCESS_KEY"
+	MinioSecretKeyEnv = "MINIO_SECRET_KEY"
+)
+
+// DefaultMinioKey is the MinIO access and secret key used when none is set
+const DefaultMinioKey = "minioadmin"
+
+// Variables the generated compose file reads the credentials from. They are
+// set only in the environment of 'docker compose up', so the credentials
+// are never written to the compose file.
+const (
+	composeAccessKeyVar = "MIUP_MINIO_ACCESS_KEY"
+	composeSecretKeyVar = "MIUP_MINIO_SECRET_KEY"
+)
+
+// MinioCredentials are the root credentials of the playground MinIO, which
+// Milvus uses too
+type MinioCredentials struct {
+	AccessKey string
+	SecretKey string
+}
+
+// LoadMinioCredentials returns the MinIO credentials set in file, if given,
+// otherwise in the MINIO_ACCESS_KEY and MINIO_SECRET_KEY environment
+// variables. The file uses the same KEY=VALUE lines as a docker env file.
+// Keys set in neither place default to minioadmin.
+func LoadMinioCredentials(file string) (MinioCredentials, error) {
+	lookup := os.Getenv
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return MinioCredentials{}, fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		values, err := parseEnvFile(data)
+		if err != nil {
+			return MinioCredentials{}, fmt.Errorf("failed to parse credentials file %s: %w", file, err)
+		}
+		lookup = func(key string) string { return values[key] }
+	}
+
+	creds := MinioCredentials{
+		AccessKey: lookup(MinioAccessKeyEnv),
+		SecretKey: lookup(MinioSecretKeyEnv),
+	}
+	creds.setDefaults()
+	return creds, nil
+}
+
+func (c *MinioCredentials) setDefaults() {
+	if c.AccessKey == "" {
+		c.AccessKey = DefaultMinioKey
+	}
+	if c.SecretKey == "" {
+		c.SecretKey = DefaultMinioKey
+	}
+}
+
+// composeEnv returns the environment 'docker compose up' passes the
+// credentials to the compose file in
+func (c MinioCredentials) composeEnv() []string {
+	return []string{
+		composeAccessKeyVar + "=" + c.AccessKey,
+		composeSecretKeyVar + "=" + c.SecretKey,
+	}
+}
+
+// parseEnvFile parses KEY=VALUE lines, skipping blank lines and # comments
+func parseEnvFile(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return values, scanner.Err()
+}
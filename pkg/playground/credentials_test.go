@@ -0,0 +1,92 @@
+package playground
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadMinioCredentials(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		file    string
+		want    MinioCredentials
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			want: MinioCredentials{AccessKey: "minioadmin", SecretKey: "minioadmin"},
+		},
+		{
+			name: "environment",
+			env:  map[string]string{MinioAccessKeyEnv: "admin", MinioSecretKeyEnv: "s3cret"},
+			want: MinioCredentials{AccessKey: "admin", SecretKey: "s3cret"},
+		},
+		{
+			name: "file wins over environment",
+			env:  map[string]string{MinioAccessKeyEnv: "admin", MinioSecretKeyEnv: "s3cret"},
+			file: writeFile("creds.env", "# playground\nMINIO_ACCESS_KEY=fileadmin\n\nMINIO_SECRET_KEY = filesecret\n"),
+			want: MinioCredentials{AccessKey: "fileadmin", SecretKey: "filesecret"},
+		},
+		{
+			name:    "malformed file",
+			file:    writeFile("bad.env", "MINIO_ACCESS_KEY\n"),
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			file:    filepath.Join(dir, "missing.env"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(MinioAccessKeyEnv, "")
+			t.Setenv(MinioSecretKeyEnv, "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			got, err := LoadMinioCredentials(tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadMinioCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("LoadMinioCredentials() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeFileOmitsCredentials(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Minio = MinioCredentials{AccessKey: "admin", SecretKey: "s3cret"}
+
+	content, err := GenerateComposeFile(cfg)
+	if err != nil {
+		t.Fatalf("GenerateComposeFile() error = %v", err)
+	}
+	if strings.Contains(content, "s3cret") {
+		t.Error("compose file should not contain the secret key")
+	}
+	if !strings.Contains(content, "MINIO_SECRET_ACCESS_KEY: ${"+composeSecretKeyVar) {
+		t.Error("Milvus should read the secret key from the compose environment")
+	}
+
+	env := strings.Join(cfg.Minio.composeEnv(), "\n")
+	if !strings.Contains(env, composeSecretKeyVar+"=s3cret") {
+		t.Errorf("composeEnv() = %s, want the secret key", env)
+	}
+}
@@ -0,0 +1,276 @@
+package playground
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	clusterexec "github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/executor"
+)
+
+const (
+	// MilvusBucket is the MinIO bucket Milvus stores data in
+	MilvusBucket = "a-bucket"
+	// MilvusMetricsPort is the host port of the Milvus health and metrics endpoint
+	MilvusMetricsPort = 9091
+
+	// diagnoseTimeout bounds each connectivity check
+	diagnoseTimeout = 3 * time.Second
+)
+
+// Diagnose performs health diagnostics on a playground instance, checking
+// container health, port connectivity and the Milvus MinIO bucket
+func (m *Manager) Diagnose(ctx context.Context, tag string) (*clusterexec.DiagnoseResult, error) {
+	playgroundDir := m.PlaygroundDir(tag)
+
+	if _, err := os.Stat(playgroundDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("playground '%s' does not exist", tag)
+	}
+
+	meta, err := m.loadMeta(tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metadata: %w", err)
+	}
+
+	result := &clusterexec.DiagnoseResult{
+		Healthy:      true,
+		Components:   []clusterexec.ComponentCheck{},
+		Connectivity: []clusterexec.ConnectivityCheck{},
+		Resources:    []clusterexec.ResourceCheck{},
+		Issues:       []clusterexec.Issue{},
+	}
+
+	compose := executor.NewDockerCompose(playgroundDir, fmt.Sprintf("miup-%s", tag))
+	states, err := compose.Services(ctx)
+	if err != nil {
+		result.Healthy = false
+		result.Summary = fmt.Sprintf("Failed to get container status: %v", err)
+		result.Issues = append(result.Issues, clusterexec.Issue{
+			Severity:    clusterexec.CheckStatusError,
+			Component:   "docker",
+			Description: fmt.Sprintf("Cannot list playground containers: %v", err),
+			Suggestion:  "Check that Docker is running",
+		})
+		return result, nil
+	}
+
+	diagnoseServices(states, result)
+
+	if serviceRunning(states, "etcd") {
+		check := clusterexec.ConnectivityCheck{Name: "etcd", Target: "etcd:2379 (container network)"}
+		start := time.Now()
+		if out, err := compose.Exec(ctx, "etcd", "etcdctl", "endpoint", "health"); err != nil {
+			check.Status = clusterexec.CheckStatusError
+			check.Message = firstLine(out, err)
+		} else {
+			check.Status = clusterexec.CheckStatusOK
+			check.Latency = time.Since(start).Round(time.Millisecond).String()
+			check.Message = "Endpoint healthy"
+		}
+		addConnectivity(result, check, "Check the etcd container logs with 'miup playground logs --service etcd'")
+	}
+
+	addConnectivity(result, dialCheck(ctx, "milvus", fmt.Sprintf("127.0.0.1:%d", meta.MilvusPort)),
+		"Check that the Milvus port is not blocked and the standalone container is running")
+	addConnectivity(result, httpCheck(ctx, "milvus-health", fmt.Sprintf("http://127.0.0.1:%d/healthz", MilvusMetricsPort)),
+		"Check the Milvus logs with 'miup playground logs --service standalone'")
+	addConnectivity(result, dialCheck(ctx, "minio", fmt.Sprintf("127.0.0.1:%d", meta.MinioPort)),
+		"Check that the MinIO port is not blocked and the minio container is running")
+	addConnectivity(result, bucketCheck(ctx, fmt.Sprintf("http://127.0.0.1:%d", meta.MinioPort), MilvusBucket),
+		"Milvus creates its bucket on startup; check the Milvus logs for MinIO errors")
+
+	summarize(result)
+	return result, nil
+}
+
+// diagnoseServices adds a component check for each compose service
+func diagnoseServices(states []executor.ServiceState, result *clusterexec.DiagnoseResult) {
+	if len(states) == 0 {
+		result.Healthy = false
+		result.Issues = append(result.Issues, clusterexec.Issue{
+			Severity:    clusterexec.CheckStatusError,
+			Component:   "playground",
+			Description: "No playground containers found",
+			Suggestion:  "Start the playground with 'miup playground start'",
+		})
+		return
+	}
+
+	for _, s := range states {
+		check := clusterexec.ComponentCheck{Name: s.Service, Replicas: 1}
+		switch {
+		case s.State != "running":
+			check.Status = clusterexec.CheckStatusError
+			check.Message = fmt.Sprintf("Container is %s", s.State)
+		case s.Health == "starting":
+			check.Status = clusterexec.CheckStatusWarning
+			check.Message = "Container is starting"
+		case s.Health != "" && s.Health != "healthy":
+			check.Status = clusterexec.CheckStatusError
+			check.Message = fmt.Sprintf("Container is %s", s.Health)
+		default:
+			check.Status = clusterexec.CheckStatusOK
+			check.Ready = 1
+			check.Message = "Container is running"
+			if s.Health != "" {
+				check.Message = "Container is healthy"
+			}
+		}
+		result.Components = append(result.Components, check)
+
+		if check.Status != clusterexec.CheckStatusOK {
+			if check.Status == clusterexec.CheckStatusError {
+				result.Healthy = false
+			}
+			result.Issues = append(result.Issues, clusterexec.Issue{
+				Severity:    check.Status,
+				Component:   s.Service,
+				Description: check.Message,
+				Suggestion:  fmt.Sprintf("Check the logs with 'miup playground logs --service %s'", s.Service),
+			})
+		}
+	}
+}
+
+// addConnectivity records a connectivity check and an issue if it failed
+func addConnectivity(result *clusterexec.DiagnoseResult, check clusterexec.ConnectivityCheck, suggestion string) {
+	result.Connectivity = append(result.Connectivity, check)
+	if check.Status == clusterexec.CheckStatusOK {
+		return
+	}
+	if check.Status == clusterexec.CheckStatusError {
+		result.Healthy = false
+	}
+	result.Issues = append(result.Issues, clusterexec.Issue{
+		Severity:    check.Status,
+		Component:   check.Name,
+		Description: fmt.Sprintf("%s: %s", check.Target, check.Message),
+		Suggestion:  suggestion,
+	})
+}
+
+// dialCheck checks that a TCP address accepts connections
+func dialCheck(ctx context.Context, name, addr string) clusterexec.ConnectivityCheck {
+	check := clusterexec.ConnectivityCheck{Name: name, Target: addr}
+
+	dialer := net.Dialer{Timeout: diagnoseTimeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		check.Status = clusterexec.CheckStatusError
+		check.Message = fmt.Sprintf("Connection failed: %v", err)
+		return check
+	}
+	conn.Close()
+
+	check.Status = clusterexec.CheckStatusOK
+	check.Latency = time.Since(start).Round(time.Millisecond).String()
+	check.Message = "Port reachable"
+	return check
+}
+
+// httpCheck checks that an HTTP endpoint returns 200
+func httpCheck(ctx context.Context, name, url string) clusterexec.ConnectivityCheck {
+	check := clusterexec.ConnectivityCheck{Name: name, Target: url}
+
+	start := time.Now()
+	status, err := httpStatus(ctx, http.MethodGet, url)
+	switch {
+	case err != nil:
+		check.Status = clusterexec.CheckStatusError
+		check.Message = fmt.Sprintf("Request failed: %v", err)
+	case status != http.StatusOK:
+		check.Status = clusterexec.CheckStatusError
+		check.Message = fmt.Sprintf("Unexpected status %d", status)
+	default:
+		check.Status = clusterexec.CheckStatusOK
+		check.Latency = time.Since(start).Round(time.Millisecond).String()
+		check.Message = "Endpoint healthy"
+	}
+	return check
+}
+
+// bucketCheck checks that a MinIO bucket exists. Anonymous requests get 403
+// for an existing private bucket and 404 for a missing one.
+func bucketCheck(ctx context.Context, endpoint, bucket string) clusterexec.ConnectivityCheck {
+	url := fmt.Sprintf("%s/%s", strings.TrimSuffix(endpoint, "/"), bucket)
+	check := clusterexec.ConnectivityCheck{Name: "minio-bucket", Target: url}
+
+	status, err := httpStatus(ctx, http.MethodHead, url)
+	switch {
+	case err != nil:
+		check.Status = clusterexec.CheckStatusError
+		check.Message = fmt.Sprintf("Request failed: %v", err)
+	case status == http.StatusNotFound:
+		check.Status = clusterexec.CheckStatusError
+		check.Message = fmt.Sprintf("Bucket '%s' does not exist", bucket)
+	case status == http.StatusOK || status == http.StatusForbidden:
+		check.Status = clusterexec.CheckStatusOK
+		check.Message = fmt.Sprintf("Bucket '%s' exists", bucket)
+	default:
+		check.Status = clusterexec.CheckStatusWarning
+		check.Message = fmt.Sprintf("Unexpected status %d", status)
+	}
+	return check
+}
+
+func httpStatus(ctx context.Context, method, url string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, diagnoseTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// summarize sets the result summary from the issue counts
+func summarize(result *clusterexec.DiagnoseResult) {
+	errorCount := 0
+	warningCount := 0
+	for _, issue := range result.Issues {
+		if issue.Severity == clusterexec.CheckStatusError {
+			errorCount++
+		} else if issue.Severity == clusterexec.CheckStatusWarning {
+			warningCount++
+		}
+	}
+
+	if errorCount > 0 {
+		result.Summary = fmt.Sprintf("Playground unhealthy: %d error(s), %d warning(s)", errorCount, warningCount)
+	} else if warningCount > 0 {
+		result.Summary = fmt.Sprintf("Playground healthy with %d warning(s)", warningCount)
+	} else {
+		result.Summary = "Playground is healthy"
+	}
+}
+
+func serviceRunning(states []executor.ServiceState, service string) bool {
+	for _, s := range states {
+		if s.Service == service {
+			return s.State == "running"
+		}
+	}
+	return false
+}
+
+// firstLine returns the first line of command output, or the error if empty
+func firstLine(out string, err error) string {
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return err.Error()
+	}
+	line, _, _ := strings.Cut(out, "\n")
+	return line
+}
@@ -0,0 +1,60 @@
+package playground
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	clusterexec "github.com/mmga-lab/miup/pkg/cluster/executor"
+	"github.com/mmga-lab/miup/pkg/executor"
+)
+
+func TestDiagnoseServices(t *testing.T) {
+	states := []executor.ServiceState{
+		{Service: "etcd", State: "running", Health: "healthy"},
+		{Service: "minio", State: "running", Health: "starting"},
+		{Service: "standalone", State: "exited"},
+		{Service: "grafana", State: "running"},
+	}
+
+	result := &clusterexec.DiagnoseResult{Healthy: true}
+	diagnoseServices(states, result)
+
+	want := map[string]clusterexec.CheckStatus{
+		"etcd":       clusterexec.CheckStatusOK,
+		"minio":      clusterexec.CheckStatusWarning,
+		"standalone": clusterexec.CheckStatusError,
+		"grafana":    clusterexec.CheckStatusOK,
+	}
+	for _, c := range result.Components {
+		if c.Status != want[c.Name] {
+			t.Errorf("%s status = %s, want %s", c.Name, c.Status, want[c.Name])
+		}
+	}
+	if result.Healthy {
+		t.Error("Healthy should be false when a container has exited")
+	}
+	if len(result.Issues) != 2 {
+		t.Errorf("len(Issues) = %d, want 2", len(result.Issues))
+	}
+}
+
+func TestBucketCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/"+MilvusBucket {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	if got := bucketCheck(ctx, server.URL, MilvusBucket); got.Status != clusterexec.CheckStatusOK {
+		t.Errorf("bucketCheck(existing) status = %s, want OK", got.Status)
+	}
+	if got := bucketCheck(ctx, server.URL, "missing"); got.Status != clusterexec.CheckStatusError {
+		t.Errorf("bucketCheck(missing) status = %s, want ERROR", got.Status)
+	}
+}
@@ -0,0 +1,98 @@
+package playground
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+)
+
+// instanceMetaFile is the metadata miup stores next to the topology of a
+// deployed instance, which records the instance's Milvus version
+const instanceMetaFile = "meta.json"
+
+// ImportTopology returns a playground configuration with the basic shape of
+// an instance topology: monitoring and the ports of Milvus, etcd, MinIO,
+// Prometheus and Grafana. If the topology is the stored one of a deployed
+// instance (~/.miup/clusters/<name>/topology.yaml), the instance's Milvus
+// version is used too. It also returns what the playground can't reproduce,
+// e.g. distributed mode, to be shown as warnings.
+func ImportTopology(path string) (*Config, []string, error) {
+	s, err := spec.LoadUserSpecification(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, notes := ConfigFromSpec(s)
+
+	if path == spec.StdinSource || spec.IsRemoteSource(path) {
+		return cfg, notes, nil
+	}
+	metaPath := filepath.Join(filepath.Dir(path), instanceMetaFile)
+	if _, err := os.Stat(metaPath); err == nil {
+		meta, err := spec.LoadMeta(metaPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if meta.MilvusVersion != "" {
+			cfg.MilvusVersion = meta.MilvusVersion
+		}
+	}
+
+	return cfg, notes, nil
+}
+
+// ConfigFromSpec maps a topology to a playground configuration, starting
+// from the defaults. It returns the parts of the topology that have no
+// playground equivalent.
+func ConfigFromSpec(s *spec.Specification) (*Config, []string) {
+	cfg := DefaultConfig()
+	var notes []string
+
+	if s.IsDistributed() {
+		notes = append(notes, fmt.Sprintf("%s mode runs as standalone; the playground has no distributed mode", s.GetMode()))
+	}
+	cfg.WithMonitor = s.HasMonitoring()
+
+	if len(s.MilvusServers) > 0 && s.MilvusServers[0].Port != 0 {
+		cfg.MilvusPort = s.MilvusServers[0].Port
+	}
+	if len(s.EtcdServers) > 0 {
+		if s.ExternalEtcd() {
+			notes = append(notes, "external etcd is replaced by a local one")
+		}
+		if s.EtcdServers[0].ClientPort != 0 {
+			cfg.EtcdPort = s.EtcdServers[0].ClientPort
+		}
+	}
+	if len(s.MinioServers) > 0 {
+		if s.ExternalMinio() {
+			notes = append(notes, "external MinIO/S3 is replaced by a local MinIO")
+		}
+		if s.MinioServers[0].Port != 0 {
+			cfg.MinioPort = s.MinioServers[0].Port
+		}
+		if s.MinioServers[0].ConsolePort != 0 {
+			cfg.MinioConsole = s.MinioServers[0].ConsolePort
+		}
+	}
+	if len(s.MonitorServers) > 0 && s.MonitorServers[0].PrometheusPort != 0 {
+		cfg.PrometheusPort = s.MonitorServers[0].PrometheusPort
+	}
+	if len(s.GrafanaServers) > 0 && s.GrafanaServers[0].Port != 0 {
+		cfg.GrafanaPort = s.GrafanaServers[0].Port
+	}
+
+	if len(s.PulsarServers) > 0 {
+		notes = append(notes, "Pulsar is not started; the playground uses the embedded message queue")
+	}
+	if s.HasTLS() {
+		notes = append(notes, "TLS is not enabled in the playground")
+	}
+	if len(s.ServerConfigs.Milvus) > 0 || (len(s.MilvusServers) > 0 && len(s.MilvusServers[0].Config) > 0) {
+		notes = append(notes, "Milvus config from the topology is not applied")
+	}
+
+	return cfg, notes
+}
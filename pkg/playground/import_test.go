@@ -0,0 +1,96 @@
+package playground
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mmga-lab/miup/pkg/cluster/spec"
+)
+
+func TestImportTopology(t *testing.T) {
+	topology := `
+milvus_servers:
+  - host: milvus
+    port: 29530
+    mode: distributed
+etcd_servers:
+  - host: localhost
+    client_port: 12379
+minio_servers:
+  - host: localhost
+    port: 19000
+monitoring_servers:
+  - host: localhost
+    prometheus_port: 19090
+grafana_servers:
+  - host: localhost
+`
+
+	tests := []struct {
+		name        string
+		meta        *spec.ClusterMeta
+		wantVersion string
+	}{
+		{name: "topology file", wantVersion: DefaultConfig().MilvusVersion},
+		{name: "stored instance", meta: &spec.ClusterMeta{Name: "prod", MilvusVersion: "v2.5.4"}, wantVersion: "v2.5.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "topology.yaml")
+			if err := os.WriteFile(path, []byte(topology), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if tt.meta != nil {
+				if err := spec.SaveMeta(tt.meta, filepath.Join(dir, instanceMetaFile)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, notes, err := ImportTopology(path)
+			if err != nil {
+				t.Fatalf("ImportTopology() error = %v", err)
+			}
+			if cfg.MilvusVersion != tt.wantVersion {
+				t.Errorf("MilvusVersion = %s, want %s", cfg.MilvusVersion, tt.wantVersion)
+			}
+			if cfg.Mode != ModeStandalone || !cfg.WithMonitor {
+				t.Errorf("Mode = %s, WithMonitor = %v, want standalone with monitoring", cfg.Mode, cfg.WithMonitor)
+			}
+			if cfg.MilvusPort != 29530 || cfg.EtcdPort != 12379 || cfg.MinioPort != 19000 || cfg.PrometheusPort != 19090 {
+				t.Errorf("ports = %d/%d/%d/%d, want the topology's", cfg.MilvusPort, cfg.EtcdPort, cfg.MinioPort, cfg.PrometheusPort)
+			}
+			if cfg.MinioConsole != 9001 || cfg.GrafanaPort != 3000 {
+				t.Errorf("MinioConsole = %d, GrafanaPort = %d, want the defaults", cfg.MinioConsole, cfg.GrafanaPort)
+			}
+			if len(notes) != 1 || !strings.Contains(notes[0], "standalone") {
+				t.Errorf("notes = %v, want one about distributed mode", notes)
+			}
+		})
+	}
+}
+
+func TestConfigFromSpecNotes(t *testing.T) {
+	s := &spec.Specification{
+		ServerConfigs: spec.ServerConfigs{Milvus: map[string]any{"proxy": map[string]any{"maxNameLength": 512}}},
+		MilvusServers: []spec.MilvusSpec{{Host: "milvus"}},
+		EtcdServers:   []spec.EtcdSpec{{Service: "my-etcd.infra.svc"}},
+		MinioServers:  []spec.MinioSpec{{Host: "s3.example.com"}},
+		PulsarServers: []spec.PulsarSpec{{Host: "pulsar"}},
+	}
+	s.Global.TLS.Enabled = true
+
+	cfg, notes := ConfigFromSpec(s)
+	if cfg.WithMonitor {
+		t.Error("WithMonitor = true without monitoring servers")
+	}
+	if cfg.MilvusPort != 19530 {
+		t.Errorf("MilvusPort = %d, want the default", cfg.MilvusPort)
+	}
+	if len(notes) != 5 {
+		t.Errorf("notes = %v, want etcd, MinIO, Pulsar, TLS and config", notes)
+	}
+}
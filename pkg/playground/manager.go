@@ -6,11 +6,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/mmga-lab/miup/pkg/executor"
 	"github.com/mmga-lab/miup/pkg/localdata"
 	"github.com/mmga-lab/miup/pkg/logger"
+	"github.com/mmga-lab/miup/pkg/timing"
 )
 
 const (
@@ -40,6 +44,33 @@ type Meta struct {
 	CreatedAt     time.Time `json:"created_at"`
 	MilvusPort    int       `json:"milvus_port"`
 	MinioPort     int       `json:"minio_port"`
+
+	// Monitoring ports, set when WithMonitor is true
+	PrometheusPort int `json:"prometheus_port,omitempty"`
+	GrafanaPort    int `json:"grafana_port,omitempty"`
+
+	// ExpiresAt is when the playground's TTL runs out. Nil means never.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired reports whether the playground has a TTL that ran out before now
+func (m *Meta) Expired(now time.Time) bool {
+	return m.ExpiresAt != nil && !now.Before(*m.ExpiresAt)
+}
+
+// LogsOptions defines options for retrieving playground logs
+type LogsOptions struct {
+	// Service filters logs to a single compose service (optional)
+	Service string
+
+	// Tail is the number of lines to show from the end of the logs
+	Tail int
+
+	// Since only returns logs newer than this duration (0 means no limit)
+	Since time.Duration
+
+	// Grep only returns lines matching this regular expression (optional)
+	Grep string
 }
 
 // Manager manages playground instances
@@ -70,13 +101,7 @@ func (m *Manager) Start(ctx context.Context, cfg *Config) error {
 	}
 
 	// Check Docker availability
-	if err := executor.CheckDockerAvailable(); err != nil {
-		return err
-	}
-	if err := executor.CheckDockerComposeAvailable(); err != nil {
-		return err
-	}
-	if err := executor.CheckDockerRunning(); err != nil {
+	if err := executor.RequireDocker(true); err != nil {
 		return err
 	}
 
@@ -85,6 +110,14 @@ func (m *Manager) Start(ctx context.Context, cfg *Config) error {
 		return fmt.Errorf("playground '%s' is already running", cfg.Tag)
 	}
 
+	// In offline mode all images must already be loaded (e.g. via 'miup mirror load')
+	if cfg.Offline {
+		if missing := executor.MissingImages(ctx, RequiredImages(cfg)); len(missing) > 0 {
+			return fmt.Errorf("offline mode: required images not found locally: %s (load them with 'miup mirror load')",
+				strings.Join(missing, ", "))
+		}
+	}
+
 	playgroundDir := m.PlaygroundDir(cfg.Tag)
 
 	// Create playground directory
@@ -110,6 +143,9 @@ func (m *Manager) Start(ctx context.Context, cfg *Config) error {
 		if err := os.WriteFile(prometheusPath, []byte(prometheusConfig), 0644); err != nil {
 			return fmt.Errorf("failed to write prometheus config: %w", err)
 		}
+		if err := os.MkdirAll(filepath.Join(playgroundDir, ScrapeDir), 0755); err != nil {
+			return fmt.Errorf("failed to create prometheus scrape directory: %w", err)
+		}
 	}
 
 	// Save metadata
@@ -122,15 +158,27 @@ func (m *Manager) Start(ctx context.Context, cfg *Config) error {
 		MilvusPort:    cfg.MilvusPort,
 		MinioPort:     cfg.MinioPort,
 	}
+	if cfg.WithMonitor {
+		meta.PrometheusPort = cfg.PrometheusPort
+		meta.GrafanaPort = cfg.GrafanaPort
+	}
+	if cfg.TTL > 0 {
+		expiresAt := meta.CreatedAt.Add(cfg.TTL)
+		meta.ExpiresAt = &expiresAt
+	}
 	if err := m.saveMeta(cfg.Tag, meta); err != nil {
 		return fmt.Errorf("failed to save metadata: %w", err)
 	}
 
 	// Start docker compose
 	logger.Info("Starting Milvus playground (mode: %s)...", cfg.Mode)
-	compose := executor.NewDockerCompose(playgroundDir, fmt.Sprintf("miup-%s", cfg.Tag))
+	compose := executor.NewDockerCompose(playgroundDir, fmt.Sprintf("miup-%s", cfg.Tag)).
+		WithEnv(cfg.Minio.composeEnv()...)
 
-	if err := compose.Up(ctx); err != nil {
+	endUp := timing.Start(ctx, "start containers")
+	err = compose.Up(ctx, string(cfg.PullPolicy))
+	endUp()
+	if err != nil {
 		return fmt.Errorf("failed to start services: %w", err)
 	}
 
@@ -249,16 +297,60 @@ func (m *Manager) List(ctx context.Context) ([]*InstanceStatus, error) {
 	return instances, nil
 }
 
+// Expired returns the metadata of the playgrounds whose TTL ran out before
+// now, sorted by tag
+func (m *Manager) Expired(now time.Time) ([]*Meta, error) {
+	entries, err := os.ReadDir(m.profile.Path(PlaygroundDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var expired []*Meta
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		meta, err := m.loadMeta(entry.Name())
+		if err != nil {
+			logger.Warn("Failed to load metadata for playground '%s': %v", entry.Name(), err)
+			continue
+		}
+		if meta.Expired(now) {
+			expired = append(expired, meta)
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool { return expired[i].Tag < expired[j].Tag })
+	return expired, nil
+}
+
 // Logs retrieves logs from a playground instance
-func (m *Manager) Logs(ctx context.Context, tag string, service string, tail int) (string, error) {
+func (m *Manager) Logs(ctx context.Context, tag string, opts LogsOptions) (string, error) {
 	playgroundDir := m.PlaygroundDir(tag)
 
 	if _, err := os.Stat(playgroundDir); os.IsNotExist(err) {
 		return "", fmt.Errorf("playground '%s' does not exist", tag)
 	}
 
+	var grep *regexp.Regexp
+	if opts.Grep != "" {
+		var err error
+		grep, err = regexp.Compile(opts.Grep)
+		if err != nil {
+			return "", fmt.Errorf("invalid grep pattern: %w", err)
+		}
+	}
+
 	compose := executor.NewDockerCompose(playgroundDir, fmt.Sprintf("miup-%s", tag))
-	return compose.Logs(ctx, service, tail)
+	logs, err := compose.Logs(ctx, opts.Service, opts.Tail, opts.Since)
+	if err != nil {
+		return "", err
+	}
+	return executor.FilterLines(logs, grep), nil
 }
 
 // Clean removes a playground instance completely
@@ -286,19 +378,23 @@ func (m *Manager) saveMeta(tag string, meta *Meta) error {
 	}
 
 	metaPath := m.MetaPath(tag)
-	return os.WriteFile(metaPath, data, 0644)
+	return localdata.WriteFileWithBackup(metaPath, data, 0644, func(data []byte) error {
+		var meta Meta
+		return json.Unmarshal(data, &meta)
+	})
 }
 
 func (m *Manager) loadMeta(tag string) (*Meta, error) {
-	metaPath := m.MetaPath(tag)
-	data, err := os.ReadFile(metaPath)
+	var meta Meta
+	usedBackup, err := localdata.ReadFileWithBackup(m.MetaPath(tag), func(data []byte) error {
+		meta = Meta{}
+		return json.Unmarshal(data, &meta)
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	var meta Meta
-	if err := json.Unmarshal(data, &meta); err != nil {
-		return nil, err
+	if usedBackup {
+		logger.Warn("Metadata for playground '%s' is missing or corrupt, using backup", tag)
 	}
 
 	return &meta, nil
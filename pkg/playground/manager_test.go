@@ -0,0 +1,32 @@
+package playground
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/mmga-lab/miup/pkg/localdata"
+)
+
+func TestExpired(t *testing.T) {
+	m := NewManager(localdata.NewProfile(t.TempDir()))
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	for tag, expiresAt := range map[string]*time.Time{"old": &past, "new": &future, "forever": nil} {
+		if err := os.MkdirAll(m.PlaygroundDir(tag), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.saveMeta(tag, &Meta{Tag: tag, ExpiresAt: expiresAt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := m.Expired(now)
+	if err != nil {
+		t.Fatalf("Expired() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Tag != "old" {
+		t.Errorf("Expired() = %v, want only 'old'", got)
+	}
+}
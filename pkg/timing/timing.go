@@ -0,0 +1,88 @@
+// Package timing records how long the phases of a command take, e.g.
+// download vs extract for an install, so that slow operations can be
+// broken down. A Recorder travels in the context; operations mark their
+// phases with Start and do nothing if the context has no recorder.
+package timing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Phase is the time spent in one phase of a command
+type Phase struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+
+	// Count is how many times the phase ran, e.g. one update per scaled
+	// component; Duration is the sum
+	Count int `json:"count"`
+}
+
+// Recorder collects the phases of a command. It is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	started time.Time
+	phases  []Phase
+	index   map[string]int
+}
+
+// NewRecorder returns a recorder whose total time starts now
+func NewRecorder() *Recorder {
+	return &Recorder{started: time.Now(), index: make(map[string]int)}
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a copy of ctx that carries r
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// FromContext returns the recorder in ctx, or nil if there is none
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+// Start begins a phase of the command recorded in ctx and returns the
+// function that ends it:
+//
+//	defer timing.Start(ctx, "wait for ready")()
+//
+// Phases should not overlap, so that they add up to the total.
+func Start(ctx context.Context, name string) (end func()) {
+	r := FromContext(ctx)
+	if r == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() { r.Add(name, time.Since(start)) }
+}
+
+// Add records d spent in the named phase, adding to earlier runs of it
+func (r *Recorder) Add(name string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i, ok := r.index[name]
+	if !ok {
+		i = len(r.phases)
+		r.index[name] = i
+		r.phases = append(r.phases, Phase{Name: name})
+	}
+	r.phases[i].Duration += d
+	r.phases[i].Count++
+}
+
+// Phases returns the recorded phases in the order they first started
+func (r *Recorder) Phases() []Phase {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Phase(nil), r.phases...)
+}
+
+// Total returns the time since the recorder was created
+func (r *Recorder) Total() time.Duration {
+	return time.Since(r.started)
+}
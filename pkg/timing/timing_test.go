@@ -0,0 +1,60 @@
+package timing
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStartWithoutRecorder(t *testing.T) {
+	// Must not panic or record anywhere
+	Start(context.Background(), "download")()
+
+	if r := FromContext(context.Background()); r != nil {
+		t.Errorf("FromContext() = %v, want nil", r)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder()
+	ctx := WithRecorder(context.Background(), r)
+
+	if got := FromContext(ctx); got != r {
+		t.Fatalf("FromContext() = %p, want %p", got, r)
+	}
+
+	end := Start(ctx, "download")
+	time.Sleep(5 * time.Millisecond)
+	end()
+	r.Add("extract", 2*time.Second)
+	r.Add("update", time.Second)
+	r.Add("update", 3*time.Second)
+
+	phases := r.Phases()
+	want := []struct {
+		name  string
+		count int
+	}{
+		{"download", 1},
+		{"extract", 1},
+		{"update", 2},
+	}
+	if len(phases) != len(want) {
+		t.Fatalf("got %d phases, want %d: %+v", len(phases), len(want), phases)
+	}
+	for i, w := range want {
+		if phases[i].Name != w.name || phases[i].Count != w.count {
+			t.Errorf("phases[%d] = %+v, want %s x%d", i, phases[i], w.name, w.count)
+		}
+	}
+
+	if phases[0].Duration < 5*time.Millisecond {
+		t.Errorf("download took %v, want at least 5ms", phases[0].Duration)
+	}
+	if phases[2].Duration != 4*time.Second {
+		t.Errorf("update took %v, want the sum 4s", phases[2].Duration)
+	}
+	if r.Total() < phases[0].Duration {
+		t.Errorf("Total() = %v, want at least %v", r.Total(), phases[0].Duration)
+	}
+}
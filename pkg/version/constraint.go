@@ -0,0 +1,221 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed release version
+type semver [3]int
+
+func (v semver) compare(o semver) int {
+	for i := range v {
+		if v[i] != o[i] {
+			if v[i] < o[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// comparator is a single bound such as ">=1.2.0"
+type comparator struct {
+	op      string
+	version semver
+}
+
+func (c comparator) check(v semver) bool {
+	cmp := v.compare(c.version)
+	switch c.op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// Constraint is a semver range such as "^1.2", "~1.2.3" or ">=1.2,<2"
+type Constraint struct {
+	raw string
+
+	// alternatives are OR-ed; the comparators within each are AND-ed
+	alternatives [][]comparator
+}
+
+// IsConstraint reports whether s is a range rather than an exact version,
+// i.e. it uses an operator, a comma or a wildcard
+func IsConstraint(s string) bool {
+	if strings.ContainsAny(s, "^~<>=*, |") {
+		return true
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(s, "v"), ".") {
+		if part == "x" || part == "X" {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseConstraint parses a semver range. Supported forms:
+//
+//	^1.2     >=1.2.0 <2.0.0 (^0.5 is >=0.5.0 <0.6.0)
+//	~1.2.3   >=1.2.3 <1.3.0
+//	1.2.x    >=1.2.0 <1.3.0 (also 1.2.*)
+//	>=1.2,<2 comma- or space-separated bounds that must all match
+//	^1 || ^2 alternatives
+//
+// Missing minor and patch numbers are zero, and a leading "v" is optional.
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: s}
+	for _, alt := range strings.Split(s, "||") {
+		var comparators []comparator
+		for _, term := range splitTerms(alt) {
+			parsed, err := parseTerm(term)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version constraint '%s': %w", s, err)
+			}
+			comparators = append(comparators, parsed...)
+		}
+		if len(comparators) == 0 {
+			return nil, fmt.Errorf("invalid version constraint '%s': empty range", s)
+		}
+		c.alternatives = append(c.alternatives, comparators)
+	}
+	return c, nil
+}
+
+// splitTerms splits bounds separated by commas or spaces, keeping an
+// operator written apart from its version (">= 1.2") with it
+func splitTerms(s string) []string {
+	var terms []string
+	pending := ""
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if strings.Trim(field, "<>=^~") == "" {
+			pending += field
+			continue
+		}
+		terms = append(terms, pending+field)
+		pending = ""
+	}
+	if pending != "" {
+		terms = append(terms, pending)
+	}
+	return terms
+}
+
+// parseTerm expands a single term like "^1.2" into its bounds
+func parseTerm(term string) ([]comparator, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, prefix) {
+			op = prefix
+			break
+		}
+	}
+	v, parts, err := parsePartial(strings.TrimPrefix(term, op))
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "^":
+		// Allow changes that keep the left-most non-zero part
+		upper := semver{v[0] + 1, 0, 0}
+		switch {
+		case v[0] == 0 && (v[1] > 0 || parts < 3):
+			upper = semver{0, v[1] + 1, 0}
+			if parts == 1 {
+				upper = semver{1, 0, 0}
+			}
+		case v[0] == 0 && v[1] == 0:
+			upper = semver{0, 0, v[2] + 1}
+		}
+		return []comparator{{">=", v}, {"<", upper}}, nil
+	case "~":
+		upper := semver{v[0], v[1] + 1, 0}
+		if parts == 1 {
+			upper = semver{v[0] + 1, 0, 0}
+		}
+		return []comparator{{">=", v}, {"<", upper}}, nil
+	case "", "=":
+		// A partial version is a wildcard: 1.2 and 1.2.x match any 1.2 patch
+		switch parts {
+		case 0:
+			return []comparator{{">=", semver{}}}, nil
+		case 1:
+			return []comparator{{">=", v}, {"<", semver{v[0] + 1, 0, 0}}}, nil
+		case 2:
+			return []comparator{{">=", v}, {"<", semver{v[0], v[1] + 1, 0}}}, nil
+		}
+		return []comparator{{"=", v}}, nil
+	default:
+		return []comparator{{op, v}}, nil
+	}
+}
+
+// parsePartial parses a version with up to three parts, stopping at the
+// first wildcard (x, X or *). It returns how many parts were given.
+func parsePartial(s string) (semver, int, error) {
+	var v semver
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return v, 0, fmt.Errorf("missing version")
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return v, 0, fmt.Errorf("invalid version '%s'", s)
+	}
+	for i, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			return v, i, nil
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, 0, fmt.Errorf("invalid version '%s'", s)
+		}
+		v[i] = n
+	}
+	return v, len(parts), nil
+}
+
+// Check reports whether version satisfies the constraint. Pre-releases
+// (e.g. v1.2.0-rc1) and unparsable versions never match.
+func (c *Constraint) Check(version string) bool {
+	if strings.ContainsAny(version, "-+") {
+		return false
+	}
+	major, minor, patch, ok := ParseSemver(version)
+	if !ok {
+		return false
+	}
+	v := semver{major, minor, patch}
+
+	for _, alt := range c.alternatives {
+		matched := true
+		for _, cmp := range alt {
+			if !cmp.check(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint as it was written
+func (c *Constraint) String() string {
+	return c.raw
+}
@@ -0,0 +1,78 @@
+package version
+
+import "testing"
+
+func TestIsConstraint(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"v1.2.0", false},
+		{"1.2.0", false},
+		{"1.2", false},
+		{"latest", false},
+		{"^1.2", true},
+		{"~1.2.3", true},
+		{">=1.2,<2", true},
+		{"1.2.x", true},
+		{"1.*", true},
+		{"^1 || ^2", true},
+	}
+	for _, tt := range tests {
+		if got := IsConstraint(tt.s); got != tt.want {
+			t.Errorf("IsConstraint(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		match      []string
+		noMatch    []string
+	}{
+		{"^1.2", []string{"v1.2.0", "v1.2.9", "v1.9.0"}, []string{"v1.1.9", "v2.0.0"}},
+		{"^0.5", []string{"v0.5.0", "v0.5.9"}, []string{"v0.4.9", "v0.6.0"}},
+		{"^0.0.3", []string{"v0.0.3"}, []string{"v0.0.4"}},
+		{"^0", []string{"v0.0.1", "v0.9.0"}, []string{"v1.0.0"}},
+		{"~1.2.3", []string{"v1.2.3", "v1.2.8"}, []string{"v1.2.2", "v1.3.0"}},
+		{"~1", []string{"v1.0.0", "v1.9.9"}, []string{"v2.0.0"}},
+		{">=1.2,<2", []string{"v1.2.0", "v1.99.0"}, []string{"v1.1.0", "v2.0.0"}},
+		{">= 1.2 < 2", []string{"v1.5.0"}, []string{"v2.0.0"}},
+		{">1.2.0", []string{"v1.2.1"}, []string{"v1.2.0"}},
+		{"<=1.2.0", []string{"v1.2.0", "v0.1.0"}, []string{"v1.2.1"}},
+		{"1.2.x", []string{"v1.2.0", "v1.2.7"}, []string{"v1.3.0", "v1.1.0"}},
+		{"=1.2.3", []string{"1.2.3"}, []string{"v1.2.4"}},
+		{"*", []string{"v0.0.1", "v9.9.9"}, []string{"nightly"}},
+		{"^1 || ^3", []string{"v1.4.0", "v3.0.0"}, []string{"v2.0.0"}},
+		// Pre-releases never match a range
+		{"^1.2", nil, []string{"v1.3.0-rc1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint() error = %v", err)
+			}
+			for _, v := range tt.match {
+				if !c.Check(v) {
+					t.Errorf("Check(%q) = false, want true", v)
+				}
+			}
+			for _, v := range tt.noMatch {
+				if c.Check(v) {
+					t.Errorf("Check(%q) = true, want false", v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	for _, s := range []string{"", "^", ">=a.b", "^1.2.3.4", ">=1 ||"} {
+		if _, err := ParseConstraint(s); err == nil {
+			t.Errorf("ParseConstraint(%q) succeeded, want error", s)
+		}
+	}
+}
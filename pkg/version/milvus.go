@@ -0,0 +1,52 @@
+package version
+
+import (
+	"os"
+	"strings"
+)
+
+// DefaultMilvusVersion is the built-in Milvus version used when none is specified
+const DefaultMilvusVersion = "v2.5.4"
+
+// DefaultMilvusVersionEnv overrides DefaultMilvusVersion when set
+const DefaultMilvusVersionEnv = "MIUP_DEFAULT_MILVUS_VERSION"
+
+// MilvusRepo is the GitHub repository of Milvus releases
+const MilvusRepo = "milvus-io/milvus"
+
+// SkipVersionCheckEnv disables the outdated Milvus version check when set
+const SkipVersionCheckEnv = "MIUP_SKIP_VERSION_CHECK"
+
+// MilvusDefault returns the default Milvus version, honoring
+// MIUP_DEFAULT_MILVUS_VERSION. All commands should use this instead of
+// referencing DefaultMilvusVersion directly so the defaults never drift.
+func MilvusDefault() string {
+	if v := strings.TrimSpace(os.Getenv(DefaultMilvusVersionEnv)); v != "" {
+		return v
+	}
+	return DefaultMilvusVersion
+}
+
+// VersionCheckDisabled reports whether the version check is disabled via environment
+func VersionCheckDisabled() bool {
+	v := strings.ToLower(strings.TrimSpace(os.Getenv(SkipVersionCheckEnv)))
+	return v != "" && v != "0" && v != "false"
+}
+
+// IsSignificantlyBehind reports whether current is at least one minor
+// release behind latest. Patch differences are not considered significant.
+// Unparseable versions (e.g. "latest" or custom tags) are never reported.
+func IsSignificantlyBehind(current, latest string) bool {
+	curMajor, curMinor, _, ok := ParseSemver(current)
+	if !ok {
+		return false
+	}
+	latMajor, latMinor, _, ok := ParseSemver(latest)
+	if !ok {
+		return false
+	}
+	if curMajor != latMajor {
+		return curMajor < latMajor
+	}
+	return curMinor < latMinor
+}
@@ -0,0 +1,66 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestIsSignificantlyBehind(t *testing.T) {
+	tests := []struct {
+		current string
+		latest  string
+		want    bool
+	}{
+		{"v2.5.4", "v2.5.9", false},
+		{"v2.5.4", "v2.6.0", true},
+		{"v2.5.4", "v3.0.0", true},
+		{"v2.6.1", "v2.5.9", false},
+		{"latest", "v2.6.0", false},
+		{"v2.5.4", "nightly", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+"_"+tt.latest, func(t *testing.T) {
+			if got := IsSignificantlyBehind(tt.current, tt.latest); got != tt.want {
+				t.Errorf("IsSignificantlyBehind(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionCheckDisabled(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", false},
+		{"0", false},
+		{"false", false},
+		{"1", true},
+		{"true", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(SkipVersionCheckEnv, tt.value)
+			if got := VersionCheckDisabled(); got != tt.want {
+				t.Errorf("VersionCheckDisabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMilvusDefault(t *testing.T) {
+	t.Run("built-in default", func(t *testing.T) {
+		t.Setenv(DefaultMilvusVersionEnv, "")
+		if got := MilvusDefault(); got != DefaultMilvusVersion {
+			t.Errorf("MilvusDefault() = %s, want %s", got, DefaultMilvusVersion)
+		}
+	})
+
+	t.Run("env override", func(t *testing.T) {
+		t.Setenv(DefaultMilvusVersionEnv, "v2.6.0")
+		if got := MilvusDefault(); got != "v2.6.0" {
+			t.Errorf("MilvusDefault() = %s, want v2.6.0", got)
+		}
+	})
+}
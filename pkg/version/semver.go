@@ -0,0 +1,139 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseSemver parses a version like "v2.5.4" into major, minor, patch.
+// Pre-release suffixes (e.g. "-rc1") are ignored.
+func ParseSemver(v string) (major, minor, patch int, ok bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return 0, 0, 0, false
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return 0, 0, 0, false
+		}
+		nums[i] = n
+	}
+	return nums[0], nums[1], nums[2], true
+}
+
+// CompareSemver compares two versions, returning -1, 0 or 1 if a is older,
+// equal to or newer than b. A pre-release is older than its release
+// (v1.0.0-rc1 < v1.0.0), and pre-releases of one version are compared by
+// their dot-separated identifiers, with a number ending an identifier
+// compared as a number (rc2 < rc10). Build metadata is ignored. ok is false
+// if either version cannot be parsed.
+func CompareSemver(a, b string) (cmp int, ok bool) {
+	aMajor, aMinor, aPatch, ok := ParseSemver(a)
+	if !ok {
+		return 0, false
+	}
+	bMajor, bMinor, bPatch, ok := ParseSemver(b)
+	if !ok {
+		return 0, false
+	}
+
+	for _, pair := range [][2]int{{aMajor, bMajor}, {aMinor, bMinor}, {aPatch, bPatch}} {
+		if pair[0] < pair[1] {
+			return -1, true
+		}
+		if pair[0] > pair[1] {
+			return 1, true
+		}
+	}
+	return comparePrerelease(prerelease(a), prerelease(b)), true
+}
+
+// prerelease returns the pre-release part of a version, e.g. "rc1" of
+// "v2.6.0-rc1+build5", or "" for a release
+func prerelease(v string) string {
+	v, _, _ = strings.Cut(strings.TrimSpace(v), "+")
+	_, pre, _ := strings.Cut(v, "-")
+	return pre
+}
+
+// comparePrerelease compares two pre-release parts; a release ("") is newer
+// than any pre-release
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	aIDs, bIDs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		if c := compareIdentifier(aIDs[i], bIDs[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(aIDs) < len(bIDs):
+		return -1
+	case len(aIDs) > len(bIDs):
+		return 1
+	}
+	return 0
+}
+
+// compareIdentifier compares two pre-release identifiers: numbers
+// numerically and before words, words by a common prefix and then their
+// trailing number (rc2 < rc10), or else by name
+func compareIdentifier(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInts(an, bn)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+
+	aPrefix, aNum, aOK := splitTrailingNumber(a)
+	bPrefix, bNum, bOK := splitTrailingNumber(b)
+	if aOK && bOK && aPrefix == bPrefix {
+		return compareInts(aNum, bNum)
+	}
+	return strings.Compare(a, b)
+}
+
+// splitTrailingNumber splits an identifier like "rc10" into "rc" and 10
+func splitTrailingNumber(id string) (string, int, bool) {
+	i := len(id)
+	for i > 0 && id[i-1] >= '0' && id[i-1] <= '9' {
+		i--
+	}
+	if i == len(id) {
+		return id, 0, false
+	}
+	n, err := strconv.Atoi(id[i:])
+	if err != nil {
+		return id, 0, false
+	}
+	return id[:i], n, true
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
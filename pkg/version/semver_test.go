@@ -0,0 +1,63 @@
+package version
+
+import (
+	"testing"
+)
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		input               string
+		major, minor, patch int
+		ok                  bool
+	}{
+		{"v2.5.4", 2, 5, 4, true},
+		{"2.4.0", 2, 4, 0, true},
+		{"v2.6.0-rc1", 2, 6, 0, true},
+		{"latest", 0, 0, 0, false},
+		{"v2.5", 0, 0, 0, false},
+		{"v2.x.1", 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			major, minor, patch, ok := ParseSemver(tt.input)
+			if ok != tt.ok {
+				t.Fatalf("ParseSemver(%q) ok = %v, want %v", tt.input, ok, tt.ok)
+			}
+			if major != tt.major || minor != tt.minor || patch != tt.patch {
+				t.Errorf("ParseSemver(%q) = %d.%d.%d, want %d.%d.%d",
+					tt.input, major, minor, patch, tt.major, tt.minor, tt.patch)
+			}
+		})
+	}
+}
+
+func TestCompareSemver(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{"v1.0.0", "v1.0.1", -1, true},
+		{"v1.2.0", "v1.1.9", 1, true},
+		{"v2.0.0", "2.0.0", 0, true},
+		{"v1.0.0-rc1", "v1.0.0", -1, true},
+		{"v1.0.0", "v1.0.0-rc1", 1, true},
+		{"v1.0.0-rc2", "v1.0.0-rc10", -1, true},
+		{"v1.0.0-beta", "v1.0.0-rc1", -1, true},
+		{"v1.0.0-rc.1", "v1.0.0-rc.1.1", -1, true},
+		{"v1.0.0-1", "v1.0.0-alpha", -1, true},
+		{"v1.0.0-rc1+build5", "v1.0.0-rc1", 0, true},
+		{"v1.0.1-rc1", "v1.0.0", 1, true},
+		{"latest", "v1.0.0", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			got, ok := CompareSemver(tt.a, tt.b)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("CompareSemver(%q, %q) = %d, %v, want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -1,11 +1,30 @@
 package metrics
 
 import (
+	"encoding/json"
+	"fmt"
 	"sort"
 	"sync"
 	"time"
 )
 
+// DefaultLatencyBuckets are the default upper bounds of the latency histogram
+var DefaultLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
 // Collector collects and calculates benchmark metrics
 type Collector struct {
 	mu        sync.Mutex
@@ -13,13 +32,55 @@ type Collector struct {
 	errors    int64
 	startTime time.Time
 	endTime   time.Time
+
+	// Operations finishing before warmupEnd are not measured
+	warmup    time.Duration
+	warmupEnd time.Time
+	warmupOps int64
+
+	// bounds are the histogram upper bounds; buckets counts latencies per
+	// bound (non-cumulative), with a final overflow bucket
+	bounds     []time.Duration
+	buckets    []int64
+	latencySum time.Duration
 }
 
 // NewCollector creates a new metrics collector
 func NewCollector() *Collector {
 	return &Collector{
 		latencies: make([]time.Duration, 0, 10000),
+		bounds:    DefaultLatencyBuckets,
+		buckets:   make([]int64, len(DefaultLatencyBuckets)+1),
+	}
+}
+
+// SetBuckets sets the histogram upper bounds. It must be called before Start.
+func (c *Collector) SetBuckets(bounds []time.Duration) error {
+	if len(bounds) == 0 {
+		return nil
 	}
+	for i := range bounds {
+		if bounds[i] <= 0 {
+			return fmt.Errorf("histogram bucket %s must be positive", bounds[i])
+		}
+		if i > 0 && bounds[i] <= bounds[i-1] {
+			return fmt.Errorf("histogram buckets must be increasing (%s after %s)", bounds[i], bounds[i-1])
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bounds = bounds
+	c.buckets = make([]int64, len(bounds)+1)
+	return nil
+}
+
+// SetWarmup excludes operations in the first d of the run from the metrics.
+// It must be called before Start.
+func (c *Collector) SetWarmup(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warmup = d
 }
 
 // Start marks the start of benchmark
@@ -27,6 +88,7 @@ func (c *Collector) Start() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.startTime = time.Now()
+	c.warmupEnd = c.startTime.Add(c.warmup)
 }
 
 // Stop marks the end of benchmark
@@ -36,20 +98,60 @@ func (c *Collector) Stop() {
 	c.endTime = time.Now()
 }
 
+// warmingUp reports whether the warmup phase is still running; c.mu must be held
+func (c *Collector) warmingUp() bool {
+	return c.warmup > 0 && time.Now().Before(c.warmupEnd)
+}
+
 // Record records a single operation latency
 func (c *Collector) Record(latency time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.warmingUp() {
+		c.warmupOps++
+		return
+	}
 	c.latencies = append(c.latencies, latency)
+	c.latencySum += latency
+	c.buckets[bucketIndex(c.bounds, latency)]++
+}
+
+// bucketIndex returns the index of the first bound >= latency, or
+// len(bounds) for the overflow bucket
+func bucketIndex(bounds []time.Duration, latency time.Duration) int {
+	return sort.Search(len(bounds), func(i int) bool { return latency <= bounds[i] })
 }
 
 // RecordError records an error
 func (c *Collector) RecordError() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.warmingUp() {
+		c.warmupOps++
+		return
+	}
 	c.errors++
 }
 
+// Bucket is one latency histogram bucket. UpperBound is zero for the final
+// bucket, which holds every latency above the last bound.
+type Bucket struct {
+	UpperBound time.Duration
+	Count      int64
+}
+
+// MarshalJSON encodes the bucket bound as a duration string, e.g. "10ms" or "+Inf"
+func (b Bucket) MarshalJSON() ([]byte, error) {
+	le := "+Inf"
+	if b.UpperBound > 0 {
+		le = b.UpperBound.String()
+	}
+	return json.Marshal(struct {
+		LE    string `json:"le"`
+		Count int64  `json:"count"`
+	}{le, b.Count})
+}
+
 // Result represents benchmark results
 type Result struct {
 	TotalOps   int64
@@ -63,6 +165,49 @@ type Result struct {
 	P99Latency time.Duration
 	Errors     int64
 	ErrorRate  float64
+
+	// Warmup is the excluded warmup phase and WarmupOps the operations in it
+	Warmup    time.Duration
+	WarmupOps int64
+
+	// Histogram holds the non-cumulative latency distribution
+	Histogram []Bucket
+}
+
+// MarshalJSON encodes durations in milliseconds
+func (r *Result) MarshalJSON() ([]byte, error) {
+	ms := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+	return json.Marshal(struct {
+		TotalOps  int64    `json:"total_ops"`
+		DurationS float64  `json:"duration_s"`
+		QPS       float64  `json:"qps"`
+		AvgMs     float64  `json:"avg_latency_ms"`
+		MinMs     float64  `json:"min_latency_ms"`
+		MaxMs     float64  `json:"max_latency_ms"`
+		P50Ms     float64  `json:"p50_latency_ms"`
+		P95Ms     float64  `json:"p95_latency_ms"`
+		P99Ms     float64  `json:"p99_latency_ms"`
+		Errors    int64    `json:"errors"`
+		ErrorRate float64  `json:"error_rate"`
+		WarmupS   float64  `json:"warmup_s,omitempty"`
+		WarmupOps int64    `json:"warmup_ops,omitempty"`
+		Histogram []Bucket `json:"histogram"`
+	}{
+		TotalOps:  r.TotalOps,
+		DurationS: r.Duration.Seconds(),
+		QPS:       r.QPS,
+		AvgMs:     ms(r.AvgLatency),
+		MinMs:     ms(r.MinLatency),
+		MaxMs:     ms(r.MaxLatency),
+		P50Ms:     ms(r.P50Latency),
+		P95Ms:     ms(r.P95Latency),
+		P99Ms:     ms(r.P99Latency),
+		Errors:    r.Errors,
+		ErrorRate: r.ErrorRate,
+		WarmupS:   r.Warmup.Seconds(),
+		WarmupOps: r.WarmupOps,
+		Histogram: r.Histogram,
+	})
 }
 
 // Calculate calculates the final metrics
@@ -70,7 +215,23 @@ func (c *Collector) Calculate() *Result {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	duration := c.endTime.Sub(c.startTime)
+	// Only the time after warmup is measured
+	measureStart := c.startTime
+	if c.warmupEnd.After(measureStart) {
+		measureStart = c.warmupEnd
+	}
+	duration := c.endTime.Sub(measureStart)
+	if duration < 0 {
+		duration = 0
+	}
+
+	histogram := make([]Bucket, len(c.buckets))
+	for i, n := range c.buckets {
+		histogram[i].Count = n
+		if i < len(c.bounds) {
+			histogram[i].UpperBound = c.bounds[i]
+		}
+	}
 
 	if len(c.latencies) == 0 {
 		// Return result with errors even if no successful ops
@@ -83,6 +244,9 @@ func (c *Collector) Calculate() *Result {
 			Duration:  duration,
 			Errors:    c.errors,
 			ErrorRate: errorRate,
+			Warmup:    c.warmup,
+			WarmupOps: c.warmupOps,
+			Histogram: histogram,
 		}
 	}
 
@@ -114,14 +278,57 @@ func (c *Collector) Calculate() *Result {
 		P99Latency: sorted[n*99/100],
 		Errors:     c.errors,
 		ErrorRate:  float64(c.errors) / float64(totalOps+c.errors) * 100,
+		Warmup:     c.warmup,
+		WarmupOps:  c.warmupOps,
+		Histogram:  histogram,
 	}
 
 	return result
 }
 
-// CurrentStats returns current statistics (for progress display)
+// CurrentStats returns current statistics (for progress display). elapsed
+// is measured from the end of warmup and is zero while warming up.
 func (c *Collector) CurrentStats() (ops int64, errors int64, elapsed time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	return int64(len(c.latencies)), c.errors, time.Since(c.startTime)
+	elapsed = time.Since(c.startTime)
+	if c.warmup > 0 {
+		elapsed = time.Since(c.warmupEnd)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+	}
+	return int64(len(c.latencies)), c.errors, elapsed
+}
+
+// Snapshot is a point-in-time copy of the collector's counters
+type Snapshot struct {
+	Ops        int64
+	Errors     int64
+	LatencySum time.Duration
+
+	// Bounds are the histogram upper bounds and Buckets the cumulative
+	// count for each bound
+	Bounds  []time.Duration
+	Buckets []int64
+}
+
+// Snapshot returns the current counters, e.g. for a metrics exporter
+func (c *Collector) Snapshot() Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := Snapshot{
+		Ops:        int64(len(c.latencies)),
+		Errors:     c.errors,
+		LatencySum: c.latencySum,
+		Bounds:     c.bounds,
+		Buckets:    make([]int64, len(c.bounds)),
+	}
+	var cumulative int64
+	for i := range c.bounds {
+		cumulative += c.buckets[i]
+		s.Buckets[i] = cumulative
+	}
+	return s
 }
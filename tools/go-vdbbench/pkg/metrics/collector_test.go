@@ -0,0 +1,161 @@
+package metrics
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBucketIndex(t *testing.T) {
+	bounds := []time.Duration{time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond}
+
+	tests := []struct {
+		latency time.Duration
+		want    int
+	}{
+		{500 * time.Microsecond, 0},
+		{time.Millisecond, 0},
+		{2 * time.Millisecond, 1},
+		{100 * time.Millisecond, 2},
+		{time.Second, 3},
+	}
+
+	for _, tt := range tests {
+		if got := bucketIndex(bounds, tt.latency); got != tt.want {
+			t.Errorf("bucketIndex(%s) = %d, want %d", tt.latency, got, tt.want)
+		}
+	}
+}
+
+func TestSetBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		bounds  []time.Duration
+		wantErr bool
+	}{
+		{"default kept", nil, false},
+		{"increasing", []time.Duration{time.Millisecond, time.Second}, false},
+		{"not positive", []time.Duration{0, time.Second}, true},
+		{"not increasing", []time.Duration{time.Second, time.Millisecond}, true},
+		{"repeated", []time.Duration{time.Second, time.Second}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewCollector().SetBuckets(tt.bounds); (err != nil) != tt.wantErr {
+				t.Errorf("SetBuckets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	c := NewCollector()
+	if err := c.SetBuckets([]time.Duration{10 * time.Millisecond, 100 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	c.Start()
+	for i := 1; i <= 100; i++ {
+		c.Record(time.Duration(i) * 2 * time.Millisecond)
+	}
+	c.RecordError()
+	c.Stop()
+
+	r := c.Calculate()
+	if r.TotalOps != 100 || r.Errors != 1 {
+		t.Errorf("TotalOps = %d, Errors = %d, want 100 and 1", r.TotalOps, r.Errors)
+	}
+	if r.MinLatency != 2*time.Millisecond || r.MaxLatency != 200*time.Millisecond {
+		t.Errorf("latency range = %s-%s, want 2ms-200ms", r.MinLatency, r.MaxLatency)
+	}
+	if r.P50Latency != 102*time.Millisecond || r.P99Latency != 200*time.Millisecond {
+		t.Errorf("P50 = %s, P99 = %s, want 102ms and 200ms", r.P50Latency, r.P99Latency)
+	}
+	if r.AvgLatency != 101*time.Millisecond {
+		t.Errorf("AvgLatency = %s, want 101ms", r.AvgLatency)
+	}
+
+	// 2-10ms, 12-100ms and the overflow above 100ms
+	want := []Bucket{{10 * time.Millisecond, 5}, {100 * time.Millisecond, 45}, {0, 50}}
+	if len(r.Histogram) != len(want) {
+		t.Fatalf("Histogram = %+v, want %+v", r.Histogram, want)
+	}
+	for i := range want {
+		if r.Histogram[i] != want[i] {
+			t.Errorf("Histogram[%d] = %+v, want %+v", i, r.Histogram[i], want[i])
+		}
+	}
+}
+
+func TestCalculateOnlyErrors(t *testing.T) {
+	c := NewCollector()
+	c.Start()
+	c.RecordError()
+	c.Stop()
+
+	r := c.Calculate()
+	if r.TotalOps != 0 || r.Errors != 1 || r.ErrorRate != 100 {
+		t.Errorf("Calculate() = %+v, want one error and a 100%% error rate", r)
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	c := NewCollector()
+	c.SetWarmup(time.Hour)
+	c.Start()
+	c.Record(time.Millisecond)
+	c.RecordError()
+	c.Stop()
+
+	r := c.Calculate()
+	if r.TotalOps != 0 || r.Errors != 0 || r.WarmupOps != 2 {
+		t.Errorf("Calculate() = %+v, want both operations counted as warmup", r)
+	}
+	if ops, errors, elapsed := c.CurrentStats(); ops != 0 || errors != 0 || elapsed != 0 {
+		t.Errorf("CurrentStats() = %d, %d, %s, want nothing while warming up", ops, errors, elapsed)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	c := NewCollector()
+	if err := c.SetBuckets([]time.Duration{time.Millisecond, 10 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	c.Start()
+	for _, l := range []time.Duration{time.Millisecond, 5 * time.Millisecond, 20 * time.Millisecond} {
+		c.Record(l)
+	}
+	c.RecordError()
+
+	s := c.Snapshot()
+	if s.Ops != 3 || s.Errors != 1 || s.LatencySum != 26*time.Millisecond {
+		t.Errorf("Snapshot() = %+v, want 3 ops, 1 error and 26ms in total", s)
+	}
+	// Cumulative, without the overflow bucket
+	if len(s.Buckets) != 2 || s.Buckets[0] != 1 || s.Buckets[1] != 2 {
+		t.Errorf("Buckets = %v, want [1 2]", s.Buckets)
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	r := &Result{
+		TotalOps:   10,
+		Duration:   2 * time.Second,
+		P99Latency: 1500 * time.Microsecond,
+		Histogram:  []Bucket{{time.Millisecond, 4}, {0, 6}},
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	for _, want := range []string{`"duration_s":2`, `"p99_latency_ms":1.5`, `{"le":"1ms","count":4}`, `{"le":"+Inf","count":6}`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON %s should contain %s", data, want)
+		}
+	}
+	if strings.Contains(string(data), "warmup") {
+		t.Errorf("JSON %s should omit the warmup without one", data)
+	}
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+// Exporter serves a collector's metrics in the Prometheus text format
+type Exporter struct {
+	collector *Collector
+	operation string
+	server    *http.Server
+}
+
+// NewExporter creates an exporter for the given collector. The operation
+// (e.g. "search") is added as a label to every metric.
+func NewExporter(collector *Collector, operation string) *Exporter {
+	return &Exporter{collector: collector, operation: operation}
+}
+
+// Start serves /metrics on addr (e.g. ":9101") in the background
+func (e *Exporter) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		e.Write(w)
+	})
+	e.server = &http.Server{Handler: mux}
+
+	go e.server.Serve(listener)
+	return nil
+}
+
+// Close stops the exporter
+func (e *Exporter) Close() error {
+	if e.server == nil {
+		return nil
+	}
+	return e.server.Close()
+}
+
+// Write writes the current metrics in the Prometheus text format
+func (e *Exporter) Write(w io.Writer) {
+	s := e.collector.Snapshot()
+	op := strconv.Quote(e.operation)
+
+	fmt.Fprintln(w, "# HELP vdbbench_operations_total Successful benchmark operations.")
+	fmt.Fprintln(w, "# TYPE vdbbench_operations_total counter")
+	fmt.Fprintf(w, "vdbbench_operations_total{operation=%s} %d\n", op, s.Ops)
+
+	fmt.Fprintln(w, "# HELP vdbbench_errors_total Failed benchmark operations.")
+	fmt.Fprintln(w, "# TYPE vdbbench_errors_total counter")
+	fmt.Fprintf(w, "vdbbench_errors_total{operation=%s} %d\n", op, s.Errors)
+
+	fmt.Fprintln(w, "# HELP vdbbench_latency_seconds Latency of successful benchmark operations.")
+	fmt.Fprintln(w, "# TYPE vdbbench_latency_seconds histogram")
+	for i, bound := range s.Bounds {
+		le := strconv.FormatFloat(bound.Seconds(), 'g', -1, 64)
+		fmt.Fprintf(w, "vdbbench_latency_seconds_bucket{operation=%s,le=%q} %d\n", op, le, s.Buckets[i])
+	}
+	fmt.Fprintf(w, "vdbbench_latency_seconds_bucket{operation=%s,le=\"+Inf\"} %d\n", op, s.Ops)
+	fmt.Fprintf(w, "vdbbench_latency_seconds_sum{operation=%s} %g\n", op, s.LatencySum.Seconds())
+	fmt.Fprintf(w, "vdbbench_latency_seconds_count{operation=%s} %d\n", op, s.Ops)
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExporterWrite(t *testing.T) {
+	c := NewCollector()
+	if err := c.SetBuckets([]time.Duration{5 * time.Millisecond, 50 * time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	c.Start()
+	c.Record(2 * time.Millisecond)
+	c.Record(20 * time.Millisecond)
+	c.Record(time.Second)
+	c.RecordError()
+
+	var buf bytes.Buffer
+	NewExporter(c, "search").Write(&buf)
+	out := buf.String()
+
+	for _, want := range []string{
+		"# TYPE vdbbench_operations_total counter",
+		`vdbbench_operations_total{operation="search"} 3`,
+		`vdbbench_errors_total{operation="search"} 1`,
+		"# TYPE vdbbench_latency_seconds histogram",
+		`vdbbench_latency_seconds_bucket{operation="search",le="0.005"} 1`,
+		`vdbbench_latency_seconds_bucket{operation="search",le="0.05"} 2`,
+		`vdbbench_latency_seconds_bucket{operation="search",le="+Inf"} 3`,
+		`vdbbench_latency_seconds_sum{operation="search"} 1.022`,
+		`vdbbench_latency_seconds_count{operation="search"} 3`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("output should contain %q, got:\n%s", want, out)
+		}
+	}
+}
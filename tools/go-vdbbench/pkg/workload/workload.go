@@ -19,6 +19,9 @@ type Config struct {
 	Duration    time.Duration
 	Collection  string
 
+	// Warmup runs before Duration and is excluded from the metrics
+	Warmup      time.Duration
+
 	// Data settings
 	Dataset     dataset.Dataset
 	BatchSize   int
@@ -62,6 +65,11 @@ func NewWorkload(db database.VectorDB, config *Config) *Workload {
 	}
 }
 
+// Collector returns the metrics collector of the workload
+func (w *Workload) Collector() *metrics.Collector {
+	return w.collector
+}
+
 // Prepare prepares the collection and data for benchmark
 func (w *Workload) Prepare(ctx context.Context, progressFn func(current, total int)) error {
 	cfg := w.config
@@ -125,12 +133,13 @@ func (w *Workload) RunSearch(ctx context.Context, progressFn func(ops int64, ela
 	ds := cfg.Dataset
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	ctx, cancel := context.WithTimeout(ctx, cfg.Warmup+cfg.Duration)
 	defer cancel()
 
 	var wg sync.WaitGroup
 	var totalOps int64
 
+	w.collector.SetWarmup(cfg.Warmup)
 	w.collector.Start()
 
 	// Start workers
@@ -204,11 +213,12 @@ func (w *Workload) RunInsert(ctx context.Context, progressFn func(ops int64, ela
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	ctx, cancel := context.WithTimeout(ctx, cfg.Warmup+cfg.Duration)
 	defer cancel()
 
 	var wg sync.WaitGroup
 
+	w.collector.SetWarmup(cfg.Warmup)
 	w.collector.Start()
 
 	// Start workers